@@ -1,96 +0,0 @@
-// Code generated by go-swagger; DO NOT EDIT.
-
-package generated
-
-// This file was generated by the swagger tool.
-// Editing this file might prove futile when you re-run the swagger generate command
-
-import (
-	"context"
-
-	"github.com/go-openapi/errors"
-	"github.com/go-openapi/strfmt"
-	"github.com/go-openapi/swag"
-	"github.com/go-openapi/validate"
-)
-
-// HistoryEvent HistoryEvent
-//
-// Состояние датчика в конкретное время
-// Example: {"payload":1,"time_stamp":"2024-12-31T23:59:59"}
-//
-// swagger:model HistoryEvent
-type HistoryEvent struct {
-
-	// Информация от датчика
-	// Required: true
-	Payload *int64 `json:"payload"`
-
-	// Время события
-	// Required: true
-	// Format: date-time
-	TimeStamp *strfmt.DateTime `json:"time_stamp"`
-}
-
-// Validate validates this history event
-func (m *HistoryEvent) Validate(formats strfmt.Registry) error {
-	var res []error
-
-	if err := m.validatePayload(formats); err != nil {
-		res = append(res, err)
-	}
-
-	if err := m.validateTimeStamp(formats); err != nil {
-		res = append(res, err)
-	}
-
-	if len(res) > 0 {
-		return errors.CompositeValidationError(res...)
-	}
-	return nil
-}
-
-func (m *HistoryEvent) validatePayload(formats strfmt.Registry) error {
-
-	if err := validate.Required("payload", "body", m.Payload); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (m *HistoryEvent) validateTimeStamp(formats strfmt.Registry) error {
-
-	if err := validate.Required("time_stamp", "body", m.TimeStamp); err != nil {
-		return err
-	}
-
-	if err := validate.FormatOf("time_stamp", "body", "date-time", m.TimeStamp.String(), formats); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// ContextValidate validates this history event based on context it is used
-func (m *HistoryEvent) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
-	return nil
-}
-
-// MarshalBinary interface implementation
-func (m *HistoryEvent) MarshalBinary() ([]byte, error) {
-	if m == nil {
-		return nil, nil
-	}
-	return swag.WriteJSON(m)
-}
-
-// UnmarshalBinary interface implementation
-func (m *HistoryEvent) UnmarshalBinary(b []byte) error {
-	var res HistoryEvent
-	if err := swag.ReadJSON(b, &res); err != nil {
-		return err
-	}
-	*m = res
-	return nil
-}
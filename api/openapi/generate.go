@@ -0,0 +1,4 @@
+// Package openapi - интерфейс сервера и типы, сгенерированные oapi-codegen по спецификации api/openapi.yaml
+package openapi
+
+//go:generate oapi-codegen -config config.yaml ../openapi.yaml
@@ -0,0 +1,3736 @@
+// Package openapi provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for AlertSeverity.
+const (
+	AlertSeverityCritical AlertSeverity = "critical"
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+)
+
+// Defines values for AlertState.
+const (
+	AlertStateAcknowledged AlertState = "acknowledged"
+	AlertStateOpen         AlertState = "open"
+	AlertStateResolved     AlertState = "resolved"
+)
+
+// Defines values for AlertRuleSeverity.
+const (
+	AlertRuleSeverityCritical AlertRuleSeverity = "critical"
+	AlertRuleSeverityInfo     AlertRuleSeverity = "info"
+	AlertRuleSeverityWarning  AlertRuleSeverity = "warning"
+)
+
+// Defines values for ChangeKind.
+const (
+	ChangeKindEvent  ChangeKind = "event"
+	ChangeKindSensor ChangeKind = "sensor"
+)
+
+// Defines values for CommandState.
+const (
+	CommandStateAcknowledged CommandState = "acknowledged"
+	CommandStateDelivered    CommandState = "delivered"
+	CommandStateFailed       CommandState = "failed"
+	CommandStatePending      CommandState = "pending"
+)
+
+// Defines values for ErrorCode.
+const (
+	AlertAlreadyOpen            ErrorCode = "alert_already_open"
+	AlertAlreadyResolved        ErrorCode = "alert_already_resolved"
+	AlertNotFound               ErrorCode = "alert_not_found"
+	Canceled                    ErrorCode = "canceled"
+	CommandNotFound             ErrorCode = "command_not_found"
+	EventBufferFull             ErrorCode = "event_buffer_full"
+	EventNotFound               ErrorCode = "event_not_found"
+	IngestionUnavailable        ErrorCode = "ingestion_unavailable"
+	Internal                    ErrorCode = "internal"
+	InvalidAlertRule            ErrorCode = "invalid_alert_rule"
+	InvalidAlertState           ErrorCode = "invalid_alert_state"
+	InvalidBody                 ErrorCode = "invalid_body"
+	InvalidCalibration          ErrorCode = "invalid_calibration"
+	InvalidCommandState         ErrorCode = "invalid_command_state"
+	InvalidCursor               ErrorCode = "invalid_cursor"
+	InvalidDateRange            ErrorCode = "invalid_date_range"
+	InvalidEventTimestamp       ErrorCode = "invalid_event_timestamp"
+	InvalidId                   ErrorCode = "invalid_id"
+	InvalidLimit                ErrorCode = "invalid_limit"
+	InvalidNotificationChannel  ErrorCode = "invalid_notification_channel"
+	InvalidParameter            ErrorCode = "invalid_parameter"
+	InvalidSchedule             ErrorCode = "invalid_schedule"
+	InvalidSensorType           ErrorCode = "invalid_sensor_type"
+	InvalidSerialNumber         ErrorCode = "invalid_serial_number"
+	InvalidSort                 ErrorCode = "invalid_sort"
+	InvalidUserName             ErrorCode = "invalid_user_name"
+	MalformedBody               ErrorCode = "malformed_body"
+	MethodNotAllowed            ErrorCode = "method_not_allowed"
+	NotAcceptable               ErrorCode = "not_acceptable"
+	NotActuator                 ErrorCode = "not_actuator"
+	NotificationChannelNotFound ErrorCode = "notification_channel_not_found"
+	PayloadOutOfRange           ErrorCode = "payload_out_of_range"
+	RateLimited                 ErrorCode = "rate_limited"
+	RouteNotFound               ErrorCode = "route_not_found"
+	ScheduleNotFound            ErrorCode = "schedule_not_found"
+	SensorAlreadyAttached       ErrorCode = "sensor_already_attached"
+	SensorAlreadyExists         ErrorCode = "sensor_already_exists"
+	SensorMismatch              ErrorCode = "sensor_mismatch"
+	SensorModified              ErrorCode = "sensor_modified"
+	SensorNotFound              ErrorCode = "sensor_not_found"
+	Timeout                     ErrorCode = "timeout"
+	UnsupportedMediaType        ErrorCode = "unsupported_media_type"
+	UserNotFound                ErrorCode = "user_not_found"
+)
+
+// Defines values for NotificationChannelKind.
+const (
+	NotificationChannelKindEmail NotificationChannelKind = "email"
+	NotificationChannelKindPush  NotificationChannelKind = "push"
+)
+
+// Defines values for NotificationChannelMinSeverity.
+const (
+	NotificationChannelMinSeverityCritical NotificationChannelMinSeverity = "critical"
+	NotificationChannelMinSeverityInfo     NotificationChannelMinSeverity = "info"
+	NotificationChannelMinSeverityWarning  NotificationChannelMinSeverity = "warning"
+)
+
+// Defines values for NotificationChannelToCreateKind.
+const (
+	NotificationChannelToCreateKindEmail NotificationChannelToCreateKind = "email"
+	NotificationChannelToCreateKindPush  NotificationChannelToCreateKind = "push"
+)
+
+// Defines values for NotificationChannelToCreateMinSeverity.
+const (
+	NotificationChannelToCreateMinSeverityCritical NotificationChannelToCreateMinSeverity = "critical"
+	NotificationChannelToCreateMinSeverityInfo     NotificationChannelToCreateMinSeverity = "info"
+	NotificationChannelToCreateMinSeverityWarning  NotificationChannelToCreateMinSeverity = "warning"
+)
+
+// Defines values for NotificationDeliveryStatus.
+const (
+	NotificationDeliveryStatusFailed     NotificationDeliveryStatus = "failed"
+	NotificationDeliveryStatusSent       NotificationDeliveryStatus = "sent"
+	NotificationDeliveryStatusSuppressed NotificationDeliveryStatus = "suppressed"
+)
+
+// Defines values for ScheduleActionKind.
+const (
+	ScheduleActionKindAlertRule ScheduleActionKind = "alert_rule"
+	ScheduleActionKindCommand   ScheduleActionKind = "command"
+	ScheduleActionKindWebhook   ScheduleActionKind = "webhook"
+)
+
+// Defines values for ScheduleRunStatus.
+const (
+	ScheduleRunStatusFailed    ScheduleRunStatus = "failed"
+	ScheduleRunStatusSucceeded ScheduleRunStatus = "succeeded"
+)
+
+// Defines values for SensorSortField.
+const (
+	Id           SensorSortField = "id"
+	LastActivity SensorSortField = "last_activity"
+	SerialNumber SensorSortField = "serial_number"
+)
+
+// Defines values for SensorTypeSemantics.
+const (
+	Measurement SensorTypeSemantics = "measurement"
+	State       SensorTypeSemantics = "state"
+)
+
+// Defines values for SortOrder.
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Defines values for GetCommandsParamsState.
+const (
+	GetCommandsParamsStateAcknowledged GetCommandsParamsState = "acknowledged"
+	GetCommandsParamsStateDelivered    GetCommandsParamsState = "delivered"
+	GetCommandsParamsStateFailed       GetCommandsParamsState = "failed"
+	GetCommandsParamsStatePending      GetCommandsParamsState = "pending"
+)
+
+// Defines values for GetUserAlertsParamsState.
+const (
+	GetUserAlertsParamsStateAcknowledged GetUserAlertsParamsState = "acknowledged"
+	GetUserAlertsParamsStateAll          GetUserAlertsParamsState = "all"
+	GetUserAlertsParamsStateOpen         GetUserAlertsParamsState = "open"
+	GetUserAlertsParamsStateResolved     GetUserAlertsParamsState = "resolved"
+)
+
+// Alert Тревога, поднятая событием датчика
+type Alert struct {
+	// AcknowledgedAt Время подтверждения
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+
+	// AcknowledgedBy Идентификатор пользователя, подтвердившего тревогу
+	AcknowledgedBy *int64 `json:"acknowledged_by,omitempty"`
+
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// Payload Сырое значение события, поднявшего тревогу
+	Payload float64 `json:"payload"`
+
+	// ResolvedAt Время устранения
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+
+	// ResolvedBy Идентификатор пользователя, устранившего тревогу
+	ResolvedBy *int64 `json:"resolved_by,omitempty"`
+
+	// SensorId Идентификатор датчика
+	SensorId int64 `json:"sensor_id"`
+
+	// Severity Важность
+	Severity AlertSeverity `json:"severity"`
+
+	// State Этап обработки
+	State AlertState `json:"state"`
+
+	// TriggeredAt Время события, поднявшего тревогу
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+// AlertSeverity Важность
+type AlertSeverity string
+
+// AlertState Этап обработки
+type AlertState string
+
+// AlertRule Правило тревоги датчика. Тревога поднимается, когда сырое значение события меньше min или больше max
+type AlertRule struct {
+	// Max Верхний порог, отсутствует если не ограничен
+	Max *float64 `json:"max"`
+
+	// Min Нижний порог, отсутствует если не ограничен
+	Min *float64 `json:"min"`
+
+	// Severity Важность поднимаемых тревог
+	Severity AlertRuleSeverity `json:"severity"`
+}
+
+// AlertRuleSeverity Важность поднимаемых тревог
+type AlertRuleSeverity string
+
+// Calibration Калибровка датчика. Если задана таблица, значение интерполируется по ней, иначе вычисляется как raw * scale + offset
+type Calibration struct {
+	// Offset Смещение линейной калибровки
+	Offset *float64 `json:"offset,omitempty"`
+
+	// Scale Множитель линейной калибровки, не может быть нулевым без таблицы
+	Scale *float64 `json:"scale,omitempty"`
+
+	// Table Таблица соответствия сырых значений откалиброванным, не менее двух точек по возрастанию raw
+	Table *[]CalibrationPoint `json:"table,omitempty"`
+
+	// Unit Единица измерения откалиброванного значения
+	Unit *string `json:"unit,omitempty"`
+}
+
+// CalibrationPoint Точка таблицы калибровки
+type CalibrationPoint struct {
+	// Raw Сырое значение
+	Raw float64 `json:"raw"`
+
+	// Value Откалиброванное значение
+	Value float64 `json:"value"`
+}
+
+// Change Запись потока изменений
+type Change struct {
+	// CreatedAt Время изменения
+	CreatedAt time.Time `json:"created_at"`
+
+	// Event Состояние датчика в конкретное время
+	Event *HistoryEvent `json:"event,omitempty"`
+
+	// Kind Что изменилось: event - принято событие, sensor - датчик зарегистрирован или изменён
+	Kind ChangeKind `json:"kind"`
+
+	// Sensor Датчик умного дома
+	Sensor *Sensor `json:"sensor,omitempty"`
+
+	// Seq Номер изменения, возрастает в порядке фиксации изменений
+	Seq int64 `json:"seq"`
+}
+
+// ChangeKind Что изменилось: event - принято событие, sensor - датчик зарегистрирован или изменён
+type ChangeKind string
+
+// Command Команда исполнительному устройству перейти в состояние payload
+type Command struct {
+	// CompletedAt Время подтверждения или неудачи
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// CreatedAt Время создания
+	CreatedAt time.Time `json:"created_at"`
+
+	// DeliveredAt Время передачи устройству
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// Payload Требуемое состояние устройства
+	Payload float64 `json:"payload"`
+
+	// Reason Причина неудачи
+	Reason *string `json:"reason,omitempty"`
+
+	// SensorId Идентификатор устройства
+	SensorId int64 `json:"sensor_id"`
+
+	// State Этап доставки: pending - ждёт устройство, delivered - передана устройству, acknowledged - устройство перешло в состояние команды, failed - устройство сообщило другое состояние или не подтвердило команду вовремя
+	State CommandState `json:"state"`
+}
+
+// CommandState Этап доставки: pending - ждёт устройство, delivered - передана устройству, acknowledged - устройство перешло в состояние команды, failed - устройство сообщило другое состояние или не подтвердило команду вовремя
+type CommandState string
+
+// CommandToCreate Команда исполнительному устройству, которую надо поставить в очередь
+type CommandToCreate struct {
+	// Payload Требуемое состояние устройства из допустимого для его типа диапазона
+	Payload float64 `json:"payload"`
+}
+
+// ErrorCode Стабильный машиночитаемый код ошибки
+type ErrorCode string
+
+// HistoryEvent Состояние датчика в конкретное время
+type HistoryEvent struct {
+	// CommandId Идентификатор команды, которую подтверждает событие исполнительного устройства
+	CommandId *int64 `json:"command_id,omitempty"`
+
+	// Id Идентификатор события
+	Id *int64 `json:"id,omitempty"`
+
+	// Payload Информация от датчика, может быть дробной
+	Payload float64 `json:"payload"`
+
+	// Readings Именованные показания каналов многоканального датчика
+	Readings *map[string]float64 `json:"readings,omitempty"`
+
+	// SensorId Идентификатор датчика
+	SensorId *int64 `json:"sensor_id,omitempty"`
+
+	// SensorSerialNumber Серийный номер датчика
+	SensorSerialNumber *string `json:"sensor_serial_number,omitempty"`
+
+	// Timestamp Время события
+	Timestamp time.Time `json:"timestamp"`
+
+	// Unit Единица измерения payload, присутствует только в откалиброванном представлении
+	Unit *string `json:"unit,omitempty"`
+}
+
+// ImportError Ошибка записи импортируемого файла
+type ImportError struct {
+	// Line Номер строки файла, с которой начинается запись
+	Line int `json:"line"`
+
+	// Message Причина
+	Message string `json:"message"`
+}
+
+// ImportReport Отчёт об импорте
+type ImportReport struct {
+	// Errors Ошибки записей, не более 1000
+	Errors []ImportError `json:"errors"`
+
+	// Failed Число записей с ошибками
+	Failed int `json:"failed"`
+
+	// Imported Число сохранённых записей
+	Imported int `json:"imported"`
+
+	// Skipped Число пропущенных записей, уже присутствующих в хранилище
+	Skipped int `json:"skipped"`
+
+	// Total Число прочитанных записей
+	Total int `json:"total"`
+}
+
+// NotificationChannel Канал, в который пользователь получает уведомления о тревогах своих датчиков
+type NotificationChannel struct {
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// Kind Способ доставки: email - письмо на адрес target, push - JSON POST запрос на URL target
+	Kind NotificationChannelKind `json:"kind"`
+
+	// MaxPerHour Максимальное число уведомлений за последний час, 0 - без ограничения
+	MaxPerHour *int64 `json:"max_per_hour,omitempty"`
+
+	// MinSeverity Минимальная важность тревог, о которых приходят уведомления
+	MinSeverity NotificationChannelMinSeverity `json:"min_severity"`
+
+	// QuietHours Интервал суток [start, end), в который уведомления не отправляются. Если end раньше start, интервал переходит через полночь
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+
+	// Target Адрес почты или URL
+	Target string `json:"target"`
+
+	// Template Шаблон text/template текста уведомления, по умолчанию стандартный текст
+	Template *string `json:"template,omitempty"`
+
+	// UserId Идентификатор пользователя
+	UserId int64 `json:"user_id"`
+}
+
+// NotificationChannelKind Способ доставки: email - письмо на адрес target, push - JSON POST запрос на URL target
+type NotificationChannelKind string
+
+// NotificationChannelMinSeverity Минимальная важность тревог, о которых приходят уведомления
+type NotificationChannelMinSeverity string
+
+// NotificationChannelToCreate Канал уведомлений о тревогах, который надо создать
+type NotificationChannelToCreate struct {
+	// Kind Способ доставки: email - письмо на адрес target, push - JSON POST запрос на URL target
+	Kind NotificationChannelToCreateKind `json:"kind"`
+
+	// MaxPerHour Максимальное число уведомлений за последний час, 0 - без ограничения
+	MaxPerHour *int64 `json:"max_per_hour,omitempty"`
+
+	// MinSeverity Минимальная важность тревог, о которых приходят уведомления, по умолчанию info
+	MinSeverity *NotificationChannelToCreateMinSeverity `json:"min_severity,omitempty"`
+
+	// QuietHours Интервал суток [start, end), в который уведомления не отправляются. Если end раньше start, интервал переходит через полночь
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+
+	// Target Адрес почты или URL
+	Target string `json:"target"`
+
+	// Template Шаблон text/template текста уведомления, по умолчанию стандартный текст.
+	// Доступны .Alert, .Sensor, .Value (откалиброванное значение) и .Unit
+	Template *string `json:"template,omitempty"`
+}
+
+// NotificationChannelToCreateKind Способ доставки: email - письмо на адрес target, push - JSON POST запрос на URL target
+type NotificationChannelToCreateKind string
+
+// NotificationChannelToCreateMinSeverity Минимальная важность тревог, о которых приходят уведомления, по умолчанию info
+type NotificationChannelToCreateMinSeverity string
+
+// NotificationDelivery Запись журнала доставки уведомления о тревоге в канал
+type NotificationDelivery struct {
+	// AlertId Идентификатор тревоги
+	AlertId int64 `json:"alert_id"`
+
+	// ChannelId Идентификатор канала
+	ChannelId int64 `json:"channel_id"`
+
+	// CreatedAt Время попытки доставки
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// Reason Ошибка отправки или причина подавления
+	Reason *string `json:"reason,omitempty"`
+
+	// Status Итог: sent - уведомление принято получателем, failed - отправка завершилась ошибкой, suppressed - не отправлялось из-за тихих часов или ограничения частоты
+	Status NotificationDeliveryStatus `json:"status"`
+}
+
+// NotificationDeliveryStatus Итог: sent - уведомление принято получателем, failed - отправка завершилась ошибкой, suppressed - не отправлялось из-за тихих часов или ограничения частоты
+type NotificationDeliveryStatus string
+
+// Problem Ошибка исполнения запроса в формате RFC 7807
+type Problem struct {
+	// Code Стабильный машиночитаемый код ошибки
+	Code ErrorCode `json:"code"`
+
+	// Detail Описание ошибки, у внутренних ошибок - общее
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance Путь запроса, на который получена ошибка
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestId Идентификатор запроса из заголовка X-Request-ID
+	RequestId string `json:"request_id"`
+
+	// Status Код ответа HTTP
+	Status int `json:"status"`
+
+	// Title Краткое описание кода ответа
+	Title string `json:"title"`
+
+	// Type Тип ошибки, about:blank - смысл ошибки определяют код ответа и поле code
+	Type string `json:"type"`
+}
+
+// QuietHours Интервал суток [start, end), в который уведомления не отправляются. Если end раньше start, интервал переходит через полночь
+type QuietHours struct {
+	// End Конец интервала
+	End string `json:"end"`
+
+	// Location Часовой пояс IANA, в котором заданы границы, по умолчанию UTC
+	Location *string `json:"location,omitempty"`
+
+	// Start Начало интервала
+	Start string `json:"start"`
+}
+
+// Schedule Расписание пользователя
+type Schedule struct {
+	// Action Действие расписания: command - команда устройству sensor_id перейти в состояние payload, webhook - JSON POST запрос на url, alert_rule - установка правила тревоги alert_rule датчику sensor_id, без alert_rule правило снимается
+	Action ScheduleAction `json:"action"`
+
+	// At Время однократного срабатывания
+	At *time.Time `json:"at,omitempty"`
+
+	// CreatedAt Время создания
+	CreatedAt time.Time `json:"created_at"`
+
+	// Cron Выражение cron, отсутствует у однократных расписаний
+	Cron *string `json:"cron,omitempty"`
+
+	// Enabled Включено ли расписание
+	Enabled bool `json:"enabled"`
+
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// Location Часовой пояс IANA, отсутствует для UTC
+	Location *string `json:"location,omitempty"`
+
+	// Name Название
+	Name string `json:"name"`
+
+	// NextRunAt Время следующего срабатывания, отсутствует у выключенных и отработавших расписаний
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+
+	// UserId Идентификатор пользователя
+	UserId int64 `json:"user_id"`
+}
+
+// ScheduleAction Действие расписания: command - команда устройству sensor_id перейти в состояние payload, webhook - JSON POST запрос на url, alert_rule - установка правила тревоги alert_rule датчику sensor_id, без alert_rule правило снимается
+type ScheduleAction struct {
+	// AlertRule Правило тревоги датчика. Тревога поднимается, когда сырое значение события меньше min или больше max
+	AlertRule *AlertRule `json:"alert_rule,omitempty"`
+
+	// Kind Вид действия
+	Kind ScheduleActionKind `json:"kind"`
+
+	// Payload Требуемое состояние устройства для команды
+	Payload *float64 `json:"payload"`
+
+	// SensorId Идентификатор устройства команды или датчика правила тревоги
+	SensorId *int64 `json:"sensor_id,omitempty"`
+
+	// Url Адрес webhook
+	Url *string `json:"url,omitempty"`
+}
+
+// ScheduleActionKind Вид действия
+type ScheduleActionKind string
+
+// ScheduleRun Запись истории срабатываний расписания
+type ScheduleRun struct {
+	// Error Ошибка действия, только у неудачных срабатываний
+	Error *string `json:"error,omitempty"`
+
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// ScheduleId Идентификатор расписания
+	ScheduleId int64 `json:"schedule_id"`
+
+	// ScheduledAt Время, на которое было запланировано срабатывание
+	ScheduledAt time.Time `json:"scheduled_at"`
+
+	// StartedAt Время выполнения действия
+	StartedAt time.Time `json:"started_at"`
+
+	// Status Итог
+	Status ScheduleRunStatus `json:"status"`
+}
+
+// ScheduleRunStatus Итог
+type ScheduleRunStatus string
+
+// ScheduleToCreate Расписание, которое надо создать. Задаётся ровно одно из cron и at
+type ScheduleToCreate struct {
+	// Action Действие расписания: command - команда устройству sensor_id перейти в состояние payload, webhook - JSON POST запрос на url, alert_rule - установка правила тревоги alert_rule датчику sensor_id, без alert_rule правило снимается
+	Action ScheduleAction `json:"action"`
+
+	// At Время однократного срабатывания
+	At *time.Time `json:"at,omitempty"`
+
+	// Cron Выражение из пяти полей: минута, час, день месяца, месяц, день недели
+	Cron *string `json:"cron,omitempty"`
+
+	// Enabled Включено ли расписание
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Location Часовой пояс IANA, в котором задано время cron, по умолчанию UTC
+	Location *string `json:"location,omitempty"`
+
+	// Name Название
+	Name string `json:"name"`
+}
+
+// ScheduleUpdate Включение или выключение расписания
+type ScheduleUpdate struct {
+	// Enabled Включено ли расписание
+	Enabled bool `json:"enabled"`
+}
+
+// Sensor Датчик умного дома
+type Sensor struct {
+	// AlertRule Правило тревоги датчика. Тревога поднимается, когда сырое значение события меньше min или больше max
+	AlertRule *AlertRule `json:"alert_rule,omitempty"`
+
+	// Calibration Калибровка датчика. Если задана таблица, значение интерполируется по ней, иначе вычисляется как raw * scale + offset
+	Calibration *Calibration `json:"calibration,omitempty"`
+
+	// CurrentReadings Показания каналов из readings последнего обработанного события
+	CurrentReadings *map[string]float64 `json:"current_readings,omitempty"`
+
+	// CurrentState Состояние датчика, соответствует значению в payload последнего обработанного события.
+	CurrentState float64 `json:"current_state"`
+
+	// Description Описание
+	Description string `json:"description"`
+
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// IsActive Флаг активности датчика
+	IsActive bool `json:"is_active"`
+
+	// LastActivity Время последнего события
+	LastActivity time.Time `json:"last_activity"`
+
+	// RegisteredAt Дата/время регистрации
+	RegisteredAt time.Time `json:"registered_at"`
+
+	// SerialNumber Серийный номер
+	SerialNumber string `json:"serial_number"`
+
+	// Type Тип, один из возвращаемых GET /sensor-types
+	Type string `json:"type"`
+
+	// Unit Единица измерения current_state, присутствует только в откалиброванном представлении
+	Unit *string `json:"unit,omitempty"`
+}
+
+// SensorEvent Событие датчика
+type SensorEvent struct {
+	// CommandId Идентификатор команды, которую подтверждает событие исполнительного устройства
+	CommandId *int64 `json:"command_id,omitempty"`
+
+	// Payload Информация от датчика, может быть дробной. Обязательна, если не переданы readings
+	Payload *float64 `json:"payload"`
+
+	// Readings Именованные показания каналов многоканального датчика
+	Readings *map[string]float64 `json:"readings,omitempty"`
+
+	// SensorSerialNumber Серийный номер датчика
+	SensorSerialNumber string `json:"sensor_serial_number"`
+}
+
+// SensorSortField Поле сортировки списка датчиков
+type SensorSortField string
+
+// SensorToCreate Датчик умного дома, который надо создать
+type SensorToCreate struct {
+	// Description Описание
+	Description string `json:"description"`
+
+	// IsActive Флаг активности датчика
+	IsActive bool `json:"is_active"`
+
+	// SerialNumber Серийный номер
+	SerialNumber string `json:"serial_number"`
+
+	// Type Тип, один из возвращаемых GET /sensor-types
+	Type string `json:"type"`
+}
+
+// SensorToUserBinding Связка датчика с пользователем
+type SensorToUserBinding struct {
+	// SensorId Идентификатор датчика
+	SensorId int64 `json:"sensor_id"`
+}
+
+// SensorType Тип датчика умного дома
+type SensorType struct {
+	// Actuator Исполнительное устройство, принимающее команды
+	Actuator *bool `json:"actuator,omitempty"`
+
+	// Description Описание
+	Description string `json:"description"`
+
+	// MaxPayload Максимальное допустимое значение payload, отсутствует если не ограничено
+	MaxPayload *float64 `json:"max_payload,omitempty"`
+
+	// MinPayload Минимальное допустимое значение payload, отсутствует если не ограничено
+	MinPayload *float64 `json:"min_payload,omitempty"`
+
+	// Semantics Смысл payload - дискретное состояние или результат измерения
+	Semantics SensorTypeSemantics `json:"semantics"`
+
+	// Type Тип
+	Type string `json:"type"`
+
+	// Unit Единица измерения payload, отсутствует у датчиков состояния
+	Unit *string `json:"unit,omitempty"`
+}
+
+// SensorTypeSemantics Смысл payload - дискретное состояние или результат измерения
+type SensorTypeSemantics string
+
+// SortOrder Направление сортировки
+type SortOrder string
+
+// User Пользователь умного дома
+type User struct {
+	// Id Идентификатор
+	Id int64 `json:"id"`
+
+	// Name Имя
+	Name string `json:"name"`
+}
+
+// UserToCreate Пользователь умного дома, которого надо создать
+type UserToCreate struct {
+	// Name Имя
+	Name string `json:"name"`
+}
+
+// GetChangesParams defines parameters for GetChanges.
+type GetChangesParams struct {
+	// Since Номер последнего полученного изменения, 0 - с начала потока
+	Since *int64 `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Максимальное количество изменений в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetCommandsParams defines parameters for GetCommands.
+type GetCommandsParams struct {
+	// State Этапы доставки команд в ответе, по умолчанию все
+	State *[]GetCommandsParamsState `form:"state,omitempty" json:"state,omitempty"`
+
+	// Limit Максимальное количество команд в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetCommandsParamsState defines parameters for GetCommands.
+type GetCommandsParamsState string
+
+// NextCommandParams defines parameters for NextCommand.
+type NextCommandParams struct {
+	// Wait Сколько ждать новую команду, от 0s до 2m
+	Wait *string `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// ExportEventsParams defines parameters for ExportEvents.
+type ExportEventsParams struct {
+	// SensorId Идентификаторы датчиков, без параметра выгружаются события всех датчиков
+	SensorId *[]int64 `form:"sensor_id,omitempty" json:"sensor_id,omitempty"`
+
+	// StartDate Начальное время UTC без часового пояса, например 2024-12-31T23:59:59
+	StartDate string `form:"start_date" json:"start_date"`
+
+	// EndDate Конечное время UTC без часового пояса, например 2024-12-31T23:59:59
+	EndDate string `form:"end_date" json:"end_date"`
+
+	// Raw Вернуть сырые значения без применения калибровки датчиков
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+}
+
+// GetSensorsParams defines parameters for GetSensors.
+type GetSensorsParams struct {
+	// Limit Максимальное число элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор следующей страницы из заголовка X-Next-Cursor предыдущего ответа. Остальные параметры запроса должны совпадать с запросом, вернувшим курсор
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Order Направление сортировки
+	Order *SortOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Sort Поле сортировки, при равных значениях датчики упорядочиваются по идентификатору
+	Sort *SensorSortField `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Type Тип датчика
+	Type *string `form:"type,omitempty" json:"type,omitempty"`
+
+	// IsActive Активность датчика
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// SerialPrefix Префикс серийного номера
+	SerialPrefix *string `form:"serial_prefix,omitempty" json:"serial_prefix,omitempty"`
+
+	// LastActivityFrom Начало диапазона времени последнего события (включительно), RFC 3339
+	LastActivityFrom *time.Time `form:"last_activity_from,omitempty" json:"last_activity_from,omitempty"`
+
+	// LastActivityTo Конец диапазона времени последнего события (включительно), RFC 3339
+	LastActivityTo *time.Time `form:"last_activity_to,omitempty" json:"last_activity_to,omitempty"`
+
+	// Raw Вернуть сырые значения без применения калибровки датчика
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+
+	// IfNoneMatch ETag из предыдущего ответа: если представление не изменилось, возвращается 304 без тела
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// HeadSensorsParams defines parameters for HeadSensors.
+type HeadSensorsParams struct {
+	// Limit Максимальное число элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор следующей страницы из заголовка X-Next-Cursor предыдущего ответа. Остальные параметры запроса должны совпадать с запросом, вернувшим курсор
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Order Направление сортировки
+	Order *SortOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Sort Поле сортировки, при равных значениях датчики упорядочиваются по идентификатору
+	Sort *SensorSortField `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Type Тип датчика
+	Type *string `form:"type,omitempty" json:"type,omitempty"`
+
+	// IsActive Активность датчика
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// SerialPrefix Префикс серийного номера
+	SerialPrefix *string `form:"serial_prefix,omitempty" json:"serial_prefix,omitempty"`
+
+	// LastActivityFrom Начало диапазона времени последнего события (включительно), RFC 3339
+	LastActivityFrom *time.Time `form:"last_activity_from,omitempty" json:"last_activity_from,omitempty"`
+
+	// LastActivityTo Конец диапазона времени последнего события (включительно), RFC 3339
+	LastActivityTo *time.Time `form:"last_activity_to,omitempty" json:"last_activity_to,omitempty"`
+
+	// Raw Вернуть сырые значения без применения калибровки датчика
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+
+	// IfNoneMatch ETag из предыдущего ответа: если представление не изменилось, возвращается 304 без тела
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetSensorParams defines parameters for GetSensor.
+type GetSensorParams struct {
+	// Raw Вернуть сырые значения без применения калибровки датчика
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+
+	// IfNoneMatch ETag из предыдущего ответа: если представление не изменилось, возвращается 304 без тела
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince Время из заголовка Last-Modified предыдущего ответа (HTTP-date): если датчик с тех пор не изменился, возвращается 304 без тела. Не учитывается, если передан If-None-Match
+	IfModifiedSince *string `json:"If-Modified-Since,omitempty"`
+}
+
+// HeadSensorParams defines parameters for HeadSensor.
+type HeadSensorParams struct {
+	// Raw Вернуть сырые значения без применения калибровки датчика
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+
+	// IfNoneMatch ETag из предыдущего ответа: если представление не изменилось, возвращается 304 без тела
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince Время из заголовка Last-Modified предыдущего ответа (HTTP-date): если датчик с тех пор не изменился, возвращается 304 без тела. Не учитывается, если передан If-None-Match
+	IfModifiedSince *string `json:"If-Modified-Since,omitempty"`
+}
+
+// DeleteSensorAlertRuleParams defines parameters for DeleteSensorAlertRule.
+type DeleteSensorAlertRuleParams struct {
+	// IfMatch ETag датчика из ответа GET /sensors/{sensor_id} (сырого или калиброванного представления) или *: изменение выполняется, только если датчик с тех пор не изменился, иначе возвращается 412
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// SetSensorAlertRuleParams defines parameters for SetSensorAlertRule.
+type SetSensorAlertRuleParams struct {
+	// IfMatch ETag датчика из ответа GET /sensors/{sensor_id} (сырого или калиброванного представления) или *: изменение выполняется, только если датчик с тех пор не изменился, иначе возвращается 412
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// DeleteSensorCalibrationParams defines parameters for DeleteSensorCalibration.
+type DeleteSensorCalibrationParams struct {
+	// IfMatch ETag датчика из ответа GET /sensors/{sensor_id} (сырого или калиброванного представления) или *: изменение выполняется, только если датчик с тех пор не изменился, иначе возвращается 412
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// SetSensorCalibrationParams defines parameters for SetSensorCalibration.
+type SetSensorCalibrationParams struct {
+	// IfMatch ETag датчика из ответа GET /sensors/{sensor_id} (сырого или калиброванного представления) или *: изменение выполняется, только если датчик с тех пор не изменился, иначе возвращается 412
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// SubscribeSensorEventsParams defines parameters for SubscribeSensorEvents.
+type SubscribeSensorEventsParams struct {
+	// Raw Вернуть сырые значения без применения калибровки датчика
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+}
+
+// GetEventsHistoryBySensorIDParams defines parameters for GetEventsHistoryBySensorID.
+type GetEventsHistoryBySensorIDParams struct {
+	// StartDate Начальное время UTC без часового пояса, например 2024-12-31T23:59:59
+	StartDate string `form:"start_date" json:"start_date"`
+
+	// EndDate Конечное время UTC без часового пояса, например 2024-12-31T23:59:59
+	EndDate string `form:"end_date" json:"end_date"`
+
+	// Raw Вернуть сырые значения без применения калибровки датчика
+	Raw *bool `form:"raw,omitempty" json:"raw,omitempty"`
+
+	// Limit Максимальное число элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор следующей страницы из заголовка X-Next-Cursor предыдущего ответа. Остальные параметры запроса должны совпадать с запросом, вернувшим курсор
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Order Направление сортировки
+	Order *SortOrder `form:"order,omitempty" json:"order,omitempty"`
+}
+
+// GetUserAlertsParams defines parameters for GetUserAlerts.
+type GetUserAlertsParams struct {
+	// State Этапы обработки тревог, all - все этапы. По умолчанию open и acknowledged
+	State *[]GetUserAlertsParamsState `form:"state,omitempty" json:"state,omitempty"`
+
+	// Limit Максимальное количество тревог в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUserAlertsParamsState defines parameters for GetUserAlerts.
+type GetUserAlertsParamsState string
+
+// GetNotificationDeliveriesParams defines parameters for GetNotificationDeliveries.
+type GetNotificationDeliveriesParams struct {
+	// Limit Максимальное количество записей в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetScheduleRunsParams defines parameters for GetScheduleRuns.
+type GetScheduleRunsParams struct {
+	// Limit Максимальное количество записей в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUserSensorsParams defines parameters for GetUserSensors.
+type GetUserSensorsParams struct {
+	// IfNoneMatch ETag из предыдущего ответа: если представление не изменилось, возвращается 304 без тела
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// HeadUserSensorsParams defines parameters for HeadUserSensors.
+type HeadUserSensorsParams struct {
+	// IfNoneMatch ETag из предыдущего ответа: если представление не изменилось, возвращается 304 без тела
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// SendCommandJSONRequestBody defines body for SendCommand for application/json ContentType.
+type SendCommandJSONRequestBody = CommandToCreate
+
+// RegisterEventJSONRequestBody defines body for RegisterEvent for application/json ContentType.
+type RegisterEventJSONRequestBody = SensorEvent
+
+// RegisterSensorJSONRequestBody defines body for RegisterSensor for application/json ContentType.
+type RegisterSensorJSONRequestBody = SensorToCreate
+
+// SetSensorAlertRuleJSONRequestBody defines body for SetSensorAlertRule for application/json ContentType.
+type SetSensorAlertRuleJSONRequestBody = AlertRule
+
+// SetSensorCalibrationJSONRequestBody defines body for SetSensorCalibration for application/json ContentType.
+type SetSensorCalibrationJSONRequestBody = Calibration
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = UserToCreate
+
+// CreateNotificationChannelJSONRequestBody defines body for CreateNotificationChannel for application/json ContentType.
+type CreateNotificationChannelJSONRequestBody = NotificationChannelToCreate
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody = ScheduleToCreate
+
+// PatchScheduleJSONRequestBody defines body for PatchSchedule for application/json ContentType.
+type PatchScheduleJSONRequestBody = ScheduleUpdate
+
+// BindSensorToUserJSONRequestBody defines body for BindSensorToUser for application/json ContentType.
+type BindSensorToUserJSONRequestBody = SensorToUserBinding
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Получение потока изменений
+	// (GET /changes)
+	GetChanges(c *gin.Context, params GetChangesParams)
+	// Получение доступных методов
+	// (OPTIONS /changes)
+	ChangesOptions(c *gin.Context)
+	// Получение команд устройства
+	// (GET /devices/{sensor_id}/commands)
+	GetCommands(c *gin.Context, sensorId int64, params GetCommandsParams)
+	// Получение доступных методов
+	// (OPTIONS /devices/{sensor_id}/commands)
+	CommandsOptions(c *gin.Context, sensorId int64)
+	// Отправка команды исполнительному устройству
+	// (POST /devices/{sensor_id}/commands)
+	SendCommand(c *gin.Context, sensorId int64)
+	// Получение устройством следующей команды
+	// (GET /devices/{sensor_id}/commands/next)
+	NextCommand(c *gin.Context, sensorId int64, params NextCommandParams)
+	// Получение доступных методов
+	// (OPTIONS /devices/{sensor_id}/commands/next)
+	NextCommandOptions(c *gin.Context, sensorId int64)
+	// Получение команды устройства
+	// (GET /devices/{sensor_id}/commands/{command_id})
+	GetCommand(c *gin.Context, sensorId int64, commandId int64)
+	// Получение доступных методов
+	// (OPTIONS /devices/{sensor_id}/commands/{command_id})
+	CommandOptions(c *gin.Context, sensorId int64, commandId int64)
+	// Получение доступных методов
+	// (OPTIONS /events)
+	EventsOptions(c *gin.Context)
+	// Регистрация события от датчика
+	// (POST /events)
+	RegisterEvent(c *gin.Context)
+	// Выгрузка событий нескольких датчиков
+	// (GET /events/export)
+	ExportEvents(c *gin.Context, params ExportEventsParams)
+	// Получение доступных методов
+	// (OPTIONS /events/export)
+	EventsExportOptions(c *gin.Context)
+	// Получение доступных методов
+	// (OPTIONS /events/import)
+	EventsImportOptions(c *gin.Context)
+	// Импорт исторических событий
+	// (POST /events/import)
+	ImportEvents(c *gin.Context)
+	// Метрики сервиса
+	// (GET /metrics)
+	GetMetrics(c *gin.Context)
+	// Проверка доступности
+	// (GET /ping)
+	Ping(c *gin.Context)
+	// Получение типов датчиков
+	// (GET /sensor-types)
+	GetSensorTypes(c *gin.Context)
+	// Получение доступных методов
+	// (OPTIONS /sensor-types)
+	SensorTypesOptions(c *gin.Context)
+	// Получение всех датчиков
+	// (GET /sensors)
+	GetSensors(c *gin.Context, params GetSensorsParams)
+	// Запрос заголовков
+	// (HEAD /sensors)
+	HeadSensors(c *gin.Context, params HeadSensorsParams)
+	// Получение доступных методов
+	// (OPTIONS /sensors)
+	SensorsOptions(c *gin.Context)
+	// Регистрация датчика
+	// (POST /sensors)
+	RegisterSensor(c *gin.Context)
+	// Получение доступных методов
+	// (OPTIONS /sensors/import)
+	SensorsImportOptions(c *gin.Context)
+	// Импорт датчиков
+	// (POST /sensors/import)
+	ImportSensors(c *gin.Context)
+	// Получение датчика
+	// (GET /sensors/{sensor_id})
+	GetSensor(c *gin.Context, sensorId int64, params GetSensorParams)
+	// Запрос заголовков
+	// (HEAD /sensors/{sensor_id})
+	HeadSensor(c *gin.Context, sensorId int64, params HeadSensorParams)
+	// Получение доступных методов
+	// (OPTIONS /sensors/{sensor_id})
+	SensorOptions(c *gin.Context, sensorId int64)
+	// Удаление правила тревоги датчика
+	// (DELETE /sensors/{sensor_id}/alert-rule)
+	DeleteSensorAlertRule(c *gin.Context, sensorId int64, params DeleteSensorAlertRuleParams)
+	// Получение доступных методов
+	// (OPTIONS /sensors/{sensor_id}/alert-rule)
+	SensorAlertRuleOptions(c *gin.Context, sensorId int64)
+	// Установка правила тревоги датчика
+	// (PUT /sensors/{sensor_id}/alert-rule)
+	SetSensorAlertRule(c *gin.Context, sensorId int64, params SetSensorAlertRuleParams)
+	// Удаление калибровки датчика
+	// (DELETE /sensors/{sensor_id}/calibration)
+	DeleteSensorCalibration(c *gin.Context, sensorId int64, params DeleteSensorCalibrationParams)
+	// Получение доступных методов
+	// (OPTIONS /sensors/{sensor_id}/calibration)
+	SensorCalibrationOptions(c *gin.Context, sensorId int64)
+	// Установка калибровки датчика
+	// (PUT /sensors/{sensor_id}/calibration)
+	SetSensorCalibration(c *gin.Context, sensorId int64, params SetSensorCalibrationParams)
+	// Открытие ws по датчику
+	// (GET /sensors/{sensor_id}/events)
+	SubscribeSensorEvents(c *gin.Context, sensorId int64, params SubscribeSensorEventsParams)
+	// Получение истории событий у датчика в диапазоне времени
+	// (GET /sensors/{sensor_id}/history)
+	GetEventsHistoryBySensorID(c *gin.Context, sensorId int64, params GetEventsHistoryBySensorIDParams)
+	// Получение доступных методов
+	// (OPTIONS /users)
+	UsersOptions(c *gin.Context)
+	// Создание пользователя
+	// (POST /users)
+	CreateUser(c *gin.Context)
+	// Получение тревог пользователя
+	// (GET /users/{user_id}/alerts)
+	GetUserAlerts(c *gin.Context, userId int64, params GetUserAlertsParams)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/alerts)
+	UserAlertsOptions(c *gin.Context, userId int64)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/alerts/{alert_id}/acknowledge)
+	AcknowledgeAlertOptions(c *gin.Context, userId int64, alertId int64)
+	// Подтверждение тревоги
+	// (POST /users/{user_id}/alerts/{alert_id}/acknowledge)
+	AcknowledgeAlert(c *gin.Context, userId int64, alertId int64)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/alerts/{alert_id}/resolve)
+	ResolveAlertOptions(c *gin.Context, userId int64, alertId int64)
+	// Устранение тревоги
+	// (POST /users/{user_id}/alerts/{alert_id}/resolve)
+	ResolveAlert(c *gin.Context, userId int64, alertId int64)
+	// Получение каналов уведомлений пользователя
+	// (GET /users/{user_id}/notification-channels)
+	GetNotificationChannels(c *gin.Context, userId int64)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/notification-channels)
+	NotificationChannelsOptions(c *gin.Context, userId int64)
+	// Создание канала уведомлений
+	// (POST /users/{user_id}/notification-channels)
+	CreateNotificationChannel(c *gin.Context, userId int64)
+	// Удаление канала уведомлений
+	// (DELETE /users/{user_id}/notification-channels/{channel_id})
+	DeleteNotificationChannel(c *gin.Context, userId int64, channelId int64)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/notification-channels/{channel_id})
+	NotificationChannelOptions(c *gin.Context, userId int64, channelId int64)
+	// Получение журнала доставки
+	// (GET /users/{user_id}/notification-channels/{channel_id}/deliveries)
+	GetNotificationDeliveries(c *gin.Context, userId int64, channelId int64, params GetNotificationDeliveriesParams)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/notification-channels/{channel_id}/deliveries)
+	NotificationDeliveriesOptions(c *gin.Context, userId int64, channelId int64)
+	// Получение расписаний пользователя
+	// (GET /users/{user_id}/schedules)
+	GetSchedules(c *gin.Context, userId int64)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/schedules)
+	SchedulesOptions(c *gin.Context, userId int64)
+	// Создание расписания
+	// (POST /users/{user_id}/schedules)
+	CreateSchedule(c *gin.Context, userId int64)
+	// Удаление расписания
+	// (DELETE /users/{user_id}/schedules/{schedule_id})
+	DeleteSchedule(c *gin.Context, userId int64, scheduleId int64)
+	// Получение расписания
+	// (GET /users/{user_id}/schedules/{schedule_id})
+	GetSchedule(c *gin.Context, userId int64, scheduleId int64)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/schedules/{schedule_id})
+	ScheduleOptions(c *gin.Context, userId int64, scheduleId int64)
+	// Включение или выключение расписания
+	// (PATCH /users/{user_id}/schedules/{schedule_id})
+	PatchSchedule(c *gin.Context, userId int64, scheduleId int64)
+	// Получение истории срабатываний расписания
+	// (GET /users/{user_id}/schedules/{schedule_id}/runs)
+	GetScheduleRuns(c *gin.Context, userId int64, scheduleId int64, params GetScheduleRunsParams)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/schedules/{schedule_id}/runs)
+	ScheduleRunsOptions(c *gin.Context, userId int64, scheduleId int64)
+	// Получений датчиков пользователя
+	// (GET /users/{user_id}/sensors)
+	GetUserSensors(c *gin.Context, userId int64, params GetUserSensorsParams)
+	// Запрос заголовков
+	// (HEAD /users/{user_id}/sensors)
+	HeadUserSensors(c *gin.Context, userId int64, params HeadUserSensorsParams)
+	// Получение доступных методов
+	// (OPTIONS /users/{user_id}/sensors)
+	UsersSensorsOptions(c *gin.Context, userId int64)
+	// Привязка датчика к пользователю
+	// (POST /users/{user_id}/sensors)
+	BindSensorToUser(c *gin.Context, userId int64)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetChanges operation middleware
+func (siw *ServerInterfaceWrapper) GetChanges(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChangesParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", c.Request.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetChanges(c, params)
+}
+
+// ChangesOptions operation middleware
+func (siw *ServerInterfaceWrapper) ChangesOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ChangesOptions(c)
+}
+
+// GetCommands operation middleware
+func (siw *ServerInterfaceWrapper) GetCommands(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCommandsParams
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCommands(c, sensorId, params)
+}
+
+// CommandsOptions operation middleware
+func (siw *ServerInterfaceWrapper) CommandsOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CommandsOptions(c, sensorId)
+}
+
+// SendCommand operation middleware
+func (siw *ServerInterfaceWrapper) SendCommand(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SendCommand(c, sensorId)
+}
+
+// NextCommand operation middleware
+func (siw *ServerInterfaceWrapper) NextCommand(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params NextCommandParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", c.Request.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter wait: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.NextCommand(c, sensorId, params)
+}
+
+// NextCommandOptions operation middleware
+func (siw *ServerInterfaceWrapper) NextCommandOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.NextCommandOptions(c, sensorId)
+}
+
+// GetCommand operation middleware
+func (siw *ServerInterfaceWrapper) GetCommand(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "command_id" -------------
+	var commandId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "command_id", c.Param("command_id"), &commandId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter command_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCommand(c, sensorId, commandId)
+}
+
+// CommandOptions operation middleware
+func (siw *ServerInterfaceWrapper) CommandOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "command_id" -------------
+	var commandId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "command_id", c.Param("command_id"), &commandId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter command_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CommandOptions(c, sensorId, commandId)
+}
+
+// EventsOptions operation middleware
+func (siw *ServerInterfaceWrapper) EventsOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EventsOptions(c)
+}
+
+// RegisterEvent operation middleware
+func (siw *ServerInterfaceWrapper) RegisterEvent(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RegisterEvent(c)
+}
+
+// ExportEvents operation middleware
+func (siw *ServerInterfaceWrapper) ExportEvents(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportEventsParams
+
+	// ------------- Optional query parameter "sensor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sensor_id", c.Request.URL.Query(), &params.SensorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "start_date" -------------
+
+	if paramValue := c.Query("start_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument start_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "start_date", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "end_date" -------------
+
+	if paramValue := c.Query("end_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument end_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "end_date", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportEvents(c, params)
+}
+
+// EventsExportOptions operation middleware
+func (siw *ServerInterfaceWrapper) EventsExportOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EventsExportOptions(c)
+}
+
+// EventsImportOptions operation middleware
+func (siw *ServerInterfaceWrapper) EventsImportOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EventsImportOptions(c)
+}
+
+// ImportEvents operation middleware
+func (siw *ServerInterfaceWrapper) ImportEvents(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportEvents(c)
+}
+
+// GetMetrics operation middleware
+func (siw *ServerInterfaceWrapper) GetMetrics(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMetrics(c)
+}
+
+// Ping operation middleware
+func (siw *ServerInterfaceWrapper) Ping(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Ping(c)
+}
+
+// GetSensorTypes operation middleware
+func (siw *ServerInterfaceWrapper) GetSensorTypes(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSensorTypes(c)
+}
+
+// SensorTypesOptions operation middleware
+func (siw *ServerInterfaceWrapper) SensorTypesOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SensorTypesOptions(c)
+}
+
+// GetSensors operation middleware
+func (siw *ServerInterfaceWrapper) GetSensors(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSensorsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", c.Request.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "is_active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_active", c.Request.URL.Query(), &params.IsActive)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter is_active: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "serial_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "serial_prefix", c.Request.URL.Query(), &params.SerialPrefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter serial_prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_activity_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_activity_from", c.Request.URL.Query(), &params.LastActivityFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_activity_from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_activity_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_activity_to", c.Request.URL.Query(), &params.LastActivityTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_activity_to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSensors(c, params)
+}
+
+// HeadSensors operation middleware
+func (siw *ServerInterfaceWrapper) HeadSensors(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HeadSensorsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", c.Request.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "is_active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_active", c.Request.URL.Query(), &params.IsActive)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter is_active: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "serial_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "serial_prefix", c.Request.URL.Query(), &params.SerialPrefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter serial_prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_activity_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_activity_from", c.Request.URL.Query(), &params.LastActivityFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_activity_from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_activity_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_activity_to", c.Request.URL.Query(), &params.LastActivityTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_activity_to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.HeadSensors(c, params)
+}
+
+// SensorsOptions operation middleware
+func (siw *ServerInterfaceWrapper) SensorsOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SensorsOptions(c)
+}
+
+// RegisterSensor operation middleware
+func (siw *ServerInterfaceWrapper) RegisterSensor(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RegisterSensor(c)
+}
+
+// SensorsImportOptions operation middleware
+func (siw *ServerInterfaceWrapper) SensorsImportOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SensorsImportOptions(c)
+}
+
+// ImportSensors operation middleware
+func (siw *ServerInterfaceWrapper) ImportSensors(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportSensors(c)
+}
+
+// GetSensor operation middleware
+func (siw *ServerInterfaceWrapper) GetSensor(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSensorParams
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSensor(c, sensorId, params)
+}
+
+// HeadSensor operation middleware
+func (siw *ServerInterfaceWrapper) HeadSensor(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HeadSensorParams
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Modified-Since, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Modified-Since: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.HeadSensor(c, sensorId, params)
+}
+
+// SensorOptions operation middleware
+func (siw *ServerInterfaceWrapper) SensorOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SensorOptions(c, sensorId)
+}
+
+// DeleteSensorAlertRule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSensorAlertRule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSensorAlertRuleParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSensorAlertRule(c, sensorId, params)
+}
+
+// SensorAlertRuleOptions operation middleware
+func (siw *ServerInterfaceWrapper) SensorAlertRuleOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SensorAlertRuleOptions(c, sensorId)
+}
+
+// SetSensorAlertRule operation middleware
+func (siw *ServerInterfaceWrapper) SetSensorAlertRule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetSensorAlertRuleParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetSensorAlertRule(c, sensorId, params)
+}
+
+// DeleteSensorCalibration operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSensorCalibration(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSensorCalibrationParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSensorCalibration(c, sensorId, params)
+}
+
+// SensorCalibrationOptions operation middleware
+func (siw *ServerInterfaceWrapper) SensorCalibrationOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SensorCalibrationOptions(c, sensorId)
+}
+
+// SetSensorCalibration operation middleware
+func (siw *ServerInterfaceWrapper) SetSensorCalibration(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetSensorCalibrationParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetSensorCalibration(c, sensorId, params)
+}
+
+// SubscribeSensorEvents operation middleware
+func (siw *ServerInterfaceWrapper) SubscribeSensorEvents(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SubscribeSensorEventsParams
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SubscribeSensorEvents(c, sensorId, params)
+}
+
+// GetEventsHistoryBySensorID operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsHistoryBySensorID(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "sensor_id" -------------
+	var sensorId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sensor_id", c.Param("sensor_id"), &sensorId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sensor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsHistoryBySensorIDParams
+
+	// ------------- Required query parameter "start_date" -------------
+
+	if paramValue := c.Query("start_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument start_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "start_date", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "end_date" -------------
+
+	if paramValue := c.Query("end_date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument end_date is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "end_date", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter end_date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "raw" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "raw", c.Request.URL.Query(), &params.Raw)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter raw: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", c.Request.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEventsHistoryBySensorID(c, sensorId, params)
+}
+
+// UsersOptions operation middleware
+func (siw *ServerInterfaceWrapper) UsersOptions(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UsersOptions(c)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateUser(c)
+}
+
+// GetUserAlerts operation middleware
+func (siw *ServerInterfaceWrapper) GetUserAlerts(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserAlertsParams
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserAlerts(c, userId, params)
+}
+
+// UserAlertsOptions operation middleware
+func (siw *ServerInterfaceWrapper) UserAlertsOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UserAlertsOptions(c, userId)
+}
+
+// AcknowledgeAlertOptions operation middleware
+func (siw *ServerInterfaceWrapper) AcknowledgeAlertOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "alert_id" -------------
+	var alertId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alert_id", c.Param("alert_id"), &alertId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter alert_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AcknowledgeAlertOptions(c, userId, alertId)
+}
+
+// AcknowledgeAlert operation middleware
+func (siw *ServerInterfaceWrapper) AcknowledgeAlert(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "alert_id" -------------
+	var alertId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alert_id", c.Param("alert_id"), &alertId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter alert_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AcknowledgeAlert(c, userId, alertId)
+}
+
+// ResolveAlertOptions operation middleware
+func (siw *ServerInterfaceWrapper) ResolveAlertOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "alert_id" -------------
+	var alertId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alert_id", c.Param("alert_id"), &alertId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter alert_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ResolveAlertOptions(c, userId, alertId)
+}
+
+// ResolveAlert operation middleware
+func (siw *ServerInterfaceWrapper) ResolveAlert(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "alert_id" -------------
+	var alertId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alert_id", c.Param("alert_id"), &alertId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter alert_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ResolveAlert(c, userId, alertId)
+}
+
+// GetNotificationChannels operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationChannels(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationChannels(c, userId)
+}
+
+// NotificationChannelsOptions operation middleware
+func (siw *ServerInterfaceWrapper) NotificationChannelsOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.NotificationChannelsOptions(c, userId)
+}
+
+// CreateNotificationChannel operation middleware
+func (siw *ServerInterfaceWrapper) CreateNotificationChannel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateNotificationChannel(c, userId)
+}
+
+// DeleteNotificationChannel operation middleware
+func (siw *ServerInterfaceWrapper) DeleteNotificationChannel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "channel_id" -------------
+	var channelId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "channel_id", c.Param("channel_id"), &channelId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteNotificationChannel(c, userId, channelId)
+}
+
+// NotificationChannelOptions operation middleware
+func (siw *ServerInterfaceWrapper) NotificationChannelOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "channel_id" -------------
+	var channelId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "channel_id", c.Param("channel_id"), &channelId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.NotificationChannelOptions(c, userId, channelId)
+}
+
+// GetNotificationDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "channel_id" -------------
+	var channelId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "channel_id", c.Param("channel_id"), &channelId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationDeliveries(c, userId, channelId, params)
+}
+
+// NotificationDeliveriesOptions operation middleware
+func (siw *ServerInterfaceWrapper) NotificationDeliveriesOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "channel_id" -------------
+	var channelId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "channel_id", c.Param("channel_id"), &channelId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter channel_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.NotificationDeliveriesOptions(c, userId, channelId)
+}
+
+// GetSchedules operation middleware
+func (siw *ServerInterfaceWrapper) GetSchedules(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSchedules(c, userId)
+}
+
+// SchedulesOptions operation middleware
+func (siw *ServerInterfaceWrapper) SchedulesOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SchedulesOptions(c, userId)
+}
+
+// CreateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateSchedule(c, userId)
+}
+
+// DeleteSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", c.Param("schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter schedule_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSchedule(c, userId, scheduleId)
+}
+
+// GetSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", c.Param("schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter schedule_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSchedule(c, userId, scheduleId)
+}
+
+// ScheduleOptions operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", c.Param("schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter schedule_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ScheduleOptions(c, userId, scheduleId)
+}
+
+// PatchSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PatchSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", c.Param("schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter schedule_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchSchedule(c, userId, scheduleId)
+}
+
+// GetScheduleRuns operation middleware
+func (siw *ServerInterfaceWrapper) GetScheduleRuns(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", c.Param("schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter schedule_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetScheduleRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetScheduleRuns(c, userId, scheduleId, params)
+}
+
+// ScheduleRunsOptions operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleRunsOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", c.Param("schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter schedule_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ScheduleRunsOptions(c, userId, scheduleId)
+}
+
+// GetUserSensors operation middleware
+func (siw *ServerInterfaceWrapper) GetUserSensors(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserSensorsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserSensors(c, userId, params)
+}
+
+// HeadUserSensors operation middleware
+func (siw *ServerInterfaceWrapper) HeadUserSensors(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HeadUserSensorsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.HeadUserSensors(c, userId, params)
+}
+
+// UsersSensorsOptions operation middleware
+func (siw *ServerInterfaceWrapper) UsersSensorsOptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UsersSensorsOptions(c, userId)
+}
+
+// BindSensorToUser operation middleware
+func (siw *ServerInterfaceWrapper) BindSensorToUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.BindSensorToUser(c, userId)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/changes", wrapper.GetChanges)
+	router.OPTIONS(options.BaseURL+"/changes", wrapper.ChangesOptions)
+	router.GET(options.BaseURL+"/devices/:sensor_id/commands", wrapper.GetCommands)
+	router.OPTIONS(options.BaseURL+"/devices/:sensor_id/commands", wrapper.CommandsOptions)
+	router.POST(options.BaseURL+"/devices/:sensor_id/commands", wrapper.SendCommand)
+	router.GET(options.BaseURL+"/devices/:sensor_id/commands/next", wrapper.NextCommand)
+	router.OPTIONS(options.BaseURL+"/devices/:sensor_id/commands/next", wrapper.NextCommandOptions)
+	router.GET(options.BaseURL+"/devices/:sensor_id/commands/:command_id", wrapper.GetCommand)
+	router.OPTIONS(options.BaseURL+"/devices/:sensor_id/commands/:command_id", wrapper.CommandOptions)
+	router.OPTIONS(options.BaseURL+"/events", wrapper.EventsOptions)
+	router.POST(options.BaseURL+"/events", wrapper.RegisterEvent)
+	router.GET(options.BaseURL+"/events/export", wrapper.ExportEvents)
+	router.OPTIONS(options.BaseURL+"/events/export", wrapper.EventsExportOptions)
+	router.OPTIONS(options.BaseURL+"/events/import", wrapper.EventsImportOptions)
+	router.POST(options.BaseURL+"/events/import", wrapper.ImportEvents)
+	router.GET(options.BaseURL+"/metrics", wrapper.GetMetrics)
+	router.GET(options.BaseURL+"/ping", wrapper.Ping)
+	router.GET(options.BaseURL+"/sensor-types", wrapper.GetSensorTypes)
+	router.OPTIONS(options.BaseURL+"/sensor-types", wrapper.SensorTypesOptions)
+	router.GET(options.BaseURL+"/sensors", wrapper.GetSensors)
+	router.HEAD(options.BaseURL+"/sensors", wrapper.HeadSensors)
+	router.OPTIONS(options.BaseURL+"/sensors", wrapper.SensorsOptions)
+	router.POST(options.BaseURL+"/sensors", wrapper.RegisterSensor)
+	router.OPTIONS(options.BaseURL+"/sensors/import", wrapper.SensorsImportOptions)
+	router.POST(options.BaseURL+"/sensors/import", wrapper.ImportSensors)
+	router.GET(options.BaseURL+"/sensors/:sensor_id", wrapper.GetSensor)
+	router.HEAD(options.BaseURL+"/sensors/:sensor_id", wrapper.HeadSensor)
+	router.OPTIONS(options.BaseURL+"/sensors/:sensor_id", wrapper.SensorOptions)
+	router.DELETE(options.BaseURL+"/sensors/:sensor_id/alert-rule", wrapper.DeleteSensorAlertRule)
+	router.OPTIONS(options.BaseURL+"/sensors/:sensor_id/alert-rule", wrapper.SensorAlertRuleOptions)
+	router.PUT(options.BaseURL+"/sensors/:sensor_id/alert-rule", wrapper.SetSensorAlertRule)
+	router.DELETE(options.BaseURL+"/sensors/:sensor_id/calibration", wrapper.DeleteSensorCalibration)
+	router.OPTIONS(options.BaseURL+"/sensors/:sensor_id/calibration", wrapper.SensorCalibrationOptions)
+	router.PUT(options.BaseURL+"/sensors/:sensor_id/calibration", wrapper.SetSensorCalibration)
+	router.GET(options.BaseURL+"/sensors/:sensor_id/events", wrapper.SubscribeSensorEvents)
+	router.GET(options.BaseURL+"/sensors/:sensor_id/history", wrapper.GetEventsHistoryBySensorID)
+	router.OPTIONS(options.BaseURL+"/users", wrapper.UsersOptions)
+	router.POST(options.BaseURL+"/users", wrapper.CreateUser)
+	router.GET(options.BaseURL+"/users/:user_id/alerts", wrapper.GetUserAlerts)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/alerts", wrapper.UserAlertsOptions)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/alerts/:alert_id/acknowledge", wrapper.AcknowledgeAlertOptions)
+	router.POST(options.BaseURL+"/users/:user_id/alerts/:alert_id/acknowledge", wrapper.AcknowledgeAlert)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/alerts/:alert_id/resolve", wrapper.ResolveAlertOptions)
+	router.POST(options.BaseURL+"/users/:user_id/alerts/:alert_id/resolve", wrapper.ResolveAlert)
+	router.GET(options.BaseURL+"/users/:user_id/notification-channels", wrapper.GetNotificationChannels)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/notification-channels", wrapper.NotificationChannelsOptions)
+	router.POST(options.BaseURL+"/users/:user_id/notification-channels", wrapper.CreateNotificationChannel)
+	router.DELETE(options.BaseURL+"/users/:user_id/notification-channels/:channel_id", wrapper.DeleteNotificationChannel)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/notification-channels/:channel_id", wrapper.NotificationChannelOptions)
+	router.GET(options.BaseURL+"/users/:user_id/notification-channels/:channel_id/deliveries", wrapper.GetNotificationDeliveries)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/notification-channels/:channel_id/deliveries", wrapper.NotificationDeliveriesOptions)
+	router.GET(options.BaseURL+"/users/:user_id/schedules", wrapper.GetSchedules)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/schedules", wrapper.SchedulesOptions)
+	router.POST(options.BaseURL+"/users/:user_id/schedules", wrapper.CreateSchedule)
+	router.DELETE(options.BaseURL+"/users/:user_id/schedules/:schedule_id", wrapper.DeleteSchedule)
+	router.GET(options.BaseURL+"/users/:user_id/schedules/:schedule_id", wrapper.GetSchedule)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/schedules/:schedule_id", wrapper.ScheduleOptions)
+	router.PATCH(options.BaseURL+"/users/:user_id/schedules/:schedule_id", wrapper.PatchSchedule)
+	router.GET(options.BaseURL+"/users/:user_id/schedules/:schedule_id/runs", wrapper.GetScheduleRuns)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/schedules/:schedule_id/runs", wrapper.ScheduleRunsOptions)
+	router.GET(options.BaseURL+"/users/:user_id/sensors", wrapper.GetUserSensors)
+	router.HEAD(options.BaseURL+"/users/:user_id/sensors", wrapper.HeadUserSensors)
+	router.OPTIONS(options.BaseURL+"/users/:user_id/sensors", wrapper.UsersSensorsOptions)
+	router.POST(options.BaseURL+"/users/:user_id/sensors", wrapper.BindSensorToUser)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9fW8b15ko/lUG3P4AuzvUq90mXCx+13WyTS6a1Nd2dvdu5DXG5Mjmhhwyw6FjX18B",
+	"llTH7VVqbYIuWhTbpukWuPefBWhZtClZooH7Cc58o4vnOe9zzgyHEimTygBtLFHDM+c8z3Oe95dHpWqr",
+	"2W4FfhB1SpVHpU71nt/08McrDT+M4Iea36mG9XZUbwWlSon8OX5M+mSPDMkL0nMd8oYMyT45jnfjLdKL",
+	"d514kwzJ83gn3iID0idHDtknvXgrfkoG5JD0Sm7Jf+A12w0f1q7XSpVlt9T2HjZaHv254wedVni7zn+7",
+	"74f16GGpUqqG9ahe9Rolt9SJvMgvVUqtth+U3FIU1u/e9UO/dtuLSpXSytLKpfLySnl1+ebKauXyu5XL",
+	"7/5TacMttcNW2w+juo/n86qfBa0vGn7tLvuecdJv8KRH8S47ZLxF9kg/fkxekn3SJ8dkEO+W3NJ6K2zi",
+	"e2te5JejetOHLT1swwY7UVgP7sLLtdfdeWh53e/oqgC3+BcUWPEWGcaP6etfx1+RV2RI9vDjPnkd77rJ",
+	"je2TAdmLf0n65AUZOvGWRFW8re60HkQ/uiR3WQ8i/64fwjbrtXF2lm9NgV1j4e/infgxGZK+Q16RY9KL",
+	"n1K4kr5GRvGuSme5Tlhrde80FEQE3eYdupvQ77Qa90fjPN6ON3H9HjkeG9viJRPDtLad02JZuWPjbC55",
+	"kXO9id9fE9SkR16SYzLEg31Vckt+0G2WKp+W6sF6q+SWvvDCAODpyqt/ywJrxgyM9f8TGBJ54yAdAdye",
+	"k2G8RQ7JQHkV4yDq7SxJ9Fnfp3ObLAo6JQmnExhS2OfdeujXEF61kopTBery8iX2zcEGB6xHwIwZwxfv",
+	"at35F78awYHxD9e7DRuQv0XI7pEBeZ04DBkkCGbB0WWHgAcZkCPSI/14K95EOB3iA/uk58SbeTmEQ47g",
+	"L/FXAFmnWQ8c3NPAIc/plaKfew90AQQfVJbscsYQGfiwhY778eP4Ce7qAA+FO37hOkBv8Wa8jf/dInvx",
+	"NhzSIf14k27tGM4FiGfXmp7OyseCbqPhwY+VKOz6Fr7WrAeWzf2BDMjLt721/EzAoAlyFO/ETzTCOiGj",
+	"SNwZsafkDUBCt9yCq16jfif06MaNc/ye9ABuyGmAcx+Snkn+/8aB+4r04I9A0A5yqefwefwlKlRJOicD",
+	"5MYg31E6kEH8ON7m1wUhhugiBy4+S7/skL14B94N74x35eOHpEcOndD7wvmh06l6Dd/5a6e1vt7xI/1m",
+	"sM8q5UtwPeDBUmVpYflHy6tuqRvUgQL+b++qeUn490xhDzc0/pU812vcbZ8cAP7JAd2aDsRBPpnOtme8",
+	"8t9x5ZdkwOToV/le6jLyP8Iv471APgPfP463yWsgxXgHdNvnpE9eaTiMd/LtOfLuWPf8Z5UcKJMbMgWP",
+	"3VVkd5Qz4uVIUMyBw+Scfi6gtmPYtDwdPN+HH/eBAeA1I0Nc55CRFVy4V8AC8M2UETwD4im5pXrkNxHj",
+	"Pwj99VKl9FeL0pZYZIbEonJrrrXqAd4kBgovDL2HpQ1OTQYg/g3V2WMGCDIgr5CAHnNlLOOYKD+GCcCg",
+	"+mYyBX751fudff3pQWyYA+AdJu50vJNG2Pq9AaCOoyHno7L7XqNro7I/ZoDuZO9K8FdKI/T1t6xQpmC0",
+	"gfqeF9y1bfq3oM8BR2OCAsiVHCrEwTV1cqDzsmroe1GmeeiW/Ps+xWrSJl1ZXrhsmqX4W8cP617jNgNB",
+	"pbS8snrp8o9+/M67S3CuetPvRF6znWGSflYPaqUKezcs+3mpcmnF4Knq/rPM1AQYxjBYxOmzLvMH9U7U",
+	"Ch++j8+K3Rsb+t+AF3UzqBwC1ioOvscpO+RN/JgMmNtgmHAauA6Fr1PWZCjKTTzsCyQC0AkGknaFwide",
+	"HH9NjhVtQUIZFrfbEvRPI+Bwgz61wRBmUbqGlFVZMOKaTBUFzB5Tz+Jdsk8OQb1F4yveJL34SzIgA2Mt",
+	"cqBiN80EM9Sez0sMca5KVuoVpdfPdjFbzaZnRfnv8cTAQPbxOsabTFc5lrIXectRvC1N2SE54NonnJ7y",
+	"9gOgAYAH0AT+eRjvMpVBGjNjXu6a36jfH+EhcnN4o5jfSSxnqj9ALg0/Oo1PSRDyMenH23gDnpJB7quc",
+	"k1nglXvF1NBxWIUOy+wTUpSyE1gRn/u1Z+uZopbqc9CzUQ3s28jRPA/p5RGVcCW9TitIsacHyO7ANjAo",
+	"IIVhjevJGbXxDH/OKGfLPoNSj6o5FaftB7V6cBd4+UuyH38db9leP3QdQVdOWSMdaiVZSMd1VLeNU7au",
+	"K5aKf4kuCjtjIYeSgcU7rrPu1RvpS1KlnDyPf8X8HmQfTbIXaYSiXGibyxaXUHewTYXEHr9KihBj4FR5",
+	"mum/otsfbQQbjiPJYCmiU2UEkwTpQuJm62ro22nl1MKCuogoLcfb8TMALBjUQypDOfkNqMW251CjhtJT",
+	"/JUuPSSzN1j5FLgDCnG8JOQN/Ts6Opi5sg+WuiMcg2RA3lA3wgAV3x56hY/z8ZgEpvlZTBwKRFlw+X4Y",
+	"tsKrrZpvtUyokTOg6Ip3wPA8Ir34l8i8huh72OI+HGppD8k+IAOeeJ5wxAKPCQOM7lS9oOo3kJBBGLS6",
+	"Ucktha1u5N8OWtHt9VYXKa/pR/daNfzIazRaX+AX8Ldq1W9T8xqMy0633W6FQMNNv1b3buMp3VLTawAQ",
+	"wUffqj0suaV6cN9r1I1f217oNf3ID5XP1Hujbqnb8fUPUOXUPvHAx6R9ErSi+nq9ijbR7eo9Lwj8hvZA",
+	"lSJK+wxU0Vq3oUOE7chrhL5Xe3jbf1DvRB3zcy+KPPi62A3/A3eGax8KX7hYp9mq1dfr+EnoRf7tRr1Z",
+	"j/DXenDX7+A5uoF336s3GBIoGO5019f98PZ6t9FQYKnbUOrn+DKGLf4pXUmaVvIvFPYeKg+M2m+3utHt",
+	"1vrtkCm0/NGqZurzU9U7TS+q3lOeo4AIqUOQf2hDlyC8qOtFLe0UDE/KR6DlGFtCGKpb7IYdfaFWGBlb",
+	"40xafItRivTtJ/UFzYSzXGpTdGlOTOSmh8iFDlGobnGPgSar0gO81JgGyqoHd5HL3us26zV0DoOjMfKb",
+	"bT/0om7o04c3pmV6G2o7Qm7ckFhCb0jKJVPHp+aebu9myUAUBSdW18Y8jh5SOa0O/TtyHP8C1kWx8KXw",
+	"2SVoyrU7WvfxvM+pmza3Vi3IyqvV6rARr3FNQ3SOZYxjUKNbdaIyNe4QZXKPm22H+CM41YZwT444AuUf",
+	"FKQa0VRD+p5llNZ2pyzcoY9emwMu7o+loyPlNErYVN7J3DHT3MbhSV3IjHhd5o+yRMQAqoi3Q2o8ZDqd",
+	"j+g6oGZyJfQ1fZfNekuoaKpQs6hrGue2UMuHTdByUGOz+nu50tWj4Sf0o1Kv0hHzPG3xyJLQSONfkB45",
+	"IK8RozrDbNQDP9P1JVjWIRkoC7lOvKmwSRqDoc5mtHlloOqV9PZaibbpdzreXX+UET0S7ngSuZwCchWk",
+	"qRC/7sN/7S72+Cnau0DSOqD7upT04RWdUuVTDtdV5XjsdjpBK3KoprdxS5h4IBHrTarfliorbqnzWb3d",
+	"hp9BmLYir1GqrBrSjr8vg0wGKpnQ2CJarxhNx6jR8tLSUt4wkApJSwSIH8Z0JdP4JRkmdoNEJA2JHjki",
+	"AyuRSNhkrI3G2xMa6QafMTnmcTXllXbGyaGdtfM38WNm79Hgp211SPABEWhlRPEz8DTAd/Ycvk/qUoAV",
+	"rRtjqB+5LWGl5T50kmvhixRAS6AIvLqc4Iy7xS6P5XJ9rKjZV5mWbQ+7o2B1uWbKtK8dlu9gSab6iv9h",
+	"O37K1bFt0NDQLD/iHBuVFT2jpQch0k38BbGhyrwh2bNqvjzC0/TqDbQ6H9xu++Hte61uWKosL2Hexm0l",
+	"+0TmMkReeNePAChfBH74X9jSC9VWk1uadavbYhqO0pRIz3fU30L5m+H5wzNTZx5ycRArDvVr9lC968eb",
+	"Dj2l67S7nXtO2fmvN37+sXPt5zduMkJEGt2k3/rk+s/Y42pQhwEWvm+1eHSIW1IEehhlofkmXEHrOzx1",
+	"ggyt1AHk9YrlMOFjfZa1cgDf7MWbrrPklHl+gJlIk6ZfN+tBvdltUvZtijyNVixnGZBj7SSQkEv2Ejk2",
+	"Kk27DvM98nsDdE1Z0BO0XXZTr8eJE/Y+79b9CPExUm78N3j0A3xyQ94I4+D/KsgJ8fE03oKoO3W6fnL9",
+	"ZxSuP/ODu9E9vJSmcuo32w27t/L/sFD+kBw7kf8gWuTPOshPDinNp8CIJv3BH4+Q5TwVORQioQKYCKgE",
+	"VKWWa1rVXH7rJ5BSeoLgYb2mcB4RRRRXUqNOhdXbWHk+jp/pRGacP/V6mszbNSQE9xvLYBhPRZWM/OQc",
+	"PEHoPq6z9OMK6k2NVpUlk5Xe7wIDX/yo1am2vqC+9xDDlCvw6EamKDDYf8GqC1adnw0xzl0w8ukx8oW1",
+	"gPyGYXSbvIG/OwuYauo6CzSVxHUW/h4ypZwLZDhWYtZFhwychU+CerQWjDR0dX6dzaCzokLq4+/RIOTD",
+	"UdlaL+Pt+DFzjvUMBpRXAe8zLZ+xfp1PU5c4U7t5HIX/miNHhD3KQ/OUuh1K3TQe2u0AcLvtduh3Orbk",
+	"D7mFcZyu6gkH+fRx9Xjjuat7HAk5X5Qz8+wNWrg7tL7CwO9bTfJIzbXQfGJw796wYoZDMuBMhzFYkZBB",
+	"09MVx54tq1RSi3kW2Dl5UXE6NA/OKrD6yfQ41WCl+hvAXUlTSGyf+fgw6oCHfI3JZl+pPpMhOB0kMTtl",
+	"5uBRV4LEcZa6h97TMgpSRMQT+D8ToNTdzQBmlZ/sQUTbVryjSJwOdWcKT4HcUc70BeUquPICChSkZTBY",
+	"mZiF2V0LW3cafnMU+agBHH5mVS2ioTMlHLJF+s71v7vq/PidpR8n8tkw3m4LL9f8CDQoi0fQLdWDTgRx",
+	"81KltEj/3Fm8tFKiAPM7lCuVVtdX7vyouuy/6/24dsm//M6dpepK7dL6j7x3/OU7q9XLCqO7tHRJg5bz",
+	"d+xN3Hl3p9WNKncaXvCZLZxW80epBDK5YEMezYQyc0b1+M1Q0gfAaQY60zE6y6hX/xjpUjw1JId4PTBZ",
+	"p686yxSmI0Bn8SRv02iUhkqXqcZWd9M2o/qe5qC0FwlKzIzDxBNkhRkl8NkLpD9effKP5et0/fKH743F",
+	"on7P0zRY0QHpOR/cvHnN7mak9GGugemtW8hmAGdJJB5SNqq9xbZJ+oEl035A3qjwHbiOQo/AVzexcmiT",
+	"vNYew62wxDK0guNn8ZaSmSKPjLyf+rodpOaR4ZuHmKhAIaKyH/plBdkKF+LcxcJ4FI3ZHlqlJUF71AZG",
+	"fzFS+6doPbqOH9Qu2ryiaaqWjfnHz2gwRqld8oOawzzltKSOvY4MElsSeXdonICX2RFZWK8cwSxByU8Y",
+	"3Ce3kxNRjtRU5WPSj780tkyjXF4EiUilSumfL3y6tHxrba32P1c+XSqv3rpY+XSpfBk++IGNVOU+LU53",
+	"JiRptAssm9140/nwysdXEhiiMURRHgbGj5SmX8Y76WbIJzevptxya3DqD2hF9GgkYIJwSKab4+tdRIVC",
+	"9gptWyj/Bs+ZMbf9J4RkgpmkO7hU66DKkcP8OVWRwigyGJb0XJONfHZDNcS9LTkrq84PnR86y+XLeGLI",
+	"farxmkhmXGQQM6Yu0Qhq/JQVp+FleQluAno1gY+qemfgP4D8pEDs73J5abm8tHxzZamyBP/7p+xQgQRK",
+	"ZrkDw8cV+jQ0MxhhDdASziHmCPVYjhDGkzdZITa42XaYZTtOnH/aue0Ul5aVd3DjL4WCDg+mVtHG2xYQ",
+	"0DrWxwYBH9j2IajH3Ao5JK/jZ0zJGGJBo21ZRdO502o1fC+Yln11YsZnBx7Lgk3hZ/SW2NgZeUX2zLMr",
+	"31TvSjbtoIuQhV37mYSbSQJQIqoii5IAKiHxltKRoIftAAbp5JEzB2amggMsE5MTsst5TZo9dkMmSqYK",
+	"hCvVFDr7DdYNseJY0rfAMd6tOIzjO2Wq8MkUdGtBkpAEY5Umuc4X/p17rdZnIxzk3bDhOjK/VFQa4J5E",
+	"AfkbpbtCL9ldQfm2GolWt+5yr7b67Bu9ZcNmsgGDNfqRS1ranGEhE+RZAkbW3aeHmr8hA1DR91VMazFI",
+	"uUOGgZKrbsHmv55Kgj9lX3pi6EnbJkyqtiexHeGrSeT2ZpFbZgRk2SYXumEj08Mv0ZTDbW3hFFeqabXa",
+	"/Inr3WCUX3rAcPoYSyztbJ4cWBmKNd1D5OUnfq9lqmioJqc/s/pPugO6WvX9ms3/7OfJ/ktcIVdPcQTB",
+	"pVadMb0lBTBn5cPVwDrOZbDjbYw3jtI1DV8Q9Xg8j3fUtLXX7OUynpOmU5B+boGvUk2WNg56iOGbNBlp",
+	"7pdmerZV164g1HEr0hRsJzChnVrsxsId4O5nsIaMzAKLjeka+LUnDiw4yF3gt69ZGitDOSCc2wTUZQcW",
+	"BCiDXjQZQ9VmhE7U3DwP1mNO6w4RRN5g9EX6AclBxSFH6C0BZ1vPZVEN12H85ysHc583413Wykf8pj5y",
+	"zL2P9opezfRb97qNiCsIE7UEp+Guwgx5gUdmIo/nr8pt32XmASSYCrNFGL1amEVWtJs/80m7ZucXOh7U",
+	"ct+kAZhmmiTdn4wA1r1Gx7d4NafhGkhAjL/EAisGBxukRPMMwzyTPTyQFJQaGFRLEwGwbhhClR+rNV92",
+	"M9fbQhWdmmjw8Tb4uWU4v96Bsrz6faFmN7xORD+i6VorS8vvMHVrSVHJQv9uvROpLSOsj2WXoVEIVaul",
+	"SRlHVb0JWc7OS/hNBtTp1Eh9O7IYCjgqf3cyFYv6WBJ9GtVGTkZVkEF7CZoZu7DRtXba4i4pvY/UM2CC",
+	"TBaf8igL+QrbtMOMCpFORyfPtvWUW2a85i+o/b5wMHtviwyoNkRr31PrxlRJpV/YEVkoBi5OWFGWuP92",
+	"nkZ6i4rAS7QmYn178mvWJ66+08M1a2u1R8tLGz8YN57qUs1rQDspvWJ9ivB8vfhXSjPGn75/02E5BmVY",
+	"sDM6Le+k9XnatZ6JKj1W+q/XrLPYr7bbkn5t1TuSpK4kjatSl8rUVGmbUcit1hdntOCeRGl2hhQ8n5XW",
+	"2dzwTEqiFxzyR/I83gWZq5yj5yabuGqtdOIdIYVP5pQ8nzXWk6x8zsWKjbZslm0YbCC19pf++UYrjP6u",
+	"7jd025FyLIu+9pp7tVnlL+9M6QhzwWhiy4rceLK4jRWanCwpC+hmMxwwI82Fk9VxnMiISFoPJ9X3T6/B",
+	"TVHDOr+ah3HNrHI7TU4bFzDTT8Ce+KTjhz+p0yZdNlDuIcs2+0M7rOjBDMfC8XVSzoy7TattRJbMs7Mz",
+	"PdfNBqB0OGYm/iUAN9qloC9z3W94gGBHKDis9kj0AltiZV26x7XpBVG92ilVSk3f63RDv6nxY2U1qu+W",
+	"/j+r05T2CLIgJ1U16ae0zONp4jSAy/IV+mbw0bzyp+ZHGrxyl2pZOp9ZOvvLrhwn61JPhvnMag3DOUq0",
+	"Zu8ECk3a+q2zDFi2G9pOFwW71rspvWEhTdaEjufxVzjeZ8tipKkRH2b4qNfj1pjCYHrG5CikxNs6Zxla",
+	"Mj1SmohbEoF1oSIRZXJE+rzJCFth9POw5oe6Oud1qiXX5h8XebvahIykdqdgS65kRdInHf5qU3W0NFXI",
+	"wYWZS5aHnn7H+zazH2BegfgFVi9NobdBtuWWEn74HWtsNo6uIbOgFIwjTC24hs8zNOKxYJ4IVr5glbwj",
+	"9eNToGWCYLNBLF3t2sDyjfVWVop8/Asa6gZoJe4I9lqmtjYVuUMmT19Ys8Jonxk71BfWgrVA75+T0GJ5",
+	"Mr2lEMhrtxusFmmxTUsC/vpfOq3AucBLhC5W9HIEisl95uzA5PrNCTTkdB1ZpwDiYpCzCmXByU4ylH2g",
+	"v5ZAMIpW+lrRCrX1X5J9DmmlPANpHHx7+EbNW8KikdRjIlzAPXKU8srEQRga2ZviHVTLNX2T0oAKfpCL",
+	"79/07joXtJIW/KqYZpOULUcIX+dnXicqf8Q6al4UZSf7Erd445+xtsls48w/omw93nE+XC9/3Ar88kfQ",
+	"xhJW+nBdrFy+UQ+qPqIp0ZDeOp3C4aWPqdmHhuq5ha+DV0NcHFAPjquED0o2F6IIip9i26Mhi7mrOyN9",
+	"ios/ownU0/FEQ0oqSQzJHhAFjUmz8TegVcXbyDUPnWSvs54rt7Wv3071OsI1dLVPqndaIVeQ1M+bnbtt",
+	"r/qZgnUMnw+oc/rAoe2djijdoEb1zGFxc0yyqmhcIVGNBKd5jnK8l5j8k6DoeNu5gv1vHeR4tAMZkhsu",
+	"RkH3uUMGZl9h+AuiG3Jif7j4Q5E9aqkuU6gcf0xcbUh9BQelxg6xpRSd65XFG9OYISWH32okn9CcxV2R",
+	"8FELT5lL0UF6f0GZfbyFbaaj1md+4NzpVj/zo0pytFhZyerUOFIyf5LyGuEsYS2spbsEW1cjHBJ3VyPd",
+	"xTb0SycDZ7HpR2G92nFTd8AmoNB6wyvXPkytBrzSje61wvr/QLAy7NtOJLp90Jw2wWKRxdOhMbCoaJNG",
+	"DrSTmK21Lq28yzv+UVHpqD2DHTIwdwsPXfej8GH5ynrkh67a4IMFdrQ9xE+0PVA6thAlByclpG+UdCfA",
+	"Rw/N1WPsrzZUhwjRmNPXcC1pNvciNiHu0L8MWZX1rj3aIC8KB8jK0orrsMlOoumcqLRLkh5cz/ipEDtM",
+	"dQAe6RqPcqZjIc2yZcCIejB1OJnWLF3ySDWDsOcaJ1XxIqeNxZvO5aVVhIMkAGuXaIoUsYk9nssCSIYQ",
+	"yDaocYkWtdiVsGdpiceTrJSmG2ToJrBnQYxA2CF6VPdZlpkNs3taTwuWBMqsa9L/GyUWjcwu3tSy+6X+",
+	"aWye8dbXVKMRsRCDLlTakyxvkEZXI/GPjFXBMbvcEvImipObsmLb6P3tOuQFsAhUVRFs4h388JkX91vZ",
+	"GsEKmYFl6o5p2EtVhCLZANQW0xqSQ3fwASnfxUzWV+jy+pLlkTu0nkfBBddqyNEC9WNXcc5O5//vgGb2",
+	"t9rBrSdQCEoIFNJz8Ov0HvyCi3FEXeg3vId6Kw42CNDhzSSZqqlcNRF5E7RxjCAbOBdYvr4rmrk6H78H",
+	"cv6iOU9O5UR4+lesotokQnkUwErH/3yBdrLhIxmvfZjqWbjvhx1q7C0tLINRCv3rvXYd+hwsLC2s0uDE",
+	"PbRQObThZ3vroW+SMYd4i4HhtLTmOqreq0U3OULNRWihkITNkUB0xmA+nTD8zxccOoyLauJSNNPJGIlK",
+	"t4F1Iy59r7SrlMTmHrNEjuBdKZlQelMEgUXjPcAxmVd1yEcYcv1SuFcRK9y8cDitx0+M9WiQqo9d9FqY",
+	"tVBvBR/WSpXST/3oKiMFtyRmO9Deu+ljvE51NNpfjFV+sarrnjbADvt3YMsh2oWEuWIQ8iwDvulpbsCl",
+	"MRuSbbj5ffSHbMroU8xeZlN0UvggxxLpp5yBDzawnIFGWbwHzB+3xIIu6UGmWziZud0KOvQirywt0YyS",
+	"IGJZMEk7Tc60h5/yTaxE6jC7FG+42ur/wnoKTWd1ZktO8gVm0sV/YLypHz+Bpy9lwlK1w/RNZe2Fd7ew",
+	"vfxbHOXXwwu2BdF2o6/JMVWdqG9iHxgN3eePznSf0t6Eid3HuC2bh0Z41TQjngVwE41ONlx5Cc7wKKMa",
+	"FiEVdrrNphc+lF7nbTWxfeTQzci72xGzFjulWyiVYQOdvCLX6qS7AgN+ZGIKamb7ek89kAJHzBW0zzJV",
+	"dNbP+P7P2X4MZnLJskV5R9zSPd+r+bQdC+4npcvmOBu0XO6UYJm8x6OxlOPFBp423NJizb9fr/qdxUci",
+	"fr+xyNL1xlWc9HpTe00q5r0pMvyQ+bCBG5AjA30guflmRonusUffoegCbVGRvspcNBmhYGlAAm85yuTT",
+	"huXFOwJVsu2cArikhE0vpqE+JQzntBvYeotu06pUsBithfQmM2PObO/fiR6iMg+wKp1aE8mA0LnRQSid",
+	"T00Jybn8KbSQ1DecGzXk0pnu8w8sQ8HCR8HPDwzkkEaZUkN2cG3OkQZ1aWXlTI8xbseFBN30yfEc630q",
+	"z02RoFyjEArDbOl+bFdS+ZsfBeJWoakm6ard6kSpM0lx8qs5UDcZ2bAhcsFRp9PGO8IDqIS59Voj1dc3",
+	"4ENbaeaYw3M9nb91AOs0OGfkMLtrgSgm1NXmMg+6j2hEk2OobMY02bXAuC03/KB2VWk7MFc3BfNHfgJD",
+	"XMfRy3JoMyINabQadprVrFrXeAuaMkCbuZwxP1ltADpgOWI6RjYMbrQ8aUBPBsATA+xogOpzp1/z/r9J",
+	"lvM2FFyaPjNMqrUs2L3FLS9qYlET9Jj0FcXlmAznXeFdvjwLICd7FLQpqi/sNpEX+DbU3P+wjdA/VlrS",
+	"y2ZxKV3FbAKKheN1+ZQy8Hxe1OQ/JlvuJ6Ex9lx7u7YzyjO3CJ0t091z3zL+syfbMGM6JDJ+4XCDXzDj",
+	"YMCS4X5FP9KVKLulY+2HKDxHjiUVltdAfL2g5nxojp1jGurTk4tekn0aHqdeQya6WFHGa9aQ+guvHjkX",
+	"Gq3gbrndajQuLjg0ZSDZkZp2cXL+wb9zowVpXwkErgX29EAMv7LCAtYcR03oWHDsVyitPjkJYS3NhBzp",
+	"OSvxpiPLp921QPITdWUax6cDhTDwLnoWxdva+6jgSnbptuOTuvssuuLH/oNoHnVF12ISHaqa/UuZRqyQ",
+	"m4YuWkriLHWQAp2VZooDEkjS7n8srS51LBUlSZNvmSpZliL/Pqt6UUI0/L73KbTJMEHowGDHdVueT61t",
+	"39TaKHAu2ftGqu2tJLeUedQ6/5LcYcCnqBQuzsLFWbg4Z9HFaTkhKuTJbuAHluramfZ8KvK5cH6eA+fn",
+	"SHPgkVQRN04Rtd/OYsV9KDTBnMEjJ/41DWfjfbFMfkuL4s+5tpi3VZF9XxJHE78f30vFLhFEnhnNhbcC",
+	"ZSmjibSYQq2ZRbVmYLgjRB3jOYznpqRnzUtEdx51mjkSJoWyxXxvsI2ZoX1sBFcksu7ZE45TkgP+pHVn",
+	"HWBpuK2yz2zHKD3ItiLRI7Vaqm8sZxSJykI9ma0gKs+0eKGrlsFgeaIy7p36anlTD73KLN41SOY66zjK",
+	"ewhOI06udik8nc6YtdLYeqO+2IZrc6MKhKWPeoDLnGjxKwZ6nHmk/IN6J2qFDycB68ylxgZ2YrUR0M4E",
+	"Kg89ryytFHBLwi0xINzIbspXzy6GdmsVx3Q9UZXrikas+UutYdHnOESIs1iNQ7GA2Ct4oevUa3zsEN1h",
+	"P/5V/DUv4CtyJsa0Q822koqRqbanwMrNRJGp1fgx513TcHGigF6XfEXmxfSpTqM4xNLQbAyVpE1afLwv",
+	"K5HpYd8926AUjVHGO9S1QIbJVi7CL6/0b0mGrrCOPznGwdIRBSk6vbOJrhErnUess3H4BGvEgTI3bZM6",
+	"RWn0j/ajEnxuj7cWM4bJa6pzZuEJAPHy0uoZexrGFyl6g47U/iHyO47wGCu9MQTa0jp/MJakxGMtcoi1",
+	"QLb12Hilt7uYF1/On8wRE5amMaYJk1byR39Z9B+0W2FmEhGv+xzSdBbZXeulhfvzqXPPGdyZ7Wb04Ngz",
+	"05H7AqcUBC627RM9I4Zylq7o5qQ9z3oypLhTEhk28S4kIP1lvK5btnZFtOVWxegc5lzAdTfx4uxddJ3I",
+	"fxAtVjv3nQvsYuxhno7wQLFmPfo7huRwLajXXDnX1dYv3oXBIp3Ia7Zd3ukUOqW6vGP/38j5O2UHNldG",
+	"QPwv5FtbF20tzR6Ugxo8uRZc4M2tJOye0UwhsfH+RbWLkNp+TQwXVUbM0/GbWk8TpTeC1uoP32rkudB+",
+	"K49lix6KHaVjGbW5fylbajCH9IIli+l9vADUr3JyX2K8YxC5bKL2Rs/DID0VSC+VFDPzJoHS9sTaij9H",
+	"vafitLR4aXKMwhy3nPMPHJOykFOy6U9uXuUQoaKdUTjvqAGj5lA+HDMhOWA9OCzT90upFa5hdJtNKUt3",
+	"hyYa11/aKMM/K/yfm/SfivaPdZLDI1uW0TEqxm/j+H5Qe5uH/wZvOU03hB4zMFyRd6tUp2jJuyqOObIp",
+	"pXkBbAAIvS/syXY4UM8y+268GOoJi251A39E5S1nvNN4DxdB+tqW0J8mgJxTyx/e23SEFLEQ2YzVDPOw",
+	"KO2sK+OffYPHxzuzklynTjuhWzrGJl4ikFn0VTkzHf6bhGpkGjZ9zbgc2GX/LLdaoYoUVaqKMNUI46ve",
+	"5MbXjOHvw2aBv7HDjCg5syxj2hxyk0XxmBdWtWH71A/7yrl64+/HtRNd3jRlSI55Q5keAHcvaZL1Hd34",
+	"v7gWMAuQtnV08pp8iXFd8L5XSatM3RO097PpDw4ZLLIdCEWDdSqGL2NoYi3AuR2/kv1gePTtb8whLOKL",
+	"6IfjU/SERnrkWjADGout3o0bZ0pTa5ybrvRhR0IeKF/uc+sUvqw1HwdQy9Ib1t040awV/J20eXc/0QyS",
+	"d6DnaLDZs/TqCns2X7TXrnUaKn+KBjlaa/sL6xuqC7wccdPJpTRSuFz34b+njP9lLjV2/C+xmn1EIiMf",
+	"epUVLcPVhjjwVrJ9PghCNMhGQuuTA4P2YJF4J0l3cx/CmQdtUMPrgIU6HqtxnviJeWOs+gRrk5zuxv2O",
+	"Yla2q9hksmXAgcxyuzfpRAULWJ1rYavpR/f8bseW2/0R28LIK4w8pN3w6gmwi4E0pb9ybv73a+8r7eJv",
+	"hz5MffFrtxk769yOWpHXcKqtbhD54Vow+tlHnWqr7f/tGnOLrZU2nCXacHgcm1PH4L8zh54NpvgsNvRP",
+	"x8ofE3HUdiu4q0xxVRfk1wJFIDk2MHCtHtydBOxhC6cDyrcy7YkPO7SEZih4tImOY1Us6GpuqvkIN0h2",
+	"ZrEM+TK6p1CtzJyQcaRMEesrA8eQqZojxyj62Bw2/HrSC2a7RHIuWI6LNIF2c/J90+o4N8YbTt50LvMl",
+	"I31Ihfvj7dXepd9NRdRRLjFjjg7lqhZ2shVZksGPzdvFbIVB/CWN3KbPpWZTHX9Bx58x00jO/Ked2VNi",
+	"yUr8xRKmo0xbUUbTWfbo0GFaq1NlxE38azryl4UYh1T17SXgcSbNTm2xvT4FBJs+8JTPCTnEmdmbtKLC",
+	"LJpNIHMndVDRP5ahYLV8tRt2WiGzo3EQyz5TepKj4NAip/kjzNxJccQnDArhHMBCLHz/G2V0XLypf2MI",
+	"/gKmzxzH22QPud6RdvAUjFTxLBpKRofRxproaXtrC0eHunlzpMWwUdtmssbF81QeZSBE/MRQc5IubMtV",
+	"tAzvykrkSAv+gv3sjpUZLufm285uGzmd8m42+XUcNP9rcmJ7/FW+d8kp5ZYXytCmBZdo9yMw401uXPBx",
+	"ZS/4WA3maUoDMvXbtUN/vf5gfMJmeR5DSzNCI5vHNnkj4ba7IKdF6Y2WLroOzM9cXV1Ni5U3vE5E4ViP",
+	"Ht5eD1vNkrViCuLpZYhvjhX//3LWDhi1JnG8s4rw9yYV3zeOgMM6yYBvLVvAVKQpLp7Wm8bwFmfqSATR",
+	"8sW1dr9CHre6dImDiGv0/MhUJ5Rn1mZ7Zt64W2dnMU7XWpy6pTimlagp6le96j2/fLUVRGGrYSq0Qatc",
+	"hScqyoxRxTiSo5z5TDB9bNvXqlaqZSQy8jyOt3lcQ6kv43Od9DmxR4Yik82x8XZYbRBtsrBMaZBzfu23",
+	"AyYgMXVWKYDbg4/w0b5oKsFcJMqJR2xV0xYte/79eFpp+rz+Y9HkU2HVBzadPCMgsuGWVq3W4LfjsxVD",
+	"Qe0Z6qkYUyW62ImxfpwBvk5OIC6ofOaofGMmeoZplnWPzjl0rQaB7AOaNPmKiU6z1wkiI7nY5ngD5pDX",
+	"65Y07gf6Lf/p+zcNT8oHvlcrXCmFK6VwpRSulMKVUrhSCldK4UqZOVdK4SGYK9upMLkLsjmRyV2YpWdk",
+	"lv5WnfFgllfPSRJIkQCShqgxG7KpmgubRjGg9xneltrZjGJhqq3NJjQBbMRiJ2xwljn/SynS0KZ/7bBO",
+	"GpPqcLY0YYhPBNKTgvDslaJOrlPVu2+tHHW8BlWon4zqG1f0npqn3lPz23cntceONf1xFgs9mepSVHpO",
+	"S4Ehg8nVcurtFhw4vusoe3LIYC2oeo36HYrhzD4/WpWnumM6LFer8uSpsXwwKFgawLcem7fC8DxBh6Xf",
+	"aPCIN/NxcSYARkkEZqhi8QY5lH5vpSgurVQzdwVmeomljFbNVo2lJZ5XVFkWVZZFleVWvlC3KreVMTtj",
+	"DtTRLNkcYbiUuoJTTDewhwHOaq5BEd+Y5fiGBV+ynWZKUoHmus4DE+fCBzdvXitDXOyiCp99wwTENJQ3",
+	"lGpNEDGRPgaAFhzIsXBQAwQHttaTwlVRpbrrnST80oDMgVC+UQ+q/lRzcs+Xn6SId8xeIq12rW3STTIG",
+	"W5RduaqCaettyBUuUETsCgo+5xT8Ntv7UfMa2zApVnT2dLlz3RNwdubNJSjqfM2UG+0LPcP04cJiKiym",
+	"wmI6dxZTYVMUGllhUxQUXNgUhU1R2BTnzKY4H2mgpx9IfZYGSDHxOVfYb9Fr+GFUDrsNn5634Ue+DVAo",
+	"7F9TQaQnTAxdddxR/JTJiOSwH9ttpuxK6UTtYKpEn06OMejwPdwdpcYrsO/rsO05NYipwpSAClpaCWeA",
+	"Y8Obc4Fa0KIDHJ9uqVvHveQwO6uaIVJnflgxRDvpa+kCUhNhEwn5mMBTW3MD7gVIN+wuLa9kGWEnKHvK",
+	"5gnnRzdZXnlrpzCRbVCFK5r5K+MrHfJGsL4Bee1I74vAdaGuTEVdYbxe3n8tPU5j0TbnG5c9KFlmUpMR",
+	"sqNQaeZPpVHIqt1Nm1MCWPma8RAmJGUTApBnbEhJ39HFaNIVnVRiXunToncMJUbIKu2W9CyEGBV6TKHH",
+	"nEKPmXwpmCTF0+XcpK8zdtqNupR91rawQhJy6UzTgucOcuektqvQzWdaNy8K1cZoTKfxMi4HByc1N1gL",
+	"urkwN7Qh+WKk9ikMjjRfl1JBNI6zy0g5iLftLi8zkyGBJFBA9rWB2HxkGxkYKqLq7bqq7LzQEws9sfB3",
+	"Ff6uwt81LX9XrhyzOYjYKVKj8HTNeaH2aFdXipoikyhBlpEjaLjqkENDWSFHCRSr7jL8y1PSX3DId8l8",
+	"m5QkziGVu/l0HeEOKxSdQtGZGYeYSoync+xkrTS2a0dfzJR/vzfEV8+8EUW/o8InVujvhU9sln1iVkb2",
+	"/faMndA0SfOIsSnbqb0fvqUCGwmbp34hyVBVGpvn4RWh0wdArm+C/kJe03Y7evKwOfCb6ZiYwroP0IE7",
+	"Noy3zDMl9MXuHdjnHeYee58eoyiNOqPSqKR9try0nGGf/ZLNrwDEkkM4GiK/73zRmZeb+Edj546lvdRY",
+	"V+9evRO1woeTHB2r3y03z1BYrdG8MaaBSmjtGafMvpnR7yWRNgGNsr7Fda9Uq347cngLKM7MbW2kjNQL",
+	"nOdCd25tmL9DXsC7ycvEgAjYFDlkJ0nO/GcMcNF/AI2T3LXAMhQEh6W4TpVNGxk4ODWDZ7Cq5jV/r62p",
+	"1k/9iHKpDyjef/KQsq4P35tbtiWmNIgJNZxU4l3nk5tXRRHeUxALrPkbJ714l1UYHZOehGL82FlZWrlU",
+	"Xl4pry7fXFmtXH63cjltbkEn8sLoNtSYZB6t7UWRH8L3/3ltrfbo0kYZ/lnh/9yk/1S0f34w1gyHp2/l",
+	"+H5Qe5uHn/963mLQUjFoaeYHLZ3J1FMmk1BATWv26VjvOPkE1LFeY+9ZOZn32PtcWhz4WmtUp15zhcTm",
+	"P+ntUWEGTyfymm237T1stLya2w3qkRv6Xq0e3O04bGCQrkA9S7Y+tfD43K2t5m446Vl783jBXw/5kizC",
+	"tPPCtOmRZ+vJ+063y5NuOTIsilyLKPp4jXPocIshqn2DpOcn3jYOv2eadv2E+ZlqYnc7jDnNTAz+E9hR",
+	"0XlcwRjFUUbf8e8QQ2rxhtrzgV7neNceaCADg7niT2awmU4TAfRMaaYKLD2hiSqZS40d/UqsZhuPbIP4",
+	"V+pkFTkBcgZnqyBSTw/xyUD6HEcZiyhWMYJkYpqD4Pqi3tTO9y2SREj+xUfwj+xo0BnTr56RYj4keyKP",
+	"ai/elTIHRN1h2m6fuSyQhcE7/jDLicI0KOkW1yij79i6hKAkcz5p3w29ml9xvvDvdFrVz/yIkkUf9SYl",
+	"sYc3T9pDmhlwdzjYU//g37mBXwVv90DcIeHJEX4OtekQbOAlQmWXnYj+KIKxLGTOOjD1jAJIA6SpWE5x",
+	"ngNDvUIxewp/eTplWTznjKIm7jf/T7z0b8D0G6Lfs4eTPLbw7qtgcx2v0XDKLPbhxL/mX1xwQFKDEnSE",
+	"B3rKbOFnTqvtB+AB8KqfBa0vGn7tLnbg8h+0G62az/ef4k2PfLuW6Afgk/wUkAK4Sawd+p1W4z7+6DUa",
+	"pVvuKK3SLXWihw34AKBXGsMxy8bGMF6JNp1e98dHUDBj7yzcsvliod9l39I+N7ITdxSANRWPH16labn6",
+	"8i1+ch9f2vozpm19O9LtPRuenz/IxI40a0uOsctO5yp8RNPwEaVh5lx5i3Q2PloFnMX2IlJJmUC9xVvQ",
+	"VYq6C6OO1G5dLD7Cf+knUh2aKf/jFbkvpMn5JEl3jP0ZHSAsu+J4K27KhHvx2D26kDh3hCkySO+uEz+F",
+	"ldJICZrwYt03jp/TEBpvU6uH7FFsc5MAJT0T3Y/RSFU6HQ9kbTbKSqWb7sj7UlyU6VyUCbddmUTLlQm1",
+	"W8ljgsyUas/8N5kNFiZpGJztLO4/aw4oNpkVmnyL7AHgFHOj7NtxNd8mgJ1xG+zphJoZ80zNlFZ2ne6p",
+	"0MgKjeztaGS/Rd/qYzkCJaFlpXZz1tO4+no+R5/1RpT9FukEa6U3Yl9GQ7bjZ1ZlzmDOJ1Dj1AtW3KxC",
+	"hStUuEmqcIWe9HaqQBM88WQaUtCK6uvsYOXqPS8I/MaYgXLYNab+vMa83T1rN1ybXa9UR9OV4m0W9xqS",
+	"I9mxwkk28uzRmlH4BYtDzRHnRqj4Y+WYV/kp590RO4UYoAVO04oInuRVJ48P5nvbfPH5IgpXROHOImdb",
+	"8Hc4pZVJY9X+yPCcKmtmLEpnkw9FvG5+bV6T1PKllwtSNxWZAyMXTvYxS9VbuLUb78KE0fiJqciMkwRH",
+	"fs+35/hNr97QQdInx2l9uiBXLH5M3WrxNr1FdJzpjY9uXrt95b33rltS7GhSuE1yzuGVmHxKvwUwE8rw",
+	"H2flsW3a7MXtHXf4lUjcB57vH2+KW5Qvu395mkiYPPCnAvSi4disqslFJcN4/bioRLLovK420y9xBNED",
+	"VIomPBNPIGdO5l9iKjSoe8dstSErKNqkWfTqanNb3iBV7F6Kgp2h3OT26yw+Yj/BI+O2u2dYTjV5EkOI",
+	"Gf7ISyj8FubDkVBZSE90R7D1uJ9/rWMcz72Kf/ueJOLO2D6YWfc2JzDt6pxb37V2zPPWWP3EnG+2PQjn",
+	"P5Y/w3zr++HXOJnoX6z5jfp9P6z744Z6gB7fYHyfNeWSsnxA2zKluEL2NCUiuwoS+zgdQsEk9knb5Qqk",
+	"bLVEC1uRIJ8AqwcXB22pRosi4X+QucAbwghgkyP2IBLwVrwzKlj0noRUcY0b09jWWIV91EbCOwtdf95+",
+	"ad/Uo3CMAB+eRRgu97smE4fLeF1RtjfrmnMRSywMgAnFF1UXQc/mIJgz9V/qDIUFUFgAb8ECgE3Wuo1x",
+	"tXv8hV+DHtfc0/jSBabk9y46LGrJuoof8kRg4eGMd4378lM/uiF2WSRimf1eGXCmpfblXv/kql7GK4o8",
+	"q0I3KvKsElLAZL/50qokt5+teZN8W0Ue1RzPm9RoK1cOlYWM+3m0CKz+6WNLNdqgjKcZDO13oy/SmOKv",
+	"afQXR37Ak1DVKfx91bAVsG72byANi/XCRk/iwVpA9phHkLXbPpKzGPpOo0X5BTRuo/kNUCeF26dOqrLe",
+	"ixbe50ULDvkNLM68VuljFRn/es5205dvwdD3Y9rNlPW85v3WhqS/FsSbrEcYZNzssOGPA9J3abc/qGqV",
+	"Qxqpq4J252f9pDhkeaZY/GtySF7hOd4AXmi3ThmcR7DDpE/ba3GkIwog5TWs9+CeMlFGGQEq5lcN1gL9",
+	"JS64gp8gM3pDXyFHt+wJAKVmqwmlo0hRU1SwCeWljVxu/NmL5oqmaP2TefcT3Wf7M5GLJhXeiUB5ctDN",
+	"C1XdbBsWOWhFDto85KDZKNlwyEPTXFFLPBTCGUeHKbJaCGXa3JVyFRDOrMFuSmdX4aXVvktDWrSeGimX",
+	"ztmmCg85htLpec1Ys/mKUvTGTA/V4iP+45g5aXaNMJmEpo4bYGNCbHrTQUoW2pxqE2MVjdsRadmbgqgz",
+	"sa2sAmpbyR8aznyIzepRPccF1rbjnqNktTGYnnsqv3uWxRxvKiaVsDqNZhy0Q4fdXMvyxhesbsqsbul7",
+	"am/MT0rxZLh2EQQohM+0AwTzFAf4HnQ2m1lV+nsWpvCi6j0r4YukYkr0jOuDb17/i00jg/gEHc0ik5Pp",
+	"Xgf2Vmh2Dz1Q8Wv6C84mF6GAIWfDh+og3cQwdijeHsZbyoCQHk66ZU/bghSpEk1pn2bpmXYNgFgohdO7",
+	"tNPz9n/Srk3O15+y2Il1Q7GeTUmjITg2Rpl6bnazhd/ZzZGba227cOLPqJVQ+PlPv3nT1T8vOv43CU2i",
+	"b9VJ+lP1uS+G3WDcRNEUv1KKnpFd7ZXljLreLQyGCeseRQHWGKmr17vBtBNw87zi9Dm49rcUVVaFM7Fw",
+	"Jn4/nYnGJH57YHzOnY6gQBSOx8LxeBaOR6vO7QedVji2eq2DQe8PGW8aI6ch00UO+T5KoVdg1mljlG+w",
+	"fc75HXn/pndX5Ho/Ro/sDtlX/amKRKooKdL8aV53+lodNdFXplmjfADy+Aq7VBjIQ1/u6tIlyOruk1dC",
+	"FnIw0EsgAfHhevnjVuCXP0KftXr8BCGfkeKLdDA1nTff6qdQd9NesOHm5ExXveo9v3y1FURhq2He16BV",
+	"rsITFTmpeV8RwJL8ZbsRcGU+oZ1I2Cj0oUGK0hePI6kwSZ8m1NO+eLy3CpIrfMjuuaoeD/F2p9OPi7fD",
+	"ynQH1OpDTU7Rb+TVtt8OzPtX5q6wJH09BY9dACysUE6cuVXY7KpVnnw7/j0Vs/OFHeE6DKwAbSV6IjAq",
+	"WOmBwzkKcImfeZ2o/FGrVl+v+7WCbGaSbIrS0cLQ+76Vjh7kbyKuqK6oqqLVBnwsr8mWtNcGOkP66fs3",
+	"DRXzA9+rFTrmPOuYhepUqE6F6lSoToXqVKhO8606SaRtmrqM7tqT+tHMeLVBi+owNaroqjG/XmNJWqkd",
+	"NdDFqwzk5ZKVi7IsrrkNSJYOYnKUtA9MN/BP6kGNEtbNFlBZ0T5BuhMpRABCXKyfIvsvx4rjJwJaF80V",
+	"X6eVykhsrNfiGbZLOF/Q/Y28YjpcYcwWOUy7F8+KBM23qDK+e6bbV0kEzY7++JRSJIxOlcwTHSPgvw6q",
+	"FuKM8ZZxGWirI2n79ufGk6ix/54xIzyDFk1tBtf2w/tcY+iGjVKldC+K2pXFReik1bjX6kSVd5beWVos",
+	"bdwSCzziuoB/Hw5a2nDFJzx8rnxE36Z8wMZbK5/oLVKVP1RbzaYX1PRXiAj+xq2N/zcA5H/JkyMCAgA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
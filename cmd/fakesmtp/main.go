@@ -0,0 +1,37 @@
+// fakesmtp - SMTP сервер для локальной проверки уведомлений: принимает любые письма и печатает их в stdout
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"homework/pkg/smtp_test"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:2525", "адрес, на котором принимаются письма")
+	flag.Parse()
+
+	server, err := smtp_test.NewServer(*addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("listening on %s", server.Addr())
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			_ = server.Close()
+			return
+		case message := <-server.Received():
+			fmt.Printf("From: %s\nTo: %s\n%s\n%s\n", message.From, strings.Join(message.To, ", "), message.Data, strings.Repeat("-", 40))
+		}
+	}
+}
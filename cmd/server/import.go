@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/gateways/importfile"
+	"io"
+	"os"
+
+	httpGateway "homework/internal/gateways/http"
+)
+
+// errImportFailed - в импортированных файлах есть записи с ошибками
+var errImportFailed = errors.New("some records were not imported")
+
+// runImport - режим импорта из командной строки: server import [-format csv|ndjson] [-sensors file] [-events file].
+// Датчики импортируются раньше событий, отчёты печатаются в out в формате json
+func runImport(ctx context.Context, useCases httpGateway.UseCases, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	sensorsPath := flags.String("sensors", "", "файл датчиков (.csv, .ndjson, .jsonl)")
+	eventsPath := flags.String("events", "", "файл событий (.csv, .ndjson, .jsonl)")
+	formatName := flags.String("format", "", "формат файлов csv или ndjson, по умолчанию определяется по расширению")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *sensorsPath == "" && *eventsPath == "" {
+		return errors.New("at least one of -sensors or -events is required")
+	}
+
+	reports := map[string]domain.ImportReport{}
+	if *sensorsPath != "" {
+		err := importFile(*sensorsPath, *formatName, func(r io.Reader, format importfile.Format) error {
+			report, err := useCases.Sensor.ImportSensors(ctx, importfile.Sensors(r, format))
+			reports["sensors"] = report
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if *eventsPath != "" {
+		err := importFile(*eventsPath, *formatName, func(r io.Reader, format importfile.Format) error {
+			report, err := useCases.Event.ImportEvents(ctx, importfile.Events(r, format))
+			reports["events"] = report
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reports); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if report.Failed > 0 {
+			return errImportFailed
+		}
+	}
+	return nil
+}
+
+func importFile(path, formatName string, load func(io.Reader, importfile.Format) error) error {
+	var format importfile.Format
+	var err error
+	switch formatName {
+	case "":
+		format, err = importfile.FormatByExtension(path)
+	case "csv":
+		format = importfile.CSV
+	case "ndjson":
+		format = importfile.NDJSON
+	default:
+		err = importfile.ErrUnknownFormat
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err = load(f, format); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -4,48 +4,71 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
 	"homework/internal/usecase"
 	"log"
 	"net/http"
+	"net/mail"
+	"net/netip"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	changesGateway "homework/internal/gateways/changes"
+	grpcGateway "homework/internal/gateways/grpc"
 	httpGateway "homework/internal/gateways/http"
+	notificationGateway "homework/internal/gateways/notification"
+	webhookGateway "homework/internal/gateways/webhook"
+	alertRepository "homework/internal/repository/alert/postgres"
+	alertSqliteRepository "homework/internal/repository/alert/sqlite"
+	commandRepository "homework/internal/repository/command/postgres"
+	commandSqliteRepository "homework/internal/repository/command/sqlite"
 	eventRepository "homework/internal/repository/event/postgres"
+	eventSqliteRepository "homework/internal/repository/event/sqlite"
+	eventBufferRepository "homework/internal/repository/eventbuffer/file"
+	notificationRepository "homework/internal/repository/notification/postgres"
+	notificationSqliteRepository "homework/internal/repository/notification/sqlite"
+	outboxRepository "homework/internal/repository/outbox/postgres"
+	outboxSqliteRepository "homework/internal/repository/outbox/sqlite"
+	"homework/internal/repository/pgerrors"
+	rateLimitInmemoryRepository "homework/internal/repository/ratelimit/inmemory"
+	rateLimitRepository "homework/internal/repository/ratelimit/postgres"
+	scheduleRepository "homework/internal/repository/schedule/postgres"
+	scheduleSqliteRepository "homework/internal/repository/schedule/sqlite"
 	sensorRepository "homework/internal/repository/sensor/postgres"
+	sensorSqliteRepository "homework/internal/repository/sensor/sqlite"
+	transaction "homework/internal/repository/transaction/postgres"
+	sqliteTransaction "homework/internal/repository/transaction/sqlite"
 	userRepository "homework/internal/repository/user/postgres"
+	userSqliteRepository "homework/internal/repository/user/sqlite"
 )
 
 func main() {
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	config, err := pgxpool.ParseConfig(os.Getenv("DATABASE_URL"))
+	useCases, closeStorage, err := newUseCases(ctx, os.Getenv("DATABASE_URL"))
 	if err != nil {
-		log.Fatalf("can't parse pgxpool config")
+		log.Fatalf("can't init storage: %v", err)
 	}
+	defer closeStorage()
 
-	pool, err := pgxpool.NewWithConfig(context.Background(), config)
-	if err != nil {
-		log.Fatalf("can't create new pool")
-	}
-	defer pool.Close()
-
-	er := eventRepository.NewEventRepository(pool)
-	sr := sensorRepository.NewSensorRepository(pool)
-	ur := userRepository.NewUserRepository(pool)
-	sor := userRepository.NewSensorOwnerRepository(pool)
-
-	useCases := httpGateway.UseCases{
-		Event:  usecase.NewEvent(er, sr),
-		Sensor: usecase.NewSensor(sr),
-		User:   usecase.NewUser(ur, sor, sr),
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err = runImport(ctx, useCases, os.Args[2:], os.Stdout); err != nil {
+			log.Printf("import: %v", err)
+			closeStorage()
+			os.Exit(1)
+		}
+		return
 	}
 
 	var host string
@@ -63,24 +86,367 @@ func main() {
 	if err != nil {
 		port = 8080
 	}
+	grpcPort, err := strconv.Atoi(os.Getenv("GRPC_PORT"))
+	if err != nil {
+		grpcPort = 9090
+	}
 
-	sigQuit := make(chan os.Signal, 1)
-	signal.Notify(sigQuit, syscall.SIGTERM, syscall.SIGINT)
-	eg, ctx := errgroup.WithContext(ctx)
+	// приём событий останавливается после серверов, чтобы сохранить всё, что они успели принять
+	ingestionCtx, stopIngestion := context.WithCancel(context.WithoutCancel(ctx))
+	ingestionStopped := make(chan struct{})
+	useCases.Ingestion = newIngestion(useCases.Event)
+	go func() {
+		defer close(ingestionStopped)
+		if useCases.Ingestion != nil {
+			useCases.Ingestion.Run(ingestionCtx)
+		}
+	}()
 
-	eg.Go(func() error {
-		s := <-sigQuit
-		_, err := fmt.Printf("capturet signal: %v", s)
-		return err
-	})
+	eg, ctx := errgroup.WithContext(ctx)
 
-	r := httpGateway.NewServer(useCases, httpGateway.WithHost(host), httpGateway.WithPort(uint16(port)))
+	r := httpGateway.NewServer(useCases, httpGateway.WithHost(host), httpGateway.WithPort(uint16(port)),
+		httpGateway.WithTrustedProxies(trustedProxies()))
 
 	eg.Go(func() error {
 		return r.Run(ctx)
 	})
+	g := grpcGateway.NewServer(grpcGateway.UseCases{
+		Event:       useCases.Event,
+		Sensor:      useCases.Sensor,
+		User:        useCases.User,
+		RateLimiter: useCases.RateLimiter,
+		Ingestion:   useCases.Ingestion,
+	}, grpcGateway.WithHost(host), grpcGateway.WithPort(uint16(grpcPort)))
+	eg.Go(func() error {
+		return g.Run(ctx)
+	})
+	eg.Go(func() error {
+		useCases.Notification.Run(ctx)
+		return nil
+	})
+	eg.Go(func() error {
+		useCases.Command.Run(ctx)
+		return nil
+	})
+	eg.Go(func() error {
+		useCases.Scheduler.Run(ctx)
+		return nil
+	})
+	eg.Go(func() error {
+		useCases.Outbox.Run(ctx)
+		return nil
+	})
+	if useCases.EventBuffer != nil {
+		eg.Go(func() error {
+			useCases.EventBuffer.Run(ctx)
+			return nil
+		})
+	}
 
 	if err := eg.Wait(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Printf("error during server shutdown: %v", err)
 	}
+	stopIngestion()
+	<-ingestionStopped
+}
+
+// newUseCases - создаёт usecase'ы поверх хранилища, выбранного по схеме DSN:
+// sqlite:// - файл sqlite, иначе - postgres
+func newUseCases(ctx context.Context, dsn string) (httpGateway.UseCases, func(), error) {
+	if strings.HasPrefix(dsn, sqlitedb.Scheme) {
+		db, err := sqlitedb.Open(dsn)
+		if err != nil {
+			return httpGateway.UseCases{}, nil, err
+		}
+
+		er := eventSqliteRepository.NewEventRepository(db)
+		sr := sensorSqliteRepository.NewSensorRepository(db)
+		ur := userSqliteRepository.NewUserRepository(db)
+		sor := userSqliteRepository.NewSensorOwnerRepository(db)
+		ar := alertSqliteRepository.NewAlertRepository(db)
+		nr := notificationSqliteRepository.NewNotificationRepository(db)
+		cr := commandSqliteRepository.NewCommandRepository(db)
+		schr := scheduleSqliteRepository.NewScheduleRepository(db)
+		tr := sqliteTransaction.NewTransactor(db)
+		outbox, closeOutbox, err := newOutbox(outboxSqliteRepository.NewOutboxRepository(db))
+		if err != nil {
+			_ = db.Close()
+			return httpGateway.UseCases{}, nil, err
+		}
+		notifications := usecase.NewNotification(nr, ur, sor, sr, notificationSenders()...)
+		alerts := usecase.NewAlert(ar, ur, sor, usecase.WithAlertTransactor(tr), usecase.WithAlertNotifications(notifications))
+		commands := usecase.NewCommand(cr, sr)
+		sensors := usecase.NewSensor(sr, usecase.WithSensorTransactor(tr), usecase.WithSensorOutbox(outbox))
+		limiter := newRateLimiter(rateLimitInmemoryRepository.NewRateLimitRepository())
+
+		return httpGateway.UseCases{
+			Event: usecase.NewEvent(er, sr, usecase.WithEventTransactor(tr), usecase.WithEventAlerts(alerts), usecase.WithEventCommands(commands),
+				usecase.WithEventRateLimiter(limiter), usecase.WithEventOutbox(outbox)),
+			Sensor:       sensors,
+			User:         usecase.NewUser(ur, sor, sr, usecase.WithUserTransactor(tr)),
+			Alert:        alerts,
+			Notification: notifications,
+			Command:      commands,
+			Scheduler:    newScheduler(schr, ur, sor, commands, sensors),
+			RateLimiter:  limiter,
+			Outbox:       outbox,
+		}, func() { closeOutbox(); _ = db.Close() }, nil
+	}
+
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return httpGateway.UseCases{}, nil, fmt.Errorf("can't parse pgxpool config: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return httpGateway.UseCases{}, nil, fmt.Errorf("can't create new pool: %w", err)
+	}
+
+	er := eventRepository.NewEventRepository(pool)
+	sr := sensorRepository.NewSensorRepository(pool)
+	ur := userRepository.NewUserRepository(pool)
+	sor := userRepository.NewSensorOwnerRepository(pool)
+	ar := alertRepository.NewAlertRepository(pool)
+	nr := notificationRepository.NewNotificationRepository(pool)
+	cr := commandRepository.NewCommandRepository(pool)
+	schr := scheduleRepository.NewScheduleRepository(pool)
+	tr := transaction.NewTransactor(pool)
+	outbox, closeOutbox, err := newOutbox(outboxRepository.NewOutboxRepository(pool))
+	if err != nil {
+		pool.Close()
+		return httpGateway.UseCases{}, nil, err
+	}
+	notifications := usecase.NewNotification(nr, ur, sor, sr, notificationSenders()...)
+	alerts := usecase.NewAlert(ar, ur, sor, usecase.WithAlertTransactor(tr), usecase.WithAlertNotifications(notifications))
+	commands := usecase.NewCommand(cr, sr)
+	sensors := usecase.NewSensor(sr, usecase.WithSensorTransactor(tr), usecase.WithSensorOutbox(outbox))
+	// RATE_LIMIT_SHARED=true - корзины в postgres, общие для всех реплик сервиса,
+	// пока postgres недоступен, реплика ограничивает запросы своими корзинами в памяти
+	var rr usecase.RateLimitRepository = rateLimitInmemoryRepository.NewRateLimitRepository()
+	var limiterOptions []func(*usecase.RateLimiter)
+	if shared, _ := strconv.ParseBool(os.Getenv("RATE_LIMIT_SHARED")); shared {
+		limiterOptions = append(limiterOptions, usecase.WithRateLimitFallback(rr, pgerrors.IsUnavailable))
+		rr = rateLimitRepository.NewRateLimitRepository(pool)
+	}
+	limiter := newRateLimiter(rr, limiterOptions...)
+	eventOptions := []func(*usecase.Event){usecase.WithEventTransactor(tr), usecase.WithEventAlerts(alerts),
+		usecase.WithEventCommands(commands), usecase.WithEventRateLimiter(limiter), usecase.WithEventOutbox(outbox)}
+	buffer, closeBuffer, err := newEventBuffer()
+	if err != nil {
+		closeOutbox()
+		pool.Close()
+		return httpGateway.UseCases{}, nil, err
+	}
+	if buffer != nil {
+		eventOptions = append(eventOptions, usecase.WithEventBuffer(buffer))
+	}
+
+	return httpGateway.UseCases{
+		Event:        usecase.NewEvent(er, sr, eventOptions...),
+		Sensor:       sensors,
+		User:         usecase.NewUser(ur, sor, sr, usecase.WithUserTransactor(tr)),
+		Alert:        alerts,
+		Notification: notifications,
+		Command:      commands,
+		Scheduler:    newScheduler(schr, ur, sor, commands, sensors),
+		RateLimiter:  limiter,
+		EventBuffer:  buffer,
+		Outbox:       outbox,
+	}, func() { closeBuffer(); closeOutbox(); pool.Close() }, nil
+}
+
+// newEventBuffer - буфер событий на время недоступности postgres в каталоге EVENT_BUFFER_DIR, без переменной nil.
+// Необязательные EVENT_BUFFER_MAX_BYTES и EVENT_BUFFER_RETRY_INTERVAL (длительность Go) меняют наибольший размер
+// буфера и то, как часто проверяется, не восстановился ли postgres
+func newEventBuffer() (*usecase.EventBuffer, func(), error) {
+	dir, ok := os.LookupEnv("EVENT_BUFFER_DIR")
+	if !ok {
+		return nil, func() {}, nil
+	}
+	var maxBytes int64
+	if value, ok := os.LookupEnv("EVENT_BUFFER_MAX_BYTES"); ok {
+		var err error
+		if maxBytes, err = strconv.ParseInt(value, 10, 64); err != nil || maxBytes <= 0 {
+			log.Fatalf("invalid EVENT_BUFFER_MAX_BYTES: must be a positive integer")
+		}
+	}
+	var options []func(*usecase.EventBuffer)
+	if value, ok := os.LookupEnv("EVENT_BUFFER_RETRY_INTERVAL"); ok {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			log.Fatalf("invalid EVENT_BUFFER_RETRY_INTERVAL: must be a positive duration")
+		}
+		options = append(options, usecase.WithEventBufferRetryInterval(interval))
+	}
+	br, err := eventBufferRepository.NewEventBufferRepository(dir, eventBufferRepository.WithMaxBytes(maxBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	return usecase.NewEventBuffer(br, pgerrors.IsUnavailable, options...), func() { _ = br.Close() }, nil
+}
+
+// newOutbox - поток изменений поверх or. Изменения доставляются в webhook OUTBOX_WEBHOOK_URL и дописываются
+// в файл NDJSON OUTBOX_FILE, без переменных relay не запускается и изменения доступны только через GET /changes.
+// Необязательные OUTBOX_POLL_INTERVAL (длительность Go) и OUTBOX_BATCH_SIZE меняют то, как часто relay проверяет
+// журнал, и наибольшую пачку доставки
+func newOutbox(or usecase.OutboxRepository) (*usecase.Outbox, func(), error) {
+	var options []func(*usecase.Outbox)
+	if url, ok := os.LookupEnv("OUTBOX_WEBHOOK_URL"); ok {
+		options = append(options, usecase.WithOutboxSink("webhook", changesGateway.NewWebhookSink(url)))
+	}
+	closeSinks := func() {}
+	if path, ok := os.LookupEnv("OUTBOX_FILE"); ok {
+		sink, err := changesGateway.NewFileSink(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, usecase.WithOutboxSink("file", sink))
+		closeSinks = func() { _ = sink.Close() }
+	}
+	var interval time.Duration
+	if value, ok := os.LookupEnv("OUTBOX_POLL_INTERVAL"); ok {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			log.Fatalf("invalid OUTBOX_POLL_INTERVAL: must be a positive duration")
+		}
+	}
+	var batchSize int
+	if value, ok := os.LookupEnv("OUTBOX_BATCH_SIZE"); ok {
+		var err error
+		if batchSize, err = strconv.Atoi(value); err != nil || batchSize <= 0 {
+			log.Fatalf("invalid OUTBOX_BATCH_SIZE: must be a positive integer")
+		}
+	}
+	options = append(options, usecase.WithOutboxRelay(interval, batchSize))
+	return usecase.NewOutbox(or, options...), closeSinks, nil
+}
+
+// trustedProxies - адреса и подсети обратных прокси через запятую из HTTP_TRUSTED_PROXIES,
+// например "10.0.0.0/8,192.168.1.10", без переменной прокси не доверяют
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("HTTP_TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err = netip.ParseAddr(proxy); err != nil {
+				log.Fatalf("invalid HTTP_TRUSTED_PROXIES: %q is neither an address nor a CIDR", proxy)
+			}
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies
+}
+
+// newRateLimiter - ограничитель запросов с корзинами в rr. Ограничения задаются в RATE_LIMIT_SENSOR
+// (события датчика), RATE_LIMIT_TOKEN (токен API) и RATE_LIMIT_IP (адрес клиента) в формате
+// domain.ParseRateLimit, например "10/s" или "600/1m,100", без переменной область не ограничивается
+func newRateLimiter(rr usecase.RateLimitRepository, options ...func(*usecase.RateLimiter)) *usecase.RateLimiter {
+	env := map[usecase.RateLimitScope]string{
+		usecase.RateLimitScopeSensor: "RATE_LIMIT_SENSOR",
+		usecase.RateLimitScopeToken:  "RATE_LIMIT_TOKEN",
+		usecase.RateLimitScopeIP:     "RATE_LIMIT_IP",
+	}
+	for _, scope := range usecase.RateLimitScopes {
+		value, ok := os.LookupEnv(env[scope])
+		if !ok {
+			continue
+		}
+		limit, err := domain.ParseRateLimit(value)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", env[scope], err)
+		}
+		options = append(options, usecase.WithRateLimit(scope, limit))
+	}
+	return usecase.NewRateLimiter(rr, options...)
+}
+
+// newIngestion - асинхронный приём событий, если INGESTION_ASYNC=true, иначе nil. Необязательные
+// INGESTION_WORKERS, INGESTION_QUEUE_SIZE, INGESTION_BATCH_SIZE и INGESTION_FLUSH_INTERVAL (длительность Go)
+// меняют число обработчиков, размер очереди и пачки и время ожидания пачки
+func newIngestion(events *usecase.Event) *usecase.Ingestion {
+	if async, _ := strconv.ParseBool(os.Getenv("INGESTION_ASYNC")); !async {
+		return nil
+	}
+	number := func(name string) int {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return 0
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			log.Fatalf("invalid %s: must be a positive integer", name)
+		}
+		return n
+	}
+	var interval time.Duration
+	if value, ok := os.LookupEnv("INGESTION_FLUSH_INTERVAL"); ok {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			log.Fatalf("invalid INGESTION_FLUSH_INTERVAL: must be a positive duration")
+		}
+	}
+	return usecase.NewIngestion(events,
+		usecase.WithIngestionWorkers(number("INGESTION_WORKERS")),
+		usecase.WithIngestionQueueSize(number("INGESTION_QUEUE_SIZE")),
+		usecase.WithIngestionBatch(number("INGESTION_BATCH_SIZE"), interval),
+	)
+}
+
+// newScheduler - планировщик, выполняющий все виды действий расписаний
+func newScheduler(sr usecase.ScheduleRepository, ur usecase.UserRepository, sor usecase.SensorOwnerRepository, commands *usecase.Command, sensors *usecase.Sensor) *usecase.Scheduler {
+	return usecase.NewScheduler(sr, ur, sor,
+		usecase.WithSchedulerCommands(commands),
+		usecase.WithSchedulerSensors(sensors),
+		usecase.WithSchedulerWebhook(webhookGateway.NewClient()),
+	)
+}
+
+// outboundNetworks - внутренние подсети через запятую из NOTIFICATION_ALLOWED_NETWORKS, в которые можно
+// отправлять push уведомления, например "10.1.0.0/16". Без переменной внутренние адреса запрещены
+func outboundNetworks() domain.OutboundNetworks {
+	var networks domain.OutboundNetworks
+	for _, network := range strings.Split(os.Getenv("NOTIFICATION_ALLOWED_NETWORKS"), ",") {
+		network = strings.TrimSpace(network)
+		if network == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			log.Fatalf("invalid NOTIFICATION_ALLOWED_NETWORKS: %v", err)
+		}
+		networks = append(networks, prefix)
+	}
+	return networks
+}
+
+// notificationSenders - отправители уведомлений о тревогах. Push доступен всегда,
+// email - если задан SMTP_ADDR (SMTP_FROM, SMTP_USERNAME и SMTP_PASSWORD необязательны)
+func notificationSenders() []func(*usecase.Notification) {
+	networks := outboundNetworks()
+	senders := []func(*usecase.Notification){
+		usecase.WithNotificationOutboundNetworks(networks),
+		usecase.WithNotificationSender(domain.NotificationChannelPush,
+			notificationGateway.NewPushSender(notificationGateway.WithPushOutboundNetworks(networks))),
+	}
+	addr, ok := os.LookupEnv("SMTP_ADDR")
+	if !ok {
+		return senders
+	}
+	from := mail.Address{Name: "Smart home", Address: "smart-home@localhost"}
+	if f, ok := os.LookupEnv("SMTP_FROM"); ok {
+		parsed, err := mail.ParseAddress(f)
+		if err != nil {
+			log.Fatalf("invalid SMTP_FROM: %v", err)
+		}
+		from = *parsed
+	}
+	var options []func(*notificationGateway.SMTPSender)
+	if username, ok := os.LookupEnv("SMTP_USERNAME"); ok {
+		options = append(options, notificationGateway.WithSMTPAuth(username, os.Getenv("SMTP_PASSWORD")))
+	}
+	return append(senders, usecase.WithNotificationSender(domain.NotificationChannelEmail, notificationGateway.NewSMTPSender(addr, from, options...)))
 }
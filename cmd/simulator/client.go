@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/coder/websocket"
+)
+
+// client - клиент HTTP API умного дома
+type client struct {
+	base *url.URL
+	http *http.Client
+}
+
+func newClient(base string, workers int) (*client, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = workers
+	return &client{base: u, http: &http.Client{Transport: transport}}, nil
+}
+
+// statusError - ответ API с неожиданным статусом и описанием ошибки из problem+json (RFC 7807)
+type statusError struct {
+	Code int
+	// ErrorCode - машиночитаемый код ошибки API, например rate_limited
+	ErrorCode string
+	Detail    string
+}
+
+func (e *statusError) Error() string {
+	if e.Detail == "" {
+		return e.reason()
+	}
+	return e.reason() + ": " + e.Detail
+}
+
+// reason - статус и код ошибки без описания, которое у однотипных ошибок может различаться
+func (e *statusError) reason() string {
+	if e.ErrorCode == "" {
+		return fmt.Sprintf("HTTP %d", e.Code)
+	}
+	return fmt.Sprintf("HTTP %d %s", e.Code, e.ErrorCode)
+}
+
+// failureReason - причина ошибки для отчёта: ответы API группируются по статусу и коду ошибки
+func failureReason(err error) string {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.reason()
+	}
+	return err.Error()
+}
+
+// do - выполняет запрос с телом body в json и разбирает ответ в out, если он не nil.
+// Ответ с любым из статусов expected считается успешным
+func (c *client) do(ctx context.Context, method, path string, body, out any, expected ...int) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base.JoinPath(path).String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(expected, resp.StatusCode) {
+		var problem struct {
+			Code   string `json:"code"`
+			Detail string `json:"detail"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&problem)
+		return &statusError{Code: resp.StatusCode, ErrorCode: problem.Code, Detail: problem.Detail}
+	}
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sensorTypes - типы датчиков, которые поддерживает сервер
+func (c *client) sensorTypes(ctx context.Context) ([]domain.SensorTypeInfo, error) {
+	var types []domain.SensorTypeInfo
+	err := c.do(ctx, http.MethodGet, "/sensor-types", nil, &types, http.StatusOK)
+	return types, err
+}
+
+// registerSensor - регистрирует датчик, для уже зарегистрированного серийного номера сервер возвращает существующий датчик
+func (c *client) registerSensor(ctx context.Context, serialNumber string, sensorType domain.SensorType) (domain.Sensor, error) {
+	var sensor domain.Sensor
+	err := c.do(ctx, http.MethodPost, "/sensors", map[string]any{
+		"serial_number": serialNumber,
+		"type":          sensorType,
+		"description":   "simulated " + string(sensorType),
+		"is_active":     true,
+	}, &sensor, http.StatusOK)
+	return sensor, err
+}
+
+// postEvent - отправляет событие датчика. Сервер с асинхронным приёмом или буфером событий
+// отвечает 202, не дожидаясь сохранения
+func (c *client) postEvent(ctx context.Context, serialNumber string, payload float64) error {
+	return c.do(ctx, http.MethodPost, "/events", map[string]any{
+		"sensor_serial_number": serialNumber,
+		"payload":              payload,
+	}, nil, http.StatusCreated, http.StatusAccepted)
+}
+
+// subscribe - подписывается на события датчика id по WebSocket и передаёт их в fn до отмены ctx
+func (c *client) subscribe(ctx context.Context, id int64, fn func(domain.Event)) error {
+	u := *c.base.JoinPath("/sensors", strconv.FormatInt(id, 10), "events")
+	u.Scheme = map[string]string{"http": "ws", "https": "wss"}[u.Scheme]
+	conn, _, err := websocket.Dial(ctx, u.String(), &websocket.DialOptions{HTTPClient: c.http})
+	if err != nil {
+		return err
+	}
+	defer conn.CloseNow()
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		var event domain.Event
+		if err = json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		fn(event)
+	}
+}
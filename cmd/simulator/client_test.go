@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_postEvent(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusAccepted)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status.Load() >= http.StatusBadRequest {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"type":"about:blank","title":"Too Many Requests","status":429,"code":"rate_limited","detail":"rate limited: sensor limit, retry after 1s"}`))
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+	c, err := newClient(server.URL, 1)
+	require.NoError(t, err)
+
+	// сервер с асинхронным приёмом отвечает 202
+	require.NoError(t, c.postEvent(context.Background(), "0000000001", 1))
+
+	status.Store(http.StatusTooManyRequests)
+	err = c.postEvent(context.Background(), "0000000001", 1)
+	require.Error(t, err)
+	assert.Equal(t, "HTTP 429 rate_limited: rate limited: sensor limit, retry after 1s", err.Error())
+	assert.Equal(t, "HTTP 429 rate_limited", failureReason(err))
+}
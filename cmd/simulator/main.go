@@ -0,0 +1,297 @@
+// Simulator - генератор нагрузки для HTTP API умного дома.
+// Регистрирует датчики, отправляет их события в POST /events с заданной частотой, подписывается
+// на события по WebSocket и периодически печатает пропускную способность и процентили задержек.
+//
+//	go run ./cmd/simulator -url http://localhost:8080 -sensors 50 -types cc,adc,humidity -rate 200 -subscribers 10 -duration 1m
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"homework/internal/domain"
+	"log"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// config - параметры запуска симулятора
+type config struct {
+	URL         string
+	Sensors     int
+	Types       []domain.SensorType
+	SerialBase  int64
+	Rate        float64
+	Duration    time.Duration
+	Workers     int
+	Subscribers int
+	Report      time.Duration
+	Seed        uint64
+	waveformConfig
+}
+
+func parseFlags(args []string) (config, error) {
+	var cfg config
+	var types string
+	flags := flag.NewFlagSet("simulator", flag.ContinueOnError)
+	flags.StringVar(&cfg.URL, "url", "http://localhost:8080", "адрес HTTP API")
+	flags.IntVar(&cfg.Sensors, "sensors", 10, "число датчиков")
+	flags.StringVar(&types, "types", "cc,adc", "типы датчиков через запятую, датчики распределяются по ним по очереди")
+	flags.Int64Var(&cfg.SerialBase, "serial-base", 9000000000, "серийный номер первого датчика, остальные идут подряд")
+	flags.Float64Var(&cfg.Rate, "rate", 100, "суммарная частота событий в секунду")
+	flags.DurationVar(&cfg.Duration, "duration", time.Minute, "длительность нагрузки, 0 - до прерывания")
+	flags.IntVar(&cfg.Workers, "workers", 16, "число одновременных запросов")
+	flags.IntVar(&cfg.Subscribers, "subscribers", 0, "число подписчиков WebSocket, распределяются по датчикам по очереди")
+	flags.DurationVar(&cfg.Report, "report", 5*time.Second, "интервал промежуточных отчётов")
+	flags.Uint64Var(&cfg.Seed, "seed", 0, "зерно генератора значений, 0 - случайное")
+	flags.Float64Var(&cfg.ToggleProbability, "toggle", 0.1, "вероятность переключения датчика состояния при событии")
+	flags.Float64Var(&cfg.SpikeProbability, "spikes", 0.01, "вероятность выброса значения измерителя")
+	flags.DurationVar(&cfg.Day, "day", 24*time.Hour, "период суточного цикла измерителей, 0 - без цикла")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	for _, t := range strings.Split(types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			cfg.Types = append(cfg.Types, domain.SensorType(t))
+		}
+	}
+	switch {
+	case cfg.Sensors < 1:
+		return cfg, errors.New("sensors must be positive")
+	case len(cfg.Types) == 0:
+		return cfg, errors.New("at least one sensor type required")
+	case cfg.Rate <= 0:
+		return cfg, errors.New("rate must be positive")
+	case cfg.Workers < 1:
+		return cfg, errors.New("workers must be positive")
+	case cfg.Report <= 0:
+		return cfg, errors.New("report interval must be positive")
+	case cfg.SerialBase < 0 || cfg.SerialBase+int64(cfg.Sensors) > 1e10:
+		return cfg, errors.New("serial numbers must fit in 10 digits")
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = rand.Uint64()
+	}
+	return cfg, nil
+}
+
+// simulatedSensor - зарегистрированный датчик и источник его значений
+type simulatedSensor struct {
+	domain.Sensor
+	waveform waveform
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err = run(ctx, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, cfg config) error {
+	c, err := newClient(cfg.URL, cfg.Workers)
+	if err != nil {
+		return err
+	}
+	sensors, err := registerSensors(ctx, c, cfg)
+	if err != nil {
+		return err
+	}
+	log.Printf("registered %d sensors, seed %d", len(sensors), cfg.Seed)
+
+	if cfg.Duration > 0 {
+		var stop context.CancelFunc
+		ctx, stop = context.WithTimeout(ctx, cfg.Duration)
+		defer stop()
+	}
+
+	events, notifications := newLatencies(), newLatencies()
+	var wg sync.WaitGroup
+	for i := range cfg.Subscribers {
+		sensor := sensors[i%len(sensors)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			subscribe(ctx, c, sensor.ID, notifications)
+		}()
+	}
+
+	jobs := make(chan job, cfg.Workers*2)
+	for range cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				start := time.Now()
+				// запрос, начатый до окончания нагрузки, доводится до конца, чтобы не считать его ошибкой
+				if err := c.postEvent(context.WithoutCancel(ctx), j.serialNumber, j.payload); err != nil {
+					events.Fail(failureReason(err))
+					continue
+				}
+				events.Add(time.Since(start))
+			}
+		}()
+	}
+
+	done, reported := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(reported)
+		report(done, cfg.Report, cfg.Subscribers > 0, events, notifications)
+	}()
+
+	generate(ctx, cfg.Rate, sensors, jobs, events)
+	close(jobs)
+	wg.Wait()
+	close(done)
+	<-reported
+	return nil
+}
+
+// registerSensors - регистрирует датчики, серийные номера которых идут подряд от cfg.SerialBase
+func registerSensors(ctx context.Context, c *client, cfg config) ([]simulatedSensor, error) {
+	types, err := c.sensorTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get sensor types: %w", err)
+	}
+	infos := make(map[domain.SensorType]domain.SensorTypeInfo, len(types))
+	for _, info := range types {
+		infos[info.Type] = info
+	}
+
+	sensors := make([]simulatedSensor, 0, cfg.Sensors)
+	for i := range cfg.Sensors {
+		sensorType := cfg.Types[i%len(cfg.Types)]
+		info, ok := infos[sensorType]
+		if !ok {
+			return nil, fmt.Errorf("unknown sensor type %q", sensorType)
+		}
+		sensor, err := c.registerSensor(ctx, fmt.Sprintf("%010d", cfg.SerialBase+int64(i)), sensorType)
+		if err != nil {
+			return nil, fmt.Errorf("can't register sensor: %w", err)
+		}
+		if sensor.Type != sensorType {
+			return nil, fmt.Errorf("sensor %s is already registered with type %s", sensor.SerialNumber, sensor.Type)
+		}
+		// у каждого датчика свой генератор, чтобы значения с одним зерном повторялись при любом числе датчиков
+		rng := rand.New(rand.NewPCG(cfg.Seed, uint64(i)))
+		sensors = append(sensors, simulatedSensor{Sensor: sensor, waveform: newWaveform(info, rng, cfg.waveformConfig)})
+	}
+	return sensors, nil
+}
+
+// job - событие, которое надо отправить
+type job struct {
+	serialNumber string
+	payload      float64
+}
+
+// pacerTick - период, с которым генератор досылает события, накопившиеся по расписанию
+const pacerTick = 10 * time.Millisecond
+
+// generate - отправляет события датчиков по очереди с частотой rate до отмены ctx.
+// Если все обработчики заняты, событие не ждёт очереди и считается неотправленным,
+// чтобы отставание сервера не снижало заданную частоту незаметно
+func generate(ctx context.Context, rate float64, sensors []simulatedSensor, jobs chan<- job, events *latencies) {
+	ticker := time.NewTicker(pacerTick)
+	defer ticker.Stop()
+	start := time.Now()
+	sent := 0
+	next := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			due := int(now.Sub(start).Seconds()*rate) - sent
+			for range due {
+				sensor := &sensors[next]
+				next = (next + 1) % len(sensors)
+				sent++
+				select {
+				case jobs <- job{serialNumber: sensor.SerialNumber, payload: sensor.waveform.Next(now)}:
+				default:
+					events.Fail("dropped: all workers busy")
+				}
+			}
+		}
+	}
+}
+
+// subscribe - принимает события датчика по WebSocket и учитывает задержку от времени события до получения.
+// Сервер рассылает последнее событие периодически, поэтому повторы одного события пропускаются,
+// а задержка включает период рассылки
+func subscribe(ctx context.Context, c *client, id int64, notifications *latencies) {
+	var lastID int64
+	for ctx.Err() == nil {
+		err := c.subscribe(ctx, id, func(event domain.Event) {
+			if event.ID == lastID {
+				return
+			}
+			lastID = event.ID
+			notifications.Add(time.Since(event.Timestamp))
+		})
+		if err != nil {
+			notifications.Fail(err.Error())
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+// report - печатает сводку каждые interval и итог после закрытия done
+func report(done <-chan struct{}, interval time.Duration, withNotifications bool, events, notifications *latencies) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	start, last := time.Now(), time.Now()
+	var allEvents, allNotifications []time.Duration
+	eventErrors, notificationErrors := map[string]int{}, map[string]int{}
+
+	collect := func(l *latencies, all *[]time.Duration, totalErrors map[string]int) summary {
+		samples, errs := l.Snapshot()
+		*all = append(*all, samples...)
+		for reason, count := range errs {
+			totalErrors[reason] += count
+		}
+		return summarize(samples, errs)
+	}
+
+	for {
+		select {
+		case <-done:
+			collect(events, &allEvents, eventErrors)
+			collect(notifications, &allNotifications, notificationErrors)
+			elapsed := time.Since(start)
+			fmt.Printf("total %v\nevents        %s\n", elapsed.Round(time.Millisecond), summarize(allEvents, eventErrors).String(elapsed))
+			if withNotifications {
+				fmt.Printf("notifications %s\n", summarize(allNotifications, notificationErrors).String(elapsed))
+			}
+			return
+		case now := <-ticker.C:
+			elapsed := now.Sub(last)
+			last = now
+			fmt.Printf("events        %s\n", collect(events, &allEvents, eventErrors).String(elapsed))
+			if withNotifications {
+				fmt.Printf("notifications %s\n", collect(notifications, &allNotifications, notificationErrors).String(elapsed))
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencies - накопитель задержек для расчёта процентилей
+type latencies struct {
+	mutex   sync.Mutex
+	samples []time.Duration
+	errors  map[string]int
+}
+
+func newLatencies() *latencies {
+	return &latencies{errors: map[string]int{}}
+}
+
+// Add - учитывает успешную операцию с задержкой d
+func (l *latencies) Add(d time.Duration) {
+	l.mutex.Lock()
+	l.samples = append(l.samples, d)
+	l.mutex.Unlock()
+}
+
+// Fail - учитывает неудачную операцию с причиной reason
+func (l *latencies) Fail(reason string) {
+	l.mutex.Lock()
+	l.errors[reason]++
+	l.mutex.Unlock()
+}
+
+// Snapshot - забирает накопленные с прошлого вызова задержки и ошибки
+func (l *latencies) Snapshot() ([]time.Duration, map[string]int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	samples, errors := l.samples, l.errors
+	l.samples, l.errors = nil, map[string]int{}
+	return samples, errors
+}
+
+// summary - сводка по задержкам за интервал
+type summary struct {
+	Count  int
+	Failed int
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+	Errors map[string]int
+}
+
+// summarize - считает процентили samples, samples сортируется на месте
+func summarize(samples []time.Duration, errors map[string]int) summary {
+	s := summary{Count: len(samples), Errors: errors}
+	for _, count := range errors {
+		s.Failed += count
+	}
+	if len(samples) == 0 {
+		return s
+	}
+	slices.Sort(samples)
+	s.P50 = percentile(samples, 0.50)
+	s.P90 = percentile(samples, 0.90)
+	s.P99 = percentile(samples, 0.99)
+	s.Max = samples[len(samples)-1]
+	return s
+}
+
+// percentile - значение процентиля p (0..1) по методу ближайшего ранга, sorted должен быть упорядочен
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(p*float64(len(sorted))+0.999999) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+func (s summary) String(elapsed time.Duration) string {
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(s.Count) / elapsed.Seconds()
+	}
+	line := fmt.Sprintf("%6d ok %5d failed %8.1f/s  p50 %-10v p90 %-10v p99 %-10v max %v",
+		s.Count, s.Failed, rate, round(s.P50), round(s.P90), round(s.P99), round(s.Max))
+	for _, reason := range slices.Sorted(maps.Keys(s.Errors)) {
+		line += fmt.Sprintf("\n    %d × %s", s.Errors[reason], reason)
+	}
+	return line
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"homework/internal/domain"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Диапазон значений датчиков-измерителей, у типа которых не задана граница
+const (
+	defaultMinPayload = 0
+	defaultMaxPayload = 4095
+)
+
+// waveform - источник значений одного датчика
+type waveform interface {
+	// Next - значение датчика в момент t
+	Next(t time.Time) float64
+}
+
+// newWaveform - источник значений для типа датчика: переключения для датчиков состояния,
+// суточный цикл с шумом и выбросами для измерителей
+func newWaveform(info domain.SensorTypeInfo, rng *rand.Rand, cfg waveformConfig) waveform {
+	low, high := float64(defaultMinPayload), float64(defaultMaxPayload)
+	if info.MinPayload != nil {
+		low = *info.MinPayload
+	}
+	if info.MaxPayload != nil {
+		high = *info.MaxPayload
+	}
+	if info.Semantics == domain.PayloadSemanticsState {
+		low, high = math.Ceil(low), math.Floor(high)
+		return &toggle{rng: rng, low: low, high: high, state: low, probability: cfg.ToggleProbability}
+	}
+	span := high - low
+	return &analog{
+		rng:              rng,
+		low:              low,
+		high:             high,
+		base:             low + span*(0.3+0.4*rng.Float64()),
+		amplitude:        span * 0.15,
+		phase:            2 * math.Pi * rng.Float64(),
+		noise:            span * 0.01,
+		spike:            span * 0.3,
+		spikeProbability: cfg.SpikeProbability,
+		day:              cfg.Day,
+	}
+}
+
+// waveformConfig - параметры генерации значений
+type waveformConfig struct {
+	// ToggleProbability - вероятность смены состояния датчика состояния при очередном событии
+	ToggleProbability float64
+	// SpikeProbability - вероятность выброса значения измерителя
+	SpikeProbability float64
+	// Day - длительность суточного цикла измерителей
+	Day time.Duration
+}
+
+// toggle - датчик состояния, который время от времени переходит в другое целое состояние из [low, high]
+type toggle struct {
+	rng         *rand.Rand
+	low, high   float64
+	state       float64
+	probability float64
+}
+
+func (w *toggle) Next(time.Time) float64 {
+	if w.high > w.low && w.rng.Float64() < w.probability {
+		next := w.low + float64(w.rng.IntN(int(w.high-w.low)))
+		if next >= w.state {
+			next++
+		}
+		w.state = next
+	}
+	return w.state
+}
+
+// analog - измеритель: синусоидальный суточный цикл вокруг base, гауссов шум и редкие выбросы
+type analog struct {
+	rng              *rand.Rand
+	low, high        float64
+	base             float64
+	amplitude        float64
+	phase            float64
+	noise            float64
+	spike            float64
+	spikeProbability float64
+	day              time.Duration
+}
+
+func (w *analog) Next(t time.Time) float64 {
+	value := w.base + w.rng.NormFloat64()*w.noise
+	if w.day > 0 {
+		cycle := float64(t.UnixNano()%int64(w.day)) / float64(w.day)
+		value += w.amplitude * math.Sin(2*math.Pi*cycle+w.phase)
+	}
+	if w.rng.Float64() < w.spikeProbability {
+		if w.rng.IntN(2) == 0 {
+			value -= w.spike
+		} else {
+			value += w.spike
+		}
+	}
+	value = math.Round(value*100) / 100
+	return min(max(value, w.low), w.high)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"homework/internal/usecase"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaveform_AcceptedBySensorType(t *testing.T) {
+	cfg := waveformConfig{ToggleProbability: 0.5, SpikeProbability: 0.2, Day: time.Minute}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, info := range usecase.DefaultSensorTypes() {
+		w := newWaveform(info, rand.New(rand.NewPCG(1, uint64(i))), cfg)
+		values := map[float64]bool{}
+		for j := range 1000 {
+			value := w.Next(start.Add(time.Duration(j) * time.Second))
+			assert.True(t, info.Accepts(value), "type %s, value %v", info.Type, value)
+			values[value] = true
+		}
+		assert.Greater(t, len(values), 1, "type %s", info.Type)
+	}
+}
+
+func TestWaveform_DailyCycle(t *testing.T) {
+	w := &analog{rng: rand.New(rand.NewPCG(1, 1)), low: 0, high: 100, base: 50, amplitude: 10, day: time.Hour}
+
+	assert.Equal(t, 50.0, w.Next(time.Unix(0, 0)))
+	assert.Equal(t, 60.0, w.Next(time.Unix(15*60, 0)))
+	assert.Equal(t, 40.0, math.Round(w.Next(time.Unix(45*60, 0))))
+}
+
+func TestSummarize(t *testing.T) {
+	samples := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	s := summarize(samples, map[string]int{"HTTP 500": 2, "timeout": 1})
+	assert.Equal(t, 100, s.Count)
+	assert.Equal(t, 3, s.Failed)
+	assert.Equal(t, 50*time.Millisecond, s.P50)
+	assert.Equal(t, 90*time.Millisecond, s.P90)
+	assert.Equal(t, 99*time.Millisecond, s.P99)
+	assert.Equal(t, 100*time.Millisecond, s.Max)
+
+	assert.Equal(t, summary{Errors: map[string]int{}}, summarize(nil, map[string]int{}))
+}
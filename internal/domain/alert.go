@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AlertSeverity - важность тревоги
+type AlertSeverity string
+
+const (
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+// Valid - проверяет, что важность одна из известных
+func (s AlertSeverity) Valid() bool {
+	switch s {
+	case AlertSeverityInfo, AlertSeverityWarning, AlertSeverityCritical:
+		return true
+	}
+	return false
+}
+
+// AlertRule - правило тревоги датчика: тревога поднимается, когда сырой payload события выходит за [Min, Max].
+// Например, для датчика протечки с замыканием контакта достаточно Max = 0
+type AlertRule struct {
+	// Severity - важность поднимаемой тревоги
+	Severity AlertSeverity `json:"severity"`
+	// Min - нижняя допустимая граница payload, nil - без ограничения
+	Min *float64 `json:"min,omitempty"`
+	// Max - верхняя допустимая граница payload, nil - без ограничения
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Triggered - проверяет, поднимает ли событие с payload тревогу. Для nil правила всегда false
+func (r *AlertRule) Triggered(payload float64) bool {
+	if r == nil {
+		return false
+	}
+	return (r.Min != nil && payload < *r.Min) || (r.Max != nil && payload > *r.Max)
+}
+
+// Clone - копия правила, не разделяющая с оригиналом границы
+func (r *AlertRule) Clone() *AlertRule {
+	if r == nil {
+		return nil
+	}
+	result := *r
+	if r.Min != nil {
+		result.Min = new(float64)
+		*result.Min = *r.Min
+	}
+	if r.Max != nil {
+		result.Max = new(float64)
+		*result.Max = *r.Max
+	}
+	return &result
+}
+
+// AlertState - этап обработки тревоги
+type AlertState string
+
+const (
+	// AlertStateOpen - тревога поднята и никем не подтверждена
+	AlertStateOpen AlertState = "open"
+	// AlertStateAcknowledged - тревога подтверждена пользователем, но не устранена
+	AlertStateAcknowledged AlertState = "acknowledged"
+	// AlertStateResolved - тревога устранена
+	AlertStateResolved AlertState = "resolved"
+)
+
+// Alert - тревога, поднятая событием датчика.
+// У датчика одновременно может быть только одна неустранённая тревога
+type Alert struct {
+	// ID - id тревоги
+	ID int64 `json:"id"`
+	// SensorID - id датчика
+	SensorID int64 `json:"sensor_id"`
+	// Severity - важность
+	Severity AlertSeverity `json:"severity"`
+	// Payload - сырое значение события, поднявшего тревогу
+	Payload float64 `json:"payload"`
+	// TriggeredAt - время события, поднявшего тревогу
+	TriggeredAt time.Time `json:"triggered_at"`
+	// AcknowledgedBy - id пользователя, подтвердившего тревогу, nil - не подтверждена
+	AcknowledgedBy *int64 `json:"acknowledged_by,omitempty"`
+	// AcknowledgedAt - время подтверждения
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	// ResolvedBy - id пользователя, устранившего тревогу, nil - не устранена
+	ResolvedBy *int64 `json:"resolved_by,omitempty"`
+	// ResolvedAt - время устранения
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// State - этап обработки тревоги
+func (a Alert) State() AlertState {
+	switch {
+	case a.ResolvedAt != nil:
+		return AlertStateResolved
+	case a.AcknowledgedAt != nil:
+		return AlertStateAcknowledged
+	default:
+		return AlertStateOpen
+	}
+}
+
+// MarshalJSON - кодирует тревогу вместе с вычисленным этапом обработки state
+func (a Alert) MarshalJSON() ([]byte, error) {
+	type alert Alert
+	return json.Marshal(struct {
+		alert
+		State AlertState `json:"state"`
+	}{alert(a), a.State()})
+}
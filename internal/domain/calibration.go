@@ -0,0 +1,68 @@
+package domain
+
+import "sort"
+
+// Calibration - калибровка датчика: перевод сырого значения payload в значение в единицах Unit.
+// Если задана таблица Table, значение интерполируется по ней, иначе вычисляется как raw*Scale + Offset
+type Calibration struct {
+	// Offset - смещение линейной калибровки
+	Offset float64 `json:"offset"`
+	// Scale - множитель линейной калибровки
+	Scale float64 `json:"scale"`
+	// Table - таблица соответствия сырых значений откалиброванным, упорядоченная по Raw
+	Table []CalibrationPoint `json:"table,omitempty"`
+	// Unit - единица измерения откалиброванного значения
+	Unit string `json:"unit,omitempty"`
+}
+
+// CalibrationPoint - точка таблицы калибровки
+type CalibrationPoint struct {
+	// Raw - сырое значение
+	Raw float64 `json:"raw"`
+	// Value - откалиброванное значение
+	Value float64 `json:"value"`
+}
+
+// Apply - переводит сырое значение в откалиброванное.
+// Между точками таблицы значение интерполируется линейно, за её границами - экстраполируется по крайнему отрезку
+func (c *Calibration) Apply(raw float64) float64 {
+	if c == nil {
+		return raw
+	}
+	if len(c.Table) < 2 {
+		return raw*c.Scale + c.Offset
+	}
+	i := sort.Search(len(c.Table), func(i int) bool {
+		return c.Table[i].Raw >= raw
+	})
+	switch {
+	case i == 0:
+		i = 1
+	case i == len(c.Table):
+		i = len(c.Table) - 1
+	}
+	from, to := c.Table[i-1], c.Table[i]
+	return from.Value + (raw-from.Raw)*(to.Value-from.Value)/(to.Raw-from.Raw)
+}
+
+// CalibrateEvent - возвращает представление события, в котором Payload переведён калибровкой в единицы Unit
+func (c *Calibration) CalibrateEvent(event Event) Event {
+	if c == nil {
+		return event
+	}
+	event.Payload = c.Apply(event.Payload)
+	event.Unit = c.Unit
+	return event
+}
+
+// Clone - возвращает копию калибровки
+func (c *Calibration) Clone() *Calibration {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	if c.Table != nil {
+		clone.Table = append([]CalibrationPoint(nil), c.Table...)
+	}
+	return &clone
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibration_Apply(t *testing.T) {
+	tests := []struct {
+		name        string
+		calibration *Calibration
+		raw         float64
+		want        float64
+	}{
+		{"nil calibration", nil, 512, 512},
+		{"linear", &Calibration{Offset: -40, Scale: 0.5}, 100, 10},
+		{"table, exact point", &Calibration{Table: []CalibrationPoint{{0, 0}, {100, 50}, {200, 150}}}, 100, 50},
+		{"table, interpolation", &Calibration{Table: []CalibrationPoint{{0, 0}, {100, 50}, {200, 150}}}, 150, 100},
+		{"table, below range", &Calibration{Table: []CalibrationPoint{{0, 0}, {100, 50}, {200, 150}}}, -100, -50},
+		{"table, above range", &Calibration{Table: []CalibrationPoint{{0, 0}, {100, 50}, {200, 150}}}, 300, 250},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.calibration.Apply(tt.raw), 1e-9)
+		})
+	}
+}
+
+func TestSensor_Calibrated(t *testing.T) {
+	sensor := Sensor{
+		CurrentState: 100,
+		Calibration:  &Calibration{Offset: -40, Scale: 0.5, Unit: "°C"},
+	}
+
+	calibrated := sensor.Calibrated()
+	assert.Equal(t, 10.0, calibrated.CurrentState)
+	assert.Equal(t, "°C", calibrated.Unit)
+	assert.Equal(t, 100.0, sensor.CurrentState, "исходный датчик не должен меняться")
+
+	event := sensor.Calibration.CalibrateEvent(Event{Payload: 200})
+	assert.Equal(t, 60.0, event.Payload)
+	assert.Equal(t, "°C", event.Unit)
+
+	assert.Equal(t, Sensor{CurrentState: 100}, Sensor{CurrentState: 100}.Calibrated())
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// ChangeKind - что изменилось в записи потока изменений
+type ChangeKind string
+
+const (
+	// ChangeKindEvent - принято событие датчика
+	ChangeKindEvent ChangeKind = "event"
+	// ChangeKindSensor - датчик зарегистрирован или изменён
+	ChangeKindSensor ChangeKind = "sensor"
+)
+
+// Change - запись потока изменений, сохраняется в одной транзакции с изменением
+type Change struct {
+	// Seq - номер записи, задаётся хранилищем и возрастает в порядке фиксации транзакций
+	Seq int64 `json:"seq"`
+	// Kind - что изменилось
+	Kind ChangeKind `json:"kind"`
+	// CreatedAt - время изменения
+	CreatedAt time.Time `json:"created_at"`
+	// Event - принятое событие, только для ChangeKindEvent
+	Event *Event `json:"event,omitempty"`
+	// Sensor - датчик после изменения, только для ChangeKindSensor
+	Sensor *Sensor `json:"sensor,omitempty"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// CommandState - этап доставки команды исполнительному устройству
+type CommandState string
+
+const (
+	// CommandStatePending - команда ждёт, пока устройство её заберёт
+	CommandStatePending CommandState = "pending"
+	// CommandStateDelivered - команда передана устройству, ожидается подтверждение
+	CommandStateDelivered CommandState = "delivered"
+	// CommandStateAcknowledged - устройство сообщило событием, что перешло в состояние команды
+	CommandStateAcknowledged CommandState = "acknowledged"
+	// CommandStateFailed - устройство сообщило другое состояние или не подтвердило команду вовремя
+	CommandStateFailed CommandState = "failed"
+)
+
+// Valid - проверяет, что этап один из известных
+func (s CommandState) Valid() bool {
+	switch s {
+	case CommandStatePending, CommandStateDelivered, CommandStateAcknowledged, CommandStateFailed:
+		return true
+	}
+	return false
+}
+
+// Command - команда исполнительному устройству перейти в состояние Payload.
+// Устройство подтверждает команду событием с CommandID, payload события - состояние, в которое оно перешло
+type Command struct {
+	// ID - id команды
+	ID int64 `json:"id"`
+	// SensorID - id устройства
+	SensorID int64 `json:"sensor_id"`
+	// Payload - требуемое состояние устройства
+	Payload float64 `json:"payload"`
+	// State - этап доставки
+	State CommandState `json:"state"`
+	// Reason - причина неудачи, только у команд в состоянии failed
+	Reason string `json:"reason,omitempty"`
+	// CreatedAt - время создания
+	CreatedAt time.Time `json:"created_at"`
+	// DeliveredAt - время передачи устройству, nil - ещё не передана
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	// CompletedAt - время подтверждения или неудачи, nil - команда ещё выполняется
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
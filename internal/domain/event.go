@@ -4,12 +4,35 @@ import "time"
 
 // Event - структура события по датчику
 type Event struct {
+	// ID - id события, задаётся хранилищем при сохранении
+	ID int64 `json:"id,omitempty"`
 	// Timestamp - время события
-	Timestamp time.Time
+	Timestamp time.Time `json:"timestamp"`
 	// SensorSerialNumber - серийный номер датчика
 	SensorSerialNumber string `json:"sensor_serial_number" validate:"len:10"`
 	// SensorID - id датчика
 	SensorID int64 `json:"sensor_id,omitempty"`
-	// Payload - данные события
-	Payload int64 `json:"payload"`
+	// Payload - данные события, может быть дробным
+	Payload float64 `json:"payload"`
+	// Readings - именованные показания многоканального датчика (например, temperature и humidity)
+	Readings Readings `json:"readings,omitempty"`
+	// CommandID - id команды, которую подтверждает событие исполнительного устройства, 0 - событие не подтверждает команду
+	CommandID int64 `json:"command_id,omitempty"`
+	// Unit - единица измерения Payload, задаётся только в откалиброванном представлении события
+	Unit string `json:"unit,omitempty"`
+}
+
+// Readings - показания многоканального датчика, ключ - имя канала
+type Readings map[string]float64
+
+// Clone - возвращает копию показаний, nil для пустых показаний
+func (r Readings) Clone() Readings {
+	if len(r) == 0 {
+		return nil
+	}
+	clone := make(Readings, len(r))
+	for channel, value := range r {
+		clone[channel] = value
+	}
+	return clone
 }
@@ -0,0 +1,34 @@
+package domain
+
+// MaxImportErrors - максимальное число ошибок строк в отчёте об импорте, остальные только считаются в Failed
+const MaxImportErrors = 1000
+
+// ImportReport - отчёт об импорте файла
+type ImportReport struct {
+	// Total - число прочитанных записей
+	Total int `json:"total"`
+	// Imported - число сохранённых записей
+	Imported int `json:"imported"`
+	// Skipped - число записей, которые уже есть в хранилище
+	Skipped int `json:"skipped"`
+	// Failed - число записей с ошибками
+	Failed int `json:"failed"`
+	// Errors - ошибки первых MaxImportErrors записей
+	Errors []ImportError `json:"errors"`
+}
+
+// ImportError - ошибка записи импортируемого файла
+type ImportError struct {
+	// Line - номер строки файла, с которой начинается запись
+	Line int `json:"line"`
+	// Message - описание ошибки
+	Message string `json:"message"`
+}
+
+// Fail - учитывает ошибку записи, начинающейся на строке line
+func (r *ImportReport) Fail(line int, err error) {
+	r.Failed++
+	if len(r.Errors) < MaxImportErrors {
+		r.Errors = append(r.Errors, ImportError{Line: line, Message: err.Error()})
+	}
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// NotificationChannelKind - способ доставки уведомлений о тревогах
+type NotificationChannelKind string
+
+const (
+	// NotificationChannelEmail - письмо на адрес Target
+	NotificationChannelEmail NotificationChannelKind = "email"
+	// NotificationChannelPush - JSON POST запрос на URL Target
+	NotificationChannelPush NotificationChannelKind = "push"
+)
+
+// OutboundNetworks - внутренние сети, в которые разрешено отправлять push уведомления. Остальные адреса внутренних
+// сетей закрыты, чтобы URL канала не позволял обращаться от имени сервера к его окружению
+type OutboundNetworks []netip.Prefix
+
+// Allows - проверяет, можно ли отправить запрос на адрес addr: публичные адреса разрешены всегда,
+// а loopback, link-local, частные, неуказанные и multicast - только из сетей n
+func (n OutboundNetworks) Allows(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsLoopback() && !addr.IsPrivate() && !addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() && !addr.IsMulticast() && !addr.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range n {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsHost - проверяет хост URL: localhost и адреса проверяются как в Allows, имена - только при подключении,
+// когда известен их адрес
+func (n OutboundNetworks) AllowsHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return n.Allows(netip.IPv6Loopback()) && n.Allows(netip.AddrFrom4([4]byte{127, 0, 0, 1}))
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return true
+	}
+	return n.Allows(addr)
+}
+
+// ClockTime - время суток с точностью до минуты, в JSON записывается как "15:04"
+type ClockTime int
+
+// ParseClockTime - разбирает время суток в формате "15:04"
+func ParseClockTime(s string) (ClockTime, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock time %q: %w", s, err)
+	}
+	return ClockTime(t.Hour()*60 + t.Minute()), nil
+}
+
+// ClockTimeOf - время суток момента t в его часовом поясе
+func ClockTimeOf(t time.Time) ClockTime {
+	return ClockTime(t.Hour()*60 + t.Minute())
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+func (c ClockTime) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *ClockTime) UnmarshalText(text []byte) error {
+	parsed, err := ParseClockTime(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// QuietHours - интервал суток [Start, End), в который уведомления не отправляются.
+// Если End раньше Start, интервал переходит через полночь
+type QuietHours struct {
+	Start ClockTime `json:"start"`
+	End   ClockTime `json:"end"`
+	// Location - часовой пояс IANA, в котором заданы границы, пустой - UTC
+	Location string `json:"location,omitempty"`
+}
+
+// Contains - проверяет, попадает ли момент t в тихие часы. Для nil интервала всегда false
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil || q.Start == q.End {
+		return false
+	}
+	if loc, err := time.LoadLocation(q.Location); err == nil {
+		t = t.In(loc)
+	}
+	clock := ClockTimeOf(t)
+	if q.Start < q.End {
+		return q.Start <= clock && clock < q.End
+	}
+	return clock >= q.Start || clock < q.End
+}
+
+// NotificationChannel - канал, в который пользователь получает уведомления о тревогах своих датчиков
+type NotificationChannel struct {
+	ID     int64                   `json:"id"`
+	UserID int64                   `json:"user_id"`
+	Kind   NotificationChannelKind `json:"kind"`
+	// Target - адрес почты или URL, в зависимости от Kind
+	Target string `json:"target"`
+	// MinSeverity - минимальная важность тревог, о которых приходят уведомления
+	MinSeverity AlertSeverity `json:"min_severity"`
+	// Template - шаблон text/template текста уведомления, пустой - шаблон по умолчанию
+	Template string `json:"template,omitempty"`
+	// QuietHours - время, в которое уведомления не отправляются, nil - отправляются всегда
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+	// MaxPerHour - максимальное число уведомлений за последний час, 0 - без ограничения
+	MaxPerHour int `json:"max_per_hour,omitempty"`
+}
+
+// severityRank - порядок важности тревог
+var severityRank = map[AlertSeverity]int{
+	AlertSeverityInfo:     1,
+	AlertSeverityWarning:  2,
+	AlertSeverityCritical: 3,
+}
+
+// AtLeast - проверяет, что важность не ниже min
+func (s AlertSeverity) AtLeast(min AlertSeverity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
+// NotificationMessage - уведомление о тревоге, подготовленное к отправке
+type NotificationMessage struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	Alert   Alert  `json:"alert"`
+	Sensor  Sensor `json:"sensor"`
+}
+
+// DeliveryStatus - итог попытки доставить уведомление
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusSent - уведомление принято получателем
+	DeliveryStatusSent DeliveryStatus = "sent"
+	// DeliveryStatusFailed - отправка завершилась ошибкой
+	DeliveryStatusFailed DeliveryStatus = "failed"
+	// DeliveryStatusSuppressed - уведомление не отправлялось из-за тихих часов или ограничения частоты
+	DeliveryStatusSuppressed DeliveryStatus = "suppressed"
+)
+
+// Delivery - запись журнала доставки уведомления о тревоге в канал
+type Delivery struct {
+	ID        int64          `json:"id"`
+	ChannelID int64          `json:"channel_id"`
+	AlertID   int64          `json:"alert_id"`
+	Status    DeliveryStatus `json:"status"`
+	// Reason - ошибка отправки или причина подавления
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHours_Contains(t *testing.T) {
+	at := func(clock string) time.Time {
+		parsed, err := time.Parse("15:04", clock)
+		require.NoError(t, err)
+		return time.Date(2024, 6, 1, parsed.Hour(), parsed.Minute(), 0, 0, time.UTC)
+	}
+	day := &QuietHours{Start: 13 * 60, End: 15 * 60}
+	night := &QuietHours{Start: 22 * 60, End: 7 * 60}
+	moscow := &QuietHours{Start: 22 * 60, End: 7 * 60, Location: "Europe/Moscow"}
+
+	tests := []struct {
+		name  string
+		quiet *QuietHours
+		at    string
+		want  bool
+	}{
+		{"nil", nil, "03:00", false},
+		{"empty interval", &QuietHours{Start: 60, End: 60}, "01:00", false},
+		{"day, inside", day, "14:00", true},
+		{"day, start inclusive", day, "13:00", true},
+		{"day, end exclusive", day, "15:00", false},
+		{"night, before midnight", night, "23:30", true},
+		{"night, after midnight", night, "03:00", true},
+		{"night, outside", night, "12:00", false},
+		{"location, 21:00 UTC is midnight in Moscow", moscow, "21:00", true},
+		{"location, 05:00 UTC is 08:00 in Moscow", moscow, "05:00", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.quiet.Contains(at(tt.at)))
+		})
+	}
+}
+
+func TestClockTime_JSON(t *testing.T) {
+	data, err := json.Marshal(QuietHours{Start: 22*60 + 30, End: 7 * 60})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"start":"22:30","end":"07:00"}`, string(data))
+
+	var quiet QuietHours
+	require.NoError(t, json.Unmarshal(data, &quiet))
+	assert.Equal(t, QuietHours{Start: 22*60 + 30, End: 7 * 60}, quiet)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"start":"25:00","end":"07:00"}`), &quiet))
+}
+
+func TestAlertSeverity_AtLeast(t *testing.T) {
+	assert.True(t, AlertSeverityCritical.AtLeast(AlertSeverityWarning))
+	assert.True(t, AlertSeverityWarning.AtLeast(AlertSeverityWarning))
+	assert.False(t, AlertSeverityInfo.AtLeast(AlertSeverityWarning))
+}
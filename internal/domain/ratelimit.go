@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit - ограничение частоты запросов корзиной токенов: корзина вмещает Burst токенов
+// и пополняется со скоростью Rate токенов в секунду, каждый запрос забирает один токен
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled - ограничение задано: запросы без токенов отклоняются
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// ParseRateLimit - разбирает ограничение вида "count/period[,burst]": не больше count запросов за period
+// и до burst запросов подряд, например "10/s" или "600/1m,100". Период - длительность Go, число перед
+// единицей можно опустить, burst по умолчанию равен count
+func ParseRateLimit(s string) (RateLimit, error) {
+	spec, burstValue, withBurst := strings.Cut(strings.TrimSpace(s), ",")
+	countValue, periodValue, ok := strings.Cut(spec, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: want count/period[,burst]", s)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(countValue))
+	if err != nil || count <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: count must be a positive integer", s)
+	}
+	periodValue = strings.TrimSpace(periodValue)
+	if periodValue != "" && (periodValue[0] < '0' || periodValue[0] > '9') {
+		periodValue = "1" + periodValue
+	}
+	period, err := time.ParseDuration(periodValue)
+	if err != nil || period <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration", s)
+	}
+	burst := count
+	if withBurst {
+		burst, err = strconv.Atoi(strings.TrimSpace(burstValue))
+		if err != nil || burst <= 0 {
+			return RateLimit{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", s)
+		}
+	}
+	return RateLimit{Rate: float64(count) / period.Seconds(), Burst: burst}, nil
+}
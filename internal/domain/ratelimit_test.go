@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    RateLimit
+		wantErr bool
+	}{
+		{name: "per_second", s: "10/s", want: RateLimit{Rate: 10, Burst: 10}},
+		{name: "period_with_count", s: "600/1m,100", want: RateLimit{Rate: 10, Burst: 100}},
+		{name: "spaces", s: " 5 / 500ms , 1 ", want: RateLimit{Rate: 10, Burst: 1}},
+		{name: "no_period", s: "10", wantErr: true},
+		{name: "zero_count", s: "0/s", wantErr: true},
+		{name: "invalid_period", s: "10/fortnight", wantErr: true},
+		{name: "negative_period", s: "10/-1s", wantErr: true},
+		{name: "invalid_burst", s: "10/s,many", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduleActionKind - действие, которое выполняет расписание
+type ScheduleActionKind string
+
+const (
+	// ScheduleActionCommand - команда исполнительному устройству SensorID перейти в состояние Payload
+	ScheduleActionCommand ScheduleActionKind = "command"
+	// ScheduleActionWebhook - JSON POST запрос на URL
+	ScheduleActionWebhook ScheduleActionKind = "webhook"
+	// ScheduleActionAlertRule - установка правила тревоги AlertRule датчику SensorID, nil правило снимает датчик с охраны
+	ScheduleActionAlertRule ScheduleActionKind = "alert_rule"
+)
+
+// ScheduleAction - действие расписания
+type ScheduleAction struct {
+	// Kind - вид действия
+	Kind ScheduleActionKind `json:"kind"`
+	// SensorID - устройство команды или датчик правила тревоги
+	SensorID int64 `json:"sensor_id,omitempty"`
+	// Payload - требуемое состояние устройства для команды
+	Payload *float64 `json:"payload,omitempty"`
+	// URL - адрес webhook
+	URL string `json:"url,omitempty"`
+	// AlertRule - устанавливаемое правило тревоги, nil - правило снимается
+	AlertRule *AlertRule `json:"alert_rule,omitempty"`
+}
+
+// Schedule - расписание пользователя: по Cron или однократно в At выполняется Action.
+// Время расписания задаётся в часовом поясе Location, поэтому "0 23 * * 1-5" срабатывает в 23:00 местного времени
+// и после перехода на летнее время
+type Schedule struct {
+	// ID - id расписания
+	ID int64 `json:"id"`
+	// UserID - id пользователя, которому принадлежат расписание и датчики его действия
+	UserID int64 `json:"user_id"`
+	// Name - название
+	Name string `json:"name"`
+	// Cron - выражение из пяти полей: минута, час, день месяца, месяц, день недели. Пустое у однократных расписаний
+	Cron string `json:"cron,omitempty"`
+	// At - время однократного срабатывания, nil у повторяющихся расписаний
+	At *time.Time `json:"at,omitempty"`
+	// Location - часовой пояс IANA, пустой - UTC
+	Location string `json:"location,omitempty"`
+	// Enabled - включено ли расписание
+	Enabled bool `json:"enabled"`
+	// Action - выполняемое действие
+	Action ScheduleAction `json:"action"`
+	// NextRunAt - время следующего срабатывания, nil - срабатываний больше не будет
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	// CreatedAt - время создания
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NextRun - время первого срабатывания расписания строго после after, nil если срабатываний больше нет
+func (s Schedule) NextRun(after time.Time) (*time.Time, error) {
+	if s.Cron == "" {
+		if s.At == nil || !s.At.After(after) {
+			return nil, nil
+		}
+		at := s.At.UTC()
+		return &at, nil
+	}
+	cron, err := ParseCron(s.Cron)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(s.Location)
+	if err != nil {
+		return nil, err
+	}
+	next, ok := cron.Next(after.In(loc))
+	if !ok {
+		return nil, nil
+	}
+	next = next.UTC()
+	return &next, nil
+}
+
+// ScheduleRunStatus - итог срабатывания расписания
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunSucceeded ScheduleRunStatus = "succeeded"
+	ScheduleRunFailed    ScheduleRunStatus = "failed"
+)
+
+// ScheduleRun - запись истории срабатываний расписания
+type ScheduleRun struct {
+	// ID - id записи
+	ID int64 `json:"id"`
+	// ScheduleID - id расписания
+	ScheduleID int64 `json:"schedule_id"`
+	// ScheduledAt - время, на которое было запланировано срабатывание
+	ScheduledAt time.Time `json:"scheduled_at"`
+	// StartedAt - время фактического выполнения действия
+	StartedAt time.Time `json:"started_at"`
+	// Status - итог
+	Status ScheduleRunStatus `json:"status"`
+	// Error - ошибка действия, только у неудачных срабатываний
+	Error string `json:"error,omitempty"`
+}
+
+// cronHorizon - на сколько лет вперёд ищется срабатывание, выражения вроде "0 0 30 2 *" не срабатывают никогда
+const cronHorizon = 5
+
+// Cron - разобранное cron выражение, каждое поле - множество допустимых значений
+type Cron struct {
+	minutes, hours, days, months, weekdays uint64
+	// anyDay, anyWeekday - поле задано как *, тогда день подходит по другому полю, иначе - по любому из двух
+	anyDay, anyWeekday bool
+}
+
+// cronField - допустимый диапазон значений поля cron выражения
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCron - разбирает выражение из пяти полей. Поле - список через запятую из *, чисел, диапазонов a-b и шагов */n, a-b/n.
+// День недели 0 и 7 - воскресенье
+func ParseCron(expr string) (Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return Cron{}, fmt.Errorf("cron expression must have %d fields, got %d", len(cronFields), len(fields))
+	}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return Cron{}, err
+		}
+		sets[i] = set
+	}
+	// воскресенье можно записать и как 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return Cron{
+		minutes:    sets[0],
+		hours:      sets[1],
+		days:       sets[2],
+		months:     sets[3],
+		weekdays:   sets[4],
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+		from, to := f.min, f.max
+		if rng != "*" {
+			lo, hi, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = strconv.Atoi(lo); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", f.name, part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(hi); err != nil {
+					return 0, fmt.Errorf("invalid %s %q", f.name, part)
+				}
+			} else if hasStep {
+				to = f.max
+			}
+		}
+		if from < f.min || to > f.max || from > to {
+			return 0, fmt.Errorf("%s %q out of range %d-%d", f.name, part, f.min, f.max)
+		}
+		n := 1
+		if hasStep {
+			var err error
+			if n, err = strconv.Atoi(step); err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, part)
+			}
+		}
+		for v := from; v <= to; v += n {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// Next - первое время срабатывания строго после after в часовом поясе after.
+// Время, которого нет из-за перевода часов, пропускается. false, если срабатываний нет в ближайшие годы
+func (c Cron) Next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	start := after.Truncate(time.Minute).Add(time.Minute)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	end := day.AddDate(cronHorizon, 0, 0)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !c.matchesDay(day) {
+			continue
+		}
+		for hour := 0; hour < 24; hour++ {
+			if c.hours&(1<<hour) == 0 {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				if c.minutes&(1<<minute) == 0 {
+					continue
+				}
+				t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+				if t.Hour() != hour || t.Minute() != minute || t.Before(start) {
+					continue
+				}
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c Cron) matchesDay(day time.Time) bool {
+	if c.months&(1<<int(day.Month())) == 0 {
+		return false
+	}
+	dayOK := c.days&(1<<day.Day()) != 0
+	weekdayOK := c.weekdays&(1<<int(day.Weekday())) != 0
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekdayOK
+	case c.anyWeekday:
+		return dayOK
+	default:
+		// как в cron, при заданных обоих полях подходит день, совпавший хотя бы с одним
+		return dayOK || weekdayOK
+	}
+}
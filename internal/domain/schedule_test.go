@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{"every minute", "* * * * *", false},
+		{"lists, ranges and steps", "0,30 8-18/2 1-15 */3 1-5", false},
+		{"sunday as 7", "0 12 * * 7", false},
+		{"too few fields", "0 23 * *", true},
+		{"minute out of range", "60 * * * *", true},
+		{"reversed range", "0 18-8 * * *", true},
+		{"zero step", "*/0 * * * *", true},
+		{"not a number", "0 noon * * *", true},
+		{"day of month zero", "0 0 0 * *", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// 2024-06-07 - пятница
+	friday := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		expr   string
+		after  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"same day", "0 23 * * 1-5", friday, time.Date(2024, 6, 7, 23, 0, 0, 0, time.UTC), true},
+		{"strictly after", "0 12 * * *", friday, time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC), true},
+		{"weekend skipped", "0 23 * * 1-5", friday.Add(12 * time.Hour), time.Date(2024, 6, 10, 23, 0, 0, 0, time.UTC), true},
+		{"sunday as 7", "30 9 * * 7", friday, time.Date(2024, 6, 9, 9, 30, 0, 0, time.UTC), true},
+		{"step", "*/15 * * * *", friday.Add(16 * time.Minute), time.Date(2024, 6, 7, 12, 30, 0, 0, time.UTC), true},
+		{"day of month or day of week", "0 0 1 * 1", friday, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), true},
+		{"month", "0 0 1 1 *", friday, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"location", "0 23 * * *", friday.In(moscow), time.Date(2024, 6, 7, 23, 0, 0, 0, moscow), true},
+		{"nonexistent time skipped", "30 2 * * *", time.Date(2024, 3, 30, 12, 0, 0, 0, berlin), time.Date(2024, 4, 1, 2, 30, 0, 0, berlin), true},
+		{"never", "0 0 30 2 *", friday, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			got, ok := cron.Next(tt.after)
+			require.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestSchedule_NextRun(t *testing.T) {
+	now := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	next, err := Schedule{At: &later}.NextRun(now)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.True(t, later.Equal(*next))
+
+	next, err = Schedule{At: &later}.NextRun(later)
+	require.NoError(t, err)
+	assert.Nil(t, next, "однократное расписание срабатывает один раз")
+
+	next, err = Schedule{Cron: "0 23 * * *", Location: "Europe/Moscow"}.NextRun(now)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, time.Date(2024, 6, 7, 20, 0, 0, 0, time.UTC), *next)
+
+	_, err = Schedule{Cron: "0 23 * * *", Location: "Mars/Olympus"}.NextRun(now)
+	assert.Error(t, err)
+}
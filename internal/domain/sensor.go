@@ -1,30 +1,107 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type SensorType string
 
 const (
 	SensorTypeContactClosure SensorType = "cc"
 	SensorTypeADC            SensorType = "adc"
+	SensorTypeMotion         SensorType = "motion"
+	SensorTypeHumidity       SensorType = "humidity"
+	SensorTypeCO2            SensorType = "co2"
+	SensorTypePowerMeter     SensorType = "power_meter"
+	SensorTypeBattery        SensorType = "battery"
+	SensorTypeDoorLock       SensorType = "door_lock"
+	SensorTypeRelay          SensorType = "relay"
+	SensorTypeValve          SensorType = "valve"
+)
+
+// PayloadSemantics - смысл значения payload события датчика
+type PayloadSemantics string
+
+const (
+	// PayloadSemanticsState - payload является дискретным состоянием (например, 0 - закрыто, 1 - открыто)
+	PayloadSemanticsState PayloadSemantics = "state"
+	// PayloadSemanticsMeasurement - payload является результатом измерения в единицах Unit
+	PayloadSemanticsMeasurement PayloadSemantics = "measurement"
 )
 
+// SensorTypeInfo - описание типа датчика и допустимых значений его событий
+type SensorTypeInfo struct {
+	// Type - тип датчика
+	Type SensorType `json:"type"`
+	// Description - описание типа датчика
+	Description string `json:"description"`
+	// Unit - единица измерения payload, пустая для датчиков состояния
+	Unit string `json:"unit,omitempty"`
+	// Semantics - смысл значения payload
+	Semantics PayloadSemantics `json:"semantics"`
+	// MinPayload - минимальное допустимое значение payload, nil - без ограничения
+	MinPayload *float64 `json:"min_payload,omitempty"`
+	// MaxPayload - максимальное допустимое значение payload, nil - без ограничения
+	MaxPayload *float64 `json:"max_payload,omitempty"`
+	// Actuator - устройство исполняет команды, payload команды - требуемое состояние из того же диапазона
+	Actuator bool `json:"actuator,omitempty"`
+}
+
+// Accepts - проверяет, что payload лежит в допустимом для типа датчика диапазоне.
+// Состояние датчика (PayloadSemanticsState) может быть только целым
+func (i SensorTypeInfo) Accepts(payload float64) bool {
+	if math.IsNaN(payload) || math.IsInf(payload, 0) {
+		return false
+	}
+	if i.Semantics == PayloadSemanticsState && payload != math.Trunc(payload) {
+		return false
+	}
+	if i.MinPayload != nil && payload < *i.MinPayload {
+		return false
+	}
+	if i.MaxPayload != nil && payload > *i.MaxPayload {
+		return false
+	}
+	return true
+}
+
 // Sensor - структура для хранения данных датчика
 type Sensor struct {
 	// ID - id датчика
-	ID int64 `json:"sensor_id,omitempty" validate:"min=1"`
+	ID int64 `json:"id" validate:"min=1"`
 	// SerialNumber - серийный номер датчика
-	SerialNumber string `json:"serial_number,omitempty" validate:"len:10"`
+	SerialNumber string `json:"serial_number" validate:"len:10"`
 	// Type - тип датчика
-	Type SensorType `json:"type,omitempty"`
+	Type SensorType `json:"type"`
 	// CurrentState - текущее состояние датчика
-	CurrentState int64 `json:"current_state,omitempty"`
+	CurrentState float64 `json:"current_state"`
+	// CurrentReadings - текущие показания каналов многоканального датчика
+	CurrentReadings Readings `json:"current_readings,omitempty"`
 	// Description - описание датчика
-	Description string `json:"description,omitempty"`
+	Description string `json:"description"`
 	// IsActive - активен ли датчик
-	IsActive bool `json:"is_active,omitempty"`
+	IsActive bool `json:"is_active"`
 	// RegisteredAt - дата регистрации датчика
-	RegisteredAt time.Time
+	RegisteredAt time.Time `json:"registered_at"`
 	// LastActivity - дата последнего изменения состояния датчика
-	LastActivity time.Time
+	LastActivity time.Time `json:"last_activity"`
+	// UpdatedAt - время последнего сохранения датчика, задаёт хранилище
+	UpdatedAt time.Time `json:"-"`
+	// Calibration - калибровка датчика, nil - значения отдаются как есть
+	Calibration *Calibration `json:"calibration,omitempty"`
+	// AlertRule - правило тревоги датчика, nil - тревоги не поднимаются
+	AlertRule *AlertRule `json:"alert_rule,omitempty"`
+	// Unit - единица измерения CurrentState, задаётся только в откалиброванном представлении датчика
+	Unit string `json:"unit,omitempty"`
+}
+
+// Calibrated - возвращает представление датчика, в котором CurrentState переведено калибровкой в единицы Unit
+func (s Sensor) Calibrated() Sensor {
+	if s.Calibration == nil {
+		return s
+	}
+	s.CurrentState = s.Calibration.Apply(s.CurrentState)
+	s.Unit = s.Calibration.Unit
+	return s
 }
@@ -0,0 +1,34 @@
+package changes
+
+import (
+	"context"
+	"homework/internal/domain"
+)
+
+// ChannelSink - передаёт изменения в канал внутри процесса, например тестам или подписчику в том же сервисе
+type ChannelSink struct {
+	changes chan domain.Change
+}
+
+// NewChannelSink - приёмник с каналом на size изменений
+func NewChannelSink(size int) *ChannelSink {
+	return &ChannelSink{changes: make(chan domain.Change, size)}
+}
+
+// Changes - канал доставленных изменений
+func (s *ChannelSink) Changes() <-chan domain.Change {
+	return s.changes
+}
+
+// Publish - передаёт изменения в канал, ожидая читателя, если канал заполнен. Изменения, переданные
+// до отмены ctx, остаются в канале и будут переданы повторно
+func (s *ChannelSink) Publish(ctx context.Context, changes []domain.Change) error {
+	for _, change := range changes {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case s.changes <- change:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package changes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"os"
+	"sync"
+)
+
+// FileSink - дописывает изменения в файл NDJSON, по изменению в строке
+type FileSink struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFileSink - открывает файл path на дозапись, создавая его при необходимости
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("can't open changes file: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Publish - дописывает изменения и сбрасывает файл на диск, чтобы позиция relay не обогнала записанное
+func (s *FileSink) Publish(ctx context.Context, changes []domain.Change) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w := bufio.NewWriter(s.file)
+	encoder := json.NewEncoder(w)
+	for _, change := range changes {
+		if err := encoder.Encode(change); err != nil {
+			return fmt.Errorf("can't encode change: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("can't write changes: %w", err)
+	}
+	return s.file.Sync()
+}
+
+// Close - закрывает файл
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return errors.Join(s.file.Sync(), s.file.Close())
+}
@@ -0,0 +1,56 @@
+package changes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"homework/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.ndjson")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	changes := testChanges()
+	require.NoError(t, sink.Publish(context.Background(), changes[:1]))
+	require.NoError(t, sink.Close())
+
+	// файл открывается на дозапись и не теряет записанное ранее
+	sink, err = NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Publish(context.Background(), changes[1:]))
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var written []domain.Change
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var change domain.Change
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &change))
+		written = append(written, change)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, changes, written)
+}
+
+func TestChannelSink_Publish(t *testing.T) {
+	sink := NewChannelSink(1)
+	changes := testChanges()
+	require.NoError(t, sink.Publish(context.Background(), changes[:1]))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, sink.Publish(ctx, changes[1:]), context.Canceled, "канал заполнен, а читателя нет")
+
+	assert.Equal(t, changes[0], <-sink.Changes())
+	require.NoError(t, sink.Publish(context.Background(), changes[1:]))
+	assert.Equal(t, changes[1], <-sink.Changes())
+}
@@ -0,0 +1,65 @@
+package changes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"homework/internal/domain"
+	"io"
+	"net/http"
+)
+
+// WebhookBody - тело запроса webhook: изменения в порядке Seq
+type WebhookBody struct {
+	Changes []domain.Change `json:"changes"`
+}
+
+// WebhookSink - доставляет изменения JSON POST запросом на url, одна пачка - один запрос
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string, options ...func(*WebhookSink)) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		client: http.DefaultClient,
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+// WithWebhookClient - HTTP клиент, которым отправляются запросы
+func WithWebhookClient(client *http.Client) func(*WebhookSink) {
+	return func(s *WebhookSink) {
+		s.client = client
+	}
+}
+
+// Publish - отправляет изменения, успешным считается любой ответ 2xx
+func (s *WebhookSink) Publish(ctx context.Context, changes []domain.Change) error {
+	body, err := json.Marshal(WebhookBody{Changes: changes})
+	if err != nil {
+		return fmt.Errorf("can't encode changes: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send request: %w", err)
+	}
+	defer resp.Body.Close()
+	// тело читается, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("changes webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"homework/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testChanges() []domain.Change {
+	createdAt := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	return []domain.Change{
+		{Seq: 1, Kind: domain.ChangeKindSensor, CreatedAt: createdAt,
+			Sensor: &domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}},
+		{Seq: 2, Kind: domain.ChangeKindEvent, CreatedAt: createdAt,
+			Event: &domain.Event{ID: 1, Timestamp: createdAt, SensorSerialNumber: "0123456789", SensorID: 7, Payload: 21.5}},
+	}
+}
+
+func TestWebhookSink_Publish(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		received := make(chan WebhookBody, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var body WebhookBody
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received <- body
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		require.NoError(t, NewWebhookSink(server.URL+"/changes").Publish(ctx, testChanges()))
+		assert.Equal(t, WebhookBody{Changes: testChanges()}, <-received)
+	})
+
+	t.Run("fail, error status", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		assert.ErrorContains(t, NewWebhookSink(server.URL).Publish(ctx, testChanges()), "503")
+	})
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"homework/internal/domain"
+	"homework/internal/gateways/grpc/pb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toSensor(sensor domain.Sensor) *pb.Sensor {
+	return &pb.Sensor{
+		Id:              sensor.ID,
+		SerialNumber:    sensor.SerialNumber,
+		Type:            string(sensor.Type),
+		CurrentState:    sensor.CurrentState,
+		CurrentReadings: sensor.CurrentReadings.Clone(),
+		Description:     sensor.Description,
+		IsActive:        sensor.IsActive,
+		RegisteredAt:    toTimestamp(sensor.RegisteredAt),
+		LastActivity:    toTimestamp(sensor.LastActivity),
+		Unit:            sensor.Unit,
+	}
+}
+
+// toSensors - датчики в откалиброванном представлении, raw - без калибровки
+func toSensors(sensors []domain.Sensor, raw bool) []*pb.Sensor {
+	result := make([]*pb.Sensor, 0, len(sensors))
+	for _, sensor := range sensors {
+		if !raw {
+			sensor = sensor.Calibrated()
+		}
+		result = append(result, toSensor(sensor))
+	}
+	return result
+}
+
+func toEvent(event domain.Event) *pb.Event {
+	return &pb.Event{
+		Id:                 event.ID,
+		Timestamp:          toTimestamp(event.Timestamp),
+		SensorSerialNumber: event.SensorSerialNumber,
+		SensorId:           event.SensorID,
+		Payload:            event.Payload,
+		Readings:           event.Readings.Clone(),
+		CommandId:          event.CommandID,
+		Unit:               event.Unit,
+	}
+}
+
+// toTimestamp - время в protobuf, nil для нулевого времени (например, у датчика без событий)
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"homework/internal/gateways/problem"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain - домен кодов ошибок в errdetails.ErrorInfo
+const errorDomain = "homework"
+
+// problemCodes - коды gRPC для видов ошибок, общих со статусами HTTP API
+var problemCodes = map[problem.Kind]codes.Code{
+	problem.KindInternal:     codes.Internal,
+	problem.KindMalformed:    codes.InvalidArgument,
+	problem.KindInvalid:      codes.InvalidArgument,
+	problem.KindNotFound:     codes.NotFound,
+	problem.KindConflict:     codes.AlreadyExists,
+	problem.KindCanceled:     codes.Canceled,
+	problem.KindTimeout:      codes.DeadlineExceeded,
+	problem.KindPrecondition: codes.Aborted,
+	problem.KindRateLimited:  codes.ResourceExhausted,
+	problem.KindUnavailable:  codes.Unavailable,
+}
+
+// statusError - переводит ошибку в статус gRPC по общему для шлюзов сопоставлению,
+// стабильный код ошибки передаётся в errdetails.ErrorInfo, время до повтора - в errdetails.RetryInfo
+func statusError(err error) error {
+	p := problem.From(err)
+	st := status.New(problemCodes[p.Kind], p.Detail)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: p.Code, Domain: errorDomain}}
+	if p.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(p.RetryAfter)})
+	}
+	if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/gateways/grpc/pb"
+	"homework/internal/gateways/problem"
+	"homework/internal/usecase"
+)
+
+type eventService struct {
+	pb.UnimplementedEventServiceServer
+	useCases UseCases
+}
+
+func (s *eventService) RegisterEvent(ctx context.Context, req *pb.RegisterEventRequest) (*pb.Event, error) {
+	if req.Payload == nil && len(req.GetReadings()) == 0 {
+		return nil, statusError(problem.Invalid(errors.New("payload or readings required")))
+	}
+	event := &domain.Event{
+		SensorSerialNumber: req.GetSensorSerialNumber(),
+		Payload:            req.GetPayload(),
+		Readings:           domain.Readings(req.GetReadings()).Clone(),
+		CommandID:          req.GetCommandId(),
+	}
+	receive := s.useCases.Event.ReceiveEvent
+	// при асинхронном приёме событие сохраняется после ответа, и его id равен 0
+	if s.useCases.Ingestion != nil {
+		receive = s.useCases.Ingestion.EnqueueEvent
+	}
+	if err := receive(ctx, event); err != nil {
+		return nil, statusError(err)
+	}
+	return toEvent(*event), nil
+}
+
+func (s *eventService) GetSensorHistory(ctx context.Context, req *pb.GetSensorHistoryRequest) (*pb.GetSensorHistoryResponse, error) {
+	if req.GetStart() == nil || req.GetEnd() == nil {
+		return nil, statusError(problem.Malformed(errors.New("start and end required")))
+	}
+	sensor, err := s.useCases.Sensor.GetSensorByID(ctx, req.GetSensorId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	query := usecase.EventQuery{
+		SensorID: sensor.ID,
+		Start:    req.GetStart().AsTime(),
+		End:      req.GetEnd().AsTime(),
+		Desc:     req.GetDesc(),
+		Limit:    int(req.GetLimit()),
+	}
+	events, next, err := s.useCases.Event.ListEvents(ctx, query, req.GetCursor())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	calibration := sensor.Calibration
+	if req.GetRaw() {
+		calibration = nil
+	}
+	resp := &pb.GetSensorHistoryResponse{Events: make([]*pb.Event, 0, len(events)), NextCursor: next}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toEvent(calibration.CalibrateEvent(event)))
+	}
+	return resp, nil
+}
+
+func (s *eventService) SubscribeEvents(req *pb.SubscribeEventsRequest, stream pb.EventService_SubscribeEventsServer) error {
+	ctx := stream.Context()
+	events, err := s.useCases.Event.SubscribeEvents(ctx, req.GetSensorId())
+	if err != nil {
+		return statusError(err)
+	}
+	// калибровка датчика фиксируется на время подписки, как у подписки по WebSocket,
+	// и запрашивается один раз для каждого датчика потока, а не для каждого события
+	calibrations := map[int64]*domain.Calibration{}
+	for event := range events {
+		if !req.GetRaw() {
+			calibration, ok := calibrations[event.SensorID]
+			if !ok {
+				sensor, err := s.useCases.Sensor.GetSensorByID(ctx, event.SensorID)
+				if err != nil && !errors.Is(err, usecase.ErrSensorNotFound) {
+					return statusError(err)
+				}
+				if sensor != nil {
+					calibration = sensor.Calibration
+				}
+				calibrations[event.SensorID] = calibration
+			}
+			event = calibration.CalibrateEvent(event)
+		}
+		if err = stream.Send(toEvent(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
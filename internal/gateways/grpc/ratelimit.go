@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"homework/internal/usecase"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// rateLimitInterceptors - ограничение вызовов по адресу клиента и токену API из метаданных authorization,
+// как у HTTP API. Без ограничителя вызовы не ограничиваются
+func rateLimitInterceptors(l *usecase.RateLimiter) []grpc.ServerOption {
+	if l == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (any, error) {
+			if err := allowCall(ctx, l); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo,
+			handler grpc.StreamHandler) error {
+			if err := allowCall(stream.Context(), l); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+}
+
+// allowCall - статус ResourceExhausted с errdetails.RetryInfo, если клиент превысил ограничение
+func allowCall(ctx context.Context, l *usecase.RateLimiter) error {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		if err = l.Allow(ctx, usecase.RateLimitScopeIP, host); err != nil {
+			return statusError(err)
+		}
+	}
+	for _, token := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		sum := sha256.Sum256([]byte(token))
+		if err := l.Allow(ctx, usecase.RateLimitScopeToken, hex.EncodeToString(sum[:])); err != nil {
+			return statusError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/gateways/grpc/pb"
+	"homework/internal/usecase"
+)
+
+type sensorService struct {
+	pb.UnimplementedSensorServiceServer
+	useCases UseCases
+}
+
+func (s *sensorService) RegisterSensor(ctx context.Context, req *pb.RegisterSensorRequest) (*pb.Sensor, error) {
+	sensor, err := s.useCases.Sensor.RegisterSensor(ctx, &domain.Sensor{
+		SerialNumber: req.GetSerialNumber(),
+		Type:         domain.SensorType(req.GetType()),
+		Description:  req.GetDescription(),
+		IsActive:     req.GetIsActive(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toSensor(*sensor), nil
+}
+
+func (s *sensorService) GetSensor(ctx context.Context, req *pb.GetSensorRequest) (*pb.Sensor, error) {
+	sensor, err := s.useCases.Sensor.GetSensorByID(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if !req.GetRaw() {
+		*sensor = sensor.Calibrated()
+	}
+	return toSensor(*sensor), nil
+}
+
+func (s *sensorService) ListSensors(ctx context.Context, req *pb.ListSensorsRequest) (*pb.ListSensorsResponse, error) {
+	query := usecase.SensorQuery{
+		Filter: usecase.SensorFilter{
+			Type:               domain.SensorType(req.GetType()),
+			SerialNumberPrefix: req.GetSerialPrefix(),
+		},
+		Limit: int(req.GetLimit()),
+	}
+	sensors, next, err := s.useCases.Sensor.ListSensors(ctx, query, req.GetCursor())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListSensorsResponse{Sensors: toSensors(sensors, req.GetRaw()), NextCursor: next}
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"homework/internal/gateways/grpc/pb"
+	"homework/internal/usecase"
+	"net"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// shutdownTimeout - сколько ждать завершения начатых вызовов при остановке, после чего открытые потоки обрываются
+const shutdownTimeout = 2 * time.Second
+
+type Server struct {
+	host   string
+	port   uint16
+	server *grpc.Server
+}
+
+type UseCases struct {
+	Event  *usecase.Event
+	Sensor *usecase.Sensor
+	User   *usecase.User
+	// RateLimiter - ограничение вызовов по адресу клиента и токену API, nil - без ограничений
+	RateLimiter *usecase.RateLimiter
+	// Ingestion - асинхронный приём событий, nil - события сохраняются до ответа
+	Ingestion *usecase.Ingestion
+}
+
+func NewServer(useCases UseCases, options ...func(*Server)) *Server {
+	s := &Server{server: grpc.NewServer(rateLimitInterceptors(useCases.RateLimiter)...), host: "localhost", port: 9090}
+	register(s.server, useCases)
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+func WithHost(host string) func(*Server) {
+	return func(s *Server) {
+		s.host = host
+	}
+}
+
+func WithPort(port uint16) func(*Server) {
+	return func(s *Server) {
+		s.port = port
+	}
+}
+
+// Run - принимает вызовы, пока не отменён ctx
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.host, strconv.Itoa(int(s.port))))
+	if err != nil {
+		return err
+	}
+	return s.serve(ctx, listener)
+}
+
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return s.server.Serve(listener)
+	})
+	eg.Go(func() error {
+		<-ctx.Done()
+		stopped := make(chan struct{})
+		go func() {
+			s.server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			// потоки SubscribeEvents не завершаются сами, их обрывает Stop
+			s.server.Stop()
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+// register - регистрирует сервисы на сервере gRPC, а также reflection для grpcurl и подобных клиентов
+func register(server *grpc.Server, useCases UseCases) {
+	pb.RegisterSensorServiceServer(server, &sensorService{useCases: useCases})
+	pb.RegisterUserServiceServer(server, &userService{useCases: useCases})
+	pb.RegisterEventServiceServer(server, &eventService{useCases: useCases})
+	reflection.Register(server)
+}
@@ -0,0 +1,185 @@
+package grpc
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/gateways/grpc/pb"
+	"homework/internal/usecase"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventRepository "homework/internal/repository/event/inmemory"
+	rateLimitRepository "homework/internal/repository/ratelimit/inmemory"
+	sensorRepository "homework/internal/repository/sensor/inmemory"
+	userRepository "homework/internal/repository/user/inmemory"
+)
+
+// startServer - запускает сервер поверх соединения в памяти, сервер останавливается отменой ctx.
+// limiter может быть nil - вызовы не ограничиваются
+func startServer(t *testing.T, ctx context.Context, limiter *usecase.RateLimiter) (*grpc.ClientConn, <-chan error) {
+	er := eventRepository.NewEventRepository()
+	sr := sensorRepository.NewSensorRepository()
+	ur := userRepository.NewUserRepository()
+	sor := userRepository.NewSensorOwnerRepository()
+	s := NewServer(UseCases{
+		Event:       usecase.NewEvent(er, sr),
+		Sensor:      usecase.NewSensor(sr),
+		User:        usecase.NewUser(ur, sor, sr),
+		RateLimiter: limiter,
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- s.serve(ctx, listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, stopped
+}
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, stopped := startServer(t, ctx, nil)
+	sensors := pb.NewSensorServiceClient(conn)
+	users := pb.NewUserServiceClient(conn)
+	events := pb.NewEventServiceClient(conn)
+
+	sensor, err := sensors.RegisterSensor(ctx, &pb.RegisterSensorRequest{SerialNumber: "0123456789", Type: "adc", IsActive: true})
+	require.NoError(t, err)
+	assert.NotZero(t, sensor.GetId())
+
+	t.Run("invalid sensor", func(t *testing.T) {
+		_, err := sensors.RegisterSensor(ctx, &pb.RegisterSensorRequest{SerialNumber: "1", Type: "adc"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		// код ошибки тот же, что в ответе HTTP API
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		assert.Equal(t, "invalid_serial_number", details[0].(*errdetails.ErrorInfo).GetReason())
+	})
+
+	t.Run("users", func(t *testing.T) {
+		user, err := users.RegisterUser(ctx, &pb.RegisterUserRequest{Name: "owner"})
+		require.NoError(t, err)
+
+		_, err = users.AttachSensor(ctx, &pb.AttachSensorRequest{UserId: user.GetId(), SensorId: sensor.GetId()})
+		require.NoError(t, err)
+		owned, err := users.ListUserSensors(ctx, &pb.ListUserSensorsRequest{UserId: user.GetId()})
+		require.NoError(t, err)
+		require.Len(t, owned.GetSensors(), 1)
+		assert.Equal(t, "0123456789", owned.GetSensors()[0].GetSerialNumber())
+
+		_, err = users.ListUserSensors(ctx, &pb.ListUserSensorsRequest{UserId: 42})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("subscribe and history", func(t *testing.T) {
+		streamCtx, streamCancel := context.WithCancel(ctx)
+		defer streamCancel()
+		stream, err := events.SubscribeEvents(streamCtx, &pb.SubscribeEventsRequest{SensorId: sensor.GetId()})
+		require.NoError(t, err)
+		// клиент получает поток раньше, чем сервер подписывается на события, поэтому даём серверу время на подписку
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = events.RegisterEvent(ctx, &pb.RegisterEventRequest{SensorSerialNumber: "0123456789", Payload: proto.Float64(512)})
+		require.NoError(t, err)
+		received, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, 512.0, received.GetPayload())
+		assert.Equal(t, sensor.GetId(), received.GetSensorId())
+
+		history, err := events.GetSensorHistory(ctx, &pb.GetSensorHistoryRequest{
+			SensorId: sensor.GetId(),
+			Start:    timestamppb.New(time.Now().Add(-time.Hour)),
+			End:      timestamppb.New(time.Now().Add(time.Hour)),
+		})
+		require.NoError(t, err)
+		require.Len(t, history.GetEvents(), 1)
+		assert.Equal(t, 512.0, history.GetEvents()[0].GetPayload())
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		_, err := events.RegisterEvent(ctx, &pb.RegisterEventRequest{SensorSerialNumber: "0123456789"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = events.RegisterEvent(ctx, &pb.RegisterEventRequest{SensorSerialNumber: "9999999999", Payload: proto.Float64(1)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		stream, err := events.SubscribeEvents(ctx, &pb.SubscribeEventsRequest{SensorId: 42})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("shutdown with open stream", func(t *testing.T) {
+		_, err := events.SubscribeEvents(ctx, &pb.SubscribeEventsRequest{})
+		require.NoError(t, err)
+
+		cancel()
+		select {
+		case err := <-stopped:
+			assert.NoError(t, err)
+		case <-time.After(2 * shutdownTimeout):
+			t.Fatal("server did not stop")
+		}
+	})
+}
+
+func TestServer_rateLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	limiter := usecase.NewRateLimiter(rateLimitRepository.NewRateLimitRepository(),
+		usecase.WithRateLimit(usecase.RateLimitScopeToken, domain.RateLimit{Rate: 1.0 / 3600, Burst: 1}))
+	conn, _ := startServer(t, ctx, limiter)
+	sensors := pb.NewSensorServiceClient(conn)
+	events := pb.NewEventServiceClient(conn)
+
+	withToken := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	_, err := sensors.ListSensors(withToken, &pb.ListSensorsRequest{})
+	require.NoError(t, err)
+
+	for _, call := range []func() error{
+		func() error {
+			_, err := sensors.ListSensors(withToken, &pb.ListSensorsRequest{})
+			return err
+		},
+		func() error {
+			stream, err := events.SubscribeEvents(withToken, &pb.SubscribeEventsRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		},
+	} {
+		err = call()
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		details := status.Convert(err).Details()
+		require.Len(t, details, 2)
+		assert.Equal(t, "rate_limited", details[0].(*errdetails.ErrorInfo).GetReason())
+		assert.Greater(t, details[1].(*errdetails.RetryInfo).GetRetryDelay().AsDuration(), 59*time.Minute)
+	}
+
+	// без токена вызов ограничивается только по адресу, а он не ограничен
+	_, err = sensors.ListSensors(ctx, &pb.ListSensorsRequest{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/gateways/grpc/pb"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type userService struct {
+	pb.UnimplementedUserServiceServer
+	useCases UseCases
+}
+
+func (s *userService) RegisterUser(ctx context.Context, req *pb.RegisterUserRequest) (*pb.User, error) {
+	user, err := s.useCases.User.RegisterUser(ctx, &domain.User{Name: req.GetName()})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.User{Id: user.ID, Name: user.Name}, nil
+}
+
+func (s *userService) AttachSensor(ctx context.Context, req *pb.AttachSensorRequest) (*emptypb.Empty, error) {
+	if err := s.useCases.User.AttachSensorToUser(ctx, req.GetUserId(), req.GetSensorId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *userService) ListUserSensors(ctx context.Context, req *pb.ListUserSensorsRequest) (*pb.ListUserSensorsResponse, error) {
+	sensors, err := s.useCases.User.GetUserSensors(ctx, req.GetUserId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	// как и GET /users/{id}/sensors, датчики пользователя отдаются без калибровки
+	return &pb.ListUserSensorsResponse{Sensors: toSensors(sensors, true)}, nil
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *apiHandler) UserAlertsOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+func (h *apiHandler) AcknowledgeAlertOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "POST,OPTIONS")
+}
+
+func (h *apiHandler) ResolveAlertOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "POST,OPTIONS")
+}
+
+func (h *apiHandler) SensorAlertRuleOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "PUT,DELETE,OPTIONS")
+}
+
+// unresolvedAlertStates - этапы тревог, которые отдаются без параметра state
+var unresolvedAlertStates = []domain.AlertState{domain.AlertStateOpen, domain.AlertStateAcknowledged}
+
+func (h *apiHandler) GetUserAlerts(c *gin.Context, userID int64, params openapi.GetUserAlertsParams) {
+	if isWebSocketUpgrade(c) {
+		// после установки соединения ошибка уже не может попасть в ответ
+		if err := h.ws.HandleAlerts(c, userID); err != nil && !c.Writer.Written() {
+			writeError(c, err)
+		}
+		return
+	}
+
+	// state=all отдаёт тревоги на всех этапах, без параметра - неустранённые
+	query := usecase.AlertQuery{States: unresolvedAlertStates}
+	if states := valueOf(params.State); len(states) > 0 {
+		query.States = nil
+		for _, state := range states {
+			if state != openapi.GetUserAlertsParamsStateAll {
+				query.States = append(query.States, domain.AlertState(state))
+			}
+		}
+	}
+	var err error
+	if query.Limit, err = limitRequested(params.Limit); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	alerts, err := h.uc.Alert.GetUserAlerts(c.Request.Context(), userID, query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, alerts)
+}
+
+func (h *apiHandler) AcknowledgeAlert(c *gin.Context, userID, alertID int64) {
+	changeAlert(c, userID, alertID, h.uc.Alert.AcknowledgeAlert)
+}
+
+func (h *apiHandler) ResolveAlert(c *gin.Context, userID, alertID int64) {
+	changeAlert(c, userID, alertID, h.uc.Alert.ResolveAlert)
+}
+
+// changeAlert - подтверждение или устранение тревоги пользователем
+func changeAlert(c *gin.Context, userID, alertID int64, change func(ctx context.Context, userID, alertID int64) (*domain.Alert, error)) {
+	alert, err := change(c.Request.Context(), userID, alertID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, alert)
+}
+
+func (h *apiHandler) SetSensorAlertRule(c *gin.Context, id int64, params openapi.SetSensorAlertRuleParams) {
+	var rule openapi.AlertRule
+	if !bindJSON(c, &rule) {
+		return
+	}
+
+	sensor, ok := h.setSensorAlertRule(c, id, alertRule(rule), ifMatch(params.IfMatch))
+	if !ok {
+		return
+	}
+	writeBody(c, http.StatusOK, sensor.AlertRule)
+}
+
+func (h *apiHandler) DeleteSensorAlertRule(c *gin.Context, id int64, params openapi.DeleteSensorAlertRuleParams) {
+	if _, ok := h.setSensorAlertRule(c, id, nil, ifMatch(params.IfMatch)); !ok {
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// alertRule - правило тревоги из тела запроса
+func alertRule(rule openapi.AlertRule) *domain.AlertRule {
+	return &domain.AlertRule{
+		Severity: domain.AlertSeverity(rule.Severity),
+		Min:      rule.Min,
+		Max:      rule.Max,
+	}
+}
+
+// setSensorAlertRule - устанавливает правило тревоги датчика, если он удовлетворяет condition, false - ошибка уже записана в ответ
+func (h *apiHandler) setSensorAlertRule(c *gin.Context, id int64, rule *domain.AlertRule, condition usecase.SensorCondition) (*domain.Sensor, bool) {
+	sensor, err := h.uc.Sensor.SetAlertRule(c.Request.Context(), id, rule, condition)
+	if err != nil {
+		writeError(c, err)
+		return nil, false
+	}
+	return sensor, true
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/api/openapi"
+	"homework/internal/gateways/problem"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// schemas - схемы из api/openapi.yaml, по которым проверяются тела запросов
+var schemas = loadSchemas()
+
+func loadSchemas() openapi3.Schemas {
+	spec, err := openapi.GetSwagger()
+	if err != nil {
+		panic(fmt.Sprintf("can't load openapi spec: %v", err))
+	}
+	return spec.Components.Schemas
+}
+
+// bindJSON - проверяет тело запроса по схеме, одноимённой типу dst, и разбирает его в dst:
+// невалидный json - 400, тело не соответствует схеме - 422. Тело в CBOR и MessagePack
+// предварительно переводится в JSON. false - ошибка уже записана в ответ
+func bindJSON(c *gin.Context, dst any) bool {
+	data, err := c.GetRawData()
+	if err == nil {
+		data, err = decodeBody(c.ContentType(), data)
+	}
+	if err != nil {
+		writeError(c, malformedBody(err))
+		return false
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		writeError(c, malformedBody(err))
+		return false
+	}
+	schema, ok := schemas[reflect.TypeOf(dst).Elem().Name()]
+	if !ok {
+		writeError(c, fmt.Errorf("no schema for %T", dst))
+		return false
+	}
+	if err = schema.Value.VisitJSON(value); err != nil {
+		writeError(c, problem.Invalid(errors.New(schemaErrorMessage(err))))
+		return false
+	}
+	if err = json.Unmarshal(data, dst); err != nil {
+		writeError(c, malformedBody(err))
+		return false
+	}
+	return true
+}
+
+// malformedBody - тело запроса не удалось прочитать или разобрать
+func malformedBody(err error) error {
+	return problem.New(problem.KindMalformed, problem.CodeMalformedBody, err)
+}
+
+// schemaErrorMessage - причина несоответствия схеме с путём до поля, без текста самой схемы
+func schemaErrorMessage(err error) string {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return err.Error()
+	}
+	if path := schemaErr.JSONPointer(); len(path) > 0 {
+		return strings.Join(path, ".") + ": " + schemaErr.Reason
+	}
+	return schemaErr.Reason
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *apiHandler) ChangesOptions(c *gin.Context) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+// GetChanges - изменения после номера since: потребитель догоняет поток, передавая seq последнего полученного изменения
+func (h *apiHandler) GetChanges(c *gin.Context, params openapi.GetChangesParams) {
+	limit, err := limitRequested(params.Limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	changes, err := h.uc.Outbox.ListChanges(c.Request.Context(), valueOf(params.Since), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if changes == nil {
+		changes = []domain.Change{}
+	}
+	writeBody(c, http.StatusOK, changes)
+}
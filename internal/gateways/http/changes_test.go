@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"homework/api/openapi"
+	"homework/internal/gateways/changes"
+	eventInmemory "homework/internal/repository/event/inmemory"
+	outboxInmemory "homework/internal/repository/outbox/inmemory"
+	sensorInmemory "homework/internal/repository/sensor/inmemory"
+	transactionInmemory "homework/internal/repository/transaction/inmemory"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanges(t *testing.T) {
+	ctx := context.Background()
+	sr := sensorInmemory.NewSensorRepository()
+	tr := transactionInmemory.NewTransactor()
+	sink := changes.NewChannelSink(10)
+	outbox := usecase.NewOutbox(outboxInmemory.NewOutboxRepository(), usecase.WithOutboxSink("channel", sink))
+	engine := newTestRouter(t, UseCases{
+		Sensor: usecase.NewSensor(sr, usecase.WithSensorTransactor(tr), usecase.WithSensorOutbox(outbox)),
+		Event:  usecase.NewEvent(eventInmemory.NewEventRepository(), sr, usecase.WithEventTransactor(tr), usecase.WithEventOutbox(outbox)),
+		Outbox: outbox,
+	})
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", mediaTypeJSON)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+	list := func(path string) []openapi.Change {
+		t.Helper()
+		w := serve(http.MethodGet, path, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var changes []openapi.Change
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &changes))
+		return changes
+	}
+
+	assert.Empty(t, list("/changes"))
+
+	w := serve(http.MethodPost, "/sensors", `{"serial_number":"0123456789","type":"adc","current_state":0,"description":"","is_active":true}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var sensor openapi.Sensor
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sensor))
+	for payload := 1; payload <= 2; payload++ {
+		w = serve(http.MethodPost, "/events", `{"sensor_serial_number":"0123456789","payload":`+strconv.Itoa(payload)+`}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	all := list("/changes")
+	require.Len(t, all, 3)
+	assert.Equal(t, openapi.ChangeKindSensor, all[0].Kind)
+	require.NotNil(t, all[0].Sensor)
+	assert.Equal(t, sensor.Id, all[0].Sensor.Id)
+	for i, change := range all[1:] {
+		assert.Equal(t, openapi.ChangeKindEvent, change.Kind)
+		require.NotNil(t, change.Event)
+		assert.Equal(t, float64(i+1), change.Event.Payload)
+		assert.Greater(t, change.Seq, all[i].Seq)
+	}
+
+	// потребитель догоняет поток с номера последнего полученного изменения
+	page := list("/changes?limit=1&since=" + strconv.FormatInt(all[0].Seq, 10))
+	require.Len(t, page, 1)
+	assert.Equal(t, all[1].Seq, page[0].Seq)
+	assert.Empty(t, list("/changes?since="+strconv.FormatInt(all[2].Seq, 10)))
+
+	assertProblem(t, serve(http.MethodGet, "/changes?since=-1", ""), http.StatusBadRequest, openapi.InvalidCursor)
+	assertProblem(t, serve(http.MethodGet, "/changes?limit=0", ""), http.StatusBadRequest, openapi.InvalidLimit)
+
+	// relay доставляет те же изменения в приёмник
+	require.NoError(t, outbox.Relay(ctx))
+	for _, change := range all {
+		relayed := <-sink.Changes()
+		assert.Equal(t, change.Seq, relayed.Seq)
+	}
+	w = serve(http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `outbox_relay_published_changes_total{sink="channel"} 3`+"\n")
+	assert.Contains(t, w.Body.String(), `outbox_relay_failures_total{sink="channel"} 0`+"\n")
+}
+
+func TestChanges_ImportedEventIDs(t *testing.T) {
+	sr := sensorInmemory.NewSensorRepository()
+	tr := transactionInmemory.NewTransactor()
+	outbox := usecase.NewOutbox(outboxInmemory.NewOutboxRepository())
+	engine := newTestRouter(t, UseCases{
+		Sensor: usecase.NewSensor(sr, usecase.WithSensorTransactor(tr), usecase.WithSensorOutbox(outbox)),
+		Event:  usecase.NewEvent(eventInmemory.NewEventRepository(), sr, usecase.WithEventTransactor(tr), usecase.WithEventOutbox(outbox)),
+		Outbox: outbox,
+	})
+	serve := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/sensors", mediaTypeJSON, `{"serial_number":"0123456789","type":"adc","current_state":0,"description":"","is_active":true}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serve(http.MethodPost, "/events/import", mediaTypeCSV, "timestamp,sensor_serial_number,payload\n"+
+		"2024-01-01T00:00:00Z,0123456789,1\n"+
+		"2024-01-01T00:01:00Z,0123456789,2\n")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serve(http.MethodGet, "/changes", "", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var all []openapi.Change
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &all))
+	require.Len(t, all, 3)
+	// события, сохранённые пачкой, попадают в журнал со своими ID
+	ids := map[int64]bool{}
+	for _, change := range all[1:] {
+		require.NotNil(t, change.Event)
+		require.NotNil(t, change.Event.Id)
+		assert.NotZero(t, *change.Event.Id)
+		ids[*change.Event.Id] = true
+	}
+	assert.Len(t, ids, 2)
+}
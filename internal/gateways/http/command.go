@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"homework/internal/gateways/problem"
+	"homework/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultCommandWait - сколько устройство ждёт новую команду, если не передан параметр wait
+	defaultCommandWait = 30 * time.Second
+	// maxCommandWait - наибольшее допустимое значение wait, чтобы запрос не обрывали прокси
+	maxCommandWait = 2 * time.Minute
+)
+
+func (h *apiHandler) CommandsOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "GET,POST,OPTIONS")
+}
+
+func (h *apiHandler) NextCommandOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+func (h *apiHandler) CommandOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+func (h *apiHandler) SendCommand(c *gin.Context, id int64) {
+	var toCreate openapi.CommandToCreate
+	if !bindJSON(c, &toCreate) {
+		return
+	}
+
+	command, err := h.uc.Command.SendCommand(c.Request.Context(), id, toCreate.Payload)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusCreated, command)
+}
+
+func (h *apiHandler) GetCommands(c *gin.Context, id int64, params openapi.GetCommandsParams) {
+	query := usecase.CommandQuery{SensorID: id}
+	for _, state := range valueOf(params.State) {
+		query.States = append(query.States, domain.CommandState(state))
+	}
+	var err error
+	if query.Limit, err = limitRequested(params.Limit); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	commands, err := h.uc.Command.GetCommands(c.Request.Context(), query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, commands)
+}
+
+func (h *apiHandler) GetCommand(c *gin.Context, id, commandID int64) {
+	command, err := h.uc.Command.GetCommand(c.Request.Context(), id, commandID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, command)
+}
+
+// NextCommand - доставка команд устройству: long-poll запрос отдаёт одну команду или 204, если её не было за время wait,
+// WebSocket передаёт команды по мере появления
+func (h *apiHandler) NextCommand(c *gin.Context, id int64, params openapi.NextCommandParams) {
+	if isWebSocketUpgrade(c) {
+		// после установки соединения ошибка уже не может попасть в ответ
+		if err := h.ws.HandleCommands(c, id); err != nil && !c.Writer.Written() {
+			writeError(c, err)
+		}
+		return
+	}
+
+	wait, err := waitRequested(valueOf(params.Wait))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	command, err := h.uc.Command.NextCommand(c.Request.Context(), id, wait)
+	switch {
+	case errors.Is(err, usecase.ErrCommandNotFound):
+		c.Status(http.StatusNoContent)
+		return
+	case err != nil:
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, command)
+}
+
+// waitRequested - разбирает параметр wait в формате time.ParseDuration, например 30s
+func waitRequested(wait string) (time.Duration, error) {
+	if wait == "" {
+		return defaultCommandWait, nil
+	}
+	value, err := time.ParseDuration(wait)
+	if err != nil || value < 0 || value > maxCommandWait {
+		return 0, problem.Malformed(fmt.Errorf("wait must be a duration from 0s to %s", maxCommandWait))
+	}
+	return value, nil
+}
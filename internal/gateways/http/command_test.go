@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	srMock := usecase.NewMockSensorRepository(ctrl)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.Sensor{ID: 1, Type: domain.SensorTypeRelay}, nil)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), int64(2)).AnyTimes().Return(&domain.Sensor{ID: 2, Type: domain.SensorTypeContactClosure}, nil)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrSensorNotFound)
+	crMock := usecase.NewMockCommandRepository(ctrl)
+
+	uc := UseCases{
+		Sensor:  usecase.NewSensor(srMock),
+		Command: usecase.NewCommand(crMock, srMock),
+	}
+	engine := newTestRouter(t, uc)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Accept", "application/json")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("create_201", func(t *testing.T) {
+		crMock.EXPECT().SaveCommand(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, command *domain.Command) error {
+			command.ID = 5
+			return nil
+		})
+
+		w := serve(http.MethodPost, "/devices/1/commands", `{"payload":1}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var command domain.Command
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &command))
+		assert.Equal(t, int64(5), command.ID)
+		assert.Equal(t, domain.CommandStatePending, command.State)
+	})
+
+	t.Run("create_422", func(t *testing.T) {
+		w := serve(http.MethodPost, "/devices/2/commands", `{"payload":1}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "датчик не принимает команды")
+
+		w = serve(http.MethodPost, "/devices/1/commands", `{"payload":2}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		w = serve(http.MethodPost, "/devices/1/commands", `{}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("create_404", func(t *testing.T) {
+		w := serve(http.MethodPost, "/devices/9/commands", `{"payload":1}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("list_400", func(t *testing.T) {
+		w := serve(http.MethodGet, "/devices/1/commands?state=lost", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("next_200", func(t *testing.T) {
+		crMock.EXPECT().DeliverNextCommand(gomock.Any(), int64(1), gomock.Any()).Times(1).Return(&domain.Command{ID: 5, SensorID: 1, State: domain.CommandStateDelivered}, nil)
+
+		w := serve(http.MethodGet, "/devices/1/commands/next", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var command domain.Command
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &command))
+		assert.Equal(t, domain.CommandStateDelivered, command.State)
+	})
+
+	t.Run("next_204", func(t *testing.T) {
+		crMock.EXPECT().DeliverNextCommand(gomock.Any(), int64(1), gomock.Any()).Times(1).Return(nil, usecase.ErrCommandNotFound)
+
+		w := serve(http.MethodGet, "/devices/1/commands/next?wait=0s", "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = serve(http.MethodGet, "/devices/1/commands/next?wait=1h", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get_404", func(t *testing.T) {
+		crMock.EXPECT().GetCommandByID(gomock.Any(), int64(6)).Times(1).Return(&domain.Command{ID: 6, SensorID: 3}, nil)
+
+		w := serve(http.MethodGet, "/devices/1/commands/6", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		srv := httptest.NewServer(engine)
+		defer srv.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		crMock.EXPECT().DeliverNextCommand(gomock.Any(), int64(1), gomock.Any()).Times(1).Return(&domain.Command{ID: 7, SensorID: 1, State: domain.CommandStateDelivered}, nil)
+		crMock.EXPECT().DeliverNextCommand(gomock.Any(), int64(1), gomock.Any()).AnyTimes().Return(nil, usecase.ErrCommandNotFound)
+
+		conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/devices/1/commands/next", nil)
+		require.NoError(t, err)
+		defer conn.CloseNow()
+
+		_, data, err := conn.Read(ctx)
+		require.NoError(t, err)
+		var command domain.Command
+		require.NoError(t, json.Unmarshal(data, &command))
+		assert.Equal(t, int64(7), command.ID)
+		require.NoError(t, conn.Close(websocket.StatusNormalClosure, "done"))
+	})
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheControl - сохранённый ответ можно использовать только после проверки условным запросом
+const cacheControl = "no-cache"
+
+// conditions - условия запроса GET и HEAD из заголовков If-None-Match и If-Modified-Since
+type conditions struct {
+	ifNoneMatch     *string
+	ifModifiedSince *string
+}
+
+// writeConditional - отдаёт body в формате, выбранном по заголовку Accept, с ETag от тела ответа и,
+// если lastModified известно, с Last-Modified. Если представление не изменилось с переданных в conditions
+// валидаторов, отдаёт 304 без тела, на HEAD - только заголовки: Content-Length равен длине тела, которое отдал бы GET
+func writeConditional(c *gin.Context, body any, lastModified time.Time, cond conditions, head bool) {
+	contentType, data, err := encodeBody(responseMediaType(c), body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	// ETag списка учитывает курсор следующей страницы: он тоже часть ответа
+	c.Header("ETag", entityTag(data, c.Writer.Header().Get(nextCursorHeader)))
+	c.Header("Cache-Control", cacheControl)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Writer.Header().Get("ETag"), lastModified, cond) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	if head {
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Length", strconv.Itoa(len(data)))
+		c.Status(http.StatusOK)
+		return
+	}
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// entityTag - сильный ETag тела ответа и дополнительных частей ответа, влияющих на его смысл
+func entityTag(data []byte, extra ...string) string {
+	hash := sha256.New()
+	hash.Write(data)
+	for _, part := range extra {
+		if part != "" {
+			hash.Write([]byte{'\n'})
+			hash.Write([]byte(part))
+		}
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// notModified - представление с тегом etag и временем изменения lastModified не изменилось для клиента (RFC 9110, 13.2.2).
+// If-Modified-Since учитывается, только если If-None-Match не передан
+func notModified(etag string, lastModified time.Time, cond conditions) bool {
+	if cond.ifNoneMatch != nil {
+		return matchTag(*cond.ifNoneMatch, etag, true)
+	}
+	if cond.ifModifiedSince == nil || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(*cond.ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// matchTag - список тегов из If-None-Match или If-Match содержит etag или "*".
+// При слабом сравнении префикс W/ не учитывается, при сильном слабые теги не совпадают ни с чем
+func matchTag(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatch - условие на датчик из заголовка If-Match: "*" или ETag сырого либо калиброванного представления датчика
+// в любом из форматов, как его отдаёт GET /sensors/{sensor_id}. nil, если заголовок не передан
+func ifMatch(header *string) usecase.SensorCondition {
+	if header == nil {
+		return nil
+	}
+	return func(sensor domain.Sensor) bool {
+		for _, representation := range []domain.Sensor{sensor, sensor.Calibrated()} {
+			for _, mediaType := range bodyMediaTypes {
+				_, data, err := encodeBody(mediaType, representation)
+				if err == nil && matchTag(*header, entityTag(data), false) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"homework/internal/repository/sensor/inmemory"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	sr := inmemory.NewSensorRepository()
+	sensor := &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC, CurrentState: 100, IsActive: true,
+		Calibration: &domain.Calibration{Offset: -40, Scale: 0.5}}
+	require.NoError(t, sr.SaveSensor(context.Background(), sensor))
+
+	path := "/sensors/" + strconv.FormatInt(sensor.ID, 10)
+
+	engine := newTestRouter(t, UseCases{Sensor: usecase.NewSensor(sr)})
+	serve := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Accept", mediaTypeJSON)
+		if body != "" {
+			req.Header.Set("Content-Type", mediaTypeJSON)
+		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	get := serve(http.MethodGet, path, "", nil)
+	require.Equal(t, http.StatusOK, get.Code, get.Body.String())
+	etag := get.Header().Get("ETag")
+	lastModified := get.Header().Get("Last-Modified")
+	require.NotEmpty(t, etag)
+	require.NotEmpty(t, lastModified)
+	assert.Equal(t, "no-cache", get.Header().Get("Cache-Control"))
+
+	t.Run("head_headers_without_body", func(t *testing.T) {
+		w := serve(http.MethodHead, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+		assert.Equal(t, lastModified, w.Header().Get("Last-Modified"))
+		assert.Equal(t, get.Header().Get("Content-Type"), w.Header().Get("Content-Type"))
+		assert.Equal(t, strconv.Itoa(get.Body.Len()), w.Header().Get("Content-Length"))
+	})
+
+	t.Run("if_none_match_304", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			w := serve(method, path, "", map[string]string{"If-None-Match": `"other", W/` + etag})
+			assert.Equal(t, http.StatusNotModified, w.Code, method)
+			assert.Empty(t, w.Body.String())
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+		}
+
+		// у сырого и калиброванного представлений разные теги
+		w := serve(http.MethodGet, path+"?raw=true", "", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("if_modified_since_304", func(t *testing.T) {
+		w := serve(http.MethodGet, path, "", map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		earlier := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+		w = serve(http.MethodGet, path, "", map[string]string{"If-Modified-Since": earlier})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// If-None-Match важнее If-Modified-Since
+		w = serve(http.MethodGet, path, "", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		w := serve(http.MethodGet, "/sensors?limit=1", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		listTag := w.Header().Get("ETag")
+		assert.NotEqual(t, etag, listTag)
+
+		w = serve(http.MethodHead, "/sensors?limit=1", "", map[string]string{"If-None-Match": listTag})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("if_match", func(t *testing.T) {
+		calibration := `{"offset":1,"scale":2}`
+		w := serve(http.MethodPut, path+"/calibration", calibration, map[string]string{"If-Match": `W/` + etag})
+		assertProblem(t, w, http.StatusPreconditionFailed, openapi.SensorModified)
+
+		w = serve(http.MethodPut, path+"/calibration", calibration, map[string]string{"If-Match": etag})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		// датчик изменился: старый тег больше не подходит ни для чтения, ни для записи
+		w = serve(http.MethodGet, path, "", map[string]string{"If-None-Match": etag})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+		w = serve(http.MethodDelete, path+"/alert-rule", "", map[string]string{"If-Match": etag})
+		assertProblem(t, w, http.StatusPreconditionFailed, openapi.SensorModified)
+
+		// подходит тег сырого представления
+		raw := serve(http.MethodGet, path+"?raw=true", "", nil)
+		w = serve(http.MethodDelete, path+"/calibration", "", map[string]string{"If-Match": raw.Header().Get("ETag")})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = serve(http.MethodDelete, path+"/alert-rule", "", map[string]string{"If-Match": "*"})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	eventInmemory "homework/internal/repository/event/inmemory"
+	eventBufferFile "homework/internal/repository/eventbuffer/file"
+	sensorInmemory "homework/internal/repository/sensor/inmemory"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStorageDown = errors.New("storage is down")
+
+// flakyEventRepository - хранилище событий, которое можно «выключить»
+type flakyEventRepository struct {
+	usecase.EventRepository
+	down atomic.Bool
+}
+
+func (r *flakyEventRepository) SaveEvent(ctx context.Context, event *domain.Event) error {
+	if r.down.Load() {
+		return errStorageDown
+	}
+	return r.EventRepository.SaveEvent(ctx, event)
+}
+
+func TestEventBuffer(t *testing.T) {
+	ctx := context.Background()
+	sr := sensorInmemory.NewSensorRepository()
+	sensor := &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC, IsActive: true}
+	require.NoError(t, sr.SaveSensor(ctx, sensor))
+
+	br, err := eventBufferFile.NewEventBufferRepository(t.TempDir(), eventBufferFile.WithMaxBytes(250))
+	require.NoError(t, err)
+	defer br.Close()
+	buffer := usecase.NewEventBuffer(br, func(err error) bool { return errors.Is(err, errStorageDown) })
+	er := &flakyEventRepository{EventRepository: eventInmemory.NewEventRepository()}
+	engine := newTestRouter(t, UseCases{
+		Sensor:      usecase.NewSensor(sr),
+		Event:       usecase.NewEvent(er, sr, usecase.WithEventBuffer(buffer)),
+		EventBuffer: buffer,
+	})
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", mediaTypeJSON)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+	event := func(payload int) string {
+		return `{"sensor_serial_number":"0123456789","payload":` + strconv.Itoa(payload) + `}`
+	}
+
+	w := serve(http.MethodPost, "/events", event(1))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// хранилище недоступно: события откладываются, пока буфер не заполнится
+	er.down.Store(true)
+	for payload := 2; payload <= 3; payload++ {
+		w = serve(http.MethodPost, "/events", event(payload))
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var accepted openapi.HistoryEvent
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		assert.Nil(t, accepted.Id)
+	}
+	w = serve(http.MethodPost, "/events", event(4))
+	assertProblem(t, w, http.StatusServiceUnavailable, openapi.EventBufferFull)
+
+	w = serve(http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "event_buffer_events 2\n")
+
+	// хранилище восстановилось: отложенные события сохраняются в порядке приёма
+	er.down.Store(false)
+	require.NoError(t, buffer.Replay(ctx))
+	w = serve(http.MethodGet, "/sensors/"+strconv.FormatInt(sensor.ID, 10), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"current_state":3`)
+
+	w = serve(http.MethodGet, "/metrics", "")
+	assert.Contains(t, w.Body.String(), "event_buffer_events 0\n")
+	assert.Contains(t, w.Body.String(), `event_buffer_replayed_events_total{result="saved"} 2`+"\n")
+}
@@ -0,0 +1,238 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mediaTypeJSON   = "application/json"
+	mediaTypeCSV    = "text/csv"
+	mediaTypeNDJSON = "application/x-ndjson"
+)
+
+// csvHeader - заголовок CSV выгрузки событий, readings записываются json-объектом
+var csvHeader = []string{"id", "sensor_id", "sensor_serial_number", "timestamp", "payload", "unit", "readings"}
+
+// eventEncoder - потоковая запись событий в тело ответа
+type eventEncoder interface {
+	Encode(event domain.Event) error
+	// Close - дописывает окончание тела и отправляет клиенту остаток буфера
+	Close() error
+}
+
+func newEventEncoder(mediaType string, w http.ResponseWriter) eventEncoder {
+	buf := bufio.NewWriter(w)
+	switch mediaType {
+	case mediaTypeCSV:
+		return &csvEncoder{w: w, csv: csv.NewWriter(buf)}
+	case mediaTypeNDJSON:
+		return &ndjsonEncoder{w: w, buf: buf, json: json.NewEncoder(buf)}
+	default:
+		return &jsonArrayEncoder{w: w, buf: buf}
+	}
+}
+
+type csvEncoder struct {
+	w             http.ResponseWriter
+	csv           *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(event domain.Event) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	readings := ""
+	if len(event.Readings) > 0 {
+		data, err := json.Marshal(event.Readings)
+		if err != nil {
+			return err
+		}
+		readings = string(data)
+	}
+	return e.csv.Write([]string{
+		strconv.FormatInt(event.ID, 10),
+		strconv.FormatInt(event.SensorID, 10),
+		event.SensorSerialNumber,
+		event.Timestamp.UTC().Format(time.RFC3339Nano),
+		strconv.FormatFloat(event.Payload, 'f', -1, 64),
+		event.Unit,
+		readings,
+	})
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.csv.Write(csvHeader)
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.csv.Flush()
+	if err := e.csv.Error(); err != nil {
+		return err
+	}
+	flush(e.w)
+	return nil
+}
+
+type ndjsonEncoder struct {
+	w    http.ResponseWriter
+	buf  *bufio.Writer
+	json *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(event domain.Event) error {
+	return e.json.Encode(event)
+}
+
+func (e *ndjsonEncoder) Close() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	flush(e.w)
+	return nil
+}
+
+// jsonArrayEncoder - записывает события json-массивом, не собирая его целиком в памяти
+type jsonArrayEncoder struct {
+	w     http.ResponseWriter
+	buf   *bufio.Writer
+	count int
+}
+
+func (e *jsonArrayEncoder) Encode(event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	separator := ","
+	if e.count == 0 {
+		separator = "["
+	}
+	e.count++
+	if _, err = io.WriteString(e.buf, separator); err != nil {
+		return err
+	}
+	_, err = e.buf.Write(data)
+	return err
+}
+
+func (e *jsonArrayEncoder) Close() error {
+	end := "]"
+	if e.count == 0 {
+		end = "[]"
+	}
+	if _, err := io.WriteString(e.buf, end); err != nil {
+		return err
+	}
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	flush(e.w)
+	return nil
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// streamEvents - записывает в ответ события, которые export передаёт в fn, в формате mediaType.
+// Если ошибка произошла до отправки первых байт, клиент получает обычный ответ с ошибкой,
+// иначе соединение закрывается без завершения тела, чтобы выгрузка не выглядела полной
+func streamEvents(c *gin.Context, mediaType, filename string, export func(fn func(domain.Event) error) error) {
+	contentType := mediaType
+	if mediaType == mediaTypeCSV {
+		contentType += "; charset=utf-8; header=present"
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`.csv"`)
+	}
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+
+	encoder := newEventEncoder(mediaType, c.Writer)
+	err := export(encoder.Encode)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		writeError(c, err)
+		return
+	}
+	_ = c.Error(err)
+	c.Abort()
+	if hijacker, ok := c.Writer.(http.Hijacker); ok {
+		if conn, _, hijackErr := hijacker.Hijack(); hijackErr == nil {
+			_ = conn.Close()
+		}
+	}
+}
+
+// calibrate - применяет к событиям калибровку их датчиков, события датчиков без калибровки не меняются
+func calibrate(calibrations map[int64]*domain.Calibration, fn func(domain.Event) error) func(domain.Event) error {
+	return func(event domain.Event) error {
+		return fn(calibrations[event.SensorID].CalibrateEvent(event))
+	}
+}
+
+func (h *apiHandler) EventsExportOptions(c *gin.Context) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+func (h *apiHandler) ExportEvents(c *gin.Context, params openapi.ExportEventsParams) {
+	start, end, err := dateRange(params.StartDate, params.EndDate)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	query := usecase.EventExportQuery{SensorIDs: valueOf(params.SensorId), Start: start, End: end}
+
+	var sensors []domain.Sensor
+	if len(query.SensorIDs) == 0 {
+		if sensors, err = h.uc.Sensor.GetSensors(c.Request.Context()); err != nil {
+			writeError(c, err)
+			return
+		}
+	}
+	for _, id := range query.SensorIDs {
+		sensor, err := h.uc.Sensor.GetSensorByID(c.Request.Context(), id)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		sensors = append(sensors, *sensor)
+	}
+	calibrations := make(map[int64]*domain.Calibration, len(sensors))
+	if !valueOf(params.Raw) {
+		for _, sensor := range sensors {
+			calibrations[sensor.ID] = sensor.Calibration
+		}
+	}
+
+	streamEvents(c, responseMediaType(c), "events", func(fn func(domain.Event) error) error {
+		return h.uc.Event.ExportEvents(c.Request.Context(), query, calibrate(calibrations, fn))
+	})
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportRouter(t *testing.T, events []domain.Event) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	erMock := usecase.NewMockEventRepository(ctrl)
+	erMock.EXPECT().ExportEvents(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, query usecase.EventExportQuery, fn func(domain.Event) error) error {
+			for _, event := range events {
+				if len(query.SensorIDs) > 0 && !slices.Contains(query.SensorIDs, event.SensorID) {
+					continue
+				}
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	srMock := usecase.NewMockSensorRepository(ctrl)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.Sensor{
+		ID:          1,
+		Calibration: &domain.Calibration{Scale: 2, Unit: "W"},
+	}, nil)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), int64(2)).AnyTimes().Return(&domain.Sensor{ID: 2}, nil)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrSensorNotFound)
+
+	uc := UseCases{
+		Event:  usecase.NewEvent(erMock, srMock),
+		Sensor: usecase.NewSensor(srMock),
+		User:   usecase.NewUser(usecase.NewMockUserRepository(ctrl), usecase.NewMockSensorOwnerRepository(ctrl), srMock),
+	}
+	engine := newTestRouter(t, uc)
+	return engine
+}
+
+func TestExportEvents(t *testing.T) {
+	timestamp := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	events := []domain.Event{
+		{ID: 1, SensorID: 1, SensorSerialNumber: "0000000001", Timestamp: timestamp, Payload: 1.5},
+		{ID: 2, SensorID: 2, SensorSerialNumber: "0000000002", Timestamp: timestamp, Payload: 3, Readings: domain.Readings{"t": 20}},
+	}
+	engine := exportRouter(t, events)
+	dates := "start_date=2024-01-01T00:00:00&end_date=2025-01-01T00:00:00"
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Add("Accept", accept)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("csv_200", func(t *testing.T) {
+		w := get("/events/export?sensor_id=1&sensor_id=2&"+dates, mediaTypeCSV)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), mediaTypeCSV))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "events.csv")
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			csvHeader,
+			{"1", "1", "0000000001", "2024-12-31T23:59:59Z", "3", "W", ""},
+			{"2", "2", "0000000002", "2024-12-31T23:59:59Z", "3", "", `{"t":20}`},
+		}, records)
+	})
+
+	t.Run("ndjson_raw_200", func(t *testing.T) {
+		w := get("/events/export?sensor_id=1&sensor_id=2&raw=true&"+dates, mediaTypeNDJSON)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, mediaTypeNDJSON, w.Header().Get("Content-Type"))
+		var payloads []float64
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			var event domain.Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			payloads = append(payloads, event.Payload)
+		}
+		assert.Equal(t, []float64{1.5, 3}, payloads)
+	})
+
+	t.Run("json_200", func(t *testing.T) {
+		w := get("/events/export?sensor_id=2&"+dates, mediaTypeJSON)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var actual []domain.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, events[1:], actual)
+	})
+
+	t.Run("history_csv_200", func(t *testing.T) {
+		w := get("/sensors/1/history?"+dates, mediaTypeCSV)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "sensor-1-history.csv")
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+	})
+
+	t.Run("sensor_not_found_404", func(t *testing.T) {
+		w := get("/events/export?sensor_id=3&"+dates, mediaTypeCSV)
+
+		assertProblem(t, w, http.StatusNotFound, "sensor_not_found")
+	})
+
+	t.Run("reversed_dates_400", func(t *testing.T) {
+		w := get("/events/export?sensor_id=1&start_date=2025-01-01T00:00:00&end_date=2024-01-01T00:00:00", mediaTypeNDJSON)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("unsupported_format_406", func(t *testing.T) {
+		w := get("/sensors?"+dates, mediaTypeCSV)
+
+		assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	})
+}
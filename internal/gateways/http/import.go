@@ -0,0 +1,61 @@
+package http
+
+import (
+	"homework/internal/gateways/importfile"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importRoutes - маршруты, принимающие тело в CSV или NDJSON вместо форматов из bodyMediaTypes
+var importRoutes = map[string]bool{
+	"/events/import":  true,
+	"/sensors/import": true,
+}
+
+// bodylessRoutes - маршруты POST, которые не читают тело запроса, поэтому Content-Type у них не проверяется
+var bodylessRoutes = map[string]bool{
+	"/users/:user_id/alerts/:alert_id/acknowledge": true,
+	"/users/:user_id/alerts/:alert_id/resolve":     true,
+}
+
+// consumable - проверяет, может ли маршрут принять тело в формате из заголовка Content-Type
+func consumable(c *gin.Context) bool {
+	if bodylessRoutes[c.FullPath()] {
+		return true
+	}
+	if importRoutes[c.FullPath()] {
+		_, err := importfile.FormatByMediaType(c.ContentType())
+		return err == nil
+	}
+	return slices.Contains(bodyMediaTypes, c.ContentType())
+}
+
+func (h *apiHandler) EventsImportOptions(c *gin.Context) {
+	setHeaderOptions(c, "POST,OPTIONS")
+}
+
+func (h *apiHandler) SensorsImportOptions(c *gin.Context) {
+	setHeaderOptions(c, "POST,OPTIONS")
+}
+
+func (h *apiHandler) ImportEvents(c *gin.Context) {
+	format, _ := importfile.FormatByMediaType(c.ContentType())
+	report, err := h.uc.Event.ImportEvents(c.Request.Context(), importfile.Events(c.Request.Body, format))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, report)
+}
+
+func (h *apiHandler) ImportSensors(c *gin.Context) {
+	format, _ := importfile.FormatByMediaType(c.ContentType())
+	report, err := h.uc.Sensor.ImportSensors(c.Request.Context(), importfile.Sensors(c.Request.Body, format))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, report)
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"encoding/json"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	erMock := usecase.NewMockEventRepository(ctrl)
+	srMock := usecase.NewMockSensorRepository(ctrl)
+	srMock.EXPECT().GetSensorBySerialNumber(gomock.Any(), "0000000001").AnyTimes().Return(&domain.Sensor{
+		ID:           1,
+		SerialNumber: "0000000001",
+		Type:         domain.SensorTypeADC,
+	}, nil)
+	srMock.EXPECT().GetSensorBySerialNumber(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrSensorNotFound)
+	srMock.EXPECT().SaveSensorState(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	uc := UseCases{
+		Event:  usecase.NewEvent(erMock, srMock),
+		Sensor: usecase.NewSensor(srMock),
+		User:   usecase.NewUser(usecase.NewMockUserRepository(ctrl), usecase.NewMockSensorOwnerRepository(ctrl), srMock),
+	}
+	engine := newTestRouter(t, uc)
+
+	post := func(contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/events/import", strings.NewReader(body))
+		req.Header.Add("Content-Type", contentType)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("csv_200", func(t *testing.T) {
+		erMock.EXPECT().SaveEvents(gomock.Any(), gomock.Len(2)).Times(1).Return(nil)
+
+		w := post(mediaTypeCSV, "timestamp,sensor_serial_number,payload\n"+
+			"2024-01-01T00:00:00Z,0000000001,10\n"+
+			"2024-01-01T00:01:00Z,0000000002,10\n"+
+			"2024-01-01T00:02:00Z,0000000001,not_a_number\n"+
+			"2024-01-01T00:03:00Z,0000000001,11\n")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report domain.ImportReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 4, report.Total)
+		assert.Equal(t, 2, report.Imported)
+		assert.Equal(t, 2, report.Failed)
+		require.Len(t, report.Errors, 2)
+		assert.Equal(t, 3, report.Errors[0].Line)
+		assert.Equal(t, 4, report.Errors[1].Line)
+	})
+
+	t.Run("ndjson_200", func(t *testing.T) {
+		erMock.EXPECT().SaveEvents(gomock.Any(), gomock.Len(1)).Times(1).Return(nil)
+
+		w := post(mediaTypeNDJSON, `{"sensor_serial_number":"0000000001","timestamp":"2024-01-01T00:00:00Z","payload":1}`+"\n")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report domain.ImportReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 1, report.Imported)
+	})
+
+	t.Run("json_415", func(t *testing.T) {
+		w := post(mediaTypeJSON, `[]`)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
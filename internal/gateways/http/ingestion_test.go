@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	eventInmemory "homework/internal/repository/event/inmemory"
+	sensorInmemory "homework/internal/repository/sensor/inmemory"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncIngestion(t *testing.T) {
+	sr := sensorInmemory.NewSensorRepository()
+	sensor := &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC, IsActive: true}
+	require.NoError(t, sr.SaveSensor(context.Background(), sensor))
+
+	events := usecase.NewEvent(eventInmemory.NewEventRepository(), sr)
+	ingestion := usecase.NewIngestion(events, usecase.WithIngestionWorkers(1), usecase.WithIngestionQueueSize(2))
+	engine := newTestRouter(t, UseCases{
+		Sensor:    usecase.NewSensor(sr),
+		Event:     events,
+		Ingestion: ingestion,
+	})
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", mediaTypeJSON)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+	event := func(payload int) string {
+		return `{"sensor_serial_number":"0123456789","payload":` + strconv.Itoa(payload) + `}`
+	}
+
+	// обработчики не запущены: события ждут в очереди
+	for payload := 1; payload <= 2; payload++ {
+		w := serve(http.MethodPost, "/events", event(payload))
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var accepted openapi.HistoryEvent
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		assert.Nil(t, accepted.Id)
+		assert.Equal(t, sensor.ID, *accepted.SensorId)
+	}
+	w := serve(http.MethodPost, "/events", event(3))
+	assertProblem(t, w, http.StatusServiceUnavailable, openapi.IngestionUnavailable)
+
+	// проверка события не откладывается до сохранения
+	w = serve(http.MethodPost, "/events", `{"sensor_serial_number":"9999999999","payload":1}`)
+	assertProblem(t, w, http.StatusNotFound, openapi.SensorNotFound)
+
+	w = serve(http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "ingestion_queue_depth 2\n")
+	assert.Contains(t, w.Body.String(), `ingestion_events_total{result="rejected"} 1`+"\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		ingestion.Run(ctx)
+		close(stopped)
+	}()
+	path := "/sensors/" + strconv.FormatInt(sensor.ID, 10)
+	require.Eventually(t, func() bool {
+		w := serve(http.MethodGet, path, "")
+		return strings.Contains(w.Body.String(), `"current_state":2`)
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-stopped
+
+	w = serve(http.MethodPost, "/events", event(4))
+	assertProblem(t, w, http.StatusServiceUnavailable, openapi.IngestionUnavailable)
+}
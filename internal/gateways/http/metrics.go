@@ -0,0 +1,77 @@
+package http
+
+import (
+	"fmt"
+	"homework/internal/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mediaTypeText = "text/plain"
+	// metricsContentType - текстовый формат Prometheus
+	metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+func (h *apiHandler) GetMetrics(c *gin.Context) {
+	var rejected map[usecase.RateLimitScope]uint64
+	if h.uc.RateLimiter != nil {
+		rejected = h.uc.RateLimiter.Rejected()
+	}
+
+	var b strings.Builder
+	b.WriteString("# HELP rate_limit_rejected_requests_total Requests rejected by rate limits.\n")
+	b.WriteString("# TYPE rate_limit_rejected_requests_total counter\n")
+	for _, scope := range usecase.RateLimitScopes {
+		fmt.Fprintf(&b, "rate_limit_rejected_requests_total{scope=%q} %d\n", scope, rejected[scope])
+	}
+	if h.uc.Ingestion != nil {
+		stats := h.uc.Ingestion.Stats()
+		b.WriteString("# HELP ingestion_queue_depth Accepted events waiting to be saved.\n")
+		b.WriteString("# TYPE ingestion_queue_depth gauge\n")
+		fmt.Fprintf(&b, "ingestion_queue_depth %d\n", stats.Queued)
+		b.WriteString("# HELP ingestion_events_total Events passed to asynchronous ingestion by result.\n")
+		b.WriteString("# TYPE ingestion_events_total counter\n")
+		fmt.Fprintf(&b, "ingestion_events_total{result=%q} %d\n", "accepted", stats.Accepted)
+		fmt.Fprintf(&b, "ingestion_events_total{result=%q} %d\n", "rejected", stats.Rejected)
+		fmt.Fprintf(&b, "ingestion_events_total{result=%q} %d\n", "saved", stats.Saved)
+		fmt.Fprintf(&b, "ingestion_events_total{result=%q} %d\n", "failed", stats.Failed)
+	}
+	if h.uc.EventBuffer != nil {
+		stats := h.uc.EventBuffer.Stats()
+		b.WriteString("# HELP event_buffer_events Events buffered on disk while the storage is unavailable.\n")
+		b.WriteString("# TYPE event_buffer_events gauge\n")
+		fmt.Fprintf(&b, "event_buffer_events %d\n", stats.Events)
+		b.WriteString("# HELP event_buffer_bytes Size of the event buffer backlog on disk.\n")
+		b.WriteString("# TYPE event_buffer_bytes gauge\n")
+		fmt.Fprintf(&b, "event_buffer_bytes %d\n", stats.Bytes)
+		b.WriteString("# HELP event_buffer_corrupted_records_total Corrupted event buffer records skipped.\n")
+		b.WriteString("# TYPE event_buffer_corrupted_records_total counter\n")
+		fmt.Fprintf(&b, "event_buffer_corrupted_records_total %d\n", stats.Corrupted)
+		b.WriteString("# HELP event_buffer_replayed_events_total Buffered events replayed into the storage by result.\n")
+		b.WriteString("# TYPE event_buffer_replayed_events_total counter\n")
+		fmt.Fprintf(&b, "event_buffer_replayed_events_total{result=%q} %d\n", "saved", stats.Replayed)
+		fmt.Fprintf(&b, "event_buffer_replayed_events_total{result=%q} %d\n", "discarded", stats.Discarded)
+	}
+	if h.uc.Notification != nil {
+		b.WriteString("# HELP notification_dropped_alerts_total Alerts not dispatched because the notification queue was full.\n")
+		b.WriteString("# TYPE notification_dropped_alerts_total counter\n")
+		fmt.Fprintf(&b, "notification_dropped_alerts_total %d\n", h.uc.Notification.Dropped())
+	}
+	if h.uc.Outbox != nil {
+		stats := h.uc.Outbox.Stats()
+		b.WriteString("# HELP outbox_relay_published_changes_total Changes delivered to outbox sinks.\n")
+		b.WriteString("# TYPE outbox_relay_published_changes_total counter\n")
+		for _, sink := range stats {
+			fmt.Fprintf(&b, "outbox_relay_published_changes_total{sink=%q} %d\n", sink.Name, sink.Published)
+		}
+		b.WriteString("# HELP outbox_relay_failures_total Failed attempts to deliver changes to outbox sinks.\n")
+		b.WriteString("# TYPE outbox_relay_failures_total counter\n")
+		for _, sink := range stats {
+			fmt.Fprintf(&b, "outbox_relay_failures_total{sink=%q} %d\n", sink.Name, sink.Failed)
+		}
+	}
+	c.Data(http.StatusOK, metricsContentType, []byte(b.String()))
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	mediaTypeCBOR    = "application/cbor"
+	mediaTypeMsgPack = "application/msgpack"
+	// mediaTypeKey - ключ выбранного по заголовку Accept формата ответа в gin.Context
+	mediaTypeKey = "media_type"
+)
+
+// bodyMediaTypes - форматы тел запросов и ответов, в которых передаются данные из api/openapi.yaml.
+// Первый формат - предпочтительный для сервиса
+var bodyMediaTypes = []string{mediaTypeJSON, mediaTypeCBOR, mediaTypeMsgPack}
+
+// routeMediaTypes - маршруты, которые отдают ответ в других форматах, чем bodyMediaTypes
+var routeMediaTypes = map[string][]string{
+	"/sensors/:sensor_id/history": append(append([]string{}, bodyMediaTypes...), mediaTypeCSV, mediaTypeNDJSON),
+	"/events/export":              {mediaTypeJSON, mediaTypeCSV, mediaTypeNDJSON},
+	"/metrics":                    {mediaTypeText},
+}
+
+// CBOR и MessagePack передают ту же модель данных, что и JSON: ответ сначала кодируется в JSON,
+// поэтому имена полей, форматы дат и схемы из спецификации у всех форматов общие.
+// Ключи отображений сортируются, чтобы одинаковые ответы кодировались одинаково и давали одинаковый ETag
+var (
+	cborHandle    = &codec.CborHandle{}
+	msgpackHandle = &codec.MsgpackHandle{}
+)
+
+func init() {
+	mapType := reflect.TypeOf(map[string]any(nil))
+	cborHandle.Canonical = true
+	cborHandle.MapType = mapType
+	msgpackHandle.Canonical = true
+	msgpackHandle.MapType = mapType
+	msgpackHandle.RawToString = true
+	msgpackHandle.WriteExt = true
+}
+
+// binaryHandles - кодеки двоичных форматов тел
+var binaryHandles = map[string]codec.Handle{
+	mediaTypeCBOR:    cborHandle,
+	mediaTypeMsgPack: msgpackHandle,
+}
+
+// routeOffers - форматы, в которых маршрут может отдать ответ, в порядке предпочтения сервиса
+func routeOffers(route string) []string {
+	if offers, ok := routeMediaTypes[route]; ok {
+		return offers
+	}
+	return bodyMediaTypes
+}
+
+// responseMediaType - формат ответа, выбранный по заголовку Accept
+func responseMediaType(c *gin.Context) string {
+	if mediaType := c.GetString(mediaTypeKey); mediaType != "" {
+		return mediaType
+	}
+	return mediaTypeJSON
+}
+
+// negotiate - выбирает из offers формат с наибольшим весом q в заголовке Accept (RFC 9110, 12.5.1):
+// вес формата задаёт самый точный подходящий диапазон, при равных весах выбирается формат, стоящий в offers раньше.
+// Без заголовка подходит любой формат, пустая строка - ни один формат не подходит
+func negotiate(accept string, offers []string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+	ranges := parseAccept(accept)
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := offerQuality(ranges, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// mediaRange - диапазон форматов из заголовка Accept: type/subtype, type/* или */*
+type mediaRange struct {
+	mediaType string
+	subtype   string
+	q         float64
+}
+
+// parseAccept - диапазоны из заголовка Accept, параметры кроме q не учитываются, невалидные диапазоны пропускаются
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(params[0])), "/")
+		if !ok || mediaType == "" || subtype == "" || (mediaType == "*" && subtype != "*") {
+			continue
+		}
+		r := mediaRange{mediaType: mediaType, subtype: subtype, q: 1}
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				ok = false
+				break
+			}
+			r.q = q
+		}
+		if ok {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// offerQuality - вес формата offer по самому точному подходящему диапазону, 0 - формат не подходит
+func offerQuality(ranges []mediaRange, offer string) float64 {
+	mediaType, subtype, _ := strings.Cut(offer, "/")
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.mediaType == mediaType && r.subtype == subtype:
+			s = 2
+		case r.mediaType == mediaType && r.subtype == "*":
+			s = 1
+		case r.mediaType == "*":
+			s = 0
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+// encodeBody - тело ответа в формате mediaType и значение заголовка Content-Type
+func encodeBody(mediaType string, body any) (string, []byte, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", nil, err
+	}
+	handle, ok := binaryHandles[mediaType]
+	if !ok {
+		return "application/json; charset=utf-8", data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return "", nil, err
+	}
+	var out []byte
+	if err = codec.NewEncoderBytes(&out, handle).Encode(jsonNumbers(value)); err != nil {
+		return "", nil, fmt.Errorf("can't encode %s body: %w", mediaType, err)
+	}
+	return mediaType, out, nil
+}
+
+// jsonNumbers - заменяет json.Number на int64, если число целое, иначе на float64
+func jsonNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = jsonNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = jsonNumbers(item)
+		}
+	}
+	return value
+}
+
+// decodeBody - тело запроса в формате mediaType, переведённое в JSON
+func decodeBody(mediaType string, data []byte) ([]byte, error) {
+	handle, ok := binaryHandles[mediaType]
+	if !ok {
+		return data, nil
+	}
+	var value any
+	if err := codec.NewDecoderBytes(data, handle).Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// writeBody - отдаёт body в формате, выбранном по заголовку Accept
+func writeBody(c *gin.Context, status int, body any) {
+	contentType, data, err := encodeBody(responseMediaType(c), body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Data(status, contentType, data)
+}
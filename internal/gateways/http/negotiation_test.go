@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	eventInmemory "homework/internal/repository/event/inmemory"
+	sensorInmemory "homework/internal/repository/sensor/inmemory"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no_header", accept: "", want: mediaTypeJSON},
+		{name: "any", accept: "*/*", want: mediaTypeJSON},
+		{name: "parameters_ignored", accept: "application/json; charset=utf-8", want: mediaTypeJSON},
+		{name: "exact", accept: "application/msgpack", want: mediaTypeMsgPack},
+		{name: "highest_q", accept: "application/json;q=0.5, application/cbor", want: mediaTypeCBOR},
+		{name: "type_wildcard_keeps_service_order", accept: "application/*", want: mediaTypeJSON},
+		{name: "specific_range_wins", accept: "application/*;q=0.9, application/json;q=0.1", want: mediaTypeCBOR},
+		{name: "excluded", accept: "*/*, application/json;q=0", want: mediaTypeCBOR},
+		{name: "case_insensitive", accept: "Application/CBOR", want: mediaTypeCBOR},
+		{name: "invalid_q_skipped", accept: "application/cbor;q=2, application/msgpack", want: mediaTypeMsgPack},
+		{name: "not_acceptable", accept: "text/html, application/xml;q=0.9", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, negotiate(tt.accept, bodyMediaTypes))
+		})
+	}
+}
+
+func TestBinaryBodies(t *testing.T) {
+	sr := sensorInmemory.NewSensorRepository()
+	sensor := &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC, IsActive: true}
+	require.NoError(t, sr.SaveSensor(context.Background(), sensor))
+
+	path := "/sensors/" + strconv.FormatInt(sensor.ID, 10)
+
+	engine := newTestRouter(t, UseCases{
+		Sensor: usecase.NewSensor(sr),
+		Event:  usecase.NewEvent(eventInmemory.NewEventRepository(), sr),
+	})
+	serve := func(method, path, contentType, accept string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req.Header.Set("Accept", accept)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+	encode := func(handle codec.Handle, value any) []byte {
+		var out []byte
+		require.NoError(t, codec.NewEncoderBytes(&out, handle).Encode(value))
+		return out
+	}
+	decode := func(handle codec.Handle, data []byte) map[string]any {
+		var value map[string]any
+		require.NoError(t, codec.NewDecoderBytes(data, handle).Decode(&value))
+		return value
+	}
+
+	for mediaType, handle := range binaryHandles {
+		t.Run(mediaType, func(t *testing.T) {
+			event := map[string]any{"sensor_serial_number": "0123456789", "payload": 21.5}
+			w := serve(http.MethodPost, "/events", mediaType, mediaType, encode(handle, event))
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			assert.Equal(t, mediaType, w.Header().Get("Content-Type"))
+			created := decode(handle, w.Body.Bytes())
+			assert.Equal(t, "0123456789", created["sensor_serial_number"])
+			assert.Equal(t, 21.5, created["payload"])
+
+			w = serve(http.MethodGet, path, "", mediaType+", application/json;q=0.5", nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			got := decode(handle, w.Body.Bytes())
+			assert.EqualValues(t, sensor.ID, got["id"])
+			assert.Equal(t, 21.5, got["current_state"])
+
+			jsonBody := serve(http.MethodGet, path, "", mediaTypeJSON, nil)
+			var want map[string]any
+			require.NoError(t, json.Unmarshal(jsonBody.Body.Bytes(), &want))
+			assert.Equal(t, len(want), len(got), "у форматов одна модель данных")
+			assert.Less(t, w.Body.Len(), jsonBody.Body.Len())
+			assert.NotEqual(t, jsonBody.Header().Get("ETag"), w.Header().Get("ETag"))
+		})
+	}
+
+	t.Run("malformed_body", func(t *testing.T) {
+		w := serve(http.MethodPost, "/events", mediaTypeCBOR, mediaTypeJSON, []byte{0xff, 0x00})
+		assertProblem(t, w, http.StatusBadRequest, openapi.MalformedBody)
+	})
+
+	t.Run("wildcard_accept", func(t *testing.T) {
+		w := serve(http.MethodGet, "/sensors", "", "*/*", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("unacceptable_write_falls_back_to_json", func(t *testing.T) {
+		w := serve(http.MethodPost, "/events", mediaTypeJSON, "text/html", []byte(`{"sensor_serial_number":"0123456789","payload":1}`))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+}
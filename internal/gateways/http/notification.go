@@ -0,0 +1,83 @@
+package http
+
+import (
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *apiHandler) NotificationChannelsOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "GET,POST,OPTIONS")
+}
+
+func (h *apiHandler) NotificationChannelOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "DELETE,OPTIONS")
+}
+
+func (h *apiHandler) NotificationDeliveriesOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+func (h *apiHandler) GetNotificationChannels(c *gin.Context, userID int64) {
+	channels, err := h.uc.Notification.GetUserChannels(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, channels)
+}
+
+func (h *apiHandler) CreateNotificationChannel(c *gin.Context, userID int64) {
+	var toCreate openapi.NotificationChannelToCreate
+	if !bindJSON(c, &toCreate) {
+		return
+	}
+
+	channel := &domain.NotificationChannel{
+		UserID:      userID,
+		Kind:        domain.NotificationChannelKind(toCreate.Kind),
+		Target:      toCreate.Target,
+		MinSeverity: domain.AlertSeverity(valueOf(toCreate.MinSeverity)),
+		Template:    valueOf(toCreate.Template),
+		MaxPerHour:  int(valueOf(toCreate.MaxPerHour)),
+	}
+	if q := toCreate.QuietHours; q != nil {
+		channel.QuietHours = &domain.QuietHours{Location: valueOf(q.Location)}
+		// формат границ уже проверен по схеме
+		channel.QuietHours.Start, _ = domain.ParseClockTime(q.Start)
+		channel.QuietHours.End, _ = domain.ParseClockTime(q.End)
+	}
+
+	err := h.uc.Notification.CreateChannel(c.Request.Context(), channel)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusCreated, channel)
+}
+
+func (h *apiHandler) DeleteNotificationChannel(c *gin.Context, userID, channelID int64) {
+	err := h.uc.Notification.DeleteChannel(c.Request.Context(), userID, channelID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *apiHandler) GetNotificationDeliveries(c *gin.Context, userID, channelID int64, params openapi.GetNotificationDeliveriesParams) {
+	limit, err := limitRequested(params.Limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	deliveries, err := h.uc.Notification.GetChannelDeliveries(c.Request.Context(), userID, channelID, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, deliveries)
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urMock := usecase.NewMockUserRepository(ctrl)
+	urMock.EXPECT().GetUserByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.User{ID: 1}, nil)
+	urMock.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrUserNotFound)
+	nrMock := usecase.NewMockNotificationRepository(ctrl)
+	sender := usecase.NewMockNotificationSender(ctrl)
+
+	uc := UseCases{
+		Notification: usecase.NewNotification(nrMock, urMock, nil, nil,
+			usecase.WithNotificationSender(domain.NotificationChannelEmail, sender)),
+	}
+	engine := newTestRouter(t, uc)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Accept", "application/json")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("create_201", func(t *testing.T) {
+		nrMock.EXPECT().SaveChannel(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, channel *domain.NotificationChannel) error {
+			channel.ID = 5
+			return nil
+		})
+
+		w := serve(http.MethodPost, "/users/1/notification-channels",
+			`{"kind":"email","target":"owner@example.com","min_severity":"warning","quiet_hours":{"start":"22:00","end":"07:30"}}`)
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var channel domain.NotificationChannel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &channel))
+		assert.Equal(t, int64(5), channel.ID)
+		assert.Equal(t, int64(1), channel.UserID)
+		assert.Equal(t, domain.AlertSeverityWarning, channel.MinSeverity)
+		assert.Equal(t, &domain.QuietHours{Start: 22 * 60, End: 7*60 + 30}, channel.QuietHours)
+	})
+
+	t.Run("create_422", func(t *testing.T) {
+		w := serve(http.MethodPost, "/users/1/notification-channels", `{"kind":"push","target":"https://example.com"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "отправитель push не настроен")
+
+		w = serve(http.MethodPost, "/users/1/notification-channels",
+			`{"kind":"email","target":"owner@example.com","quiet_hours":{"start":"24:00","end":"07:00"}}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("list_404", func(t *testing.T) {
+		w := serve(http.MethodGet, "/users/2/notification-channels", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("deliveries_200", func(t *testing.T) {
+		nrMock.EXPECT().GetChannelByID(gomock.Any(), int64(5)).Times(1).Return(&domain.NotificationChannel{ID: 5, UserID: 1}, nil)
+		nrMock.EXPECT().ListDeliveries(gomock.Any(), int64(5), 10).Times(1).Return([]domain.Delivery{
+			{ID: 1, ChannelID: 5, AlertID: 7, Status: domain.DeliveryStatusSuppressed, Reason: "quiet hours"},
+		}, nil)
+
+		w := serve(http.MethodGet, "/users/1/notification-channels/5/deliveries?limit=10", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var deliveries []domain.Delivery
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deliveries))
+		assert.Equal(t, domain.DeliveryStatusSuppressed, deliveries[0].Status)
+	})
+
+	t.Run("delete_404", func(t *testing.T) {
+		nrMock.EXPECT().GetChannelByID(gomock.Any(), int64(6)).Times(1).Return(&domain.NotificationChannel{ID: 6, UserID: 3}, nil)
+
+		w := serve(http.MethodDelete, "/users/1/notification-channels/6", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"homework/api/openapi"
+	"homework/internal/gateways/problem"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mediaTypeProblem = "application/problem+json"
+	// requestIDHeader - заголовок с идентификатором запроса, клиент может передать свой
+	requestIDHeader = "X-Request-ID"
+	// requestIDKey - ключ идентификатора запроса в gin.Context
+	requestIDKey = "request_id"
+	// maxRequestIDLength - наибольшая длина идентификатора запроса от клиента
+	maxRequestIDLength = 128
+	// statusClientClosedRequest - клиент закрыл соединение, не дождавшись ответа (nginx)
+	statusClientClosedRequest = 499
+)
+
+// problemStatuses - коды ответа для видов ошибок, общих для всех шлюзов
+var problemStatuses = map[problem.Kind]int{
+	problem.KindInternal:     http.StatusInternalServerError,
+	problem.KindMalformed:    http.StatusBadRequest,
+	problem.KindInvalid:      http.StatusUnprocessableEntity,
+	problem.KindNotFound:     http.StatusNotFound,
+	problem.KindConflict:     http.StatusConflict,
+	problem.KindCanceled:     statusClientClosedRequest,
+	problem.KindTimeout:      http.StatusGatewayTimeout,
+	problem.KindPrecondition: http.StatusPreconditionFailed,
+	problem.KindRateLimited:  http.StatusTooManyRequests,
+	problem.KindUnavailable:  http.StatusServiceUnavailable,
+}
+
+// requestIDMiddleware - присваивает запросу идентификатор и возвращает его в заголовке ответа,
+// идентификатор из заголовка запроса используется, если он подходит по формату
+func requestIDMiddleware(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !validRequestID(id) {
+		id = newRequestID()
+	}
+	c.Set(requestIDKey, id)
+	c.Header(requestIDHeader, id)
+	c.Next()
+}
+
+// validRequestID - непустая строка из латинских букв, цифр, '-', '_' и '.'
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// writeError - ответ на ошибку usecase или шлюза: код ответа и код ошибки по общему для шлюзов сопоставлению.
+// Текст внутренних ошибок клиенту не передаётся, а пишется в лог вместе с идентификатором запроса
+func writeError(c *gin.Context, err error) {
+	p := problem.From(err)
+	if p.Kind == problem.KindInternal {
+		log.Printf("%s %s, request %s: %v", c.Request.Method, c.Request.URL.Path, c.GetString(requestIDKey), err)
+	}
+	if p.RetryAfter > 0 {
+		// Retry-After - целое число секунд, округлённое вверх, чтобы повтор не пришёл раньше токена
+		c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(p.RetryAfter.Seconds())), 10))
+	}
+	writeProblem(c, problemStatuses[p.Kind], openapi.ErrorCode(p.Code), p.Detail)
+}
+
+// writeProblem - ответ application/problem+json (RFC 7807)
+func writeProblem(c *gin.Context, status int, code openapi.ErrorCode, detail string) {
+	body := openapi.Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Code:      code,
+		RequestId: c.GetString(requestIDKey),
+	}
+	if detail != "" {
+		body.Detail = &detail
+	}
+	if path := c.Request.URL.Path; path != "" {
+		body.Instance = &path
+	}
+	if body.Title == "" {
+		body.Title = "Client Closed Request"
+	}
+	c.Header("Content-Type", mediaTypeProblem)
+	c.AbortWithStatusJSON(status, body)
+}
+
+// routeNotFound - ответ на неизвестный путь
+func routeNotFound(c *gin.Context) {
+	writeProblem(c, http.StatusNotFound, openapi.RouteNotFound, "route not found")
+}
+
+// methodNotAllowed - ответ на известный путь с неподдерживаемым методом
+func methodNotAllowed(c *gin.Context) {
+	writeProblem(c, http.StatusMethodNotAllowed, openapi.MethodNotAllowed, "method not allowed")
+}
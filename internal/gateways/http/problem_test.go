@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertProblem - проверяет, что ответ - application/problem+json с заданными кодом ответа и кодом ошибки
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, status int, code openapi.ErrorCode) openapi.Problem {
+	t.Helper()
+	require.Equal(t, status, w.Code, w.Body.String())
+	assert.Equal(t, mediaTypeProblem, w.Header().Get("Content-Type"))
+
+	var problem openapi.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, status, problem.Status)
+	assert.Equal(t, code, problem.Code)
+	assert.Equal(t, w.Header().Get(requestIDHeader), problem.RequestId)
+	return problem
+}
+
+func TestProblemResponses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	srMock := usecase.NewMockSensorRepository(ctrl)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().
+		Return(nil, errors.New(`FATAL: password authentication failed for user "postgres" (SQLSTATE 28P01)`))
+	srMock.EXPECT().GetSensorByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrSensorNotFound)
+	// датчик с тем же серийным номером зарегистрировали между проверкой и сохранением
+	srMock.EXPECT().GetSensorBySerialNumber(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrSensorNotFound)
+	srMock.EXPECT().SaveSensor(gomock.Any(), gomock.Any()).AnyTimes().Return(usecase.ErrSensorAlreadyExists)
+	urMock := usecase.NewMockUserRepository(ctrl)
+	urMock.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).AnyTimes().Return(&domain.User{ID: 1, Name: "owner"}, nil)
+	sorMock := usecase.NewMockSensorOwnerRepository(ctrl)
+	sorMock.EXPECT().GetSensorsByUserID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+
+	engine := newTestRouter(t, UseCases{
+		Sensor: usecase.NewSensor(srMock),
+		User:   usecase.NewUser(urMock, sorMock, srMock),
+	})
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req.Header.Set("Accept", mediaTypeJSON)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("internal_error_is_hidden", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/sensors/1", nil)
+		problem := assertProblem(t, serve(req), http.StatusInternalServerError, openapi.Internal)
+		assert.Equal(t, "internal error", valueOf(problem.Detail))
+		assert.Equal(t, "/sensors/1", valueOf(problem.Instance))
+	})
+
+	t.Run("same_error_same_status", func(t *testing.T) {
+		// датчик из пути и датчик из тела запроса - один и тот же ответ
+		req, _ := http.NewRequest(http.MethodGet, "/sensors/2", nil)
+		assertProblem(t, serve(req), http.StatusNotFound, openapi.SensorNotFound)
+
+		req, _ = http.NewRequest(http.MethodPost, "/users/1/sensors", strings.NewReader(`{"sensor_id":2}`))
+		req.Header.Set("Content-Type", mediaTypeJSON)
+		assertProblem(t, serve(req), http.StatusNotFound, openapi.SensorNotFound)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/sensors", strings.NewReader(`{"serial_number":"0123456789","type":"cc","description":"","is_active":true}`))
+		req.Header.Set("Content-Type", mediaTypeJSON)
+		assertProblem(t, serve(req), http.StatusConflict, openapi.SensorAlreadyExists)
+	})
+
+	t.Run("gateway_errors", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/sensors/abc", nil)
+		assertProblem(t, serve(req), http.StatusUnprocessableEntity, openapi.InvalidId)
+
+		req, _ = http.NewRequest(http.MethodGet, "/sensors?limit=x", nil)
+		assertProblem(t, serve(req), http.StatusBadRequest, openapi.InvalidParameter)
+
+		req, _ = http.NewRequest(http.MethodPost, "/sensors", strings.NewReader(`{`))
+		req.Header.Set("Content-Type", mediaTypeJSON)
+		assertProblem(t, serve(req), http.StatusBadRequest, openapi.MalformedBody)
+
+		req, _ = http.NewRequest(http.MethodPost, "/sensors", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "text/plain")
+		assertProblem(t, serve(req), http.StatusUnsupportedMediaType, openapi.UnsupportedMediaType)
+
+		req, _ = http.NewRequest(http.MethodGet, "/unknown", nil)
+		assertProblem(t, serve(req), http.StatusNotFound, openapi.RouteNotFound)
+
+		req, _ = http.NewRequest(http.MethodPatch, "/sensors", nil)
+		assertProblem(t, serve(req), http.StatusMethodNotAllowed, openapi.MethodNotAllowed)
+	})
+
+	t.Run("not_acceptable", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/sensors", nil)
+		req.Header.Set("Accept", "text/html")
+		engine.ServeHTTP(w, req)
+		assertProblem(t, w, http.StatusNotAcceptable, openapi.NotAcceptable)
+	})
+
+	t.Run("request_id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/sensors/2", nil)
+		req.Header.Set(requestIDHeader, "client-request.42")
+		problem := assertProblem(t, serve(req), http.StatusNotFound, openapi.SensorNotFound)
+		assert.Equal(t, "client-request.42", problem.RequestId)
+
+		// идентификатор не в том формате заменяется своим
+		req, _ = http.NewRequest(http.MethodGet, "/sensors/2", nil)
+		req.Header.Set(requestIDHeader, "bad id\r\n")
+		problem = assertProblem(t, serve(req), http.StatusNotFound, openapi.SensorNotFound)
+		assert.Len(t, problem.RequestId, 32)
+	})
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"homework/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unlimitedRoutes - маршруты, которые не ограничиваются по частоте: проверки доступности и сбор метрик
+var unlimitedRoutes = map[string]struct{}{
+	"/ping":    {},
+	"/metrics": {},
+}
+
+// rateLimitMiddleware - отклоняет запрос с 429, если клиент превысил ограничение по адресу
+// или по токену API из заголовка Authorization. Неизвестные маршруты и OPTIONS не ограничиваются
+func rateLimitMiddleware(l *usecase.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := unlimitedRoutes[c.FullPath()]; ok || c.FullPath() == "" || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+		if err := l.Allow(c.Request.Context(), usecase.RateLimitScopeIP, c.ClientIP()); err != nil {
+			writeError(c, err)
+			return
+		}
+		if token := c.GetHeader("Authorization"); token != "" {
+			if err := l.Allow(c.Request.Context(), usecase.RateLimitScopeToken, tokenKey(token)); err != nil {
+				writeError(c, err)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// tokenKey - ключ корзины токена API: сам токен не попадает ни в память ограничителя, ни в общее хранилище
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"homework/api/openapi"
+	"homework/internal/domain"
+	eventInmemory "homework/internal/repository/event/inmemory"
+	rateLimitInmemory "homework/internal/repository/ratelimit/inmemory"
+	sensorInmemory "homework/internal/repository/sensor/inmemory"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimits(t *testing.T) {
+	sr := sensorInmemory.NewSensorRepository()
+	sensor := &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC, IsActive: true}
+	require.NoError(t, sr.SaveSensor(context.Background(), sensor))
+
+	// корзины пополняются раз в час: повторный запрос в тесте не успевает получить токен
+	slow := func(burst int) domain.RateLimit {
+		return domain.RateLimit{Rate: 1.0 / 3600, Burst: burst}
+	}
+	limiter := usecase.NewRateLimiter(rateLimitInmemory.NewRateLimitRepository(),
+		usecase.WithRateLimit(usecase.RateLimitScopeSensor, slow(1)),
+		usecase.WithRateLimit(usecase.RateLimitScopeToken, slow(2)),
+		usecase.WithRateLimit(usecase.RateLimitScopeIP, slow(6)),
+	)
+	engine := newTestRouter(t, UseCases{
+		Sensor:      usecase.NewSensor(sr),
+		Event:       usecase.NewEvent(eventInmemory.NewEventRepository(), sr, usecase.WithEventRateLimiter(limiter)),
+		RateLimiter: limiter,
+	})
+	serve := func(method, path, body, ip, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.RemoteAddr = ip + ":40000"
+		if body != "" {
+			req.Header.Set("Content-Type", mediaTypeJSON)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("sensor", func(t *testing.T) {
+		event := `{"sensor_serial_number":"0123456789","payload":1}`
+		w := serve(http.MethodPost, "/events", event, "10.0.0.1", "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		// ограничение датчика не зависит от адреса, с которого пришло событие
+		w = serve(http.MethodPost, "/events", event, "10.0.0.2", "")
+		assertProblem(t, w, http.StatusTooManyRequests, openapi.RateLimited)
+		retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.InDelta(t, 3600, retryAfter, 1)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			w := serve(http.MethodGet, "/sensors", "", "10.0.0.3", "secret")
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+		w := serve(http.MethodGet, "/sensors", "", "10.0.0.4", "secret")
+		assertProblem(t, w, http.StatusTooManyRequests, openapi.RateLimited)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		w = serve(http.MethodGet, "/sensors", "", "10.0.0.4", "other")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ip", func(t *testing.T) {
+		for i := 0; i < 6; i++ {
+			w := serve(http.MethodGet, "/sensors", "", "10.0.0.5", "")
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+		w := serve(http.MethodGet, "/sensors", "", "10.0.0.5", "")
+		assertProblem(t, w, http.StatusTooManyRequests, openapi.RateLimited)
+
+		// проверки доступности, метрики и OPTIONS не ограничиваются
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/ping", "", "10.0.0.5", "").Code)
+		assert.Equal(t, http.StatusNoContent, serve(http.MethodOptions, "/sensors", "", "10.0.0.5", "").Code)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		w := serve(http.MethodGet, "/metrics", "", "10.0.0.5", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, metricsContentType, w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.Contains(t, body, "# TYPE rate_limit_rejected_requests_total counter\n")
+		assert.Contains(t, body, `rate_limit_rejected_requests_total{scope="sensor"} 1`+"\n")
+		assert.Contains(t, body, `rate_limit_rejected_requests_total{scope="token"} 1`+"\n")
+		assert.Contains(t, body, `rate_limit_rejected_requests_total{scope="ip"} 1`+"\n")
+	})
+}
+
+func TestRateLimits_ForwardedFor(t *testing.T) {
+	newServer := func(options ...func(*Server)) *Server {
+		limiter := usecase.NewRateLimiter(rateLimitInmemory.NewRateLimitRepository(),
+			usecase.WithRateLimit(usecase.RateLimitScopeIP, domain.RateLimit{Rate: 1.0 / 3600, Burst: 1}))
+		return NewServer(UseCases{Sensor: usecase.NewSensor(sensorInmemory.NewSensorRepository()), RateLimiter: limiter}, options...)
+	}
+	serve := func(s *Server, forwardedFor string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/sensors", nil)
+		req.RemoteAddr = "10.0.0.1:40000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("spoofed header ignored", func(t *testing.T) {
+		s := newServer()
+		assert.Equal(t, http.StatusOK, serve(s, "203.0.113.1"))
+		assert.Equal(t, http.StatusTooManyRequests, serve(s, "203.0.113.2"))
+	})
+
+	t.Run("trusted proxy forwards client address", func(t *testing.T) {
+		s := newServer(WithTrustedProxies([]string{"10.0.0.0/8"}))
+		assert.Equal(t, http.StatusOK, serve(s, "203.0.113.1"))
+		assert.Equal(t, http.StatusOK, serve(s, "203.0.113.2"))
+		assert.Equal(t, http.StatusTooManyRequests, serve(s, "203.0.113.1"))
+	})
+}
@@ -2,334 +2,485 @@ package http
 
 import (
 	"errors"
+	"homework/api/openapi"
 	"homework/internal/domain"
-	"homework/internal/repository/event/postgres"
+	"homework/internal/gateways/problem"
+	"homework/internal/usecase"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-openapi/strfmt"
-	model "homework/api/generated"
 )
 
-const contentTypeErrorMessage = "Content-Type must be 'application/json'"
+const (
+	contentTypeErrorMessage = "Content-Type must be 'application/json', 'application/cbor' or 'application/msgpack'"
+	acceptErrorMessage      = "response can't be produced in a media type from Accept"
+)
+
+// nextCursorHeader - заголовок с курсором следующей страницы списка
+const nextCursorHeader = "X-Next-Cursor"
+
+// apiHandler - обработчики операций из api/openapi.yaml, маршруты и разбор параметров генерирует oapi-codegen
+type apiHandler struct {
+	uc UseCases
+	ws *WebSocketHandler
+}
+
+var _ openapi.ServerInterface = (*apiHandler)(nil)
 
 func setupRouter(r *gin.Engine, uc UseCases, ws *WebSocketHandler) {
 	r.HandleMethodNotAllowed = true
+	r.Use(requestIDMiddleware)
+	if uc.RateLimiter != nil {
+		r.Use(rateLimitMiddleware(uc.RateLimiter))
+	}
 	r.Use(checkMediaTypeMiddleWare)
+	r.NoRoute(routeNotFound)
+	r.NoMethod(methodNotAllowed)
 
-	r.GET("/ping", func(c *gin.Context) {
-		c.String(200, "pong")
+	openapi.RegisterHandlersWithOptions(r, &apiHandler{uc: uc, ws: ws}, openapi.GinServerOptions{
+		ErrorHandler: parameterError,
 	})
+}
 
-	setEvents(r, uc)
-	setSensors(r, uc)
-	setUsers(r, uc)
+// parameterError - ответ на параметр, не подходящий под спецификацию:
+// невалидный идентификатор в пути - 422, остальные параметры - 400
+func parameterError(c *gin.Context, err error, _ int) {
+	for _, param := range c.Params {
+		if strings.HasPrefix(err.Error(), "Invalid format for parameter "+param.Key+":") {
+			writeError(c, problem.New(problem.KindInvalid, problem.CodeInvalidID, err))
+			return
+		}
+	}
+	writeError(c, problem.Malformed(err))
+}
 
-	r.GET("/sensors/:id/events", getLastEventBySensor(uc, ws))
+func (h *apiHandler) Ping(c *gin.Context) {
+	c.String(http.StatusOK, "pong")
 }
 
-func setEvents(r *gin.Engine, uc UseCases) {
-	r.POST("/events", receiveEventToSensor(uc))
-	r.OPTIONS("/events", setHeaderOptions("POST,OPTIONS"))
+func (h *apiHandler) EventsOptions(c *gin.Context) {
+	setHeaderOptions(c, "POST,OPTIONS")
 }
 
-func setSensors(r *gin.Engine, uc UseCases) {
-	r.POST("/sensors", postSensor(uc))
-	r.GET("/sensors", getSensors(uc, false))
-	r.HEAD("/sensors", getSensors(uc, true))
-	r.OPTIONS("/sensors", setHeaderOptions("GET,POST,OPTIONS,HEAD"))
+func (h *apiHandler) SensorsOptions(c *gin.Context) {
+	setHeaderOptions(c, "GET,POST,OPTIONS,HEAD")
+}
 
-	r.GET("/sensors/:id", getSensorByID(uc, false))
-	r.HEAD("/sensors/:id", getSensorByID(uc, true))
-	r.GET("/sensors/:id/history", getSensorHistory(uc))
-	r.OPTIONS("/sensors/:id", setHeaderOptions("GET,OPTIONS,HEAD"))
+func (h *apiHandler) SensorOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "GET,OPTIONS,HEAD")
 }
 
-func setUsers(r *gin.Engine, uc UseCases) {
-	r.POST("/users", postUser(uc))
-	r.OPTIONS("/users", setHeaderOptions("GET,POST,OPTIONS,HEAD"))
+func (h *apiHandler) SensorCalibrationOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "PUT,DELETE,OPTIONS")
+}
 
-	r.GET("/users/:id/sensors", getUserSensors(uc, false))
-	r.HEAD("/users/:id/sensors", getUserSensors(uc, true))
-	r.POST("/users/:id/sensors", postSensorToUser(uc))
-	r.OPTIONS("users/:id/sensors", setHeaderOptions("POST,GET,OPTIONS,HEAD"))
+func (h *apiHandler) SensorTypesOptions(c *gin.Context) {
+	setHeaderOptions(c, "GET,OPTIONS")
 }
 
-func getLastEventBySensor(uc UseCases, ws *WebSocketHandler) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		if _, err = uc.Sensor.GetSensorByID(c, id); err != nil {
-			setError(c, http.StatusNotFound, err.Error())
-			return
-		}
+func (h *apiHandler) UsersOptions(c *gin.Context) {
+	setHeaderOptions(c, "POST,OPTIONS")
+}
 
-		if err = ws.Handle(c, id); err != nil {
-			setError(c, http.StatusInternalServerError, err.Error())
-			return
-		}
+func (h *apiHandler) UsersSensorsOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "POST,GET,OPTIONS,HEAD")
+}
+
+func (h *apiHandler) SubscribeSensorEvents(c *gin.Context, id int64, params openapi.SubscribeSensorEventsParams) {
+	sensor, err := h.uc.Sensor.GetSensorByID(c, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	calibration := sensor.Calibration
+	if valueOf(params.Raw) {
+		calibration = nil
+	}
 
-		c.Status(http.StatusSwitchingProtocols)
+	if err = h.ws.Handle(c, id, calibration); err != nil {
+		writeError(c, err)
+		return
 	}
+
+	c.Status(http.StatusSwitchingProtocols)
 }
 
 func checkMediaTypeMiddleWare(c *gin.Context) {
-	connectionHeader := c.GetHeader("Connection")
-	upgrade := c.GetHeader("Upgrade")
-
-	if (strings.Contains(connectionHeader, "Upgrade") && upgrade == "websocket") || c.FullPath() == "" {
+	if isWebSocketUpgrade(c) || c.FullPath() == "" {
 		c.Next()
 		return
 	}
 
+	// ответы с телом могут различаться по заголовку Accept
+	c.Header("Vary", "Accept")
+	mediaType := negotiate(c.GetHeader("Accept"), routeOffers(c.FullPath()))
 	switch c.Request.Method {
 	case "GET", "HEAD":
-		if c.GetHeader("Accept") != "application/json" {
-			c.AbortWithStatusJSON(http.StatusNotAcceptable, errors.New(contentTypeErrorMessage))
+		if mediaType == "" {
+			writeProblem(c, http.StatusNotAcceptable, openapi.NotAcceptable, acceptErrorMessage)
 			return
 		}
-	case "POST":
-		if c.ContentType() != "application/json" {
-			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, errors.New(contentTypeErrorMessage))
+	case "POST", "PUT", "PATCH":
+		if !consumable(c) {
+			writeProblem(c, http.StatusUnsupportedMediaType, openapi.UnsupportedMediaType, contentTypeErrorMessage)
 			return
 		}
 	}
+	// на изменяющие запросы без подходящего формата ответ отдаётся в JSON
+	c.Set(mediaTypeKey, mediaType)
 	c.Next()
 }
 
-func postSensor(uc UseCases) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var sensorToCreate model.SensorToCreate
-		if err := c.ShouldBindJSON(&sensorToCreate); err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
-		}
+func (h *apiHandler) RegisterSensor(c *gin.Context) {
+	var sensorToCreate openapi.SensorToCreate
+	if !bindJSON(c, &sensorToCreate) {
+		return
+	}
 
-		if err := sensorToCreate.Validate(strfmt.Default); err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
+	sensor := domain.Sensor{
+		Description:  sensorToCreate.Description,
+		IsActive:     sensorToCreate.IsActive,
+		SerialNumber: sensorToCreate.SerialNumber,
+		Type:         domain.SensorType(sensorToCreate.Type),
+	}
 
-		sensor := domain.Sensor{
-			Description:  *sensorToCreate.Description,
-			IsActive:     *sensorToCreate.IsActive,
-			SerialNumber: *sensorToCreate.SerialNumber,
-			Type:         domain.SensorType(*sensorToCreate.Type),
-		}
+	registeredSensor, err := h.uc.Sensor.RegisterSensor(c.Request.Context(), &sensor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, registeredSensor)
+}
+
+func (h *apiHandler) RegisterEvent(c *gin.Context) {
+	var event openapi.SensorEvent
+	if !bindJSON(c, &event) {
+		return
+	}
+
+	readings := valueOf(event.Readings)
+	if event.Payload == nil && len(readings) == 0 {
+		writeError(c, problem.Invalid(errors.New("payload or readings required")))
+		return
+	}
+
+	domainEvent := domain.Event{
+		SensorSerialNumber: event.SensorSerialNumber,
+		Readings:           readings,
+		CommandID:          valueOf(event.CommandId),
+	}
+	if event.Payload != nil {
+		domainEvent.Payload = *event.Payload
+	}
 
-		registeredSensor, err := uc.Sensor.RegisterSensor(c.Request.Context(), &sensor)
-		if err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
+	// при асинхронном приёме событие сохраняется после ответа и ещё не имеет ID
+	if h.uc.Ingestion != nil {
+		if err := h.uc.Ingestion.EnqueueEvent(c.Request.Context(), &domainEvent); err != nil {
+			writeError(c, err)
 			return
 		}
-		c.JSON(http.StatusOK, registeredSensor)
+		writeBody(c, http.StatusAccepted, &domainEvent)
+		return
+	}
+
+	err := h.uc.Event.ReceiveEvent(c.Request.Context(), &domainEvent)
+	if err != nil {
+		writeError(c, err)
+		return
 	}
+	// событие без ID отложено в буфер, пока хранилище недоступно
+	if domainEvent.ID == 0 {
+		writeBody(c, http.StatusAccepted, &domainEvent)
+		return
+	}
+	writeBody(c, http.StatusCreated, &domainEvent)
 }
 
-func receiveEventToSensor(uc UseCases) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		var event model.SensorEvent
-		if err := c.ShouldBindJSON(&event); err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
-		}
+func (h *apiHandler) CreateUser(c *gin.Context) {
+	var userToCreate openapi.UserToCreate
+	if !bindJSON(c, &userToCreate) {
+		return
+	}
 
-		if err := event.Validate(strfmt.Default); err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
+	user := domain.User{
+		Name: userToCreate.Name,
+	}
 
-		domainEvent := domain.Event{
-			Payload:            *event.Payload,
-			SensorSerialNumber: *event.SensorSerialNumber,
-		}
+	newUser, err := h.uc.User.RegisterUser(c.Request.Context(), &user)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, newUser)
+}
 
-		err := uc.Event.ReceiveEvent(c.Request.Context(), &domainEvent)
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		c.JSON(http.StatusCreated, &domainEvent)
+func (h *apiHandler) BindSensorToUser(c *gin.Context, id int64) {
+	_, err := h.uc.User.GetUserSensors(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	var sensor openapi.SensorToUserBinding
+	if !bindJSON(c, &sensor) {
+		return
+	}
+
+	err = h.uc.User.AttachSensorToUser(c.Request.Context(), id, sensor.SensorId)
+	if err != nil {
+		writeError(c, err)
+		return
 	}
+	writeBody(c, http.StatusCreated, sensor)
 }
 
-func postUser(uc UseCases) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var userToCreate model.UserToCreate
+func (h *apiHandler) GetEventsHistoryBySensorID(c *gin.Context, id int64, params openapi.GetEventsHistoryBySensorIDParams) {
+	start, end, err := dateRange(params.StartDate, params.EndDate)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	raw := valueOf(params.Raw)
+	sensor, err := h.uc.Sensor.GetSensorByID(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
 
-		if err := c.ShouldBindJSON(&userToCreate); err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
+	if mediaType := responseMediaType(c); mediaType == mediaTypeCSV || mediaType == mediaTypeNDJSON {
+		calibrations := map[int64]*domain.Calibration{}
+		if !raw {
+			calibrations[id] = sensor.Calibration
 		}
+		query := usecase.EventExportQuery{SensorIDs: []int64{id}, Start: start, End: end}
+		streamEvents(c, mediaType, "sensor-"+strconv.FormatInt(id, 10)+"-history", func(fn func(domain.Event) error) error {
+			return h.uc.Event.ExportEvents(c.Request.Context(), query, calibrate(calibrations, fn))
+		})
+		return
+	}
 
-		err := userToCreate.Validate(strfmt.Default)
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		user := domain.User{
-			Name: *userToCreate.Name,
-		}
+	query := usecase.EventQuery{SensorID: id, Start: start, End: end}
+	if query.Desc, err = descRequested(params.Order); err != nil {
+		writeError(c, err)
+		return
+	}
+	if query.Limit, err = limitRequested(params.Limit); err != nil {
+		writeError(c, err)
+		return
+	}
+	events, next, err := h.uc.Event.ListEvents(c.Request.Context(), query, valueOf(params.Cursor))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
 
-		newUser, err := uc.User.RegisterUser(c.Request.Context(), &user)
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
+	if events == nil {
+		events = []domain.Event{}
+	}
+	setNextCursor(c, next)
+	if !raw {
+		for i := range events {
+			events[i] = sensor.Calibration.CalibrateEvent(events[i])
 		}
-		c.JSON(http.StatusOK, newUser)
 	}
+	writeBody(c, http.StatusOK, events)
 }
 
-func postSensorToUser(uc UseCases) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		id, err := strconv.Atoi(c.Param("id"))
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		_, err = uc.User.GetUserSensors(c.Request.Context(), int64(id))
-		if err != nil {
-			setError(c, http.StatusNotFound, err.Error())
-			return
-		}
+func (h *apiHandler) GetUserSensors(c *gin.Context, id int64, params openapi.GetUserSensorsParams) {
+	h.userSensors(c, id, conditions{ifNoneMatch: params.IfNoneMatch}, false)
+}
 
-		var sensor model.SensorToUserBinding
-		if err = c.ShouldBindJSON(&sensor); err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
-		}
-		if err = sensor.Validate(strfmt.Default); err != nil {
-			setError(c, http.StatusUnprocessableEntity, "Sensor ID must be greater than zero")
-			return
-		}
+func (h *apiHandler) HeadUserSensors(c *gin.Context, id int64, params openapi.HeadUserSensorsParams) {
+	h.userSensors(c, id, conditions{ifNoneMatch: params.IfNoneMatch}, true)
+}
 
-		err = uc.User.AttachSensorToUser(c.Request.Context(), int64(id), *sensor.SensorID)
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		c.JSON(http.StatusCreated, sensor)
+func (h *apiHandler) userSensors(c *gin.Context, id int64, cond conditions, head bool) {
+	sensors, err := h.uc.User.GetUserSensors(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
 	}
+	writeConditional(c, sensors, time.Time{}, cond, head)
 }
 
-func getSensorHistory(uc UseCases) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		startDate := c.DefaultQuery("start_date", "")
-		endDate := c.DefaultQuery("end_date", "")
-		if startDate == "" || endDate == "" {
-			setError(c, http.StatusBadRequest, "Start date or end date required")
-			return
-		}
-		_, err = uc.Sensor.GetSensorByID(c.Request.Context(), id)
-		if err != nil {
-			setError(c, http.StatusNotFound, err.Error())
-			return
-		}
+func setHeaderOptions(c *gin.Context, methods string) {
+	c.Header("Allow", methods)
+	c.Status(http.StatusNoContent)
+}
 
-		layout := "2006-01-02T15:04:05"
-		start, err := time.Parse(layout, startDate)
-		if err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
-		}
-		end, err := time.Parse(layout, endDate)
-		if err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
-		}
-		events, err := uc.Event.GetEventsBySensorIDWithDate(c.Request.Context(), id, start, end)
-		if errors.Is(err, postgres.ErrEventNotFound) {
-			c.JSON(http.StatusOK, gin.H{})
-			return
-		} else if err != nil {
-			setError(c, http.StatusBadRequest, err.Error())
-			return
-		}
+func (h *apiHandler) GetSensor(c *gin.Context, id int64, params openapi.GetSensorParams) {
+	h.sensorByID(c, id, valueOf(params.Raw), conditions{ifNoneMatch: params.IfNoneMatch, ifModifiedSince: params.IfModifiedSince}, false)
+}
 
-		c.JSON(http.StatusOK, events)
+func (h *apiHandler) HeadSensor(c *gin.Context, id int64, params openapi.HeadSensorParams) {
+	h.sensorByID(c, id, valueOf(params.Raw), conditions{ifNoneMatch: params.IfNoneMatch, ifModifiedSince: params.IfModifiedSince}, true)
+}
+
+func (h *apiHandler) sensorByID(c *gin.Context, id int64, raw bool, cond conditions, head bool) {
+	sensor, err := h.uc.Sensor.GetSensorByID(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if !raw {
+		*sensor = sensor.Calibrated()
 	}
+	writeConditional(c, sensor, sensor.UpdatedAt, cond, head)
 }
 
-func getUserSensors(uc UseCases, head bool) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		sensors := getErrorOfUserOfSensorID(c, uc, c.GetHeader("Accept"))
-		if sensors == nil {
-			return
-		}
-		if head {
-			c.Header("Content-Length", strconv.Itoa(len(sensors)))
+func (h *apiHandler) GetSensors(c *gin.Context, params openapi.GetSensorsParams) {
+	h.sensors(c, params, false)
+}
+
+func (h *apiHandler) HeadSensors(c *gin.Context, params openapi.HeadSensorsParams) {
+	h.sensors(c, openapi.GetSensorsParams(params), true)
+}
+
+func (h *apiHandler) sensors(c *gin.Context, params openapi.GetSensorsParams, head bool) {
+	query, err := sensorQuery(params)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	sensors, next, err := h.uc.Sensor.ListSensors(c.Request.Context(), query, valueOf(params.Cursor))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if sensors == nil {
+		sensors = []domain.Sensor{}
+	}
+	setNextCursor(c, next)
+	if !valueOf(params.Raw) {
+		for i := range sensors {
+			sensors[i] = sensors[i].Calibrated()
 		}
-		c.JSON(http.StatusOK, sensors)
 	}
+	writeConditional(c, sensors, time.Time{}, conditions{ifNoneMatch: params.IfNoneMatch}, head)
 }
 
-func setHeaderOptions(methods string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("Allow", methods)
-		c.Status(http.StatusNoContent)
+func (h *apiHandler) SetSensorCalibration(c *gin.Context, id int64, params openapi.SetSensorCalibrationParams) {
+	var calibration openapi.Calibration
+	if !bindJSON(c, &calibration) {
+		return
 	}
-}
 
-func getSensorByID(uc UseCases, head bool) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		id, err := strconv.Atoi(c.Param("id"))
-		if err != nil {
-			setError(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
+	domainCalibration := &domain.Calibration{
+		Offset: valueOf(calibration.Offset),
+		Scale:  valueOf(calibration.Scale),
+		Unit:   valueOf(calibration.Unit),
+	}
+	for _, point := range valueOf(calibration.Table) {
+		domainCalibration.Table = append(domainCalibration.Table, domain.CalibrationPoint{
+			Raw:   point.Raw,
+			Value: point.Value,
+		})
+	}
 
-		sensor, err := uc.Sensor.GetSensorByID(c.Request.Context(), int64(id))
-		if err != nil {
-			setError(c, http.StatusNotFound, err.Error())
-			return
-		}
-		if head {
-			c.Header("Content-Length", strconv.Itoa(len(sensor.SerialNumber)))
-		}
-		c.JSON(http.StatusOK, sensor)
+	sensor, ok := h.setSensorCalibration(c, id, domainCalibration, ifMatch(params.IfMatch))
+	if !ok {
+		return
 	}
+	writeBody(c, http.StatusOK, sensor.Calibrated())
 }
 
-func getSensors(uc UseCases, head bool) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		sensors, _ := uc.Sensor.GetSensors(c.Request.Context())
-		if head {
-			c.Header("Content-Length", strconv.Itoa(len(sensors)))
-		}
-		c.JSON(http.StatusOK, sensors)
+func (h *apiHandler) DeleteSensorCalibration(c *gin.Context, id int64, params openapi.DeleteSensorCalibrationParams) {
+	if _, ok := h.setSensorCalibration(c, id, nil, ifMatch(params.IfMatch)); !ok {
+		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
-func getErrorOfUserOfSensorID(c *gin.Context, uc UseCases, header string) []domain.Sensor {
-	id, err := strconv.Atoi(c.Param("id"))
+// setSensorCalibration - устанавливает калибровку датчика, если он удовлетворяет condition, false - ошибка уже записана в ответ
+func (h *apiHandler) setSensorCalibration(c *gin.Context, id int64, calibration *domain.Calibration, condition usecase.SensorCondition) (*domain.Sensor, bool) {
+	sensor, err := h.uc.Sensor.SetCalibration(c.Request.Context(), id, calibration, condition)
 	if err != nil {
-		setError(c, http.StatusUnprocessableEntity, err.Error())
-		return nil
+		writeError(c, err)
+		return nil, false
 	}
-	if header != "application/json" {
-		setError(c, http.StatusNotAcceptable, contentTypeErrorMessage)
-		return nil
+	return sensor, true
+}
+
+// valueOf - значение необязательного параметра, для непереданного - нулевое значение типа
+func valueOf[T any](param *T) T {
+	if param == nil {
+		var zero T
+		return zero
 	}
+	return *param
+}
 
-	sensors, err := uc.User.GetUserSensors(c.Request.Context(), int64(id))
-	if err != nil {
-		setError(c, http.StatusNotFound, err.Error())
-		return nil
+// dateRange - разбирает параметры start_date и end_date, время в UTC без часового пояса
+func dateRange(startDate, endDate string) (start, end time.Time, err error) {
+	const layout = "2006-01-02T15:04:05"
+	if start, err = time.Parse(layout, startDate); err != nil {
+		return start, end, problem.Malformed(err)
+	}
+	if end, err = time.Parse(layout, endDate); err != nil {
+		return start, end, problem.Malformed(err)
+	}
+	return start, end, nil
+}
+
+// sensorQuery - собирает из параметров сортировку, фильтр и размер страницы списка датчиков
+func sensorQuery(params openapi.GetSensorsParams) (usecase.SensorQuery, error) {
+	query := usecase.SensorQuery{
+		SortBy: usecase.SensorSortField(valueOf(params.Sort)),
+		Filter: usecase.SensorFilter{
+			Type:               domain.SensorType(valueOf(params.Type)),
+			SerialNumberPrefix: valueOf(params.SerialPrefix),
+			IsActive:           params.IsActive,
+			LastActivityFrom:   valueOf(params.LastActivityFrom),
+			LastActivityTo:     valueOf(params.LastActivityTo),
+		},
+	}
+	var err error
+	if query.Desc, err = descRequested(params.Order); err != nil {
+		return query, err
+	}
+	if query.Limit, err = limitRequested(params.Limit); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// limitRequested - размер страницы из параметра limit, 0 - размер по умолчанию
+func limitRequested(limit *int) (int, error) {
+	if limit == nil {
+		return 0, nil
+	}
+	if *limit <= 0 {
+		return 0, usecase.ErrInvalidLimit
+	}
+	return *limit, nil
+}
+
+// descRequested - проверяет, запрошена ли сортировка по убыванию (параметр order)
+func descRequested(order *openapi.SortOrder) (bool, error) {
+	switch valueOf(order) {
+	case "", openapi.Asc:
+		return false, nil
+	case openapi.Desc:
+		return true, nil
 	}
-	return sensors
+	return false, problem.Malformed(errors.New("order must be 'asc' or 'desc'"))
 }
 
-func setError(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, model.Error{Reason: &message})
+// setNextCursor - передаёт курсор следующей страницы в заголовке, на последней странице заголовка нет
+func setNextCursor(c *gin.Context, cursor string) {
+	if cursor != "" {
+		c.Header(nextCursorHeader, cursor)
+	}
+}
+
+func (h *apiHandler) GetSensorTypes(c *gin.Context) {
+	types, err := h.uc.Sensor.GetSensorTypes(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, types)
 }
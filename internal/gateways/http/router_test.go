@@ -3,10 +3,13 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"homework/internal/domain"
 	"homework/internal/usecase"
 	"homework/pkg/pg_test"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
@@ -33,6 +36,9 @@ var useCases = UseCases{
 
 var router = gin.Default()
 
+// specErrors - расхождения обмена router со спецификацией, проверяются после всех тестов
+var specErrors = &collectedErrors{}
+
 func init() {
 	testDB := pg_test.SetupTestDatabase()
 	testDbInstance := testDB.DbInstance
@@ -42,9 +48,23 @@ func init() {
 	*ur = *userRepository.NewUserRepository(testDbInstance)
 	*sor = *userRepository.NewSensorOwnerRepository(testDbInstance)
 
+	validator, err := specValidator(specErrors)
+	if err != nil {
+		panic(err)
+	}
+	router.Use(validator)
 	setupRouter(router, useCases, NewWebSocketHandler(useCases))
 }
 
+func TestMain(m *testing.M) {
+	code := m.Run()
+	for _, err := range specErrors.errors {
+		fmt.Println(err)
+		code = 1
+	}
+	os.Exit(code)
+}
+
 // Все неизвестные пути должны возвращать http.StatusNotFound.
 func TestUnknownRoute(t *testing.T) {
 	tests := []struct {
@@ -196,6 +216,45 @@ func TestSensorsRoutes(t *testing.T) {
 
 			assert.Equal(t, http.StatusNotAcceptable, w.Code, "Получили в ответ не тот код")
 		})
+
+		t.Run("filters_and_pages_200", func(t *testing.T) {
+			for _, query := range []string{
+				"?limit=1",
+				"?sort=serial_number&order=desc",
+				"?sort=last_activity&last_activity_from=2000-01-01T00:00:00Z&last_activity_to=2100-01-01T00:00:00Z",
+				"?type=cc&is_active=false&serial_prefix=01",
+			} {
+				w := httptest.NewRecorder()
+
+				req, _ := http.NewRequest(http.MethodGet, "/sensors"+query, nil)
+				req.Header.Add("Accept", "application/json")
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusOK, w.Code, "Получили в ответ не тот код: %s", query)
+				assert.True(t, json.Valid(w.Body.Bytes()), "В ответе не json")
+			}
+		})
+
+		t.Run("invalid_page_params_400", func(t *testing.T) {
+			for _, query := range []string{
+				"?limit=0",
+				"?limit=1001",
+				"?sort=type",
+				"?order=up",
+				"?cursor=garbage",
+				"?is_active=maybe",
+				"?last_activity_from=yesterday",
+				"?last_activity_from=2001-01-01T00:00:00Z&last_activity_to=2000-01-01T00:00:00Z",
+			} {
+				w := httptest.NewRecorder()
+
+				req, _ := http.NewRequest(http.MethodGet, "/sensors"+query, nil)
+				req.Header.Add("Accept", "application/json")
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code, "Получили в ответ не тот код: %s", query)
+			}
+		})
 	})
 
 	t.Run("HEAD_sensors", func(t *testing.T) {
@@ -447,6 +506,45 @@ func TestSensorsRoutes(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("POST_sensors_unknown_type_422", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		body := `{
+			"serial_number": "1234567899",
+			"type": "thermometer",
+			"description": "Датчик температуры",
+			"is_active": true
+		}`
+		req, _ := http.NewRequest(http.MethodPost, "/sensors", bytes.NewReader([]byte(body)))
+		req.Header.Add("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "Получили в ответ не тот код")
+	})
+
+	t.Run("GET_sensor_types_200", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodGet, "/sensor-types", nil)
+		req.Header.Add("Accept", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Получили в ответ не тот код")
+		var types []map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &types), "В ответе не список типов")
+		assert.NotEmpty(t, types)
+	})
+
+	t.Run("OPTIONS_sensor_types_204", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodOptions, "/sensor-types", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code, "Получили в ответ не тот код")
+		allowed := strings.Split(w.Header().Get("Allow"), ",")
+		assert.Contains(t, allowed, http.MethodGet, "В разрешённых методах нет GET")
+	})
 }
 
 // Тесты /users/{user_id}/sensors
@@ -651,6 +749,21 @@ func TestEventsRoutes(t *testing.T) {
 		t.Run("valid_request_201", func(t *testing.T) {
 			w := httptest.NewRecorder()
 
+			// датчик 1234567890 имеет тип cc, поэтому payload - 0 или 1
+			body := `{
+				"sensor_serial_number": "1234567890",
+				"payload": 1
+			}`
+			req, _ := http.NewRequest(http.MethodPost, "/events", bytes.NewReader([]byte(body)))
+			req.Header.Add("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code, "Получили в ответ не тот код")
+		})
+
+		t.Run("payload_out_of_range_422", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
 			body := `{
 				"sensor_serial_number": "1234567890",
 				"payload": 10
@@ -659,9 +772,36 @@ func TestEventsRoutes(t *testing.T) {
 			req.Header.Add("Content-Type", "application/json")
 			router.ServeHTTP(w, req)
 
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "Получили в ответ не тот код")
+		})
+
+		t.Run("readings_without_payload_201", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			body := `{
+				"sensor_serial_number": "1234567890",
+				"readings": {"temperature": 21.5, "humidity": 40}
+			}`
+			req, _ := http.NewRequest(http.MethodPost, "/events", bytes.NewReader([]byte(body)))
+			req.Header.Add("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
 			assert.Equal(t, http.StatusCreated, w.Code, "Получили в ответ не тот код")
 		})
 
+		t.Run("no_payload_and_no_readings_422", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			body := `{
+				"sensor_serial_number": "1234567890"
+			}`
+			req, _ := http.NewRequest(http.MethodPost, "/events", bytes.NewReader([]byte(body)))
+			req.Header.Add("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "Получили в ответ не тот код")
+		})
+
 		t.Run("request_body_has_unsupported_format_415", func(t *testing.T) {
 			w := httptest.NewRecorder()
 
@@ -758,6 +898,9 @@ func TestGetSensorEventsHistory(t *testing.T) {
 			{"bad_sensor_id", "/a/history?" + validDate, http.StatusUnprocessableEntity},
 			{"sensor_not_found", "/0/history?" + validDate, http.StatusNotFound},
 			{"right_format_but_incorrect_order", "/1/history?" + reverseDate, http.StatusBadRequest},
+			{"limit_and_order", "/1/history?" + validDate + "&limit=10&order=desc", http.StatusOK},
+			{"invalid_limit", "/1/history?" + validDate + "&limit=-1", http.StatusBadRequest},
+			{"invalid_cursor", "/1/history?" + validDate + "&cursor=garbage", http.StatusBadRequest},
 		}
 
 		for _, tt := range table {
@@ -772,3 +915,73 @@ func TestGetSensorEventsHistory(t *testing.T) {
 		}
 	})
 }
+
+// Тесты /sensors/:id/calibration
+func TestSensorCalibrationRoutes(t *testing.T) {
+	t.Run("PUT_calibration_200", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		body := `{"offset": -40, "scale": 0.5, "unit": "°C"}`
+		req, _ := http.NewRequest(http.MethodPut, "/sensors/1/calibration", bytes.NewReader([]byte(body)))
+		req.Header.Add("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Получили в ответ не тот код")
+		assert.Contains(t, w.Body.String(), `"unit":"°C"`)
+	})
+
+	t.Run("GET_sensor_raw_200", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodGet, "/sensors/1?raw=true", nil)
+		req.Header.Add("Accept", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Получили в ответ не тот код")
+		// единица измерения есть у калибровки, но не у сырого значения датчика
+		var sensor domain.Sensor
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &sensor))
+		assert.Empty(t, sensor.Unit)
+	})
+
+	t.Run("GET_sensor_raw_invalid_400", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodGet, "/sensors/1?raw=maybe", nil)
+		req.Header.Add("Accept", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Получили в ответ не тот код")
+	})
+
+	t.Run("PUT_calibration_invalid_422", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		body := `{"table": [{"raw": 0, "value": 1}]}`
+		req, _ := http.NewRequest(http.MethodPut, "/sensors/1/calibration", bytes.NewReader([]byte(body)))
+		req.Header.Add("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "Получили в ответ не тот код")
+	})
+
+	t.Run("PUT_calibration_unknown_sensor_404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		body := `{"offset": 1, "scale": 1}`
+		req, _ := http.NewRequest(http.MethodPut, "/sensors/100500/calibration", bytes.NewReader([]byte(body)))
+		req.Header.Add("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, "Получили в ответ не тот код")
+	})
+
+	t.Run("DELETE_calibration_204", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/sensors/1/calibration", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code, "Получили в ответ не тот код")
+	})
+}
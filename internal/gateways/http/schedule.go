@@ -0,0 +1,111 @@
+package http
+
+import (
+	"homework/api/openapi"
+	"homework/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *apiHandler) SchedulesOptions(c *gin.Context, _ int64) {
+	setHeaderOptions(c, "GET,POST,OPTIONS")
+}
+
+func (h *apiHandler) ScheduleOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "GET,PATCH,DELETE,OPTIONS")
+}
+
+func (h *apiHandler) ScheduleRunsOptions(c *gin.Context, _, _ int64) {
+	setHeaderOptions(c, "GET,OPTIONS")
+}
+
+func (h *apiHandler) GetSchedules(c *gin.Context, userID int64) {
+	schedules, err := h.uc.Scheduler.GetUserSchedules(c.Request.Context(), userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, schedules)
+}
+
+func (h *apiHandler) CreateSchedule(c *gin.Context, userID int64) {
+	var toCreate openapi.ScheduleToCreate
+	if !bindJSON(c, &toCreate) {
+		return
+	}
+
+	action := toCreate.Action
+	schedule := &domain.Schedule{
+		UserID:   userID,
+		Name:     toCreate.Name,
+		Cron:     valueOf(toCreate.Cron),
+		At:       toCreate.At,
+		Location: valueOf(toCreate.Location),
+		// расписание без enabled сразу включено
+		Enabled: toCreate.Enabled == nil || *toCreate.Enabled,
+		Action: domain.ScheduleAction{
+			Kind:     domain.ScheduleActionKind(action.Kind),
+			SensorID: valueOf(action.SensorId),
+			Payload:  action.Payload,
+			URL:      valueOf(action.Url),
+		},
+	}
+	if action.AlertRule != nil {
+		schedule.Action.AlertRule = alertRule(*action.AlertRule)
+	}
+
+	err := h.uc.Scheduler.CreateSchedule(c.Request.Context(), schedule)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusCreated, schedule)
+}
+
+func (h *apiHandler) GetSchedule(c *gin.Context, userID, scheduleID int64) {
+	schedule, err := h.uc.Scheduler.GetSchedule(c.Request.Context(), userID, scheduleID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, schedule)
+}
+
+func (h *apiHandler) PatchSchedule(c *gin.Context, userID, scheduleID int64) {
+	var update openapi.ScheduleUpdate
+	if !bindJSON(c, &update) {
+		return
+	}
+
+	schedule, err := h.uc.Scheduler.SetScheduleEnabled(c.Request.Context(), userID, scheduleID, update.Enabled)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, schedule)
+}
+
+func (h *apiHandler) DeleteSchedule(c *gin.Context, userID, scheduleID int64) {
+	err := h.uc.Scheduler.DeleteSchedule(c.Request.Context(), userID, scheduleID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *apiHandler) GetScheduleRuns(c *gin.Context, userID, scheduleID int64, params openapi.GetScheduleRunsParams) {
+	limit, err := limitRequested(params.Limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	runs, err := h.uc.Scheduler.GetScheduleRuns(c.Request.Context(), userID, scheduleID, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeBody(c, http.StatusOK, runs)
+}
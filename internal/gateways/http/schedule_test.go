@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	srMock := usecase.NewMockSensorRepository(ctrl)
+	srMock.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.Sensor{ID: 1, Type: domain.SensorTypeRelay}, nil)
+	urMock := usecase.NewMockUserRepository(ctrl)
+	urMock.EXPECT().GetUserByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.User{ID: 1, Name: "owner"}, nil)
+	urMock.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, usecase.ErrUserNotFound)
+	sorMock := usecase.NewMockSensorOwnerRepository(ctrl)
+	sorMock.EXPECT().GetSensorsByUserID(gomock.Any(), int64(1)).AnyTimes().Return([]domain.SensorOwner{{UserID: 1, SensorID: 1}}, nil)
+	scheduleMock := usecase.NewMockScheduleRepository(ctrl)
+
+	uc := UseCases{
+		Scheduler: usecase.NewScheduler(scheduleMock, urMock, sorMock,
+			usecase.WithSchedulerCommands(usecase.NewCommand(usecase.NewMockCommandRepository(ctrl), srMock))),
+	}
+	engine := newTestRouter(t, uc)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Accept", "application/json")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("create_201", func(t *testing.T) {
+		scheduleMock.EXPECT().SaveSchedule(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, schedule *domain.Schedule) error {
+			schedule.ID = 3
+			return nil
+		})
+
+		w := serve(http.MethodPost, "/users/1/schedules",
+			`{"name":"night mode","cron":"0 23 * * 1-5","location":"Europe/Moscow","action":{"kind":"command","sensor_id":1,"payload":0}}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var schedule domain.Schedule
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &schedule))
+		assert.Equal(t, int64(3), schedule.ID)
+		assert.True(t, schedule.Enabled)
+		assert.NotNil(t, schedule.NextRunAt)
+	})
+
+	t.Run("create_422", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"no action":         `{"name":"x","cron":"* * * * *"}`,
+			"unknown kind":      `{"name":"x","cron":"* * * * *","action":{"kind":"reboot"}}`,
+			"invalid cron":      `{"name":"x","cron":"0 25 * * *","action":{"kind":"command","sensor_id":1,"payload":0}}`,
+			"payload too large": `{"name":"x","cron":"* * * * *","action":{"kind":"command","sensor_id":1,"payload":5}}`,
+		} {
+			w := serve(http.MethodPost, "/users/1/schedules", body)
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, name)
+		}
+	})
+
+	t.Run("create_404", func(t *testing.T) {
+		w := serve(http.MethodPost, "/users/9/schedules", `{"name":"x","cron":"* * * * *","action":{"kind":"command","sensor_id":1,"payload":0}}`)
+		assertProblem(t, w, http.StatusNotFound, "user_not_found")
+
+		// датчик другого пользователя для расписания не существует
+		w = serve(http.MethodPost, "/users/1/schedules", `{"name":"x","cron":"* * * * *","action":{"kind":"command","sensor_id":2,"payload":0}}`)
+		assertProblem(t, w, http.StatusNotFound, "sensor_not_found")
+	})
+
+	t.Run("patch_200", func(t *testing.T) {
+		scheduleMock.EXPECT().GetScheduleByID(gomock.Any(), int64(3)).Times(1).Return(&domain.Schedule{ID: 3, UserID: 1, Name: "night mode", Cron: "0 23 * * *", Enabled: true,
+			Action: domain.ScheduleAction{Kind: domain.ScheduleActionWebhook, URL: "http://localhost/hook"}}, nil)
+		scheduleMock.EXPECT().SaveSchedule(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		w := serve(http.MethodPatch, "/users/1/schedules/3", `{"enabled":false}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var schedule domain.Schedule
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &schedule))
+		assert.False(t, schedule.Enabled)
+		assert.Nil(t, schedule.NextRunAt)
+
+		w = serve(http.MethodPatch, "/users/1/schedules/3", `{}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("get_404", func(t *testing.T) {
+		scheduleMock.EXPECT().GetScheduleByID(gomock.Any(), int64(4)).Times(1).Return(&domain.Schedule{ID: 4, UserID: 2}, nil)
+
+		w := serve(http.MethodGet, "/users/1/schedules/4", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("runs_200", func(t *testing.T) {
+		scheduleMock.EXPECT().GetScheduleByID(gomock.Any(), int64(3)).Times(1).Return(&domain.Schedule{ID: 3, UserID: 1}, nil)
+		scheduleMock.EXPECT().ListRuns(gomock.Any(), int64(3), 2).Times(1).Return([]domain.ScheduleRun{
+			{ID: 1, ScheduleID: 3, Status: domain.ScheduleRunFailed, Error: "device is offline"},
+		}, nil)
+
+		w := serve(http.MethodGet, "/users/1/schedules/3/runs?limit=2", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var runs []domain.ScheduleRun
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &runs))
+		require.Len(t, runs, 1)
+		assert.Equal(t, domain.ScheduleRunFailed, runs[0].Status)
+	})
+
+	t.Run("delete_204", func(t *testing.T) {
+		scheduleMock.EXPECT().GetScheduleByID(gomock.Any(), int64(3)).Times(1).Return(&domain.Schedule{ID: 3, UserID: 1}, nil)
+		scheduleMock.EXPECT().DeleteSchedule(gomock.Any(), int64(3)).Times(1).Return(nil)
+
+		w := serve(http.MethodDelete, "/users/1/schedules/3", "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
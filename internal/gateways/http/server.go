@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"homework/internal/usecase"
 	"log"
@@ -13,16 +14,33 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownTimeout - сколько ждать завершения начатых запросов при остановке
+const shutdownTimeout = 2 * time.Second
+
 type Server struct {
 	host   string
 	port   uint16
 	router *gin.Engine
+	// trustedProxies - адреса и подсети прокси, которым верят заголовки X-Forwarded-For и X-Real-IP
+	trustedProxies []string
 }
 
 type UseCases struct {
-	Event  *usecase.Event
-	Sensor *usecase.Sensor
-	User   *usecase.User
+	Event        *usecase.Event
+	Sensor       *usecase.Sensor
+	User         *usecase.User
+	Alert        *usecase.Alert
+	Notification *usecase.Notification
+	Command      *usecase.Command
+	Scheduler    *usecase.Scheduler
+	// RateLimiter - ограничение запросов по адресу клиента и токену API, nil - без ограничений
+	RateLimiter *usecase.RateLimiter
+	// Ingestion - асинхронный приём событий, nil - события сохраняются до ответа
+	Ingestion *usecase.Ingestion
+	// EventBuffer - буфер событий на время недоступности хранилища, nil - без буфера; нужен только для метрик
+	EventBuffer *usecase.EventBuffer
+	// Outbox - поток изменений для GET /changes и метрик доставки в приёмники
+	Outbox *usecase.Outbox
 }
 
 func NewServer(useCases UseCases, options ...func(*Server)) *Server {
@@ -33,10 +51,24 @@ func NewServer(useCases UseCases, options ...func(*Server)) *Server {
 	for _, o := range options {
 		o(s)
 	}
+	// без доверенных прокси адрес клиента - адрес соединения: иначе клиент обходит ограничение по адресу,
+	// меняя X-Forwarded-For
+	if err := r.SetTrustedProxies(s.trustedProxies); err != nil {
+		log.Printf("invalid trusted proxies, trusting none: %v", err)
+		_ = r.SetTrustedProxies(nil)
+	}
 
 	return s
 }
 
+// WithTrustedProxies - адреса и подсети CIDR обратных прокси, от которых адрес клиента берётся
+// из X-Forwarded-For и X-Real-IP. Без них заголовки не учитываются
+func WithTrustedProxies(proxies []string) func(*Server) {
+	return func(s *Server) {
+		s.trustedProxies = proxies
+	}
+}
+
 func WithHost(host string) func(*Server) {
 	return func(s *Server) {
 		s.host = host
@@ -49,6 +81,7 @@ func WithPort(port uint16) func(*Server) {
 	}
 }
 
+// Run - принимает запросы, пока не отменён ctx, после чего дожидается завершения начатых запросов
 func (s *Server) Run(ctx context.Context) error {
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", s.host, s.port),
@@ -56,14 +89,16 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
-		return server.ListenAndServe()
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
 	})
-	_ = eg.Wait()
-
-	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, time.Second*2)
-	defer shutdownCancel()
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("server shutdown error: %v", err)
-	}
-	return s.router.Run(fmt.Sprintf("%s:%d", s.host, s.port))
+	eg.Go(func() error {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer shutdownCancel()
+		return server.Shutdown(shutdownCtx)
+	})
+	return eg.Wait()
 }
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewServer(UseCases{}, WithHost("127.0.0.1"), WithPort(0))
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- s.Run(ctx)
+	}()
+
+	cancel()
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop after ctx was canceled")
+	}
+}
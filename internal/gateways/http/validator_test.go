@@ -0,0 +1,175 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"homework/api/openapi"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// в сообщениях о расхождениях достаточно пути до поля и причины, без текста схемы
+	openapi3.SchemaErrorDetailsDisabled = true
+	openapi3filter.RegisterBodyDecoder(mediaTypeCSV, openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder(mediaTypeNDJSON, ndjsonBodyDecoder)
+	for mediaType := range binaryHandles {
+		openapi3filter.RegisterBodyDecoder(mediaType, binaryBodyDecoder(mediaType))
+	}
+}
+
+// binaryBodyDecoder - тело в CBOR или MessagePack проверяется по схеме так же, как то же тело в JSON
+func binaryBodyDecoder(mediaType string) openapi3filter.BodyDecoder {
+	return func(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (any, error) {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = decodeBody(mediaType, data); err != nil {
+			return nil, err
+		}
+		var value any
+		if err = json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// ndjsonBodyDecoder - тело в NDJSON проверяется как массив из объектов строк, тело импорта - как строка
+func ndjsonBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, fn openapi3filter.EncodingFn) (any, error) {
+	if schema.Value.Type.Is(openapi3.TypeString) {
+		return openapi3filter.FileBodyDecoder(body, header, schema, fn)
+	}
+	var values []any
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		var value any
+		if err := json.Unmarshal(scanner.Bytes(), &value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, scanner.Err()
+}
+
+// errorReporter - получатель расхождений со спецификацией, например *testing.T
+type errorReporter interface {
+	Errorf(format string, args ...any)
+}
+
+// collectedErrors - расхождения, собранные роутером без доступа к *testing.T
+type collectedErrors struct {
+	mutex  sync.Mutex
+	errors []string
+}
+
+func (e *collectedErrors) Errorf(format string, args ...any) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.errors = append(e.errors, fmt.Sprintf(format, args...))
+}
+
+// newTestRouter - роутер с обработчиками сервиса, который проверяет обмен в тестах по api/openapi.yaml
+func newTestRouter(t *testing.T, uc UseCases) *gin.Engine {
+	validator, err := specValidator(t)
+	require.NoError(t, err)
+
+	engine := gin.New()
+	engine.Use(validator)
+	setupRouter(engine, uc, NewWebSocketHandler(uc))
+	return engine
+}
+
+// specValidator - проверяет запросы и ответы по спецификации: на запрос, который ей не соответствует, сервис
+// должен ответить 4xx, ответ на остальные запросы должен соответствовать спецификации.
+// Неизвестные спецификации пути и WebSocket не проверяются
+func specValidator(reporter errorReporter) (gin.HandlerFunc, error) {
+	spec, err := openapi.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	// пути в тестах относительные, сервер из спецификации не должен участвовать в поиске маршрута
+	spec.Servers = nil
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil || isWebSocketUpgrade(c) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			if body, err = io.ReadAll(c.Request.Body); err != nil {
+				reporter.Errorf("%s %s: can't read request body: %v", c.Request.Method, c.Request.URL, err)
+				return
+			}
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		request := c.Request.Clone(c.Request.Context())
+		request.Body = io.NopCloser(bytes.NewReader(body))
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{IncludeResponseStatus: true},
+		}
+		requestErr := openapi3filter.ValidateRequest(c.Request.Context(), input)
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+		operation := fmt.Sprintf("%s %s -> %d", c.Request.Method, c.Request.URL, status)
+		if requestErr != nil {
+			if status < http.StatusBadRequest || status >= http.StatusInternalServerError {
+				reporter.Errorf("%s: request doesn't match the spec but the response isn't 4xx: %v", operation, requestErr)
+			}
+			return
+		}
+
+		err = openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 status,
+			Header:                 writer.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+			Options:                input.Options,
+		})
+		if err != nil {
+			reporter.Errorf("%s: response doesn't match the spec: %v", operation, err)
+		}
+	}, nil
+}
+
+// recordingWriter - передаёт ответ клиенту и сохраняет копию тела для проверки
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
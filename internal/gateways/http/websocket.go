@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,7 +27,8 @@ func NewWebSocketHandler(useCases UseCases) *WebSocketHandler {
 	}
 }
 
-func (h *WebSocketHandler) Handle(c *gin.Context, id int64) (err error) {
+// Handle - рассылает в ws последние события датчика id, откалиброванные calibration (nil - сырые значения)
+func (h *WebSocketHandler) Handle(c *gin.Context, id int64, calibration *domain.Calibration) (err error) {
 	w, r := c.Writer, c.Request
 	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{})
 	if err != nil {
@@ -64,6 +67,8 @@ func (h *WebSocketHandler) Handle(c *gin.Context, id int64) (err error) {
 				if err != nil {
 					continue
 				}
+				calibrated := calibration.CalibrateEvent(*events)
+				events = &calibrated
 				err = marshallAndWrite(ctx, events, conn)
 				if err != nil {
 					log.Println(err)
@@ -76,6 +81,11 @@ func (h *WebSocketHandler) Handle(c *gin.Context, id int64) (err error) {
 	return nil
 }
 
+// isWebSocketUpgrade - проверяет, что клиент просит перейти на WebSocket
+func isWebSocketUpgrade(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Connection"), "Upgrade") && c.GetHeader("Upgrade") == "websocket"
+}
+
 func marshallAndWrite(ctx context.Context, events *domain.Event, conn *websocket.Conn) error {
 	jsonEvent, err := json.Marshal(events)
 	if err != nil {
@@ -121,3 +131,89 @@ func (h *WebSocketHandler) Shutdown() error {
 	}
 	return nil
 }
+
+// alertConnection - ключ подключения подписчика тревог пользователя среди connections
+type alertConnection int64
+
+// HandleAlerts - рассылает в ws тревоги датчиков пользователя userID по мере их появления и изменения
+func (h *WebSocketHandler) HandleAlerts(c *gin.Context, userID int64) error {
+	alerts, err := h.useCases.Alert.SubscribeAlerts(c.Request.Context(), userID)
+	if err != nil {
+		return err
+	}
+	conn, err := websocket.Accept(c.Writer, c.Request, &websocket.AcceptOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(websocket.StatusNormalClosure, "closed"); err != nil {
+			log.Printf("failed to close websocket connection: %v", err)
+		}
+	}()
+	h.connections.Store(alertConnection(userID), conn)
+	defer h.connections.Delete(alertConnection(userID))
+
+	// клиент ничего не присылает, CloseRead отменяет ctx, когда он закрывает соединение
+	ctx := conn.CloseRead(c.Request.Context())
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case alert, ok := <-alerts:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(alert)
+			if err != nil {
+				return err
+			}
+			if err = conn.Write(ctx, websocket.MessageText, data); err != nil {
+				log.Println(err)
+				return nil
+			}
+		}
+	}
+}
+
+// commandConnection - ключ подключения исполнительного устройства среди connections
+type commandConnection int64
+
+// HandleCommands - передаёт в ws команды устройства sensorID по мере их появления, каждая команда считается доставленной
+// в момент отправки. Подтверждения устройство присылает событиями с command_id
+func (h *WebSocketHandler) HandleCommands(c *gin.Context, sensorID int64) error {
+	if _, err := h.useCases.Sensor.GetSensorByID(c.Request.Context(), sensorID); err != nil {
+		return err
+	}
+	conn, err := websocket.Accept(c.Writer, c.Request, &websocket.AcceptOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(websocket.StatusNormalClosure, "closed"); err != nil {
+			log.Printf("failed to close websocket connection: %v", err)
+		}
+	}()
+	h.connections.Store(commandConnection(sensorID), conn)
+	defer h.connections.Delete(commandConnection(sensorID))
+
+	ctx := conn.CloseRead(c.Request.Context())
+	for {
+		command, err := h.useCases.Command.NextCommand(ctx, sensorID, maxCommandWait)
+		switch {
+		case errors.Is(err, usecase.ErrCommandNotFound):
+			continue
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			return err
+		}
+		data, err := json.Marshal(command)
+		if err != nil {
+			return err
+		}
+		if err = conn.Write(ctx, websocket.MessageText, data); err != nil {
+			log.Println(err)
+			return nil
+		}
+	}
+}
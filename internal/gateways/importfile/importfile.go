@@ -0,0 +1,248 @@
+// Package importfile разбирает файлы импорта датчиков и событий в форматах CSV и NDJSON.
+// CSV должен начинаться со строки заголовка, колонки сопоставляются по именам, лишние колонки игнорируются.
+// Формат событий совпадает с выгрузкой /events/export с параметром raw=true.
+package importfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"io"
+	"iter"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format - формат файла импорта
+type Format string
+
+const (
+	CSV    Format = "text/csv"
+	NDJSON Format = "application/x-ndjson"
+)
+
+var (
+	ErrUnknownFormat  = errors.New("unknown import format, expected text/csv or application/x-ndjson")
+	ErrNoPayload      = errors.New("payload or readings required")
+	ErrCalibratedUnit = errors.New("event has calibrated value with unit, export events with raw=true")
+)
+
+// FormatByMediaType - формат по Content-Type запроса
+func FormatByMediaType(mediaType string) (Format, error) {
+	switch format := Format(mediaType); format {
+	case CSV, NDJSON:
+		return format, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// FormatByExtension - формат по расширению файла: .csv, .ndjson или .jsonl
+func FormatByExtension(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, nil
+	case ".ndjson", ".jsonl":
+		return NDJSON, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// maxLineSize - максимальная длина строки NDJSON
+const maxLineSize = 1 << 20
+
+// Events - записи событий из r. Колонки CSV: timestamp, sensor_serial_number и/или sensor_id, payload, readings (json-объект), unit
+func Events(r io.Reader, format Format) iter.Seq[usecase.ImportLine[domain.Event]] {
+	if format == CSV {
+		return csvRecords(r, []string{"timestamp"}, parseEventCSV)
+	}
+	return ndjsonRecords(r, parseEventJSON)
+}
+
+// Sensors - записи датчиков из r. Колонки CSV: serial_number, type, description, calibration (json-объект)
+func Sensors(r io.Reader, format Format) iter.Seq[usecase.ImportLine[domain.Sensor]] {
+	if format == CSV {
+		return csvRecords(r, []string{"serial_number", "type"}, parseSensorCSV)
+	}
+	return ndjsonRecords(r, parseSensorJSON)
+}
+
+// row - запись CSV с доступом к полям по имени колонки
+type row struct {
+	columns map[string]int
+	record  []string
+}
+
+func (r row) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+func csvRecords[T any](r io.Reader, required []string, parse func(row) (T, error)) iter.Seq[usecase.ImportLine[T]] {
+	return func(yield func(usecase.ImportLine[T]) bool) {
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+
+		header, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(usecase.ImportLine[T]{Line: 1, Err: err})
+			return
+		}
+		columns := make(map[string]int, len(header))
+		for i, name := range header {
+			columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+		}
+		for _, name := range required {
+			if _, ok := columns[name]; !ok {
+				yield(usecase.ImportLine[T]{Line: 1, Err: fmt.Errorf("missing column %q", name)})
+				return
+			}
+		}
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				if !yield(usecase.ImportLine[T]{Line: parseErr.StartLine, Err: parseErr.Err}) {
+					return
+				}
+				continue
+			}
+			if err != nil {
+				yield(usecase.ImportLine[T]{Err: err})
+				return
+			}
+			line, _ := reader.FieldPos(0)
+			value, err := parse(row{columns: columns, record: record})
+			if !yield(usecase.ImportLine[T]{Line: line, Value: value, Err: err}) {
+				return
+			}
+		}
+	}
+}
+
+func ndjsonRecords[T any](r io.Reader, parse func([]byte) (T, error)) iter.Seq[usecase.ImportLine[T]] {
+	return func(yield func(usecase.ImportLine[T]) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		line := 0
+		for scanner.Scan() {
+			line++
+			data := scanner.Bytes()
+			if len(bytes.TrimSpace(data)) == 0 {
+				continue
+			}
+			value, err := parse(data)
+			if !yield(usecase.ImportLine[T]{Line: line, Value: value, Err: err}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(usecase.ImportLine[T]{Line: line + 1, Err: err})
+		}
+	}
+}
+
+func parseEventCSV(r row) (domain.Event, error) {
+	var event domain.Event
+	if r.get("unit") != "" {
+		return event, ErrCalibratedUnit
+	}
+	var err error
+	if event.Timestamp, err = parseTime(r.get("timestamp")); err != nil {
+		return event, err
+	}
+	event.SensorSerialNumber = r.get("sensor_serial_number")
+	if id := r.get("sensor_id"); id != "" {
+		if event.SensorID, err = strconv.ParseInt(id, 10, 64); err != nil {
+			return event, fmt.Errorf("invalid sensor_id: %w", err)
+		}
+	}
+	payload, readings := r.get("payload"), r.get("readings")
+	if payload == "" && readings == "" {
+		return event, ErrNoPayload
+	}
+	if payload != "" {
+		if event.Payload, err = strconv.ParseFloat(payload, 64); err != nil {
+			return event, fmt.Errorf("invalid payload: %w", err)
+		}
+	}
+	if readings != "" {
+		if err = json.Unmarshal([]byte(readings), &event.Readings); err != nil {
+			return event, fmt.Errorf("invalid readings: %w", err)
+		}
+	}
+	return event, nil
+}
+
+// parseTime - время в формате RFC 3339 или без часового пояса (UTC), как в параметрах истории событий
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, usecase.ErrInvalidEventTimestamp
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02T15:04:05", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	return t, nil
+}
+
+func parseEventJSON(data []byte) (domain.Event, error) {
+	var record struct {
+		domain.Event
+		Payload *float64 `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return domain.Event{}, err
+	}
+	event := record.Event
+	if event.Unit != "" {
+		return event, ErrCalibratedUnit
+	}
+	if record.Payload == nil && len(event.Readings) == 0 {
+		return event, ErrNoPayload
+	}
+	if record.Payload != nil {
+		event.Payload = *record.Payload
+	}
+	return event, nil
+}
+
+func parseSensorCSV(r row) (domain.Sensor, error) {
+	sensor := domain.Sensor{
+		SerialNumber: r.get("serial_number"),
+		Type:         domain.SensorType(r.get("type")),
+		Description:  r.get("description"),
+	}
+	if calibration := r.get("calibration"); calibration != "" {
+		if err := json.Unmarshal([]byte(calibration), &sensor.Calibration); err != nil {
+			return sensor, fmt.Errorf("invalid calibration: %w", err)
+		}
+	}
+	return sensor, nil
+}
+
+func parseSensorJSON(data []byte) (domain.Sensor, error) {
+	var sensor domain.Sensor
+	err := json.Unmarshal(data, &sensor)
+	return sensor, err
+}
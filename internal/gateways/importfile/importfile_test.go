@@ -0,0 +1,102 @@
+package importfile
+
+import (
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_CSV(t *testing.T) {
+	input := "id,sensor_id,sensor_serial_number,timestamp,payload,unit,readings\n" +
+		"1,1,0000000001,2024-12-31T23:59:59Z,1.5,,\n" +
+		",,0000000002,2024-12-31T23:00:00,,,\"{\"\"t\"\":20}\"\n" +
+		"3,1,0000000001,yesterday,1,,\n" +
+		"4,1,0000000001,2024-12-31T23:59:59Z,,,\n" +
+		"5,1,0000000001,2024-12-31T23:59:59Z,10,W,\n" +
+		"6,1,0000000001,2024-12-31T23:59:59Z,\"1,\n"
+
+	lines := slices.Collect(Events(strings.NewReader(input), CSV))
+	require.Len(t, lines, 6)
+
+	assert.Equal(t, usecase.ImportLine[domain.Event]{Line: 2, Value: domain.Event{
+		SensorID:           1,
+		SensorSerialNumber: "0000000001",
+		Timestamp:          time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+		Payload:            1.5,
+	}}, lines[0])
+	assert.NoError(t, lines[1].Err)
+	assert.Equal(t, domain.Readings{"t": 20}, lines[1].Value.Readings)
+	assert.Equal(t, time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC), lines[1].Value.Timestamp)
+	assert.Error(t, lines[2].Err)
+	assert.Equal(t, 4, lines[2].Line)
+	assert.ErrorIs(t, lines[3].Err, ErrNoPayload)
+	assert.ErrorIs(t, lines[4].Err, ErrCalibratedUnit)
+	assert.Error(t, lines[5].Err)
+	assert.Equal(t, 7, lines[5].Line)
+}
+
+func TestEvents_CSVMissingColumn(t *testing.T) {
+	lines := slices.Collect(Events(strings.NewReader("sensor_id,payload\n1,1\n"), CSV))
+
+	require.Len(t, lines, 1)
+	assert.Equal(t, 1, lines[0].Line)
+	assert.ErrorContains(t, lines[0].Err, "timestamp")
+}
+
+func TestEvents_NDJSON(t *testing.T) {
+	input := `{"Timestamp":"2024-12-31T23:59:59Z","sensor_serial_number":"0000000001","payload":0}` + "\n" +
+		"\n" +
+		`{"timestamp":"2024-12-31T23:59:59Z","sensor_id":2,"readings":{"t":1}}` + "\n" +
+		`{"timestamp":"2024-12-31T23:59:59Z","sensor_id":2}` + "\n" +
+		`{"timestamp":` + "\n"
+
+	lines := slices.Collect(Events(strings.NewReader(input), NDJSON))
+	require.Len(t, lines, 4)
+
+	assert.NoError(t, lines[0].Err)
+	assert.Equal(t, "0000000001", lines[0].Value.SensorSerialNumber)
+	assert.Equal(t, 3, lines[1].Line)
+	assert.Equal(t, int64(2), lines[1].Value.SensorID)
+	assert.False(t, lines[1].Value.Timestamp.IsZero())
+	assert.ErrorIs(t, lines[2].Err, ErrNoPayload)
+	assert.Error(t, lines[3].Err)
+	assert.Equal(t, 5, lines[3].Line)
+}
+
+func TestSensors(t *testing.T) {
+	csvLines := slices.Collect(Sensors(strings.NewReader("serial_number,type,description,calibration\n"+
+		"0000000001,adc,boiler,\"{\"\"scale\"\":2}\"\n"+
+		"0000000002,cc,,\n"), CSV))
+	require.Len(t, csvLines, 2)
+	assert.Equal(t, domain.Sensor{
+		SerialNumber: "0000000001",
+		Type:         domain.SensorTypeADC,
+		Description:  "boiler",
+		Calibration:  &domain.Calibration{Scale: 2},
+	}, csvLines[0].Value)
+	assert.Equal(t, domain.SensorTypeContactClosure, csvLines[1].Value.Type)
+
+	jsonLines := slices.Collect(Sensors(strings.NewReader(`{"serial_number":"0000000003","type":"motion"}`), NDJSON))
+	require.Len(t, jsonLines, 1)
+	assert.Equal(t, domain.Sensor{SerialNumber: "0000000003", Type: domain.SensorTypeMotion}, jsonLines[0].Value)
+}
+
+func TestFormat(t *testing.T) {
+	format, err := FormatByExtension("/tmp/Events.CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, CSV, format)
+	format, err = FormatByExtension("events.jsonl")
+	assert.NoError(t, err)
+	assert.Equal(t, NDJSON, format)
+	_, err = FormatByExtension("events.xlsx")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = FormatByMediaType("application/json")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
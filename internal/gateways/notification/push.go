@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"homework/internal/domain"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// PushSender - отправляет уведомления каналов push JSON POST запросом на URL канала
+type PushSender struct {
+	client   *http.Client
+	outbound domain.OutboundNetworks
+}
+
+func NewPushSender(options ...func(*PushSender)) *PushSender {
+	s := &PushSender{}
+	for _, o := range options {
+		o(s)
+	}
+	if s.client == nil {
+		s.client = s.defaultClient()
+	}
+	return s
+}
+
+// WithPushClient - HTTP клиент, которым отправляются запросы. Проверку адресов при подключении
+// такой клиент должен выполнять сам
+func WithPushClient(client *http.Client) func(*PushSender) {
+	return func(s *PushSender) {
+		s.client = client
+	}
+}
+
+// WithPushOutboundNetworks - внутренние сети, в которые клиент по умолчанию может отправлять уведомления
+func WithPushOutboundNetworks(networks domain.OutboundNetworks) func(*PushSender) {
+	return func(s *PushSender) {
+		s.outbound = networks
+	}
+}
+
+// defaultClient - клиент, который проверяет адрес каждого подключения, в том числе после редиректов
+// и повторного разрешения имени. Прокси из окружения не используется, иначе проверялся бы адрес прокси
+func (s *PushSender) defaultClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("can't parse address %q: %w", address, err)
+			}
+			if !s.outbound.Allows(addrPort.Addr()) {
+				return fmt.Errorf("address %s is not allowed", addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}
+}
+
+// Send - отправляет уведомление телом запроса, успешным считается любой ответ 2xx
+func (s *PushSender) Send(ctx context.Context, channel domain.NotificationChannel, message domain.NotificationMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("can't encode message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.Target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send request: %w", err)
+	}
+	defer resp.Body.Close()
+	// тело читается, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("push endpoint responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"homework/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushSender_Send(t *testing.T) {
+	message := domain.NotificationMessage{
+		Subject: "[warning] Влажность",
+		Text:    "значение 95 %",
+		Alert:   domain.Alert{ID: 7, SensorID: 1, Severity: domain.AlertSeverityWarning, Payload: 95},
+		Sensor:  domain.Sensor{ID: 1, SerialNumber: "0000000001", Description: "Влажность"},
+	}
+
+	// тестовые серверы слушают loopback, который по умолчанию закрыт
+	loopback := WithPushOutboundNetworks(domain.OutboundNetworks{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")})
+
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		received := make(chan map[string]any, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received <- body
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		channel := domain.NotificationChannel{Kind: domain.NotificationChannelPush, Target: server.URL + "/hook"}
+		require.NoError(t, NewPushSender(loopback).Send(ctx, channel, message))
+
+		body := <-received
+		assert.Equal(t, message.Subject, body["subject"])
+		assert.Equal(t, message.Text, body["text"])
+		assert.Equal(t, "open", body["alert"].(map[string]any)["state"])
+		assert.Equal(t, "Влажность", body["sensor"].(map[string]any)["description"])
+	})
+
+	t.Run("fail, error status", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		channel := domain.NotificationChannel{Kind: domain.NotificationChannelPush, Target: server.URL}
+		assert.ErrorContains(t, NewPushSender(loopback).Send(ctx, channel, message), "503")
+	})
+	t.Run("fail, internal address", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		channel := domain.NotificationChannel{Kind: domain.NotificationChannelPush, Target: server.URL}
+		assert.ErrorContains(t, NewPushSender().Send(ctx, channel, message), "is not allowed")
+		assert.False(t, called)
+	})
+}
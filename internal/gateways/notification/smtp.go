@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"homework/internal/domain"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender - отправляет уведомления каналов email письмами через SMTP сервер
+type SMTPSender struct {
+	addr   string
+	from   mail.Address
+	auth   smtp.Auth
+	dialer net.Dialer
+}
+
+// NewSMTPSender - отправитель писем через сервер addr ("host:port") от имени from
+func NewSMTPSender(addr string, from mail.Address, options ...func(*SMTPSender)) *SMTPSender {
+	s := &SMTPSender{
+		addr: addr,
+		from: from,
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+// WithSMTPAuth - вход на сервер с PLAIN аутентификацией. net/smtp передаёт пароль только по TLS или на localhost
+func WithSMTPAuth(username, password string) func(*SMTPSender) {
+	return func(s *SMTPSender) {
+		host, _, _ := net.SplitHostPort(s.addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, channel domain.NotificationChannel, message domain.NotificationMessage) error {
+	to, err := mail.ParseAddress(channel.Target)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	data, err := s.compose(to, message)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("can't connect to smtp server: %w", err)
+	}
+	// net/smtp не принимает ctx, поэтому отмена и дедлайн ctx прерывают диалог через соединение
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	host, _, _ := net.SplitHostPort(s.addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("can't start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("can't start tls: %w", err)
+		}
+	}
+	if s.auth != nil {
+		if err = client.Auth(s.auth); err != nil {
+			return fmt.Errorf("can't authenticate: %w", err)
+		}
+	}
+	if err = client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	if err = client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("recipient rejected: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("can't send message: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		return fmt.Errorf("can't send message: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose - письмо с заголовками, текст кодируется quoted-printable, чтобы не зависеть от поддержки 8BITMIME
+func (s *SMTPSender) compose(to *mail.Address, message domain.NotificationMessage) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(message.Text)); err != nil {
+		return nil, fmt.Errorf("can't encode message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("can't encode message: %w", err)
+	}
+	b.WriteString("\r\n")
+	return b.Bytes(), nil
+}
@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/pkg/smtp_test"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPSender_Send(t *testing.T) {
+	server, err := smtp_test.NewServer("127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	sender := NewSMTPSender(server.Addr(), mail.Address{Name: "Умный дом", Address: "home@example.com"})
+	message := domain.NotificationMessage{
+		Subject: "[critical] Протечка в ванной",
+		Text:    "Датчик 0000000001 (Протечка в ванной): значение 1\n.точка в начале строки",
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		channel := domain.NotificationChannel{Kind: domain.NotificationChannelEmail, Target: "owner@example.com"}
+		require.NoError(t, sender.Send(ctx, channel, message))
+
+		received := <-server.Received()
+		assert.Equal(t, "home@example.com", received.From)
+		assert.Equal(t, []string{"owner@example.com"}, received.To)
+
+		parsed, err := mail.ReadMessage(strings.NewReader(received.Data))
+		require.NoError(t, err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, message.Subject, subject)
+		assert.Equal(t, "<owner@example.com>", parsed.Header.Get("To"))
+		body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+		require.NoError(t, err)
+		// строки письма передаются с CRLF
+		assert.Equal(t, message.Text, strings.TrimRight(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n"))
+	})
+
+	t.Run("fail, recipient rejected", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		server.Reject("nobody@example.com")
+		channel := domain.NotificationChannel{Kind: domain.NotificationChannelEmail, Target: "nobody@example.com"}
+		err := sender.Send(ctx, channel, message)
+		assert.ErrorContains(t, err, "550")
+	})
+
+	t.Run("fail, server unavailable", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		unavailable, err := smtp_test.NewServer("127.0.0.1:0")
+		require.NoError(t, err)
+		addr := unavailable.Addr()
+		require.NoError(t, unavailable.Close())
+
+		channel := domain.NotificationChannel{Kind: domain.NotificationChannelEmail, Target: "owner@example.com"}
+		assert.Error(t, NewSMTPSender(addr, mail.Address{Address: "home@example.com"}).Send(ctx, channel, message))
+	})
+}
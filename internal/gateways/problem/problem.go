@@ -0,0 +1,164 @@
+// Package problem - общее для всех шлюзов сопоставление ошибок usecase с видом ошибки и стабильным машиночитаемым кодом.
+// Шлюз переводит вид ошибки в свой код ответа (статус HTTP, код gRPC), код ошибки передаётся клиенту как есть
+package problem
+
+import (
+	"context"
+	"errors"
+	"homework/internal/usecase"
+	"time"
+)
+
+// Kind - вид ошибки, от которого зависит код ответа шлюза
+type Kind int
+
+const (
+	// KindInternal - внутренняя ошибка сервиса, её текст клиенту не передаётся
+	KindInternal Kind = iota
+	// KindMalformed - запрос не удалось разобрать: синтаксис тела, формат параметров, пагинация
+	KindMalformed
+	// KindInvalid - запрос разобран, но содержит невалидные данные
+	KindInvalid
+	// KindNotFound - объекта, к которому обращается запрос, нет
+	KindNotFound
+	// KindConflict - запрос противоречит текущему состоянию объекта
+	KindConflict
+	// KindCanceled - клиент отменил запрос
+	KindCanceled
+	// KindTimeout - запрос не успел выполниться
+	KindTimeout
+	// KindPrecondition - объект изменился с тех пор, как клиент его прочитал
+	KindPrecondition
+	// KindRateLimited - клиент превысил ограничение частоты запросов
+	KindRateLimited
+	// KindUnavailable - сервис временно не может принять запрос
+	KindUnavailable
+)
+
+// Коды ошибок, которые находит сам шлюз или которые не связаны с конкретной ошибкой usecase
+const (
+	CodeInternal         = "internal"
+	CodeMalformedBody    = "malformed_body"
+	CodeInvalidBody      = "invalid_body"
+	CodeInvalidParameter = "invalid_parameter"
+	CodeInvalidID        = "invalid_id"
+	CodeCanceled         = "canceled"
+	CodeTimeout          = "timeout"
+)
+
+// internalDetail - описание внутренней ошибки для клиента
+const internalDetail = "internal error"
+
+// Problem - ошибка в виде, пригодном для ответа клиенту
+type Problem struct {
+	Kind Kind
+	// Code - стабильный машиночитаемый код ошибки, например sensor_not_found
+	Code string
+	// Detail - описание ошибки для клиента, у внутренних ошибок - общее, без текста исходной ошибки
+	Detail string
+	// RetryAfter - через сколько можно повторить запрос, 0 - не известно
+	RetryAfter time.Duration
+}
+
+// knownErrors - ошибки usecase с их видом и кодом, порядок важен для ошибок, которые оборачивают другие
+var knownErrors = []struct {
+	err  error
+	kind Kind
+	code string
+}{
+	{usecase.ErrSensorNotFound, KindNotFound, "sensor_not_found"},
+	{usecase.ErrUserNotFound, KindNotFound, "user_not_found"},
+	{usecase.ErrEventNotFound, KindNotFound, "event_not_found"},
+	{usecase.ErrAlertNotFound, KindNotFound, "alert_not_found"},
+	{usecase.ErrChannelNotFound, KindNotFound, "notification_channel_not_found"},
+	{usecase.ErrCommandNotFound, KindNotFound, "command_not_found"},
+	{usecase.ErrScheduleNotFound, KindNotFound, "schedule_not_found"},
+
+	{usecase.ErrSensorAlreadyExists, KindConflict, "sensor_already_exists"},
+	{usecase.ErrSensorOwnerExists, KindConflict, "sensor_already_attached"},
+	{usecase.ErrAlertExists, KindConflict, "alert_already_open"},
+	{usecase.ErrAlertResolved, KindConflict, "alert_already_resolved"},
+
+	{usecase.ErrSensorModified, KindPrecondition, "sensor_modified"},
+
+	{usecase.ErrRateLimited, KindRateLimited, "rate_limited"},
+	{usecase.ErrIngestionUnavailable, KindUnavailable, "ingestion_unavailable"},
+	{usecase.ErrEventBufferFull, KindUnavailable, "event_buffer_full"},
+
+	{usecase.ErrWrongSensorSerialNumber, KindInvalid, "invalid_serial_number"},
+	{usecase.ErrWrongSensorType, KindInvalid, "invalid_sensor_type"},
+	{usecase.ErrInvalidEventTimestamp, KindInvalid, "invalid_event_timestamp"},
+	{usecase.ErrInvalidUserName, KindInvalid, "invalid_user_name"},
+	{usecase.ErrPayloadOutOfRange, KindInvalid, "payload_out_of_range"},
+	{usecase.ErrInvalidCalibration, KindInvalid, "invalid_calibration"},
+	{usecase.ErrSensorMismatch, KindInvalid, "sensor_mismatch"},
+	{usecase.ErrInvalidAlertRule, KindInvalid, "invalid_alert_rule"},
+	{usecase.ErrInvalidChannel, KindInvalid, "invalid_notification_channel"},
+	{usecase.ErrNotActuator, KindInvalid, "not_actuator"},
+	{usecase.ErrInvalidSchedule, KindInvalid, "invalid_schedule"},
+
+	{usecase.ErrInputDate, KindMalformed, "invalid_date_range"},
+	{usecase.ErrInvalidLimit, KindMalformed, "invalid_limit"},
+	{usecase.ErrInvalidCursor, KindMalformed, "invalid_cursor"},
+	{usecase.ErrInvalidSort, KindMalformed, "invalid_sort"},
+	{usecase.ErrInvalidAlertState, KindMalformed, "invalid_alert_state"},
+	{usecase.ErrInvalidCommandState, KindMalformed, "invalid_command_state"},
+
+	{context.Canceled, KindCanceled, CodeCanceled},
+	{context.DeadlineExceeded, KindTimeout, CodeTimeout},
+}
+
+// From - вид, код и описание ошибки для ответа клиенту. Неизвестные ошибки - внутренние
+func From(err error) Problem {
+	var gatewayErr *gatewayError
+	if errors.As(err, &gatewayErr) {
+		return Problem{Kind: gatewayErr.kind, Code: gatewayErr.code, Detail: gatewayErr.err.Error()}
+	}
+	for _, known := range knownErrors {
+		if !errors.Is(err, known.err) {
+			continue
+		}
+		detail := err.Error()
+		if known.kind == KindCanceled || known.kind == KindTimeout {
+			// ошибки контекста приходят обёрнутыми в текст ошибок хранилища
+			detail = known.err.Error()
+		}
+		p := Problem{Kind: known.kind, Code: known.code, Detail: detail}
+		var rateLimitErr *usecase.RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			p.RetryAfter = rateLimitErr.RetryAfter
+		}
+		return p
+	}
+	return Problem{Kind: KindInternal, Code: CodeInternal, Detail: internalDetail}
+}
+
+// gatewayError - ошибка, которую нашёл сам шлюз, с уже известными видом и кодом
+type gatewayError struct {
+	kind Kind
+	code string
+	err  error
+}
+
+func (e *gatewayError) Error() string {
+	return e.err.Error()
+}
+
+func (e *gatewayError) Unwrap() error {
+	return e.err
+}
+
+// New - ошибка шлюза с заданными видом и кодом
+func New(kind Kind, code string, err error) error {
+	return &gatewayError{kind: kind, code: code, err: err}
+}
+
+// Malformed - параметр запроса в неверном формате
+func Malformed(err error) error {
+	return New(KindMalformed, CodeInvalidParameter, err)
+}
+
+// Invalid - тело запроса синтаксически валидно, но содержит невалидные данные
+func Invalid(err error) error {
+	return New(KindInvalid, CodeInvalidBody, err)
+}
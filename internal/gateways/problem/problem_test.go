@@ -0,0 +1,71 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "usecase_error",
+			err:  usecase.ErrSensorNotFound,
+			want: Problem{Kind: KindNotFound, Code: "sensor_not_found", Detail: "sensor not found"},
+		},
+		{
+			name: "wrapped_usecase_error_keeps_detail",
+			err:  fmt.Errorf("%w: name is required", usecase.ErrInvalidSchedule),
+			want: Problem{Kind: KindInvalid, Code: "invalid_schedule", Detail: "invalid schedule: name is required"},
+		},
+		{
+			name: "internal_error_hides_detail",
+			err:  errors.New(`ERROR: relation "sensors" does not exist (SQLSTATE 42P01)`),
+			want: Problem{Kind: KindInternal, Code: CodeInternal, Detail: internalDetail},
+		},
+		{
+			name: "context_error_hides_storage_detail",
+			err:  fmt.Errorf("can't get sensor by id: %w", context.DeadlineExceeded),
+			want: Problem{Kind: KindTimeout, Code: CodeTimeout, Detail: context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "rate_limit_error_keeps_retry_after",
+			err:  &usecase.RateLimitError{Scope: usecase.RateLimitScopeSensor, RetryAfter: 1500 * time.Millisecond},
+			want: Problem{Kind: KindRateLimited, Code: "rate_limited", RetryAfter: 1500 * time.Millisecond,
+				Detail: "rate limit exceeded: sensor limit, retry after 1.5s"},
+		},
+		{
+			name: "event_buffer_full",
+			err:  fmt.Errorf("%w: 64 of 64 bytes used", usecase.ErrEventBufferFull),
+			want: Problem{Kind: KindUnavailable, Code: "event_buffer_full", Detail: "event buffer is full: 64 of 64 bytes used"},
+		},
+		{
+			name: "gateway_error",
+			err:  Malformed(errors.New("order must be 'asc' or 'desc'")),
+			want: Problem{Kind: KindMalformed, Code: CodeInvalidParameter, Detail: "order must be 'asc' or 'desc'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, From(tt.err))
+		})
+	}
+}
+
+func TestFrom_sameErrorSameProblem(t *testing.T) {
+	// ошибка usecase сопоставляется одинаково, где бы её ни обернули
+	direct := From(usecase.ErrSensorOwnerExists)
+	wrapped := From(fmt.Errorf("attach: %w", usecase.ErrSensorOwnerExists))
+
+	assert.Equal(t, direct.Kind, wrapped.Kind)
+	assert.Equal(t, direct.Code, wrapped.Code)
+}
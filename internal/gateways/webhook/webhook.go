@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Client - вызывает webhook действий расписаний JSON POST запросом
+type Client struct {
+	client *http.Client
+}
+
+func NewClient(options ...func(*Client)) *Client {
+	c := &Client{
+		client: http.DefaultClient,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	return c
+}
+
+// WithHTTPClient - HTTP клиент, которым отправляются запросы
+func WithHTTPClient(client *http.Client) func(*Client) {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// Call - отправляет body в формате JSON на url, успешным считается любой ответ 2xx
+func (c *Client) Call(ctx context.Context, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("can't encode body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send request: %w", err)
+	}
+	defer resp.Body.Close()
+	// тело читается, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"slices"
+	"sort"
+	"sync"
+
+	transaction "homework/internal/repository/transaction/inmemory"
+)
+
+type AlertRepository struct {
+	alerts  map[int64]*domain.Alert
+	lastID  int64
+	rwMutex *sync.RWMutex
+}
+
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{
+		alerts:  make(map[int64]*domain.Alert),
+		rwMutex: new(sync.RWMutex),
+	}
+}
+
+func (r *AlertRepository) SaveAlert(ctx context.Context, alert *domain.Alert) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if alert == nil {
+		return errors.New("alert is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	if alert.ID == 0 {
+		for _, stored := range r.alerts {
+			if stored.SensorID == alert.SensorID && stored.ResolvedAt == nil {
+				return usecase.ErrAlertExists
+			}
+		}
+		r.lastID++
+		alert.ID = r.lastID
+		stored := clone(alert)
+		r.alerts[alert.ID] = &stored
+		transaction.OnRollback(ctx, func() {
+			r.rwMutex.Lock()
+			defer r.rwMutex.Unlock()
+			delete(r.alerts, stored.ID)
+		})
+		return nil
+	}
+
+	prev, ok := r.alerts[alert.ID]
+	if !ok {
+		return usecase.ErrAlertNotFound
+	}
+	// меняются только подтверждение и устранение, как в UPDATE хранилищ на SQL
+	stored := *prev
+	stored.AcknowledgedBy, stored.AcknowledgedAt = clonePtr(alert.AcknowledgedBy), clonePtr(alert.AcknowledgedAt)
+	stored.ResolvedBy, stored.ResolvedAt = clonePtr(alert.ResolvedBy), clonePtr(alert.ResolvedAt)
+	r.alerts[alert.ID] = &stored
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		r.alerts[prev.ID] = prev
+	})
+	return nil
+}
+
+func (r *AlertRepository) GetAlertByID(ctx context.Context, id int64) (*domain.Alert, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	alert, ok := r.alerts[id]
+	if !ok {
+		return nil, usecase.ErrAlertNotFound
+	}
+	result := clone(alert)
+	return &result, nil
+}
+
+func (r *AlertRepository) GetUnresolvedAlertBySensorID(ctx context.Context, sensorID int64) (*domain.Alert, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	for _, alert := range r.alerts {
+		if alert.SensorID == sensorID && alert.ResolvedAt == nil {
+			result := clone(alert)
+			return &result, nil
+		}
+	}
+	return nil, usecase.ErrAlertNotFound
+}
+
+func (r *AlertRepository) ListAlerts(ctx context.Context, query usecase.AlertQuery) ([]domain.Alert, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	alerts := []domain.Alert{}
+	for _, alert := range r.alerts {
+		if len(query.SensorIDs) > 0 && !slices.Contains(query.SensorIDs, alert.SensorID) {
+			continue
+		}
+		if len(query.States) > 0 && !slices.Contains(query.States, alert.State()) {
+			continue
+		}
+		alerts = append(alerts, clone(alert))
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].TriggeredAt.Equal(alerts[j].TriggeredAt) {
+			return alerts[i].TriggeredAt.After(alerts[j].TriggeredAt)
+		}
+		return alerts[i].ID > alerts[j].ID
+	})
+	if len(alerts) > query.Limit {
+		alerts = alerts[:query.Limit]
+	}
+	return alerts, nil
+}
+
+// clone - копия тревоги, не разделяющая с оригиналом указатели подтверждения и устранения
+func clone(alert *domain.Alert) domain.Alert {
+	result := *alert
+	result.AcknowledgedBy = clonePtr(alert.AcknowledgedBy)
+	result.AcknowledgedAt = clonePtr(alert.AcknowledgedAt)
+	result.ResolvedBy = clonePtr(alert.ResolvedBy)
+	result.ResolvedAt = clonePtr(alert.ResolvedAt)
+	return result
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	result := *v
+	return &result
+}
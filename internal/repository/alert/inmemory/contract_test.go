@@ -0,0 +1,21 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/inmemory"
+	transaction "homework/internal/repository/transaction/inmemory"
+	userRepository "homework/internal/repository/user/inmemory"
+)
+
+func TestAlertRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.AlertRepositorySuite{
+		Alerts:     NewAlertRepository(),
+		Sensors:    sensorRepository.NewSensorRepository(),
+		Users:      userRepository.NewUserRepository(),
+		Transactor: transaction.NewTransactor(),
+	})
+}
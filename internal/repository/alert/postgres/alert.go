@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
+)
+
+type AlertRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAlertRepository(pool *pgxpool.Pool) *AlertRepository {
+	return &AlertRepository{
+		pool: pool,
+	}
+}
+
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *AlertRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
+
+const alertColumns = `id, sensor_id, severity, payload, triggered_at, acknowledged_by, acknowledged_at, resolved_by, resolved_at`
+
+// saveAlertQuery - вторая неустранённая тревога датчика не вставляется, а не нарушает уникальность:
+// ошибка оборвала бы транзакцию, в которой сохраняется событие
+const saveAlertQuery = `INSERT INTO alerts (sensor_id, severity, payload, triggered_at) VALUES ($1, $2, $3, $4) ON CONFLICT (sensor_id) WHERE resolved_at IS NULL DO NOTHING RETURNING id`
+
+const updateAlertQuery = `UPDATE alerts SET acknowledged_by = $2, acknowledged_at = $3, resolved_by = $4, resolved_at = $5 WHERE id = $1`
+
+const getAlertByIDQuery = `SELECT ` + alertColumns + ` FROM alerts WHERE id = $1`
+
+const getUnresolvedAlertBySensorIDQuery = `SELECT ` + alertColumns + ` FROM alerts WHERE sensor_id = $1 AND resolved_at IS NULL`
+
+const alertsSensorIDForeignKey = "alerts_sensor_id_fkey"
+
+// alertStateConditions - условия выборки тревог на каждом этапе обработки
+var alertStateConditions = map[domain.AlertState]string{
+	domain.AlertStateOpen:         `(acknowledged_at IS NULL AND resolved_at IS NULL)`,
+	domain.AlertStateAcknowledged: `(acknowledged_at IS NOT NULL AND resolved_at IS NULL)`,
+	domain.AlertStateResolved:     `(resolved_at IS NOT NULL)`,
+}
+
+func (r *AlertRepository) SaveAlert(ctx context.Context, alert *domain.Alert) error {
+	if alert.ID != 0 {
+		tag, err := r.db(ctx).Exec(ctx, updateAlertQuery, alert.ID, alert.AcknowledgedBy, alert.AcknowledgedAt, alert.ResolvedBy, alert.ResolvedAt)
+		if err != nil {
+			return fmt.Errorf("can't update alert: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return usecase.ErrAlertNotFound
+		}
+		return nil
+	}
+
+	row := r.db(ctx).QueryRow(ctx, saveAlertQuery, alert.SensorID, alert.Severity, alert.Payload, alert.TriggeredAt)
+	err := row.Scan(&alert.ID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return usecase.ErrAlertExists
+	case pgerrors.IsForeignKeyViolation(err, alertsSensorIDForeignKey):
+		return usecase.ErrSensorNotFound
+	case err != nil:
+		return fmt.Errorf("can't save alert: %w", err)
+	}
+	return nil
+}
+
+func (r *AlertRepository) GetAlertByID(ctx context.Context, id int64) (*domain.Alert, error) {
+	alert, err := scanAlert(r.db(ctx).QueryRow(ctx, getAlertByIDQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrAlertNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (r *AlertRepository) GetUnresolvedAlertBySensorID(ctx context.Context, sensorID int64) (*domain.Alert, error) {
+	alert, err := scanAlert(r.db(ctx).QueryRow(ctx, getUnresolvedAlertBySensorIDQuery, sensorID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrAlertNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get unresolved alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (r *AlertRepository) ListAlerts(ctx context.Context, query usecase.AlertQuery) ([]domain.Alert, error) {
+	b := sqlquery.New(sqlquery.Dollar)
+	sqlquery.In(b, "sensor_id", query.SensorIDs)
+	if len(query.States) > 0 {
+		conditions := make([]string, 0, len(query.States))
+		for _, state := range query.States {
+			conditions = append(conditions, alertStateConditions[state])
+		}
+		b.Where("(" + strings.Join(conditions, " OR ") + ")")
+	}
+
+	sql := `SELECT ` + alertColumns + ` FROM alerts` + b.WhereClause() + ` ORDER BY triggered_at DESC, id DESC LIMIT ` + b.Arg(query.Limit)
+	rows, err := r.db(ctx).Query(ctx, sql, b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list alerts: %w", err)
+	}
+	defer rows.Close()
+	alerts := []domain.Alert{}
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan alert: %w", err)
+		}
+		alerts = append(alerts, *alert)
+	}
+	return alerts, rows.Err()
+}
+
+func scanAlert(row pgx.Row) (*domain.Alert, error) {
+	alert := &domain.Alert{}
+	err := row.Scan(&alert.ID, &alert.SensorID, &alert.Severity, &alert.Payload, &alert.TriggeredAt, &alert.AcknowledgedBy, &alert.AcknowledgedAt, &alert.ResolvedBy, &alert.ResolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
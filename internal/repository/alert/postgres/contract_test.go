@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/postgres"
+	transaction "homework/internal/repository/transaction/postgres"
+	userRepository "homework/internal/repository/user/postgres"
+)
+
+type alertContractSuite struct {
+	contract.AlertRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *alertContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Alerts = NewAlertRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+	suite.Users = userRepository.NewUserRepository(db)
+	suite.Transactor = transaction.NewTransactor(db)
+}
+
+func (suite *alertContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestAlertRepositoryContract(t *testing.T) {
+	suite.Run(t, new(alertContractSuite))
+}
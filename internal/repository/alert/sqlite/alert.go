@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"strings"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type AlertRepository struct {
+	db *sql.DB
+}
+
+func NewAlertRepository(db *sql.DB) *AlertRepository {
+	return &AlertRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *AlertRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const alertColumns = `id, sensor_id, severity, payload, triggered_at, acknowledged_by, acknowledged_at, resolved_by, resolved_at`
+
+const saveAlertQuery = `INSERT INTO alerts (sensor_id, severity, payload, triggered_at) VALUES (?, ?, ?, ?) RETURNING id`
+
+const updateAlertQuery = `UPDATE alerts SET acknowledged_by = ?, acknowledged_at = ?, resolved_by = ?, resolved_at = ? WHERE id = ?`
+
+const getAlertByIDQuery = `SELECT ` + alertColumns + ` FROM alerts WHERE id = ?`
+
+const getUnresolvedAlertBySensorIDQuery = `SELECT ` + alertColumns + ` FROM alerts WHERE sensor_id = ? AND resolved_at IS NULL`
+
+// alertStateConditions - условия выборки тревог на каждом этапе обработки
+var alertStateConditions = map[domain.AlertState]string{
+	domain.AlertStateOpen:         `(acknowledged_at IS NULL AND resolved_at IS NULL)`,
+	domain.AlertStateAcknowledged: `(acknowledged_at IS NOT NULL AND resolved_at IS NULL)`,
+	domain.AlertStateResolved:     `(resolved_at IS NOT NULL)`,
+}
+
+func (r *AlertRepository) SaveAlert(ctx context.Context, alert *domain.Alert) error {
+	if alert.ID != 0 {
+		res, err := r.conn(ctx).ExecContext(ctx, updateAlertQuery, alert.AcknowledgedBy, sqlitedb.NullTimestamp(alert.AcknowledgedAt), alert.ResolvedBy, sqlitedb.NullTimestamp(alert.ResolvedAt), alert.ID)
+		if err != nil {
+			return fmt.Errorf("can't update alert: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't update alert: %w", err)
+		}
+		if affected == 0 {
+			return usecase.ErrAlertNotFound
+		}
+		return nil
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx, saveAlertQuery, alert.SensorID, alert.Severity, alert.Payload, sqlitedb.Timestamp(alert.TriggeredAt))
+	err := row.Scan(&alert.ID)
+	switch {
+	case sqlitedb.IsUniqueViolation(err, "alerts", "sensor_id"):
+		return usecase.ErrAlertExists
+	case sqlitedb.IsForeignKeyViolation(err):
+		return usecase.ErrSensorNotFound
+	case err != nil:
+		return fmt.Errorf("can't save alert: %w", err)
+	}
+	return nil
+}
+
+func (r *AlertRepository) GetAlertByID(ctx context.Context, id int64) (*domain.Alert, error) {
+	alert, err := scanAlert(r.conn(ctx).QueryRowContext(ctx, getAlertByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrAlertNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (r *AlertRepository) GetUnresolvedAlertBySensorID(ctx context.Context, sensorID int64) (*domain.Alert, error) {
+	alert, err := scanAlert(r.conn(ctx).QueryRowContext(ctx, getUnresolvedAlertBySensorIDQuery, sensorID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrAlertNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get unresolved alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (r *AlertRepository) ListAlerts(ctx context.Context, query usecase.AlertQuery) ([]domain.Alert, error) {
+	b := sqlquery.New(sqlquery.Question)
+	sqlquery.In(b, "sensor_id", query.SensorIDs)
+	if len(query.States) > 0 {
+		conditions := make([]string, 0, len(query.States))
+		for _, state := range query.States {
+			conditions = append(conditions, alertStateConditions[state])
+		}
+		b.Where("(" + strings.Join(conditions, " OR ") + ")")
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+alertColumns+` FROM alerts`+b.WhereClause()+` ORDER BY triggered_at DESC, id DESC LIMIT `+b.Arg(query.Limit), b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list alerts: %w", err)
+	}
+	defer rows.Close()
+	alerts := []domain.Alert{}
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan alert: %w", err)
+		}
+		alerts = append(alerts, *alert)
+	}
+	return alerts, rows.Err()
+}
+
+func scanAlert(row interface{ Scan(dest ...any) error }) (*domain.Alert, error) {
+	var triggeredAt int64
+	var acknowledgedBy, acknowledgedAt, resolvedBy, resolvedAt sql.NullInt64
+	alert := &domain.Alert{}
+	err := row.Scan(&alert.ID, &alert.SensorID, &alert.Severity, &alert.Payload, &triggeredAt, &acknowledgedBy, &acknowledgedAt, &resolvedBy, &resolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	alert.TriggeredAt = sqlitedb.Time(triggeredAt)
+	alert.AcknowledgedAt = sqlitedb.NullTime(acknowledgedAt)
+	alert.ResolvedAt = sqlitedb.NullTime(resolvedAt)
+	if acknowledgedBy.Valid {
+		alert.AcknowledgedBy = &acknowledgedBy.Int64
+	}
+	if resolvedBy.Valid {
+		alert.ResolvedBy = &resolvedBy.Int64
+	}
+	return alert, nil
+}
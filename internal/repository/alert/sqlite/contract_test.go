@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/sqlite"
+	transaction "homework/internal/repository/transaction/sqlite"
+	userRepository "homework/internal/repository/user/sqlite"
+)
+
+type alertContractSuite struct {
+	contract.AlertRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *alertContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Alerts = NewAlertRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+	suite.Users = userRepository.NewUserRepository(db)
+	suite.Transactor = transaction.NewTransactor(db)
+}
+
+func (suite *alertContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestAlertRepositoryContract(t *testing.T) {
+	suite.Run(t, new(alertContractSuite))
+}
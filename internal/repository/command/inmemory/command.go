@@ -0,0 +1,184 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	transaction "homework/internal/repository/transaction/inmemory"
+)
+
+type CommandRepository struct {
+	commands map[int64]*domain.Command
+	lastID   int64
+	rwMutex  *sync.RWMutex
+}
+
+func NewCommandRepository() *CommandRepository {
+	return &CommandRepository{
+		commands: make(map[int64]*domain.Command),
+		rwMutex:  new(sync.RWMutex),
+	}
+}
+
+func (r *CommandRepository) SaveCommand(ctx context.Context, command *domain.Command) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if command == nil {
+		return errors.New("command is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	if command.ID == 0 {
+		r.lastID++
+		command.ID = r.lastID
+		stored := clone(command)
+		r.commands[command.ID] = &stored
+		transaction.OnRollback(ctx, func() {
+			r.rwMutex.Lock()
+			defer r.rwMutex.Unlock()
+			delete(r.commands, stored.ID)
+		})
+		return nil
+	}
+
+	prev, ok := r.commands[command.ID]
+	if !ok {
+		return usecase.ErrCommandNotFound
+	}
+	// меняются только этап, причина и времена, как в UPDATE хранилищ на SQL
+	stored := *prev
+	stored.State, stored.Reason = command.State, command.Reason
+	stored.DeliveredAt, stored.CompletedAt = clonePtr(command.DeliveredAt), clonePtr(command.CompletedAt)
+	r.commands[command.ID] = &stored
+	r.rollback(ctx, prev)
+	return nil
+}
+
+func (r *CommandRepository) GetCommandByID(ctx context.Context, id int64) (*domain.Command, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	command, ok := r.commands[id]
+	if !ok {
+		return nil, usecase.ErrCommandNotFound
+	}
+	result := clone(command)
+	return &result, nil
+}
+
+func (r *CommandRepository) ListCommands(ctx context.Context, query usecase.CommandQuery) ([]domain.Command, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	commands := []domain.Command{}
+	for _, command := range r.commands {
+		if command.SensorID != query.SensorID {
+			continue
+		}
+		if len(query.States) > 0 && !slices.Contains(query.States, command.State) {
+			continue
+		}
+		commands = append(commands, clone(command))
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(commands, func(i, j int) bool {
+		if !commands[i].CreatedAt.Equal(commands[j].CreatedAt) {
+			return commands[i].CreatedAt.After(commands[j].CreatedAt)
+		}
+		return commands[i].ID > commands[j].ID
+	})
+	if len(commands) > query.Limit {
+		commands = commands[:query.Limit]
+	}
+	return commands, nil
+}
+
+func (r *CommandRepository) DeliverNextCommand(ctx context.Context, sensorID int64, at time.Time) (*domain.Command, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	var next *domain.Command
+	for _, command := range r.commands {
+		if command.SensorID == sensorID && command.State == domain.CommandStatePending && (next == nil || command.ID < next.ID) {
+			next = command
+		}
+	}
+	if next == nil {
+		return nil, usecase.ErrCommandNotFound
+	}
+	stored := clone(next)
+	stored.State, stored.DeliveredAt = domain.CommandStateDelivered, &at
+	r.commands[stored.ID] = &stored
+	r.rollback(ctx, next)
+	result := clone(&stored)
+	return &result, nil
+}
+
+func (r *CommandRepository) FailExpiredCommands(ctx context.Context, deliveredBefore time.Time, reason string, at time.Time) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	failed := 0
+	for id, command := range r.commands {
+		if command.State != domain.CommandStateDelivered || !command.DeliveredAt.Before(deliveredBefore) {
+			continue
+		}
+		stored := clone(command)
+		stored.State, stored.Reason, stored.CompletedAt = domain.CommandStateFailed, reason, &at
+		r.commands[id] = &stored
+		r.rollback(ctx, command)
+		failed++
+	}
+	return failed, nil
+}
+
+// rollback - при откате транзакции возвращает команде прежнее значение prev. Вызывается под блокировкой
+func (r *CommandRepository) rollback(ctx context.Context, prev *domain.Command) {
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		r.commands[prev.ID] = prev
+	})
+}
+
+// clone - копия команды, не разделяющая с оригиналом указатели времён
+func clone(command *domain.Command) domain.Command {
+	result := *command
+	result.DeliveredAt = clonePtr(command.DeliveredAt)
+	result.CompletedAt = clonePtr(command.CompletedAt)
+	return result
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	result := *v
+	return &result
+}
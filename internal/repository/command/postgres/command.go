@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
+)
+
+type CommandRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCommandRepository(pool *pgxpool.Pool) *CommandRepository {
+	return &CommandRepository{
+		pool: pool,
+	}
+}
+
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *CommandRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
+
+const commandColumns = `id, sensor_id, payload, state, reason, created_at, delivered_at, completed_at`
+
+const saveCommandQuery = `INSERT INTO commands (sensor_id, payload, state, reason, created_at, delivered_at, completed_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+const updateCommandQuery = `UPDATE commands SET state = $2, reason = $3, delivered_at = $4, completed_at = $5 WHERE id = $1`
+
+const getCommandByIDQuery = `SELECT ` + commandColumns + ` FROM commands WHERE id = $1`
+
+// deliverNextCommandQuery - SKIP LOCKED отдаёт параллельным запросам разные команды вместо ожидания блокировки
+const deliverNextCommandQuery = `UPDATE commands SET state = 'delivered', delivered_at = $2
+WHERE id = (SELECT id FROM commands WHERE sensor_id = $1 AND state = 'pending' ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED)
+RETURNING ` + commandColumns
+
+const failExpiredCommandsQuery = `UPDATE commands SET state = 'failed', reason = $1, completed_at = $2 WHERE state = 'delivered' AND delivered_at < $3`
+
+const commandsSensorIDForeignKey = "commands_sensor_id_fkey"
+
+func (r *CommandRepository) SaveCommand(ctx context.Context, command *domain.Command) error {
+	if command.ID != 0 {
+		tag, err := r.db(ctx).Exec(ctx, updateCommandQuery, command.ID, command.State, command.Reason, command.DeliveredAt, command.CompletedAt)
+		if err != nil {
+			return fmt.Errorf("can't update command: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return usecase.ErrCommandNotFound
+		}
+		return nil
+	}
+
+	row := r.db(ctx).QueryRow(ctx, saveCommandQuery, command.SensorID, command.Payload, command.State, command.Reason, command.CreatedAt, command.DeliveredAt, command.CompletedAt)
+	err := row.Scan(&command.ID)
+	switch {
+	case pgerrors.IsForeignKeyViolation(err, commandsSensorIDForeignKey):
+		return usecase.ErrSensorNotFound
+	case err != nil:
+		return fmt.Errorf("can't save command: %w", err)
+	}
+	return nil
+}
+
+func (r *CommandRepository) GetCommandByID(ctx context.Context, id int64) (*domain.Command, error) {
+	command, err := scanCommand(r.db(ctx).QueryRow(ctx, getCommandByIDQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrCommandNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get command: %w", err)
+	}
+	return command, nil
+}
+
+func (r *CommandRepository) ListCommands(ctx context.Context, query usecase.CommandQuery) ([]domain.Command, error) {
+	b := sqlquery.New(sqlquery.Dollar)
+	b.Where("sensor_id = ?", query.SensorID)
+	sqlquery.In(b, "state", query.States)
+
+	sql := `SELECT ` + commandColumns + ` FROM commands` + b.WhereClause() + ` ORDER BY created_at DESC, id DESC LIMIT ` + b.Arg(query.Limit)
+	rows, err := r.db(ctx).Query(ctx, sql, b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list commands: %w", err)
+	}
+	defer rows.Close()
+	commands := []domain.Command{}
+	for rows.Next() {
+		command, err := scanCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan command: %w", err)
+		}
+		commands = append(commands, *command)
+	}
+	return commands, rows.Err()
+}
+
+func (r *CommandRepository) DeliverNextCommand(ctx context.Context, sensorID int64, at time.Time) (*domain.Command, error) {
+	command, err := scanCommand(r.db(ctx).QueryRow(ctx, deliverNextCommandQuery, sensorID, at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrCommandNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't deliver command: %w", err)
+	}
+	return command, nil
+}
+
+func (r *CommandRepository) FailExpiredCommands(ctx context.Context, deliveredBefore time.Time, reason string, at time.Time) (int, error) {
+	tag, err := r.db(ctx).Exec(ctx, failExpiredCommandsQuery, reason, at, deliveredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("can't fail expired commands: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func scanCommand(row pgx.Row) (*domain.Command, error) {
+	command := &domain.Command{}
+	err := row.Scan(&command.ID, &command.SensorID, &command.Payload, &command.State, &command.Reason, &command.CreatedAt, &command.DeliveredAt, &command.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return command, nil
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/postgres"
+)
+
+type commandContractSuite struct {
+	contract.CommandRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *commandContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Commands = NewCommandRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+}
+
+func (suite *commandContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestCommandRepositoryContract(t *testing.T) {
+	suite.Run(t, new(commandContractSuite))
+}
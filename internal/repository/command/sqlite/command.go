@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"time"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type CommandRepository struct {
+	db *sql.DB
+}
+
+func NewCommandRepository(db *sql.DB) *CommandRepository {
+	return &CommandRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *CommandRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const commandColumns = `id, sensor_id, payload, state, reason, created_at, delivered_at, completed_at`
+
+const saveCommandQuery = `INSERT INTO commands (sensor_id, payload, state, reason, created_at, delivered_at, completed_at) VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id`
+
+const updateCommandQuery = `UPDATE commands SET state = ?, reason = ?, delivered_at = ?, completed_at = ? WHERE id = ?`
+
+const getCommandByIDQuery = `SELECT ` + commandColumns + ` FROM commands WHERE id = ?`
+
+// deliverNextCommandQuery - выборка и обновление в одном запросе, поэтому команду не доставят дважды
+const deliverNextCommandQuery = `UPDATE commands SET state = 'delivered', delivered_at = ?
+WHERE id = (SELECT id FROM commands WHERE sensor_id = ? AND state = 'pending' ORDER BY id LIMIT 1)
+RETURNING ` + commandColumns
+
+const failExpiredCommandsQuery = `UPDATE commands SET state = 'failed', reason = ?, completed_at = ? WHERE state = 'delivered' AND delivered_at < ?`
+
+func (r *CommandRepository) SaveCommand(ctx context.Context, command *domain.Command) error {
+	if command.ID != 0 {
+		res, err := r.conn(ctx).ExecContext(ctx, updateCommandQuery, command.State, command.Reason, sqlitedb.NullTimestamp(command.DeliveredAt), sqlitedb.NullTimestamp(command.CompletedAt), command.ID)
+		if err != nil {
+			return fmt.Errorf("can't update command: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't update command: %w", err)
+		}
+		if affected == 0 {
+			return usecase.ErrCommandNotFound
+		}
+		return nil
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx, saveCommandQuery, command.SensorID, command.Payload, command.State, command.Reason,
+		sqlitedb.Timestamp(command.CreatedAt), sqlitedb.NullTimestamp(command.DeliveredAt), sqlitedb.NullTimestamp(command.CompletedAt))
+	err := row.Scan(&command.ID)
+	switch {
+	case sqlitedb.IsForeignKeyViolation(err):
+		return usecase.ErrSensorNotFound
+	case err != nil:
+		return fmt.Errorf("can't save command: %w", err)
+	}
+	return nil
+}
+
+func (r *CommandRepository) GetCommandByID(ctx context.Context, id int64) (*domain.Command, error) {
+	command, err := scanCommand(r.conn(ctx).QueryRowContext(ctx, getCommandByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrCommandNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get command: %w", err)
+	}
+	return command, nil
+}
+
+func (r *CommandRepository) ListCommands(ctx context.Context, query usecase.CommandQuery) ([]domain.Command, error) {
+	b := sqlquery.New(sqlquery.Question)
+	b.Where("sensor_id = ?", query.SensorID)
+	sqlquery.In(b, "state", query.States)
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+commandColumns+` FROM commands`+b.WhereClause()+` ORDER BY created_at DESC, id DESC LIMIT `+b.Arg(query.Limit), b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list commands: %w", err)
+	}
+	defer rows.Close()
+	commands := []domain.Command{}
+	for rows.Next() {
+		command, err := scanCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan command: %w", err)
+		}
+		commands = append(commands, *command)
+	}
+	return commands, rows.Err()
+}
+
+func (r *CommandRepository) DeliverNextCommand(ctx context.Context, sensorID int64, at time.Time) (*domain.Command, error) {
+	command, err := scanCommand(r.conn(ctx).QueryRowContext(ctx, deliverNextCommandQuery, sqlitedb.Timestamp(at), sensorID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrCommandNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't deliver command: %w", err)
+	}
+	return command, nil
+}
+
+func (r *CommandRepository) FailExpiredCommands(ctx context.Context, deliveredBefore time.Time, reason string, at time.Time) (int, error) {
+	res, err := r.conn(ctx).ExecContext(ctx, failExpiredCommandsQuery, reason, sqlitedb.Timestamp(at), sqlitedb.Timestamp(deliveredBefore))
+	if err != nil {
+		return 0, fmt.Errorf("can't fail expired commands: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't fail expired commands: %w", err)
+	}
+	return int(affected), nil
+}
+
+func scanCommand(row interface{ Scan(dest ...any) error }) (*domain.Command, error) {
+	var createdAt int64
+	var deliveredAt, completedAt sql.NullInt64
+	command := &domain.Command{}
+	err := row.Scan(&command.ID, &command.SensorID, &command.Payload, &command.State, &command.Reason, &createdAt, &deliveredAt, &completedAt)
+	if err != nil {
+		return nil, err
+	}
+	command.CreatedAt = sqlitedb.Time(createdAt)
+	command.DeliveredAt = sqlitedb.NullTime(deliveredAt)
+	command.CompletedAt = sqlitedb.NullTime(completedAt)
+	return command, nil
+}
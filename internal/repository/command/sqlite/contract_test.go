@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/sqlite"
+)
+
+type commandContractSuite struct {
+	contract.CommandRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *commandContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Commands = NewCommandRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+}
+
+func (suite *commandContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestCommandRepositoryContract(t *testing.T) {
+	suite.Run(t, new(commandContractSuite))
+}
@@ -0,0 +1,193 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// AlertRepositorySuite - контракт usecase.AlertRepository
+type AlertRepositorySuite struct {
+	suite.Suite
+
+	// Alerts - проверяемый репозиторий
+	Alerts usecase.AlertRepository
+	// Sensors - репозиторий того же хранилища, в котором создаются датчики
+	Sensors usecase.SensorRepository
+	// Users - репозиторий того же хранилища, в котором создаются пользователи
+	Users usecase.UserRepository
+	// Transactor - транзакции того же хранилища
+	Transactor usecase.Transactor
+}
+
+func (s *AlertRepositorySuite) newSensor(ctx context.Context) *domain.Sensor {
+	sensor := &domain.Sensor{SerialNumber: serialNumber(), Type: domain.SensorTypeContactClosure}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	return sensor
+}
+
+func (s *AlertRepositorySuite) newAlert(ctx context.Context, sensorID int64, triggeredAt time.Time) *domain.Alert {
+	alert := &domain.Alert{
+		SensorID:    sensorID,
+		Severity:    domain.AlertSeverityCritical,
+		Payload:     1,
+		TriggeredAt: triggeredAt,
+	}
+	s.Require().NoError(s.Alerts.SaveAlert(ctx, alert))
+	return alert
+}
+
+// resolve - устраняет тревогу без пользователя
+func (s *AlertRepositorySuite) resolve(ctx context.Context, alert *domain.Alert) {
+	resolvedAt := now()
+	alert.ResolvedAt = &resolvedAt
+	s.Require().NoError(s.Alerts.SaveAlert(ctx, alert))
+}
+
+func (s *AlertRepositorySuite) TestSaveAlert() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	alert := s.newAlert(ctx, sensor.ID, now())
+	s.NotZero(alert.ID)
+
+	actual, err := s.Alerts.GetAlertByID(ctx, alert.ID)
+	s.Require().NoError(err)
+	s.Equal(*alert, *actual)
+	s.Equal(domain.AlertStateOpen, actual.State())
+
+	_, err = s.Alerts.GetAlertByID(ctx, alert.ID+1_000_000)
+	s.ErrorIs(err, usecase.ErrAlertNotFound)
+}
+
+func (s *AlertRepositorySuite) TestSaveAlert_OneUnresolvedPerSensor() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	first := s.newAlert(ctx, sensor.ID, now())
+
+	err := s.Alerts.SaveAlert(ctx, &domain.Alert{SensorID: sensor.ID, Severity: domain.AlertSeverityInfo, TriggeredAt: now()})
+	s.ErrorIs(err, usecase.ErrAlertExists)
+
+	s.resolve(ctx, first)
+	second := s.newAlert(ctx, sensor.ID, now())
+	s.NotEqual(first.ID, second.ID)
+}
+
+func (s *AlertRepositorySuite) TestSaveAlert_ExistsWithinTransaction() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	var first *domain.Alert
+	// два события одной транзакции поднимают тревогу: вторая не вставляется, а транзакция фиксируется
+	err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		first = s.newAlert(ctx, sensor.ID, now())
+		err := s.Alerts.SaveAlert(ctx, &domain.Alert{SensorID: sensor.ID, Severity: domain.AlertSeverityInfo, TriggeredAt: now()})
+		s.ErrorIs(err, usecase.ErrAlertExists)
+		_, err = s.Alerts.GetUnresolvedAlertBySensorID(ctx, sensor.ID)
+		return err
+	})
+	s.Require().NoError(err)
+
+	actual, err := s.Alerts.GetUnresolvedAlertBySensorID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(first.ID, actual.ID)
+}
+
+func (s *AlertRepositorySuite) TestSaveAlert_Update() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user := &domain.User{Name: "alert owner"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	sensor := s.newSensor(ctx)
+	alert := s.newAlert(ctx, sensor.ID, now())
+
+	acknowledgedAt := now()
+	alert.AcknowledgedBy, alert.AcknowledgedAt = &user.ID, &acknowledgedAt
+	s.Require().NoError(s.Alerts.SaveAlert(ctx, alert))
+	actual, err := s.Alerts.GetAlertByID(ctx, alert.ID)
+	s.Require().NoError(err)
+	s.Equal(*alert, *actual)
+	s.Equal(domain.AlertStateAcknowledged, actual.State())
+
+	resolvedAt := acknowledgedAt.Add(time.Minute)
+	alert.ResolvedBy, alert.ResolvedAt = &user.ID, &resolvedAt
+	s.Require().NoError(s.Alerts.SaveAlert(ctx, alert))
+	actual, err = s.Alerts.GetAlertByID(ctx, alert.ID)
+	s.Require().NoError(err)
+	s.Equal(*alert, *actual)
+	s.Equal(domain.AlertStateResolved, actual.State())
+
+	err = s.Alerts.SaveAlert(ctx, &domain.Alert{ID: alert.ID + 1_000_000, ResolvedAt: &resolvedAt})
+	s.ErrorIs(err, usecase.ErrAlertNotFound)
+}
+
+func (s *AlertRepositorySuite) TestGetUnresolvedAlertBySensorID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	_, err := s.Alerts.GetUnresolvedAlertBySensorID(ctx, sensor.ID)
+	s.ErrorIs(err, usecase.ErrAlertNotFound)
+
+	alert := s.newAlert(ctx, sensor.ID, now())
+	actual, err := s.Alerts.GetUnresolvedAlertBySensorID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(*alert, *actual)
+
+	s.resolve(ctx, alert)
+	_, err = s.Alerts.GetUnresolvedAlertBySensorID(ctx, sensor.ID)
+	s.ErrorIs(err, usecase.ErrAlertNotFound)
+}
+
+func (s *AlertRepositorySuite) TestListAlerts() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first, second, other := s.newSensor(ctx), s.newSensor(ctx), s.newSensor(ctx)
+	start := now().Add(-time.Hour)
+	resolved := s.newAlert(ctx, first.ID, start)
+	s.resolve(ctx, resolved)
+	open := s.newAlert(ctx, first.ID, start.Add(time.Minute))
+	acknowledged := s.newAlert(ctx, second.ID, start.Add(time.Minute))
+	acknowledgedAt := now()
+	acknowledged.AcknowledgedAt = &acknowledgedAt
+	s.Require().NoError(s.Alerts.SaveAlert(ctx, acknowledged))
+	s.newAlert(ctx, other.ID, start.Add(2*time.Minute))
+
+	ids := func(alerts []domain.Alert) []int64 {
+		result := make([]int64, 0, len(alerts))
+		for _, alert := range alerts {
+			result = append(result, alert.ID)
+		}
+		return result
+	}
+	sensorIDs := []int64{first.ID, second.ID}
+
+	alerts, err := s.Alerts.ListAlerts(ctx, usecase.AlertQuery{SensorIDs: sensorIDs, Limit: 10})
+	s.Require().NoError(err)
+	s.Equal([]int64{acknowledged.ID, open.ID, resolved.ID}, ids(alerts))
+
+	alerts, err = s.Alerts.ListAlerts(ctx, usecase.AlertQuery{
+		SensorIDs: sensorIDs,
+		States:    []domain.AlertState{domain.AlertStateOpen, domain.AlertStateAcknowledged},
+		Limit:     10,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int64{acknowledged.ID, open.ID}, ids(alerts))
+
+	alerts, err = s.Alerts.ListAlerts(ctx, usecase.AlertQuery{SensorIDs: sensorIDs, States: []domain.AlertState{domain.AlertStateResolved}, Limit: 10})
+	s.Require().NoError(err)
+	s.Equal([]int64{resolved.ID}, ids(alerts))
+
+	alerts, err = s.Alerts.ListAlerts(ctx, usecase.AlertQuery{SensorIDs: sensorIDs, Limit: 1})
+	s.Require().NoError(err)
+	s.Equal([]int64{acknowledged.ID}, ids(alerts))
+}
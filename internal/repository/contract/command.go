@@ -0,0 +1,198 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// CommandRepositorySuite - контракт usecase.CommandRepository
+type CommandRepositorySuite struct {
+	suite.Suite
+
+	// Commands - проверяемый репозиторий
+	Commands usecase.CommandRepository
+	// Sensors - репозиторий того же хранилища, в котором создаются устройства
+	Sensors usecase.SensorRepository
+}
+
+func (s *CommandRepositorySuite) newActuator(ctx context.Context) *domain.Sensor {
+	sensor := &domain.Sensor{SerialNumber: serialNumber(), Type: domain.SensorTypeRelay}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	return sensor
+}
+
+func (s *CommandRepositorySuite) newCommand(ctx context.Context, sensorID int64, createdAt time.Time) *domain.Command {
+	command := &domain.Command{
+		SensorID:  sensorID,
+		Payload:   1,
+		State:     domain.CommandStatePending,
+		CreatedAt: createdAt,
+	}
+	s.Require().NoError(s.Commands.SaveCommand(ctx, command))
+	return command
+}
+
+func (s *CommandRepositorySuite) TestSaveCommand() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newActuator(ctx)
+	command := s.newCommand(ctx, sensor.ID, now())
+	s.NotZero(command.ID)
+
+	actual, err := s.Commands.GetCommandByID(ctx, command.ID)
+	s.Require().NoError(err)
+	s.Equal(*command, *actual)
+
+	_, err = s.Commands.GetCommandByID(ctx, command.ID+1_000_000)
+	s.ErrorIs(err, usecase.ErrCommandNotFound)
+}
+
+func (s *CommandRepositorySuite) TestSaveCommand_Update() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newActuator(ctx)
+	command := s.newCommand(ctx, sensor.ID, now())
+
+	completedAt := now()
+	command.State, command.Reason, command.CompletedAt = domain.CommandStateFailed, "device reported state 0", &completedAt
+	s.Require().NoError(s.Commands.SaveCommand(ctx, command))
+
+	actual, err := s.Commands.GetCommandByID(ctx, command.ID)
+	s.Require().NoError(err)
+	s.Equal(*command, *actual)
+
+	err = s.Commands.SaveCommand(ctx, &domain.Command{ID: command.ID + 1_000_000, State: domain.CommandStateFailed})
+	s.ErrorIs(err, usecase.ErrCommandNotFound)
+}
+
+func (s *CommandRepositorySuite) TestListCommands() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newActuator(ctx)
+	other := s.newActuator(ctx)
+	start := now()
+	first := s.newCommand(ctx, sensor.ID, start)
+	second := s.newCommand(ctx, sensor.ID, start.Add(time.Second))
+	s.newCommand(ctx, other.ID, start)
+
+	_, err := s.Commands.DeliverNextCommand(ctx, sensor.ID, start.Add(2*time.Second))
+	s.Require().NoError(err)
+
+	commands, err := s.Commands.ListCommands(ctx, usecase.CommandQuery{SensorID: sensor.ID, Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(commands, 2)
+	s.Equal(second.ID, commands[0].ID)
+	s.Equal(first.ID, commands[1].ID)
+
+	commands, err = s.Commands.ListCommands(ctx, usecase.CommandQuery{SensorID: sensor.ID, States: []domain.CommandState{domain.CommandStateDelivered}, Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(commands, 1)
+	s.Equal(first.ID, commands[0].ID)
+
+	commands, err = s.Commands.ListCommands(ctx, usecase.CommandQuery{SensorID: sensor.ID, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(commands, 1)
+	s.Equal(second.ID, commands[0].ID)
+}
+
+func (s *CommandRepositorySuite) TestDeliverNextCommand() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newActuator(ctx)
+	start := now()
+	first := s.newCommand(ctx, sensor.ID, start)
+	second := s.newCommand(ctx, sensor.ID, start)
+
+	deliveredAt := start.Add(time.Second)
+	delivered, err := s.Commands.DeliverNextCommand(ctx, sensor.ID, deliveredAt)
+	s.Require().NoError(err)
+	s.Equal(first.ID, delivered.ID)
+	s.Equal(domain.CommandStateDelivered, delivered.State)
+	s.Require().NotNil(delivered.DeliveredAt)
+	s.True(deliveredAt.Equal(*delivered.DeliveredAt))
+
+	delivered, err = s.Commands.DeliverNextCommand(ctx, sensor.ID, deliveredAt)
+	s.Require().NoError(err)
+	s.Equal(second.ID, delivered.ID)
+
+	_, err = s.Commands.DeliverNextCommand(ctx, sensor.ID, deliveredAt)
+	s.ErrorIs(err, usecase.ErrCommandNotFound)
+}
+
+func (s *CommandRepositorySuite) TestDeliverNextCommand_Concurrent() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newActuator(ctx)
+	const count = 5
+	for range count {
+		s.newCommand(ctx, sensor.ID, now())
+	}
+
+	var mutex sync.Mutex
+	delivered := map[int64]int{}
+	var wg sync.WaitGroup
+	for range 2 * count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			command, err := s.Commands.DeliverNextCommand(ctx, sensor.ID, now())
+			if err != nil {
+				return
+			}
+			mutex.Lock()
+			delivered[command.ID]++
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	s.Len(delivered, count)
+	for id, times := range delivered {
+		s.Equal(1, times, "command %d delivered more than once", id)
+	}
+}
+
+func (s *CommandRepositorySuite) TestFailExpiredCommands() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newActuator(ctx)
+	start := now()
+	expired := s.newCommand(ctx, sensor.ID, start)
+	fresh := s.newCommand(ctx, sensor.ID, start)
+	pending := s.newCommand(ctx, sensor.ID, start)
+
+	_, err := s.Commands.DeliverNextCommand(ctx, sensor.ID, start.Add(-time.Hour))
+	s.Require().NoError(err)
+	_, err = s.Commands.DeliverNextCommand(ctx, sensor.ID, start)
+	s.Require().NoError(err)
+
+	// другие наборы могут оставить в хранилище свои просроченные команды, поэтому число не проверяется
+	_, err = s.Commands.FailExpiredCommands(ctx, start.Add(-time.Minute), "acknowledgement timeout", start)
+	s.Require().NoError(err)
+
+	actual, err := s.Commands.GetCommandByID(ctx, expired.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.CommandStateFailed, actual.State)
+	s.Equal("acknowledgement timeout", actual.Reason)
+	s.Require().NotNil(actual.CompletedAt)
+	s.True(start.Equal(*actual.CompletedAt))
+
+	actual, err = s.Commands.GetCommandByID(ctx, fresh.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.CommandStateDelivered, actual.State)
+
+	actual, err = s.Commands.GetCommandByID(ctx, pending.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.CommandStatePending, actual.State)
+}
@@ -0,0 +1,23 @@
+// Package contract содержит общие наборы тестов для реализаций репозиториев usecase.
+// Каждое хранилище запускает их против своих репозиториев, поэтому наборы не
+// рассчитывают на пустую базу и создают данные с уникальными серийными номерами.
+package contract
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+var serialNumberCounter = rand.Int63n(1_000_000_000) //nolint: gosec // test data
+
+// serialNumber - возвращает уникальный в рамках процесса серийный номер из 10 цифр
+func serialNumber() string {
+	return fmt.Sprintf("%010d", atomic.AddInt64(&serialNumberCounter, 1)%10_000_000_000)
+}
+
+// now - текущее время с точностью, которую сохраняют все хранилища
+func now() time.Time {
+	return time.Now().Truncate(time.Microsecond).UTC()
+}
@@ -0,0 +1,257 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// EventRepositorySuite - контракт usecase.EventRepository
+type EventRepositorySuite struct {
+	suite.Suite
+
+	// Events - проверяемый репозиторий
+	Events usecase.EventRepository
+	// Sensors - репозиторий того же хранилища, в котором создаются датчики событий
+	Sensors usecase.SensorRepository
+}
+
+func (s *EventRepositorySuite) newSensor(ctx context.Context) *domain.Sensor {
+	sensor := &domain.Sensor{
+		SerialNumber: serialNumber(),
+		Type:         domain.SensorTypeADC,
+	}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	return sensor
+}
+
+func (s *EventRepositorySuite) saveEvent(ctx context.Context, sensor *domain.Sensor, timestamp time.Time, payload float64) domain.Event {
+	event := domain.Event{
+		Timestamp:          timestamp,
+		SensorSerialNumber: sensor.SerialNumber,
+		SensorID:           sensor.ID,
+		Payload:            payload,
+	}
+	s.Require().NoError(s.Events.SaveEvent(ctx, &event))
+	return event
+}
+
+func (s *EventRepositorySuite) TestGetLastEventBySensorID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	other := s.newSensor(ctx)
+	start := now()
+
+	last := s.saveEvent(ctx, sensor, start.Add(10*time.Minute), 2)
+	s.saveEvent(ctx, sensor, start, 1)
+	s.saveEvent(ctx, other, start.Add(time.Hour), 3)
+
+	event, err := s.Events.GetLastEventBySensorID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(last, *event)
+}
+
+func (s *EventRepositorySuite) TestGetLastEventBySensorID_NotFound() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+
+	_, err := s.Events.GetLastEventBySensorID(ctx, sensor.ID)
+	s.ErrorIs(err, usecase.ErrEventNotFound)
+}
+
+func (s *EventRepositorySuite) TestGetEventsBySensorIDWithDate() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	other := s.newSensor(ctx)
+	start := now()
+	end := start.Add(time.Hour)
+
+	s.saveEvent(ctx, sensor, start.Add(-time.Second), 0)
+	third := s.saveEvent(ctx, sensor, end, 3)
+	first := s.saveEvent(ctx, sensor, start, 1)
+	second := s.saveEvent(ctx, sensor, start.Add(time.Minute), 2)
+	s.saveEvent(ctx, sensor, end.Add(time.Second), 4)
+	s.saveEvent(ctx, other, start.Add(time.Minute), 5)
+
+	events, err := s.Events.GetEventsBySensorIDWithDate(ctx, sensor.ID, start, end)
+	s.Require().NoError(err)
+	s.Equal([]domain.Event{first, second, third}, events)
+}
+
+func (s *EventRepositorySuite) TestGetEventsBySensorIDWithDate_Empty() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	start := now()
+
+	events, err := s.Events.GetEventsBySensorIDWithDate(ctx, sensor.ID, start, start.Add(time.Hour))
+	s.NoError(err)
+	s.Empty(events)
+
+	s.saveEvent(ctx, sensor, start.Add(2*time.Hour), 1)
+
+	events, err = s.Events.GetEventsBySensorIDWithDate(ctx, sensor.ID, start, start.Add(time.Hour))
+	s.NoError(err)
+	s.Empty(events)
+}
+
+func (s *EventRepositorySuite) TestSaveEvent_FractionalAndReadings() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	event := domain.Event{
+		Timestamp:          now(),
+		SensorSerialNumber: sensor.SerialNumber,
+		SensorID:           sensor.ID,
+		Payload:            21.5,
+		Readings:           domain.Readings{"temperature": 21.5, "humidity": 40.25},
+	}
+	s.Require().NoError(s.Events.SaveEvent(ctx, &event))
+
+	last, err := s.Events.GetLastEventBySensorID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(event, *last)
+
+	events, err := s.Events.GetEventsBySensorIDWithDate(ctx, sensor.ID, event.Timestamp, event.Timestamp)
+	s.Require().NoError(err)
+	s.Equal([]domain.Event{event}, events)
+}
+
+func (s *EventRepositorySuite) TestListEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	other := s.newSensor(ctx)
+	start := now()
+	end := start.Add(time.Hour)
+
+	s.saveEvent(ctx, sensor, start.Add(-time.Second), 0)
+	third := s.saveEvent(ctx, sensor, end, 3)
+	first := s.saveEvent(ctx, sensor, start, 1)
+	second := s.saveEvent(ctx, sensor, start, 2)
+	s.saveEvent(ctx, other, start, 5)
+
+	for _, desc := range []bool{false, true} {
+		want := []domain.Event{first, second, third}
+		if desc {
+			want = []domain.Event{third, second, first}
+		}
+		query := usecase.EventQuery{SensorID: sensor.ID, Start: start, End: end, Desc: desc, Limit: 10}
+		events, err := s.Events.ListEvents(ctx, query)
+		s.Require().NoError(err)
+		s.Equal(want, events, "desc %v", desc)
+
+		query.Limit = 2
+		page, err := s.Events.ListEvents(ctx, query)
+		s.Require().NoError(err)
+		s.Equal(want[:2], page, "desc %v", desc)
+
+		query.After = &page[1]
+		page, err = s.Events.ListEvents(ctx, query)
+		s.Require().NoError(err)
+		s.Equal(want[2:], page, "desc %v", desc)
+	}
+}
+
+func (s *EventRepositorySuite) TestExportEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	other := s.newSensor(ctx)
+	skipped := s.newSensor(ctx)
+	start := now()
+	end := start.Add(time.Hour)
+
+	s.saveEvent(ctx, sensor, start.Add(-time.Second), 0)
+	third := s.saveEvent(ctx, other, end, 3)
+	first := s.saveEvent(ctx, sensor, start, 1)
+	second := s.saveEvent(ctx, other, start, 2)
+	s.saveEvent(ctx, skipped, start, 4)
+
+	var events []domain.Event
+	query := usecase.EventExportQuery{SensorIDs: []int64{sensor.ID, other.ID}, Start: start, End: end}
+	err := s.Events.ExportEvents(ctx, query, func(event domain.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal([]domain.Event{first, second, third}, events)
+
+	// ошибка обработчика прерывает выгрузку
+	stop := errors.New("stop")
+	calls := 0
+	err = s.Events.ExportEvents(ctx, query, func(domain.Event) error {
+		calls++
+		return stop
+	})
+	s.ErrorIs(err, stop)
+	s.Equal(1, calls)
+}
+
+func (s *EventRepositorySuite) TestExportEvents_AllSensors() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	other := s.newSensor(ctx)
+	start := now()
+
+	first := s.saveEvent(ctx, sensor, start, 1)
+	second := s.saveEvent(ctx, other, start, 2)
+
+	ids := map[int64]bool{}
+	err := s.Events.ExportEvents(ctx, usecase.EventExportQuery{Start: start, End: start}, func(event domain.Event) error {
+		ids[event.ID] = true
+		return nil
+	})
+	s.Require().NoError(err)
+	s.True(ids[first.ID])
+	s.True(ids[second.ID])
+}
+
+func (s *EventRepositorySuite) TestSaveEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	start := now()
+	events := make([]domain.Event, 0, 1200)
+	for i := range cap(events) {
+		event := domain.Event{
+			Timestamp:          start.Add(time.Duration(i) * time.Second),
+			SensorSerialNumber: sensor.SerialNumber,
+			SensorID:           sensor.ID,
+			Payload:            float64(i) / 2,
+		}
+		if i%2 == 0 {
+			event.Readings = domain.Readings{"channel": float64(i)}
+		}
+		events = append(events, event)
+	}
+	s.Require().NoError(s.Events.SaveEvents(ctx, events))
+
+	stored, err := s.Events.GetEventsBySensorIDWithDate(ctx, sensor.ID, start, start.Add(time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(stored, len(events))
+	// ID задаются каждому событию и совпадают с сохранёнными
+	for i := range events {
+		s.NotZero(events[i].ID)
+	}
+	s.Equal(events, stored)
+
+	s.NoError(s.Events.SaveEvents(ctx, nil))
+}
@@ -0,0 +1,169 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// NotificationRepositorySuite - контракт usecase.NotificationRepository
+type NotificationRepositorySuite struct {
+	suite.Suite
+
+	// Notifications - проверяемый репозиторий
+	Notifications usecase.NotificationRepository
+	// Alerts - репозиторий того же хранилища, в котором создаются тревоги
+	Alerts usecase.AlertRepository
+	// Sensors - репозиторий того же хранилища, в котором создаются датчики
+	Sensors usecase.SensorRepository
+	// Users - репозиторий того же хранилища, в котором создаются пользователи
+	Users usecase.UserRepository
+}
+
+func (s *NotificationRepositorySuite) newChannel(ctx context.Context) *domain.NotificationChannel {
+	user := &domain.User{Name: "subscriber"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	channel := &domain.NotificationChannel{
+		UserID:      user.ID,
+		Kind:        domain.NotificationChannelEmail,
+		Target:      "subscriber@example.com",
+		MinSeverity: domain.AlertSeverityWarning,
+	}
+	s.Require().NoError(s.Notifications.SaveChannel(ctx, channel))
+	return channel
+}
+
+func (s *NotificationRepositorySuite) newAlert(ctx context.Context) *domain.Alert {
+	sensor := &domain.Sensor{SerialNumber: serialNumber(), Type: domain.SensorTypeContactClosure}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	alert := &domain.Alert{SensorID: sensor.ID, Severity: domain.AlertSeverityCritical, Payload: 1, TriggeredAt: now()}
+	s.Require().NoError(s.Alerts.SaveAlert(ctx, alert))
+	return alert
+}
+
+func (s *NotificationRepositorySuite) TestSaveChannel() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user := &domain.User{Name: "subscriber"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	first := &domain.NotificationChannel{
+		UserID:      user.ID,
+		Kind:        domain.NotificationChannelPush,
+		Target:      "https://example.com/hook",
+		MinSeverity: domain.AlertSeverityInfo,
+		Template:    "{{.Sensor.Description}}: {{.Value}}",
+		QuietHours:  &domain.QuietHours{Start: 22 * 60, End: 7 * 60, Location: "Europe/Moscow"},
+		MaxPerHour:  5,
+	}
+	s.Require().NoError(s.Notifications.SaveChannel(ctx, first))
+	s.NotZero(first.ID)
+	second := &domain.NotificationChannel{
+		UserID:      user.ID,
+		Kind:        domain.NotificationChannelEmail,
+		Target:      "subscriber@example.com",
+		MinSeverity: domain.AlertSeverityCritical,
+	}
+	s.Require().NoError(s.Notifications.SaveChannel(ctx, second))
+
+	actual, err := s.Notifications.GetChannelByID(ctx, first.ID)
+	s.Require().NoError(err)
+	s.Equal(*first, *actual)
+
+	channels, err := s.Notifications.GetChannelsByUserID(ctx, user.ID)
+	s.Require().NoError(err)
+	s.Equal([]domain.NotificationChannel{*first, *second}, channels)
+}
+
+func (s *NotificationRepositorySuite) TestGetChannel_NotFound() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.Notifications.GetChannelByID(ctx, 1_000_000)
+	s.ErrorIs(err, usecase.ErrChannelNotFound)
+
+	channels, err := s.Notifications.GetChannelsByUserID(ctx, 1_000_000)
+	s.NoError(err)
+	s.Empty(channels)
+}
+
+func (s *NotificationRepositorySuite) TestDeleteChannel() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	channel := s.newChannel(ctx)
+	alert := s.newAlert(ctx)
+	s.Require().NoError(s.Notifications.SaveDelivery(ctx, &domain.Delivery{
+		ChannelID: channel.ID,
+		AlertID:   alert.ID,
+		Status:    domain.DeliveryStatusSent,
+		CreatedAt: now(),
+	}))
+
+	s.Require().NoError(s.Notifications.DeleteChannel(ctx, channel.ID))
+	_, err := s.Notifications.GetChannelByID(ctx, channel.ID)
+	s.ErrorIs(err, usecase.ErrChannelNotFound)
+	deliveries, err := s.Notifications.ListDeliveries(ctx, channel.ID, 10)
+	s.NoError(err)
+	s.Empty(deliveries)
+
+	s.ErrorIs(s.Notifications.DeleteChannel(ctx, channel.ID), usecase.ErrChannelNotFound)
+}
+
+func (s *NotificationRepositorySuite) TestSaveDelivery_ChannelNotFound() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	alert := s.newAlert(ctx)
+	err := s.Notifications.SaveDelivery(ctx, &domain.Delivery{
+		ChannelID: 1_000_000,
+		AlertID:   alert.ID,
+		Status:    domain.DeliveryStatusSent,
+		CreatedAt: now(),
+	})
+	s.ErrorIs(err, usecase.ErrChannelNotFound)
+}
+
+func (s *NotificationRepositorySuite) TestListAndCountDeliveries() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	channel := s.newChannel(ctx)
+	alert := s.newAlert(ctx)
+	start := now()
+	statuses := []domain.DeliveryStatus{
+		domain.DeliveryStatusSent,
+		domain.DeliveryStatusFailed,
+		domain.DeliveryStatusSent,
+		domain.DeliveryStatusSuppressed,
+	}
+	deliveries := make([]domain.Delivery, 0, len(statuses))
+	for i, status := range statuses {
+		delivery := domain.Delivery{
+			ChannelID: channel.ID,
+			AlertID:   alert.ID,
+			Status:    status,
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		}
+		if status == domain.DeliveryStatusFailed {
+			delivery.Reason = "connection refused"
+		}
+		s.Require().NoError(s.Notifications.SaveDelivery(ctx, &delivery))
+		s.NotZero(delivery.ID)
+		deliveries = append(deliveries, delivery)
+	}
+
+	actual, err := s.Notifications.ListDeliveries(ctx, channel.ID, 3)
+	s.Require().NoError(err)
+	s.Equal([]domain.Delivery{deliveries[3], deliveries[2], deliveries[1]}, actual)
+
+	count, err := s.Notifications.CountDeliveries(ctx, channel.ID, domain.DeliveryStatusSent, start)
+	s.Require().NoError(err)
+	s.Equal(2, count)
+	count, err = s.Notifications.CountDeliveries(ctx, channel.ID, domain.DeliveryStatusSent, start.Add(time.Minute))
+	s.Require().NoError(err)
+	s.Equal(1, count)
+}
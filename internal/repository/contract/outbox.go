@@ -0,0 +1,106 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// OutboxRepositorySuite - контракт usecase.OutboxRepository
+type OutboxRepositorySuite struct {
+	suite.Suite
+
+	// Outbox - проверяемый репозиторий
+	Outbox usecase.OutboxRepository
+}
+
+func (s *OutboxRepositorySuite) saveChange(ctx context.Context, change domain.Change) domain.Change {
+	change.CreatedAt = now()
+	s.Require().NoError(s.Outbox.SaveChange(ctx, &change))
+	s.Require().Positive(change.Seq)
+	return change
+}
+
+func (s *OutboxRepositorySuite) TestSaveChange_ListChanges() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	event := s.saveChange(ctx, domain.Change{Kind: domain.ChangeKindEvent, Event: &domain.Event{
+		ID:                 1,
+		Timestamp:          now(),
+		SensorSerialNumber: serialNumber(),
+		SensorID:           1,
+		Payload:            21.5,
+		Readings:           domain.Readings{"temperature": 21.5},
+	}})
+	minPayload := 10.0
+	sensor := s.saveChange(ctx, domain.Change{Kind: domain.ChangeKindSensor, Sensor: &domain.Sensor{
+		ID:           1,
+		SerialNumber: serialNumber(),
+		Type:         domain.SensorTypeADC,
+		IsActive:     true,
+		RegisteredAt: now(),
+		AlertRule:    &domain.AlertRule{Severity: domain.AlertSeverityWarning, Min: &minPayload},
+	}})
+	s.Greater(sensor.Seq, event.Seq)
+
+	changes, err := s.Outbox.ListChanges(ctx, event.Seq-1, 10)
+	s.Require().NoError(err)
+	s.Equal([]domain.Change{event, sensor}, changes)
+
+	changes, err = s.Outbox.ListChanges(ctx, event.Seq-1, 1)
+	s.Require().NoError(err)
+	s.Equal([]domain.Change{event}, changes)
+
+	changes, err = s.Outbox.ListChanges(ctx, sensor.Seq, 10)
+	s.Require().NoError(err)
+	s.Empty(changes)
+}
+
+func (s *OutboxRepositorySuite) TestRelayPosition() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sink := "sink-" + serialNumber()
+	seq, err := s.Outbox.GetRelayPosition(ctx, sink)
+	s.Require().NoError(err)
+	s.Zero(seq)
+
+	s.Require().NoError(s.Outbox.SaveRelayPosition(ctx, sink, 5))
+	s.Require().NoError(s.Outbox.SaveRelayPosition(ctx, sink, 7))
+	seq, err = s.Outbox.GetRelayPosition(ctx, sink)
+	s.Require().NoError(err)
+	s.Equal(int64(7), seq)
+
+	seq, err = s.Outbox.GetRelayPosition(ctx, "other-"+sink)
+	s.Require().NoError(err)
+	s.Zero(seq, "у каждого приёмника своя позиция")
+}
+
+func (s *OutboxRepositorySuite) TestLockRelay() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sink := "sink-" + serialNumber()
+	release, ok, err := s.Outbox.LockRelay(ctx, sink)
+	s.Require().NoError(err)
+	s.Require().True(ok)
+
+	// пока доставка захвачена, второй relay в тот же приёмник не начинается, в другой - начинается
+	_, ok, err = s.Outbox.LockRelay(ctx, sink)
+	s.Require().NoError(err)
+	s.False(ok)
+	releaseOther, ok, err := s.Outbox.LockRelay(ctx, "other-"+sink)
+	s.Require().NoError(err)
+	s.True(ok)
+	releaseOther()
+
+	release()
+	release, ok, err = s.Outbox.LockRelay(ctx, sink)
+	s.Require().NoError(err)
+	s.True(ok)
+	release()
+}
@@ -0,0 +1,67 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// RateLimitRepositorySuite - контракт usecase.RateLimitRepository
+type RateLimitRepositorySuite struct {
+	suite.Suite
+
+	// RateLimits - проверяемый репозиторий
+	RateLimits usecase.RateLimitRepository
+}
+
+func (s *RateLimitRepositorySuite) TestTakeToken_Burst() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := "sensor:" + serialNumber()
+	limit := domain.RateLimit{Rate: 1.0 / 3600, Burst: 3}
+	for i := 0; i < limit.Burst; i++ {
+		retryAfter, err := s.RateLimits.TakeToken(ctx, key, limit)
+		s.Require().NoError(err)
+		s.Zero(retryAfter, "токен %d из вместимости корзины", i+1)
+	}
+
+	retryAfter, err := s.RateLimits.TakeToken(ctx, key, limit)
+	s.Require().NoError(err)
+	s.Greater(retryAfter, 59*time.Minute)
+	s.LessOrEqual(retryAfter, time.Hour+time.Second)
+
+	// отказ не забирает токен и не откладывает пополнение
+	again, err := s.RateLimits.TakeToken(ctx, key, limit)
+	s.Require().NoError(err)
+	s.LessOrEqual(again, retryAfter)
+
+	// у другого ключа своя корзина
+	retryAfter, err = s.RateLimits.TakeToken(ctx, "sensor:"+serialNumber(), limit)
+	s.Require().NoError(err)
+	s.Zero(retryAfter)
+}
+
+func (s *RateLimitRepositorySuite) TestTakeToken_Refill() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := "ip:" + serialNumber()
+	limit := domain.RateLimit{Rate: 20, Burst: 1}
+	retryAfter, err := s.RateLimits.TakeToken(ctx, key, limit)
+	s.Require().NoError(err)
+	s.Zero(retryAfter)
+
+	retryAfter, err = s.RateLimits.TakeToken(ctx, key, limit)
+	s.Require().NoError(err)
+	s.Positive(retryAfter)
+	s.LessOrEqual(retryAfter, 50*time.Millisecond)
+
+	time.Sleep(retryAfter + 10*time.Millisecond)
+	retryAfter, err = s.RateLimits.TakeToken(ctx, key, limit)
+	s.Require().NoError(err)
+	s.Zero(retryAfter, "за 1/Rate секунды появляется токен")
+}
@@ -0,0 +1,238 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ScheduleRepositorySuite - контракт usecase.ScheduleRepository
+type ScheduleRepositorySuite struct {
+	suite.Suite
+
+	// Schedules - проверяемый репозиторий
+	Schedules usecase.ScheduleRepository
+	// Users - репозиторий того же хранилища, в котором создаются пользователи
+	Users usecase.UserRepository
+}
+
+func (s *ScheduleRepositorySuite) newSchedule(ctx context.Context, nextRunAt *time.Time, enabled bool) *domain.Schedule {
+	user := &domain.User{Name: "planner"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	payload := 0.0
+	schedule := &domain.Schedule{
+		UserID:    user.ID,
+		Name:      "heating night mode",
+		Cron:      "0 23 * * 1-5",
+		Location:  "Europe/Moscow",
+		Enabled:   enabled,
+		Action:    domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &payload},
+		NextRunAt: nextRunAt,
+		CreatedAt: now(),
+	}
+	s.Require().NoError(s.Schedules.SaveSchedule(ctx, schedule))
+	return schedule
+}
+
+func (s *ScheduleRepositorySuite) TestSaveSchedule() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user := &domain.User{Name: "planner"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	at := now().Add(time.Hour)
+	threshold := 0.5
+	once := &domain.Schedule{
+		UserID:  user.ID,
+		Name:    "arm door",
+		At:      &at,
+		Enabled: true,
+		Action: domain.ScheduleAction{
+			Kind:      domain.ScheduleActionAlertRule,
+			SensorID:  1,
+			AlertRule: &domain.AlertRule{Max: &threshold, Severity: domain.AlertSeverityCritical},
+		},
+		NextRunAt: &at,
+		CreatedAt: now(),
+	}
+	s.Require().NoError(s.Schedules.SaveSchedule(ctx, once))
+	s.NotZero(once.ID)
+	repeated := s.newSchedule(ctx, nil, false)
+
+	actual, err := s.Schedules.GetScheduleByID(ctx, once.ID)
+	s.Require().NoError(err)
+	s.Equal(*once, *actual)
+
+	actual, err = s.Schedules.GetScheduleByID(ctx, repeated.ID)
+	s.Require().NoError(err)
+	s.Equal(*repeated, *actual)
+
+	schedules, err := s.Schedules.GetSchedulesByUserID(ctx, user.ID)
+	s.Require().NoError(err)
+	s.Equal([]domain.Schedule{*once}, schedules)
+
+	_, err = s.Schedules.GetScheduleByID(ctx, repeated.ID+1_000_000)
+	s.ErrorIs(err, usecase.ErrScheduleNotFound)
+}
+
+func (s *ScheduleRepositorySuite) TestSaveSchedule_Update() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule := s.newSchedule(ctx, nil, false)
+	next := now().Add(time.Hour)
+	schedule.Enabled, schedule.NextRunAt = true, &next
+	s.Require().NoError(s.Schedules.SaveSchedule(ctx, schedule))
+
+	actual, err := s.Schedules.GetScheduleByID(ctx, schedule.ID)
+	s.Require().NoError(err)
+	s.Equal(*schedule, *actual)
+
+	err = s.Schedules.SaveSchedule(ctx, &domain.Schedule{ID: schedule.ID + 1_000_000})
+	s.ErrorIs(err, usecase.ErrScheduleNotFound)
+}
+
+func (s *ScheduleRepositorySuite) TestDeleteSchedule() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule := s.newSchedule(ctx, nil, false)
+	s.Require().NoError(s.Schedules.SaveRun(ctx, &domain.ScheduleRun{
+		ScheduleID:  schedule.ID,
+		ScheduledAt: now(),
+		StartedAt:   now(),
+		Status:      domain.ScheduleRunSucceeded,
+	}))
+
+	s.Require().NoError(s.Schedules.DeleteSchedule(ctx, schedule.ID))
+	_, err := s.Schedules.GetScheduleByID(ctx, schedule.ID)
+	s.ErrorIs(err, usecase.ErrScheduleNotFound)
+	runs, err := s.Schedules.ListRuns(ctx, schedule.ID, 10)
+	s.Require().NoError(err)
+	s.Empty(runs)
+
+	s.ErrorIs(s.Schedules.DeleteSchedule(ctx, schedule.ID), usecase.ErrScheduleNotFound)
+}
+
+func (s *ScheduleRepositorySuite) TestDueSchedules() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := now()
+	earlier, later, future := start.Add(-2*time.Hour), start.Add(-time.Hour), start.Add(time.Hour)
+	second := s.newSchedule(ctx, &later, true)
+	first := s.newSchedule(ctx, &earlier, true)
+	notYet := s.newSchedule(ctx, &future, true)
+	disabled := s.newSchedule(ctx, &earlier, false)
+	finished := s.newSchedule(ctx, nil, true)
+
+	// другие наборы могут оставить в хранилище свои расписания, поэтому проверяется только порядок созданных здесь
+	due, err := s.Schedules.DueSchedules(ctx, start, 1_000)
+	s.Require().NoError(err)
+	position := map[int64]int{}
+	for i, schedule := range due {
+		position[schedule.ID] = i
+		s.False(schedule.NextRunAt.After(start))
+	}
+	s.Contains(position, first.ID)
+	s.Contains(position, second.ID)
+	s.Less(position[first.ID], position[second.ID])
+	s.NotContains(position, notYet.ID)
+	s.NotContains(position, disabled.ID)
+	s.NotContains(position, finished.ID)
+
+	due, err = s.Schedules.DueSchedules(ctx, start, 1)
+	s.Require().NoError(err)
+	s.Len(due, 1)
+}
+
+func (s *ScheduleRepositorySuite) TestAdvanceSchedule() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	scheduledAt := now().Add(-time.Minute)
+	schedule := s.newSchedule(ctx, &scheduledAt, true)
+	next := scheduledAt.Add(24 * time.Hour)
+
+	claimed, err := s.Schedules.AdvanceSchedule(ctx, schedule.ID, scheduledAt, &next)
+	s.Require().NoError(err)
+	s.True(claimed)
+	actual, err := s.Schedules.GetScheduleByID(ctx, schedule.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(actual.NextRunAt)
+	s.True(next.Equal(*actual.NextRunAt))
+
+	claimed, err = s.Schedules.AdvanceSchedule(ctx, schedule.ID, scheduledAt, &next)
+	s.Require().NoError(err)
+	s.False(claimed, "срабатывание уже забрано")
+
+	claimed, err = s.Schedules.AdvanceSchedule(ctx, schedule.ID, next, nil)
+	s.Require().NoError(err)
+	s.True(claimed)
+	actual, err = s.Schedules.GetScheduleByID(ctx, schedule.ID)
+	s.Require().NoError(err)
+	s.Nil(actual.NextRunAt)
+}
+
+func (s *ScheduleRepositorySuite) TestAdvanceSchedule_Concurrent() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	scheduledAt := now().Add(-time.Minute)
+	schedule := s.newSchedule(ctx, &scheduledAt, true)
+	next := scheduledAt.Add(time.Hour)
+
+	var mutex sync.Mutex
+	claims := 0
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := s.Schedules.AdvanceSchedule(ctx, schedule.ID, scheduledAt, &next)
+			if err != nil || !claimed {
+				return
+			}
+			mutex.Lock()
+			claims++
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(1, claims)
+}
+
+func (s *ScheduleRepositorySuite) TestListRuns() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule := s.newSchedule(ctx, nil, true)
+	start := now()
+	first := &domain.ScheduleRun{ScheduleID: schedule.ID, ScheduledAt: start, StartedAt: start, Status: domain.ScheduleRunSucceeded}
+	second := &domain.ScheduleRun{
+		ScheduleID:  schedule.ID,
+		ScheduledAt: start.Add(time.Hour),
+		StartedAt:   start.Add(time.Hour + time.Second),
+		Status:      domain.ScheduleRunFailed,
+		Error:       "webhook responded with 503 Service Unavailable",
+	}
+	s.Require().NoError(s.Schedules.SaveRun(ctx, first))
+	s.Require().NoError(s.Schedules.SaveRun(ctx, second))
+	s.NotZero(first.ID)
+
+	runs, err := s.Schedules.ListRuns(ctx, schedule.ID, 10)
+	s.Require().NoError(err)
+	s.Equal([]domain.ScheduleRun{*second, *first}, runs)
+
+	runs, err = s.Schedules.ListRuns(ctx, schedule.ID, 1)
+	s.Require().NoError(err)
+	s.Equal([]domain.ScheduleRun{*second}, runs)
+
+	err = s.Schedules.SaveRun(ctx, &domain.ScheduleRun{ScheduleID: schedule.ID + 1_000_000, Status: domain.ScheduleRunSucceeded})
+	s.ErrorIs(err, usecase.ErrScheduleNotFound)
+}
@@ -0,0 +1,408 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// SensorRepositorySuite - контракт usecase.SensorRepository
+type SensorRepositorySuite struct {
+	suite.Suite
+
+	// Sensors - проверяемый репозиторий
+	Sensors usecase.SensorRepository
+	// Transactor - транзакции того же хранилища
+	Transactor usecase.Transactor
+}
+
+func (s *SensorRepositorySuite) newSensor(ctx context.Context) *domain.Sensor {
+	sensor := &domain.Sensor{
+		SerialNumber: serialNumber(),
+		Type:         domain.SensorTypeADC,
+		CurrentState: 1,
+		Description:  "contract sensor",
+	}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	return sensor
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_AssignsID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := s.newSensor(ctx)
+	second := s.newSensor(ctx)
+
+	s.NotZero(first.ID)
+	s.NotZero(second.ID)
+	s.NotEqual(first.ID, second.ID)
+	s.False(first.RegisteredAt.IsZero())
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_DuplicateSerialNumber() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+
+	err := s.Sensors.SaveSensor(ctx, &domain.Sensor{
+		SerialNumber: sensor.SerialNumber,
+		Type:         domain.SensorTypeContactClosure,
+	})
+	s.ErrorIs(err, usecase.ErrSensorAlreadyExists)
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_Update() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	stored, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+
+	updated := *stored
+	updated.CurrentState = 42.5
+	updated.CurrentReadings = domain.Readings{"temperature": 21.5, "humidity": 40}
+	updated.Description = "updated"
+	updated.LastActivity = now()
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, &updated))
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(42.5, actual.CurrentState)
+	s.Equal(updated.CurrentReadings, actual.CurrentReadings)
+	s.Equal("updated", actual.Description)
+	s.Equal(updated.LastActivity, actual.LastActivity)
+	s.Equal(stored.RegisteredAt, actual.RegisteredAt)
+	s.Equal(stored.SerialNumber, actual.SerialNumber)
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_UpdatedAt() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	s.False(sensor.UpdatedAt.IsZero())
+	stored, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.True(sensor.UpdatedAt.Equal(stored.UpdatedAt))
+
+	// изменение без смены состояния и времени активности тоже сдвигает время сохранения
+	time.Sleep(time.Millisecond)
+	stored.Calibration = &domain.Calibration{Scale: 2}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, stored))
+	s.True(stored.UpdatedAt.After(sensor.UpdatedAt))
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.True(stored.UpdatedAt.Equal(actual.UpdatedAt))
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_Calibration() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	sensor.Calibration = &domain.Calibration{
+		Table: []domain.CalibrationPoint{{Raw: 0, Value: -40}, {Raw: 1023, Value: 125}},
+		Unit:  "°C",
+	}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(sensor.Calibration, actual.Calibration)
+
+	actual.Calibration = nil
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, actual))
+
+	actual, err = s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Nil(actual.Calibration)
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_AlertRule() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	limit := 0.0
+	sensor.AlertRule = &domain.AlertRule{Severity: domain.AlertSeverityCritical, Max: &limit}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(sensor.AlertRule, actual.AlertRule)
+
+	actual.AlertRule = nil
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, actual))
+
+	actual, err = s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Nil(actual.AlertRule)
+}
+
+func (s *SensorRepositorySuite) TestSaveSensorState() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	// событие обрабатывается по датчику, прочитанному до изменения калибровки и описания
+	read, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	sensor.Calibration = &domain.Calibration{Scale: 2}
+	sensor.Description = "changed meanwhile"
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+
+	read.CurrentState = 42.5
+	read.CurrentReadings = domain.Readings{"temperature": 21.5}
+	read.LastActivity = now()
+	s.Require().NoError(s.Sensors.SaveSensorState(ctx, read))
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(42.5, actual.CurrentState)
+	s.Equal(read.CurrentReadings, actual.CurrentReadings)
+	s.Equal(read.LastActivity, actual.LastActivity)
+	s.Equal(sensor.Calibration, actual.Calibration)
+	s.Equal("changed meanwhile", actual.Description)
+
+	// более раннее событие не сдвигает состояние назад
+	stale := *read
+	stale.CurrentState = 1
+	stale.LastActivity = read.LastActivity.Add(-time.Minute)
+	s.Require().NoError(s.Sensors.SaveSensorState(ctx, &stale))
+
+	actual, err = s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(42.5, actual.CurrentState)
+	s.Equal(read.LastActivity, actual.LastActivity)
+}
+
+func (s *SensorRepositorySuite) TestSaveSensor_UpdateUnknown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+
+	err := s.Sensors.SaveSensor(ctx, &domain.Sensor{
+		ID:           sensor.ID + 1_000_000,
+		SerialNumber: serialNumber(),
+		Type:         domain.SensorTypeADC,
+	})
+	s.ErrorIs(err, usecase.ErrSensorNotFound)
+}
+
+func (s *SensorRepositorySuite) TestGetSensors() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := s.newSensor(ctx)
+	second := s.newSensor(ctx)
+
+	sensors, err := s.Sensors.GetSensors(ctx)
+	s.Require().NoError(err)
+
+	ids := make([]int64, 0, len(sensors))
+	for _, sensor := range sensors {
+		ids = append(ids, sensor.ID)
+	}
+	s.Contains(ids, first.ID)
+	s.Contains(ids, second.ID)
+}
+
+func (s *SensorRepositorySuite) TestGetSensorByID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(sensor.ID, actual.ID)
+	s.Equal(sensor.SerialNumber, actual.SerialNumber)
+	s.Equal(sensor.Type, actual.Type)
+	s.Equal(sensor.CurrentState, actual.CurrentState)
+	s.Equal(sensor.Description, actual.Description)
+	s.Equal(sensor.IsActive, actual.IsActive)
+
+	_, err = s.Sensors.GetSensorByID(ctx, sensor.ID+1_000_000)
+	s.ErrorIs(err, usecase.ErrSensorNotFound)
+}
+
+func (s *SensorRepositorySuite) TestLockSensorByID_ConditionalUpdate() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+	errStale := errors.New("stale sensor")
+	const writers = 5
+	var (
+		wg      sync.WaitGroup
+		updated atomic.Int32
+	)
+	// каждый писатель проверяет версию датчика под блокировкой: успевает только первый
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				locked, err := s.Sensors.LockSensorByID(ctx, sensor.ID)
+				if err != nil {
+					return err
+				}
+				if !locked.UpdatedAt.Equal(sensor.UpdatedAt) {
+					return errStale
+				}
+				time.Sleep(time.Millisecond)
+				locked.Description = "updated"
+				return s.Sensors.SaveSensor(ctx, locked)
+			})
+			if err == nil {
+				updated.Add(1)
+				return
+			}
+			s.ErrorIs(err, errStale)
+		}()
+	}
+	wg.Wait()
+	s.Equal(int32(1), updated.Load())
+
+	_, err := s.Sensors.LockSensorByID(ctx, sensor.ID+1_000_000)
+	s.ErrorIs(err, usecase.ErrSensorNotFound)
+}
+
+func (s *SensorRepositorySuite) TestGetSensorBySerialNumber() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+
+	actual, err := s.Sensors.GetSensorBySerialNumber(ctx, sensor.SerialNumber)
+	s.Require().NoError(err)
+	s.Equal(sensor.ID, actual.ID)
+
+	_, err = s.Sensors.GetSensorBySerialNumber(ctx, serialNumber())
+	s.ErrorIs(err, usecase.ErrSensorNotFound)
+}
+
+func (s *SensorRepositorySuite) TestGetSensorByID_ReturnsCopy() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := s.newSensor(ctx)
+
+	actual, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	actual.CurrentState = 100
+
+	stored, err := s.Sensors.GetSensorByID(ctx, sensor.ID)
+	s.Require().NoError(err)
+	s.Equal(sensor.CurrentState, stored.CurrentState)
+}
+
+// listSensors - создаёт датчики с общим уникальным префиксом серийного номера,
+// чтобы выборка не зависела от датчиков других тестов
+func (s *SensorRepositorySuite) listSensors(ctx context.Context) (string, []*domain.Sensor) {
+	prefix := "list_" + serialNumber() + "_"
+	start := now()
+	sensors := []*domain.Sensor{
+		{SerialNumber: prefix + "c", Type: domain.SensorTypeADC, LastActivity: start.Add(time.Minute)},
+		{SerialNumber: prefix + "a", Type: domain.SensorTypeContactClosure, LastActivity: start},
+		{SerialNumber: prefix + "b", Type: domain.SensorTypeADC, LastActivity: start.Add(time.Minute)},
+	}
+	for _, sensor := range sensors {
+		s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	}
+	sensors[0].IsActive = true
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensors[0]))
+	return prefix, sensors
+}
+
+func sensorIDs(sensors []domain.Sensor) []int64 {
+	ids := make([]int64, 0, len(sensors))
+	for _, sensor := range sensors {
+		ids = append(ids, sensor.ID)
+	}
+	return ids
+}
+
+func (s *SensorRepositorySuite) TestListSensors_Sort() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	prefix, sensors := s.listSensors(ctx)
+	c, a, b := sensors[0], sensors[1], sensors[2]
+	filter := usecase.SensorFilter{SerialNumberPrefix: prefix}
+
+	tests := []struct {
+		sortBy usecase.SensorSortField
+		desc   bool
+		want   []int64
+	}{
+		{usecase.SensorSortByID, false, []int64{c.ID, a.ID, b.ID}},
+		{usecase.SensorSortByID, true, []int64{b.ID, a.ID, c.ID}},
+		{usecase.SensorSortBySerialNumber, false, []int64{a.ID, b.ID, c.ID}},
+		{usecase.SensorSortBySerialNumber, true, []int64{c.ID, b.ID, a.ID}},
+		{usecase.SensorSortByLastActivity, false, []int64{a.ID, c.ID, b.ID}},
+		{usecase.SensorSortByLastActivity, true, []int64{b.ID, c.ID, a.ID}},
+	}
+	for _, tt := range tests {
+		query := usecase.SensorQuery{Filter: filter, SortBy: tt.sortBy, Desc: tt.desc, Limit: 10}
+		actual, err := s.Sensors.ListSensors(ctx, query)
+		s.Require().NoError(err)
+		s.Equal(tt.want, sensorIDs(actual), "sort %s desc %v", tt.sortBy, tt.desc)
+
+		// постраничное чтение по одному датчику должно дать тот же порядок
+		var paged []int64
+		for {
+			query.Limit = 1
+			page, err := s.Sensors.ListSensors(ctx, query)
+			s.Require().NoError(err)
+			if len(page) == 0 {
+				break
+			}
+			paged = append(paged, page[0].ID)
+			query.After = &page[0]
+		}
+		s.Equal(tt.want, paged, "paged sort %s desc %v", tt.sortBy, tt.desc)
+	}
+}
+
+func (s *SensorRepositorySuite) TestListSensors_Filter() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	prefix, sensors := s.listSensors(ctx)
+	c, a, b := sensors[0], sensors[1], sensors[2]
+	active := true
+
+	tests := []struct {
+		name   string
+		filter usecase.SensorFilter
+		want   []int64
+	}{
+		{"prefix", usecase.SensorFilter{}, []int64{c.ID, a.ID, b.ID}},
+		{"type", usecase.SensorFilter{Type: domain.SensorTypeADC}, []int64{c.ID, b.ID}},
+		{"active", usecase.SensorFilter{IsActive: &active}, []int64{c.ID}},
+		{"last activity from", usecase.SensorFilter{LastActivityFrom: b.LastActivity}, []int64{c.ID, b.ID}},
+		{"last activity to", usecase.SensorFilter{LastActivityTo: a.LastActivity}, []int64{a.ID}},
+	}
+	for _, tt := range tests {
+		tt.filter.SerialNumberPrefix = prefix
+		actual, err := s.Sensors.ListSensors(ctx, usecase.SensorQuery{Filter: tt.filter, Limit: 10})
+		s.Require().NoError(err)
+		s.Equal(tt.want, sensorIDs(actual), tt.name)
+	}
+
+	actual, err := s.Sensors.ListSensors(ctx, usecase.SensorQuery{Filter: usecase.SensorFilter{SerialNumberPrefix: prefix + "%"}, Limit: 10})
+	s.Require().NoError(err)
+	s.Empty(actual)
+}
@@ -0,0 +1,126 @@
+package contract
+
+import (
+	"context"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// UserRepositorySuite - контракт usecase.UserRepository
+type UserRepositorySuite struct {
+	suite.Suite
+
+	// Users - проверяемый репозиторий
+	Users usecase.UserRepository
+}
+
+func (s *UserRepositorySuite) TestSaveUser() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := &domain.User{Name: "first"}
+	second := &domain.User{Name: "second"}
+	s.Require().NoError(s.Users.SaveUser(ctx, first))
+	s.Require().NoError(s.Users.SaveUser(ctx, second))
+
+	s.NotZero(first.ID)
+	s.NotZero(second.ID)
+	s.NotEqual(first.ID, second.ID)
+}
+
+func (s *UserRepositorySuite) TestGetUserByID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user := &domain.User{Name: "vasya pupkin"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+
+	actual, err := s.Users.GetUserByID(ctx, user.ID)
+	s.Require().NoError(err)
+	s.Equal(*user, *actual)
+
+	_, err = s.Users.GetUserByID(ctx, user.ID+1_000_000)
+	s.ErrorIs(err, usecase.ErrUserNotFound)
+}
+
+// SensorOwnerRepositorySuite - контракт usecase.SensorOwnerRepository
+type SensorOwnerRepositorySuite struct {
+	suite.Suite
+
+	// SensorOwners - проверяемый репозиторий
+	SensorOwners usecase.SensorOwnerRepository
+	// Users - репозиторий того же хранилища, в котором создаются пользователи
+	Users usecase.UserRepository
+	// Sensors - репозиторий того же хранилища, в котором создаются датчики
+	Sensors usecase.SensorRepository
+}
+
+func (s *SensorOwnerRepositorySuite) newBinding(ctx context.Context) domain.SensorOwner {
+	user := &domain.User{Name: "owner"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	sensor := &domain.Sensor{SerialNumber: serialNumber(), Type: domain.SensorTypeContactClosure}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	return domain.SensorOwner{UserID: user.ID, SensorID: sensor.ID}
+}
+
+func (s *SensorOwnerRepositorySuite) TestSaveSensorOwner() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := s.newBinding(ctx)
+	sensor := &domain.Sensor{SerialNumber: serialNumber(), Type: domain.SensorTypeADC}
+	s.Require().NoError(s.Sensors.SaveSensor(ctx, sensor))
+	second := domain.SensorOwner{UserID: first.UserID, SensorID: sensor.ID}
+
+	s.Require().NoError(s.SensorOwners.SaveSensorOwner(ctx, first))
+	s.Require().NoError(s.SensorOwners.SaveSensorOwner(ctx, second))
+
+	owners, err := s.SensorOwners.GetSensorsByUserID(ctx, first.UserID)
+	s.Require().NoError(err)
+	s.ElementsMatch([]domain.SensorOwner{first, second}, owners)
+}
+
+func (s *SensorOwnerRepositorySuite) TestSaveSensorOwner_Duplicate() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	binding := s.newBinding(ctx)
+
+	s.Require().NoError(s.SensorOwners.SaveSensorOwner(ctx, binding))
+	s.ErrorIs(s.SensorOwners.SaveSensorOwner(ctx, binding), usecase.ErrSensorOwnerExists)
+}
+
+func (s *SensorOwnerRepositorySuite) TestGetSensorsByUserID_Empty() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	binding := s.newBinding(ctx)
+
+	owners, err := s.SensorOwners.GetSensorsByUserID(ctx, binding.UserID)
+	s.NoError(err)
+	s.Empty(owners)
+}
+
+func (s *SensorOwnerRepositorySuite) TestGetUsersBySensorID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := s.newBinding(ctx)
+	user := &domain.User{Name: "second owner"}
+	s.Require().NoError(s.Users.SaveUser(ctx, user))
+	second := domain.SensorOwner{UserID: user.ID, SensorID: first.SensorID}
+
+	owners, err := s.SensorOwners.GetUsersBySensorID(ctx, first.SensorID)
+	s.Require().NoError(err)
+	s.Empty(owners)
+
+	s.Require().NoError(s.SensorOwners.SaveSensorOwner(ctx, first))
+	s.Require().NoError(s.SensorOwners.SaveSensorOwner(ctx, second))
+
+	owners, err = s.SensorOwners.GetUsersBySensorID(ctx, first.SensorID)
+	s.Require().NoError(err)
+	s.ElementsMatch([]domain.SensorOwner{first, second}, owners)
+}
@@ -0,0 +1,17 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/inmemory"
+)
+
+func TestEventRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.EventRepositorySuite{
+		Events:  NewEventRepository(),
+		Sensors: sensorRepository.NewSensorRepository(),
+	})
+}
@@ -5,14 +5,18 @@ import (
 	"errors"
 	"homework/internal/domain"
 	"homework/internal/usecase"
-	"math"
+	"slices"
+	"sort"
 	"sync"
 	"time"
+
+	transaction "homework/internal/repository/transaction/inmemory"
 )
 
 type EventRepository struct {
 	// key - SensorID, value - events slice
 	events  map[int64][]*domain.Event
+	lastID  int64
 	rwMutex *sync.RWMutex
 }
 
@@ -32,34 +36,55 @@ func (r *EventRepository) SaveEvent(ctx context.Context, event *domain.Event) er
 			return errors.New("event is nil")
 		}
 		r.rwMutex.Lock()
-		r.events[event.SensorID] = append(r.events[event.SensorID], event)
+		r.lastID++
+		event.ID = r.lastID
+		stored := *event
+		stored.Readings = event.Readings.Clone()
+		r.events[event.SensorID] = append(r.events[event.SensorID], &stored)
 		r.rwMutex.Unlock()
+		transaction.OnRollback(ctx, func() {
+			r.rwMutex.Lock()
+			defer r.rwMutex.Unlock()
+			events := r.events[stored.SensorID]
+			for i := len(events) - 1; i >= 0; i-- {
+				if events[i] == &stored {
+					r.events[stored.SensorID] = append(events[:i:i], events[i+1:]...)
+					break
+				}
+			}
+		})
 		return nil
 	}
 }
 
+func (r *EventRepository) SaveEvents(ctx context.Context, events []domain.Event) error {
+	for i := range events {
+		if err := r.SaveEvent(ctx, &events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *EventRepository) GetLastEventBySensorID(ctx context.Context, id int64) (*domain.Event, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 		r.rwMutex.RLock()
-		if _, ok := r.events[id]; !ok {
-			return nil, usecase.ErrEventNotFound
-		}
-		events := r.events[id]
-		r.rwMutex.RUnlock()
-
-		var diffTime int64 = math.MaxInt64
+		defer r.rwMutex.RUnlock()
 		var resEvent *domain.Event
-		for _, event := range events {
-			timeCheck := time.Since(event.Timestamp).Nanoseconds()
-			if timeCheck < diffTime {
+		for _, event := range r.events[id] {
+			if resEvent == nil || eventLess(resEvent, event) {
 				resEvent = event
-				diffTime = timeCheck
 			}
 		}
-		return resEvent, nil
+		if resEvent == nil {
+			return nil, usecase.ErrEventNotFound
+		}
+		result := *resEvent
+		result.Readings = resEvent.Readings.Clone()
+		return &result, nil
 	}
 }
 
@@ -69,16 +94,79 @@ func (r *EventRepository) GetEventsBySensorIDWithDate(ctx context.Context, id in
 		return nil, ctx.Err()
 	default:
 		r.rwMutex.RLock()
-		if _, ok := r.events[id]; !ok {
-			return nil, usecase.ErrEventNotFound
-		}
 		var events []domain.Event
 		for _, event := range r.events[id] {
-			if event.Timestamp.After(start) && event.Timestamp.Before(end) {
-				events = append(events, *event)
+			if !event.Timestamp.Before(start) && !event.Timestamp.After(end) {
+				stored := *event
+				stored.Readings = event.Readings.Clone()
+				events = append(events, stored)
 			}
 		}
 		r.rwMutex.RUnlock()
+		sort.Slice(events, func(i, j int) bool {
+			return eventLess(&events[i], &events[j])
+		})
 		return events, nil
 	}
 }
+
+func (r *EventRepository) ListEvents(ctx context.Context, query usecase.EventQuery) ([]domain.Event, error) {
+	events, err := r.GetEventsBySensorIDWithDate(ctx, query.SensorID, query.Start, query.End)
+	if err != nil {
+		return nil, err
+	}
+	less := eventLess
+	if query.Desc {
+		less = func(a, b *domain.Event) bool { return eventLess(b, a) }
+		slices.Reverse(events)
+	}
+	if query.After != nil {
+		i := sort.Search(len(events), func(i int) bool { return less(query.After, &events[i]) })
+		events = events[i:]
+	}
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+	return events, nil
+}
+
+func (r *EventRepository) ExportEvents(ctx context.Context, query usecase.EventExportQuery, fn func(domain.Event) error) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	ids := slices.Compact(slices.Sorted(slices.Values(query.SensorIDs)))
+	if len(ids) == 0 {
+		r.rwMutex.RLock()
+		for id := range r.events {
+			ids = append(ids, id)
+		}
+		r.rwMutex.RUnlock()
+	}
+	var events []domain.Event
+	for _, id := range ids {
+		sensorEvents, err := r.GetEventsBySensorIDWithDate(ctx, id, query.Start, query.End)
+		if err != nil {
+			return err
+		}
+		events = append(events, sensorEvents...)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return eventLess(&events[i], &events[j])
+	})
+	for _, event := range events {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventLess - порядок событий по времени, при равенстве времени - по ID
+func eventLess(a, b *domain.Event) bool {
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	return a.ID < b.ID
+}
@@ -168,13 +168,14 @@ func TestEventRepository_GetEventsBySensorIDWithDate(t *testing.T) {
 		_, err := er.GetEventsBySensorIDWithDate(ctx, 0, time.Now(), time.Now().Add(time.Hour))
 		assert.ErrorIs(t, err, context.DeadlineExceeded)
 	})
-	t.Run("fail, event not found", func(t *testing.T) {
+	t.Run("ok, no events", func(t *testing.T) {
 		t.Parallel()
 		er := NewEventRepository()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		_, err := er.GetEventsBySensorIDWithDate(ctx, 0, time.Now(), time.Now())
-		assert.ErrorIs(t, err, usecase.ErrEventNotFound)
+		events, err := er.GetEventsBySensorIDWithDate(ctx, 0, time.Now(), time.Now())
+		assert.NoError(t, err)
+		assert.Empty(t, events)
 	})
 	t.Run("ok, save and get one", func(t *testing.T) {
 		t.Parallel()
@@ -187,8 +188,8 @@ func TestEventRepository_GetEventsBySensorIDWithDate(t *testing.T) {
 			SensorSerialNumber: "0123456789",
 			Payload:            0,
 		}
-		events := []domain.Event{*event}
 		_ = er.SaveEvent(ctx, event)
+		events := []domain.Event{*event}
 
 		actualEvent, err := er.GetEventsBySensorIDWithDate(ctx, event.SensorID, now, time.Now())
 		assert.NoError(t, err)
@@ -233,7 +234,8 @@ func FuzzTestGetEventsBySensorIDWithDate(f *testing.F) {
 			}
 			_ = er.SaveEvent(ctx, event)
 		}
-		_, err := er.GetEventsBySensorIDWithDate(ctx, b, time.Now().Add(-1*time.Minute), time.Now())
-		assert.Error(t, err)
+		events, err := er.GetEventsBySensorIDWithDate(ctx, b, time.Now().Add(-1*time.Minute), time.Now())
+		assert.NoError(t, err)
+		assert.Empty(t, events)
 	})
 }
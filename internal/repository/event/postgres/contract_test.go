@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/postgres"
+)
+
+type eventContractSuite struct {
+	contract.EventRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *eventContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Events = NewEventRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+}
+
+func (suite *eventContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestEventRepositoryContract(t *testing.T) {
+	suite.Run(t, new(eventContractSuite))
+}
@@ -5,12 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"slices"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
 )
 
-var ErrEventNotFound = errors.New("event not found")
+var ErrEventNotFound = usecase.ErrEventNotFound
 
 type EventRepository struct {
 	pool *pgxpool.Pool
@@ -22,45 +29,150 @@ func NewEventRepository(pool *pgxpool.Pool) *EventRepository {
 	}
 }
 
-const saveEventQuery = `INSERT INTO events (timestamp, sensor_serial_number, sensor_id, payload) VALUES ($1, $2, $3, $4)`
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *EventRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
+
+const eventColumns = `id, timestamp, sensor_serial_number, sensor_id, payload, readings`
+
+const saveEventQuery = `INSERT INTO events (timestamp, sensor_serial_number, sensor_id, payload, readings) VALUES ($1, $2, $3, $4, $5) RETURNING id`
 
-const getLastEventBySensorIDQuery = `SELECT * FROM events WHERE sensor_id = $1 ORDER BY timestamp DESC LIMIT 1`
+const nextEventIDsQuery = `SELECT nextval(pg_get_serial_sequence('events', 'id')) FROM generate_series(1, $1)`
 
-const getEventsByIDWithDateQuery = `SELECT * FROM events WHERE sensor_id = $1 BETWEEN $2 AND $3`
+const getLastEventBySensorIDQuery = `SELECT ` + eventColumns + ` FROM events WHERE sensor_id = $1 ORDER BY timestamp DESC, id DESC LIMIT 1`
+
+const getEventsByIDWithDateQuery = `SELECT ` + eventColumns + ` FROM events WHERE sensor_id = $1 AND timestamp BETWEEN $2 AND $3 ORDER BY timestamp, id`
+
+const eventsSensorIDForeignKey = "events_sensor_id_fkey"
 
 func (r *EventRepository) SaveEvent(ctx context.Context, event *domain.Event) error {
-	_, err := r.pool.Exec(ctx, saveEventQuery, event.Timestamp, event.SensorSerialNumber, event.SensorID, event.Payload)
+	row := r.db(ctx).QueryRow(ctx, saveEventQuery, event.Timestamp, event.SensorSerialNumber, event.SensorID, event.Payload, event.Readings.Clone())
+	err := row.Scan(&event.ID)
+	if pgerrors.IsForeignKeyViolation(err, eventsSensorIDForeignKey) {
+		return usecase.ErrSensorNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("can't save event: %w", err)
 	}
 	return nil
 }
 
-func (r *EventRepository) GetLastEventBySensorID(ctx context.Context, id int64) (*domain.Event, error) {
-	row := r.pool.QueryRow(ctx, getLastEventBySensorIDQuery, id)
-	event := &domain.Event{}
-	err := row.Scan(&event.Timestamp, &event.SensorSerialNumber, &event.SensorID, &event.Payload)
+// SaveEvents - сохраняет события через COPY. ID берутся из последовательности до вставки
+// и передаются в COPY явно, поэтому каждое событие получает ID своей строки
+func (r *EventRepository) SaveEvents(ctx context.Context, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	rows, err := r.db(ctx).Query(ctx, nextEventIDsQuery, len(events))
+	if err != nil {
+		return fmt.Errorf("can't save events: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return fmt.Errorf("can't save events: %w", err)
+	}
+	if len(ids) != len(events) {
+		return fmt.Errorf("can't save events: %d ids reserved for %d events", len(ids), len(events))
+	}
+	// события одного времени сохраняют порядок приёма в сортировке по (timestamp, id)
+	slices.Sort(ids)
+	source := pgx.CopyFromSlice(len(events), func(i int) ([]any, error) {
+		event := events[i]
+		return []any{ids[i], event.Timestamp, event.SensorSerialNumber, event.SensorID, event.Payload, event.Readings.Clone()}, nil
+	})
+	_, err = r.db(ctx).CopyFrom(ctx, pgx.Identifier{"events"}, []string{"id", "timestamp", "sensor_serial_number", "sensor_id", "payload", "readings"}, source)
+	if pgerrors.IsForeignKeyViolation(err, eventsSensorIDForeignKey) {
+		return usecase.ErrSensorNotFound
+	}
 	if err != nil {
+		return fmt.Errorf("can't save events: %w", err)
+	}
+	for i := range events {
+		events[i].ID = ids[i]
+	}
+	return nil
+}
+
+func (r *EventRepository) GetLastEventBySensorID(ctx context.Context, id int64) (*domain.Event, error) {
+	row := r.db(ctx).QueryRow(ctx, getLastEventBySensorIDQuery, id)
+	event, err := scanEvent(row)
+	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, ErrEventNotFound
 	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get last event: %w", err)
+	}
 	return event, nil
 }
 
 func (r *EventRepository) GetEventsBySensorIDWithDate(ctx context.Context, id int64, start, end time.Time) ([]domain.Event, error) {
-	rows, err := r.pool.Query(ctx, getEventsByIDWithDateQuery, id, start, end)
+	rows, err := r.db(ctx).Query(ctx, getEventsByIDWithDateQuery, id, start, end)
 	if err != nil {
-		return nil, ErrEventNotFound
+		return nil, fmt.Errorf("can't get events: %w", err)
 	}
 	defer rows.Close()
+	return scanEvents(rows)
+}
+
+func (r *EventRepository) ListEvents(ctx context.Context, query usecase.EventQuery) ([]domain.Event, error) {
+	b := sqlquery.New(sqlquery.Dollar)
+	b.Where("sensor_id = ?", query.SensorID)
+	b.Where("timestamp BETWEEN ? AND ?", query.Start, query.End)
+	direction, after := sqlquery.Order(query.Desc)
+	if query.After != nil {
+		b.Where("(timestamp, id) "+after+" (?, ?)", query.After.Timestamp, query.After.ID)
+	}
+
+	sql := `SELECT ` + eventColumns + ` FROM events` + b.WhereClause() + ` ORDER BY timestamp ` + direction + `, id ` + direction + ` LIMIT ` + b.Arg(query.Limit)
+	rows, err := r.db(ctx).Query(ctx, sql, b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list events: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+func (r *EventRepository) ExportEvents(ctx context.Context, query usecase.EventExportQuery, fn func(domain.Event) error) error {
+	b := sqlquery.New(sqlquery.Dollar)
+	b.Where("timestamp BETWEEN ? AND ?", query.Start, query.End)
+	sqlquery.In(b, "sensor_id", query.SensorIDs)
+
+	rows, err := r.db(ctx).Query(ctx, `SELECT `+eventColumns+` FROM events`+b.WhereClause()+` ORDER BY timestamp, id`, b.Args()...)
+	if err != nil {
+		return fmt.Errorf("can't export events: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return err
+		}
+		if err = fn(*event); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func scanEvents(rows pgx.Rows) ([]domain.Event, error) {
 	var events []domain.Event
 	for rows.Next() {
-		event := domain.Event{}
-		err = rows.Scan(&event.Timestamp, &event.SensorSerialNumber, &event.SensorID, &event.Payload)
+		event, err := scanEvent(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		events = append(events, event)
+		events = append(events, *event)
 	}
-	return events, nil
+	return events, rows.Err()
+}
+
+func scanEvent(row pgx.Row) (*domain.Event, error) {
+	event := &domain.Event{}
+	err := row.Scan(&event.ID, &event.Timestamp, &event.SensorSerialNumber, &event.SensorID, &event.Payload, &event.Readings)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
 }
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"homework/pkg/pg_test"
 	"testing"
 	"time"
@@ -25,6 +26,9 @@ func (suite *EventTestSuite) SetupSuite() {
 	suite.testDbInstance = suite.testDB.DbInstance
 
 	suite.repo = NewEventRepository(suite.testDbInstance)
+
+	_, err := suite.testDbInstance.Exec(context.Background(), `INSERT INTO sensors (id, serial_number, type) VALUES (1, '1234567890', 'cc'), (2, '0987654321', 'adc')`)
+	suite.Require().NoError(err)
 }
 
 func (suite *EventTestSuite) TearDownSuite() {
@@ -32,7 +36,8 @@ func (suite *EventTestSuite) TearDownSuite() {
 }
 
 func (suite *EventTestSuite) TestEventRepository_SaveEvent() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	err := suite.repo.SaveEvent(ctx, &domain.Event{
 		Timestamp:          time.Now().In(time.UTC),
@@ -45,7 +50,8 @@ func (suite *EventTestSuite) TestEventRepository_SaveEvent() {
 }
 
 func (suite *EventTestSuite) TestEventRepository_GetLastEventBySensorID() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	firstEvent := domain.Event{
 		Timestamp:          time.Now().Truncate(time.Microsecond).In(time.UTC),
@@ -73,6 +79,61 @@ func (suite *EventTestSuite) TestEventRepository_GetLastEventBySensorID() {
 	assert.Equal(suite.T(), secondEvent, *event)
 }
 
+func (suite *EventTestSuite) TestEventRepository_SaveEventUnknownSensor() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := suite.repo.SaveEvent(ctx, &domain.Event{
+		Timestamp:          time.Now().In(time.UTC),
+		SensorSerialNumber: "1111111111",
+		SensorID:           100,
+		Payload:            1,
+	})
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+}
+
+func (suite *EventTestSuite) TestEventRepository_SaveEventsUnknownSensor() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := suite.repo.SaveEvents(ctx, []domain.Event{{
+		Timestamp:          time.Now().In(time.UTC),
+		SensorSerialNumber: "1111111111",
+		SensorID:           100,
+		Payload:            1,
+	}})
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+}
+
+func (suite *EventTestSuite) TestEventRepository_SaveEventsIDs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	timestamp := time.Now().Truncate(time.Microsecond).In(time.UTC)
+	events := make([]domain.Event, 100)
+	for i := range events {
+		events[i] = domain.Event{
+			Timestamp:          timestamp.Add(time.Duration(i%3) * time.Second),
+			SensorSerialNumber: "1234567890",
+			SensorID:           1,
+			Payload:            float64(i),
+		}
+	}
+	suite.Require().NoError(suite.repo.SaveEvents(ctx, events))
+
+	// ID каждого события указывает на строку с его данными
+	for _, event := range events {
+		var stored domain.Event
+		err := suite.testDbInstance.QueryRow(ctx, `SELECT timestamp, payload FROM events WHERE id = $1`, event.ID).
+			Scan(&stored.Timestamp, &stored.Payload)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), event.Payload, stored.Payload)
+		assert.True(suite.T(), event.Timestamp.Equal(stored.Timestamp))
+	}
+}
+
 func TestEventTestSuite(t *testing.T) {
 	suite.Run(t, new(EventTestSuite))
 }
@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/sqlite"
+)
+
+type eventContractSuite struct {
+	contract.EventRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *eventContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Events = NewEventRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+}
+
+func (suite *eventContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestEventRepositoryContract(t *testing.T) {
+	suite.Run(t, new(eventContractSuite))
+}
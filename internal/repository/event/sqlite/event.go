@@ -0,0 +1,197 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"slices"
+	"strings"
+	"time"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type EventRepository struct {
+	db *sql.DB
+}
+
+func NewEventRepository(db *sql.DB) *EventRepository {
+	return &EventRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *EventRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const eventColumns = `id, timestamp, sensor_serial_number, sensor_id, payload, readings`
+
+const saveEventQuery = `INSERT INTO events (timestamp, sensor_serial_number, sensor_id, payload, readings) VALUES (?, ?, ?, ?, ?) RETURNING id`
+
+const getLastEventBySensorIDQuery = `SELECT ` + eventColumns + ` FROM events WHERE sensor_id = ? ORDER BY timestamp DESC, id DESC LIMIT 1`
+
+const getEventsByIDWithDateQuery = `SELECT ` + eventColumns + ` FROM events WHERE sensor_id = ? AND timestamp BETWEEN ? AND ? ORDER BY timestamp, id`
+
+func (r *EventRepository) SaveEvent(ctx context.Context, event *domain.Event) error {
+	readings, err := sqlitedb.Readings(event.Readings)
+	if err != nil {
+		return fmt.Errorf("can't save event: %w", err)
+	}
+	row := r.conn(ctx).QueryRowContext(ctx, saveEventQuery, sqlitedb.Timestamp(event.Timestamp), event.SensorSerialNumber, event.SensorID, event.Payload, readings)
+	err = row.Scan(&event.ID)
+	if sqlitedb.IsForeignKeyViolation(err) {
+		return usecase.ErrSensorNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("can't save event: %w", err)
+	}
+	return nil
+}
+
+// saveEventsChunk - число событий в одном INSERT, ограничено числом параметров запроса sqlite
+const saveEventsChunk = 500
+
+// SaveEvents - сохраняет события многострочными INSERT и задаёт их ID, атомарность обеспечивает транзакция из ctx
+func (r *EventRepository) SaveEvents(ctx context.Context, events []domain.Event) error {
+	for chunk := range slices.Chunk(events, saveEventsChunk) {
+		values := make([]string, 0, len(chunk))
+		args := make([]any, 0, 5*len(chunk))
+		for _, event := range chunk {
+			readings, err := sqlitedb.Readings(event.Readings)
+			if err != nil {
+				return fmt.Errorf("can't save events: %w", err)
+			}
+			values = append(values, "(?, ?, ?, ?, ?)")
+			args = append(args, sqlitedb.Timestamp(event.Timestamp), event.SensorSerialNumber, event.SensorID, event.Payload, readings)
+		}
+		ids, err := r.insertEvents(ctx, `INSERT INTO events (timestamp, sensor_serial_number, sensor_id, payload, readings) VALUES `+strings.Join(values, ", ")+` RETURNING id`, args)
+		if sqlitedb.IsForeignKeyViolation(err) {
+			return usecase.ErrSensorNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("can't save events: %w", err)
+		}
+		if len(ids) != len(chunk) {
+			return fmt.Errorf("can't save events: %d ids returned for %d events", len(ids), len(chunk))
+		}
+		for i := range chunk {
+			chunk[i].ID = ids[i]
+		}
+	}
+	return nil
+}
+
+// insertEvents - выполняет INSERT ... RETURNING id, id возвращаются в порядке строк VALUES
+func (r *EventRepository) insertEvents(ctx context.Context, query string, args []any) ([]int64, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *EventRepository) GetLastEventBySensorID(ctx context.Context, id int64) (*domain.Event, error) {
+	row := r.conn(ctx).QueryRowContext(ctx, getLastEventBySensorIDQuery, id)
+	event, err := scanEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get last event: %w", err)
+	}
+	return event, nil
+}
+
+func (r *EventRepository) GetEventsBySensorIDWithDate(ctx context.Context, id int64, start, end time.Time) ([]domain.Event, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, getEventsByIDWithDateQuery, id, sqlitedb.Timestamp(start), sqlitedb.Timestamp(end))
+	if err != nil {
+		return nil, fmt.Errorf("can't get events: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+func (r *EventRepository) ListEvents(ctx context.Context, query usecase.EventQuery) ([]domain.Event, error) {
+	b := sqlquery.New(sqlquery.Question)
+	b.Where("sensor_id = ?", query.SensorID)
+	b.Where("timestamp BETWEEN ? AND ?", sqlitedb.Timestamp(query.Start), sqlitedb.Timestamp(query.End))
+	direction, after := sqlquery.Order(query.Desc)
+	if query.After != nil {
+		b.Where("(timestamp, id) "+after+" (?, ?)", sqlitedb.Timestamp(query.After.Timestamp), query.After.ID)
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+eventColumns+` FROM events`+b.WhereClause()+` ORDER BY timestamp `+direction+`, id `+direction+` LIMIT `+b.Arg(query.Limit), b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list events: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+// ExportEvents - пока выгрузка не завершена, единственное соединение с базой занято
+func (r *EventRepository) ExportEvents(ctx context.Context, query usecase.EventExportQuery, fn func(domain.Event) error) error {
+	b := sqlquery.New(sqlquery.Question)
+	b.Where("timestamp BETWEEN ? AND ?", sqlitedb.Timestamp(query.Start), sqlitedb.Timestamp(query.End))
+	sqlquery.In(b, "sensor_id", query.SensorIDs)
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+eventColumns+` FROM events`+b.WhereClause()+` ORDER BY timestamp, id`, b.Args()...)
+	if err != nil {
+		return fmt.Errorf("can't export events: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return fmt.Errorf("can't scan event: %w", err)
+		}
+		if err = fn(*event); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func scanEvents(rows *sql.Rows) ([]domain.Event, error) {
+	var events []domain.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan event: %w", err)
+		}
+
+		events = append(events, *event)
+	}
+	return events, rows.Err()
+}
+
+func scanEvent(row interface{ Scan(dest ...any) error }) (*domain.Event, error) {
+	var timestamp int64
+	var readings sql.NullString
+	event := &domain.Event{}
+	if err := row.Scan(&event.ID, &timestamp, &event.SensorSerialNumber, &event.SensorID, &event.Payload, &readings); err != nil {
+		return nil, err
+	}
+	event.Timestamp = sqlitedb.Time(timestamp)
+	var err error
+	event.Readings, err = sqlitedb.ParseReadings(readings)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
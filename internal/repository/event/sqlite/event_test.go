@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/usecase"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// EventTestSuite - поведение, которое проверяется только для sqlite: общий контракт хранилищ событий
+// проверяется в contract_test.go. sqlite не называет нарушенный внешний ключ, поэтому неизвестный датчик
+// определяется отдельно
+type EventTestSuite struct {
+	suite.Suite
+	testDbInstance *sql.DB
+
+	repo *EventRepository
+}
+
+func (suite *EventTestSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.repo = NewEventRepository(suite.testDbInstance)
+
+	_, err = suite.testDbInstance.ExecContext(context.Background(), `INSERT INTO sensors (id, serial_number, type) VALUES (1, '1234567890', 'cc')`)
+	suite.Require().NoError(err)
+}
+
+func (suite *EventTestSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func (suite *EventTestSuite) TestEventRepository_SaveEventUnknownSensor() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := suite.repo.SaveEvent(ctx, &domain.Event{
+		Timestamp:          time.Now().In(time.UTC),
+		SensorSerialNumber: "1111111111",
+		SensorID:           100,
+		Payload:            1,
+	})
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+}
+
+func (suite *EventTestSuite) TestEventRepository_SaveEventsUnknownSensor() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := suite.repo.SaveEvents(ctx, []domain.Event{{
+		Timestamp:          time.Now().In(time.UTC),
+		SensorSerialNumber: "1111111111",
+		SensorID:           100,
+		Payload:            1,
+	}})
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+}
+
+func TestEventTestSuite(t *testing.T) {
+	suite.Run(t, new(EventTestSuite))
+}
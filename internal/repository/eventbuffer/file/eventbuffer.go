@@ -0,0 +1,469 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultMaxBytes - наибольший размер отложенных событий на диске
+	DefaultMaxBytes = 64 << 20
+	// DefaultSegmentSize - размер сегмента журнала, после которого записи идут в новый сегмент
+	DefaultSegmentSize = 4 << 20
+
+	// headerSize - заголовок записи: длина данных и их CRC-32C, big endian
+	headerSize = 8
+	// maxRecordSize - наибольший размер данных записи, большая длина в заголовке означает повреждение
+	maxRecordSize = 1 << 20
+
+	segmentExt = ".log"
+	cursorFile = "cursor"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// position - место в журнале: сегмент и смещение в нём
+type position struct {
+	segment uint64
+	offset  int64
+}
+
+// EventBufferRepository - журнал событий в каталоге на диске. Журнал состоит из сегментов, в которые записи
+// только дописываются; каждая запись защищена CRC-32C. Начало журнала хранится в файле cursor, прочитанные
+// сегменты удаляются. Повреждённые записи пропускаются, оборванная запись в конце журнала отрезается при открытии
+type EventBufferRepository struct {
+	dir         string
+	maxBytes    int64
+	segmentSize int64
+
+	mutex sync.Mutex
+	// segments - сегменты журнала по возрастанию, начиная с сегмента cursor
+	segments []uint64
+	// file - последний сегмент, открытый на запись, size - его размер
+	file      *os.File
+	size      int64
+	cursor    position
+	events    int
+	bytes     int64
+	corrupted uint64
+}
+
+// NewEventBufferRepository - открывает журнал в каталоге dir, создавая каталог при необходимости
+func NewEventBufferRepository(dir string, options ...func(*EventBufferRepository)) (*EventBufferRepository, error) {
+	r := &EventBufferRepository{
+		dir:         dir,
+		maxBytes:    DefaultMaxBytes,
+		segmentSize: DefaultSegmentSize,
+	}
+	for _, o := range options {
+		o(r)
+	}
+	if err := r.open(); err != nil {
+		return nil, fmt.Errorf("open event buffer %s: %w", dir, err)
+	}
+	return r, nil
+}
+
+// WithMaxBytes - наибольший размер отложенных событий, после которого AppendEvents возвращает usecase.ErrEventBufferFull
+func WithMaxBytes(maxBytes int64) func(*EventBufferRepository) {
+	return func(r *EventBufferRepository) {
+		if maxBytes > 0 {
+			r.maxBytes = maxBytes
+		}
+	}
+}
+
+// WithSegmentSize - размер сегмента журнала
+func WithSegmentSize(size int64) func(*EventBufferRepository) {
+	return func(r *EventBufferRepository) {
+		if size > 0 {
+			r.segmentSize = size
+		}
+	}
+}
+
+func (r *EventBufferRepository) AppendEvents(ctx context.Context, events []domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var buf []byte
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if len(payload) > maxRecordSize {
+			return fmt.Errorf("event of %d bytes does not fit into a record", len(payload))
+		}
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+		buf = binary.BigEndian.AppendUint32(buf, crc32.Checksum(payload, castagnoli))
+		buf = append(buf, payload...)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.bytes+int64(len(buf)) > r.maxBytes {
+		return fmt.Errorf("%w: %d of %d bytes used", usecase.ErrEventBufferFull, r.bytes, r.maxBytes)
+	}
+	if r.size >= r.segmentSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	_, err := r.file.Write(buf)
+	if err == nil {
+		err = r.file.Sync()
+	}
+	if err != nil {
+		// недописанные события не должны остаться в журнале
+		_, seekErr := r.file.Seek(r.size, io.SeekStart)
+		return errors.Join(err, r.file.Truncate(r.size), seekErr)
+	}
+	r.size += int64(len(buf))
+	r.bytes += int64(len(buf))
+	r.events += len(events)
+	return nil
+}
+
+func (r *EventBufferRepository) PeekEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	events, _, _, err := r.scan(r.cursor, limit)
+	return events, err
+}
+
+func (r *EventBufferRepository) DropEvents(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	n = min(n, r.events)
+	limit := n
+	if n == r.events {
+		// за последним событием могут быть только повреждённые записи, они пропускаются вместе с ним
+		limit = -1
+	}
+	_, next, corrupted, err := r.scan(r.cursor, limit)
+	if err != nil {
+		return err
+	}
+	if err = r.moveCursor(next); err != nil {
+		return err
+	}
+	r.events -= n
+	r.corrupted += corrupted
+	return nil
+}
+
+func (r *EventBufferRepository) Stats() usecase.EventBufferStats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return usecase.EventBufferStats{
+		Events:    r.events,
+		Bytes:     r.bytes,
+		Corrupted: r.corrupted,
+	}
+}
+
+// Close - закрывает сегмент, открытый на запись
+func (r *EventBufferRepository) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.file.Close()
+}
+
+// open - читает состояние журнала с диска
+func (r *EventBufferRepository) open() error {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	segments, err := r.listSegments()
+	if err != nil {
+		return err
+	}
+	cursor, err := r.readCursor()
+	if err != nil {
+		return err
+	}
+	// сегменты до cursor уже прочитаны, но могли остаться, если удаление прервалось
+	for len(segments) > 0 && segments[0] < cursor.segment {
+		if err = os.Remove(r.segmentPath(segments[0])); err != nil {
+			return err
+		}
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		segments = []uint64{max(cursor.segment, 1)}
+		cursor = position{segment: segments[0]}
+	}
+	if segments[0] != cursor.segment {
+		cursor = position{segment: segments[0]}
+	}
+	r.segments = segments
+	r.cursor = cursor
+
+	last := segments[len(segments)-1]
+	if r.file, err = os.OpenFile(r.segmentPath(last), os.O_RDWR|os.O_CREATE, 0o644); err != nil {
+		return err
+	}
+	if err = r.repairTail(); err != nil {
+		return errors.Join(err, r.file.Close())
+	}
+	for _, segment := range segments[:len(segments)-1] {
+		info, err := os.Stat(r.segmentPath(segment))
+		if err != nil {
+			return errors.Join(err, r.file.Close())
+		}
+		r.bytes += info.Size()
+	}
+	if cursor.segment == last {
+		// cursor не может указывать дальше целых записей
+		cursor.offset = min(cursor.offset, r.size)
+		r.cursor = cursor
+	}
+	r.bytes += r.size - cursor.offset
+	events, _, _, err := r.scan(cursor, -1)
+	if err != nil {
+		return errors.Join(err, r.file.Close())
+	}
+	r.events = len(events)
+	return nil
+}
+
+// repairTail - отрезает от последнего сегмента оборванную запись, чтобы новые записи шли за целыми
+func (r *EventBufferRepository) repairTail() error {
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	reader := bufio.NewReader(io.NewSectionReader(r.file, 0, size))
+	var offset int64
+	header := make([]byte, headerSize)
+	for offset < size {
+		if _, err = io.ReadFull(reader, header); err != nil {
+			break
+		}
+		length := int64(binary.BigEndian.Uint32(header))
+		if length > maxRecordSize || offset+headerSize+length > size {
+			break
+		}
+		if _, err = reader.Discard(int(length)); err != nil {
+			return err
+		}
+		offset += headerSize + length
+	}
+	if offset < size {
+		r.corrupted++
+		if err = r.file.Truncate(offset); err != nil {
+			return err
+		}
+		if err = r.file.Sync(); err != nil {
+			return err
+		}
+	}
+	r.size = offset
+	_, err = r.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+// scan - читает не более limit событий, начиная с from (все события при limit < 0). Возвращает место за последней
+// прочитанной записью и число пропущенных повреждённых записей. Запись с неверной CRC или данными пропускается,
+// а после неверной длины не прочитать ни одной записи сегмента, поэтому пропускается весь остаток сегмента
+func (r *EventBufferRepository) scan(from position, limit int) ([]domain.Event, position, uint64, error) {
+	var events []domain.Event
+	var corrupted uint64
+	pos := from
+	for idx, segment := range r.segments {
+		if segment < from.segment {
+			continue
+		}
+		if segment != pos.segment {
+			pos = position{segment: segment}
+		}
+		file, err := os.Open(r.segmentPath(segment))
+		if err != nil {
+			return nil, from, 0, err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			return nil, from, 0, errors.Join(err, file.Close())
+		}
+		size := info.Size()
+		if idx == len(r.segments)-1 {
+			// последний сегмент читается только до конца целых записей
+			size = r.size
+		}
+		reader := bufio.NewReader(io.NewSectionReader(file, pos.offset, size-pos.offset))
+		header := make([]byte, headerSize)
+		for pos.offset < size && (limit < 0 || len(events) < limit) {
+			if _, err = io.ReadFull(reader, header); err != nil {
+				corrupted++
+				pos.offset = size
+				break
+			}
+			length := int64(binary.BigEndian.Uint32(header))
+			if length > maxRecordSize || pos.offset+headerSize+length > size {
+				corrupted++
+				pos.offset = size
+				break
+			}
+			payload := make([]byte, length)
+			if _, err = io.ReadFull(reader, payload); err != nil {
+				return nil, from, 0, errors.Join(err, file.Close())
+			}
+			pos.offset += headerSize + length
+
+			var event domain.Event
+			if crc32.Checksum(payload, castagnoli) != binary.BigEndian.Uint32(header[4:]) ||
+				json.Unmarshal(payload, &event) != nil {
+				corrupted++
+				continue
+			}
+			events = append(events, event)
+		}
+		if err = file.Close(); err != nil {
+			return nil, from, 0, err
+		}
+		if limit >= 0 && len(events) == limit {
+			break
+		}
+	}
+	return events, pos, corrupted, nil
+}
+
+// moveCursor - сохраняет новое начало журнала и удаляет прочитанные сегменты
+func (r *EventBufferRepository) moveCursor(next position) error {
+	if next == r.cursor {
+		return nil
+	}
+	if err := r.writeCursor(next); err != nil {
+		return err
+	}
+	var dropped int64
+	for r.segments[0] < next.segment {
+		info, err := os.Stat(r.segmentPath(r.segments[0]))
+		if err != nil {
+			return err
+		}
+		dropped += info.Size()
+		// остаток сегмента удаляется с диска, а не остаётся в журнале
+		if err = os.Remove(r.segmentPath(r.segments[0])); err != nil {
+			return err
+		}
+		r.segments = r.segments[1:]
+	}
+	r.bytes -= dropped - r.cursor.offset + next.offset
+	r.cursor = next
+	return nil
+}
+
+// rotate - закрывает последний сегмент и начинает новый
+func (r *EventBufferRepository) rotate() error {
+	segment := r.segments[len(r.segments)-1] + 1
+	file, err := os.OpenFile(r.segmentPath(segment), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if err = syncDir(r.dir); err != nil {
+		return errors.Join(err, file.Close(), os.Remove(file.Name()))
+	}
+	if err = r.file.Close(); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	r.file = file
+	r.size = 0
+	r.segments = append(r.segments, segment)
+	return nil
+}
+
+func (r *EventBufferRepository) segmentPath(segment uint64) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%020d%s", segment, segmentExt))
+}
+
+// listSegments - сегменты в каталоге по возрастанию
+func (r *EventBufferRepository) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), segmentExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		segment, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// readCursor - начало журнала, нулевая позиция для нового журнала
+func (r *EventBufferRepository) readCursor() (position, error) {
+	data, err := os.ReadFile(filepath.Join(r.dir, cursorFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return position{}, nil
+	}
+	if err != nil {
+		return position{}, err
+	}
+	var cursor position
+	if _, err = fmt.Sscanf(string(data), "%d %d", &cursor.segment, &cursor.offset); err != nil {
+		return position{}, fmt.Errorf("read cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// writeCursor - записывает начало журнала через временный файл, чтобы сбой не оставил его недописанным
+func (r *EventBufferRepository) writeCursor(cursor position) error {
+	tmp := filepath.Join(r.dir, cursorFile+".tmp")
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(file, "%d %d\n", cursor.segment, cursor.offset); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	if err = file.Sync(); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, filepath.Join(r.dir, cursorFile)); err != nil {
+		return err
+	}
+	return syncDir(r.dir)
+}
+
+// syncDir - сохраняет на диск создание, удаление и переименование файлов в каталоге
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	return errors.Join(d.Sync(), d.Close())
+}
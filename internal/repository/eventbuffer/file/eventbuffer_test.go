@@ -0,0 +1,129 @@
+package file
+
+import (
+	"context"
+	"encoding/binary"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvents(payloads ...float64) []domain.Event {
+	events := make([]domain.Event, 0, len(payloads))
+	for _, payload := range payloads {
+		events = append(events, domain.Event{
+			Timestamp:          time.Now().Truncate(time.Microsecond).UTC(),
+			SensorSerialNumber: "0123456789",
+			SensorID:           7,
+			Payload:            payload,
+		})
+	}
+	return events
+}
+
+func payloads(events []domain.Event) []float64 {
+	var result []float64
+	for _, event := range events {
+		result = append(result, event.Payload)
+	}
+	return result
+}
+
+func open(t *testing.T, dir string, options ...func(*EventBufferRepository)) *EventBufferRepository {
+	r, err := NewEventBufferRepository(dir, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func TestEventBufferRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ok, events in order across segments and restarts", func(t *testing.T) {
+		dir := t.TempDir()
+		r := open(t, dir, WithSegmentSize(200))
+		require.NoError(t, r.AppendEvents(ctx, newEvents(1, 2)))
+		require.NoError(t, r.AppendEvents(ctx, newEvents(3)))
+		require.NoError(t, r.AppendEvents(ctx, newEvents(4, 5)))
+
+		events, err := r.PeekEvents(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 2}, payloads(events))
+		require.NoError(t, r.DropEvents(ctx, 3))
+		stats := r.Stats()
+		assert.Equal(t, 2, stats.Events)
+		assert.Positive(t, stats.Bytes)
+		require.NoError(t, r.Close())
+
+		r = open(t, dir, WithSegmentSize(200))
+		assert.Equal(t, stats, r.Stats())
+		events, err = r.PeekEvents(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{4, 5}, payloads(events))
+
+		require.NoError(t, r.DropEvents(ctx, 2))
+		assert.Equal(t, usecase.EventBufferStats{}, r.Stats())
+		segments, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+		require.NoError(t, err)
+		assert.Len(t, segments, 1, "прочитанные сегменты удалены")
+	})
+
+	t.Run("err, buffer is full", func(t *testing.T) {
+		r := open(t, t.TempDir(), WithMaxBytes(300))
+		require.NoError(t, r.AppendEvents(ctx, newEvents(1)))
+		err := r.AppendEvents(ctx, newEvents(2, 3, 4))
+		assert.ErrorIs(t, err, usecase.ErrEventBufferFull)
+		assert.Equal(t, 1, r.Stats().Events, "события пишутся все или ни одного")
+
+		require.NoError(t, r.DropEvents(ctx, 1))
+		require.NoError(t, r.AppendEvents(ctx, newEvents(2, 3)), "место освобождается после чтения")
+	})
+
+	t.Run("ok, corrupted record skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		r := open(t, dir)
+		require.NoError(t, r.AppendEvents(ctx, newEvents(1, 2, 3)))
+		require.NoError(t, r.Close())
+
+		// портим данные второй записи
+		path := r.segmentPath(r.segments[0])
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		first := headerSize + int(binary.BigEndian.Uint32(data))
+		data[first+headerSize+1] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+
+		r = open(t, dir)
+		assert.Equal(t, 2, r.Stats().Events)
+		events, err := r.PeekEvents(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 3}, payloads(events))
+		require.NoError(t, r.DropEvents(ctx, 2))
+		assert.Equal(t, uint64(1), r.Stats().Corrupted)
+	})
+
+	t.Run("ok, torn tail truncated", func(t *testing.T) {
+		dir := t.TempDir()
+		r := open(t, dir)
+		require.NoError(t, r.AppendEvents(ctx, newEvents(1, 2)))
+		require.NoError(t, r.Close())
+
+		path := r.segmentPath(r.segments[0])
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, info.Size()-3))
+
+		r = open(t, dir)
+		assert.Equal(t, uint64(1), r.Stats().Corrupted)
+		require.NoError(t, r.AppendEvents(ctx, newEvents(3)))
+		events, err := r.PeekEvents(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 3}, payloads(events))
+	})
+}
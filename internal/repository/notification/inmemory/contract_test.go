@@ -0,0 +1,21 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	alertRepository "homework/internal/repository/alert/inmemory"
+	sensorRepository "homework/internal/repository/sensor/inmemory"
+	userRepository "homework/internal/repository/user/inmemory"
+)
+
+func TestNotificationRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.NotificationRepositorySuite{
+		Notifications: NewNotificationRepository(),
+		Alerts:        alertRepository.NewAlertRepository(),
+		Sensors:       sensorRepository.NewSensorRepository(),
+		Users:         userRepository.NewUserRepository(),
+	})
+}
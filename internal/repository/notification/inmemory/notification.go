@@ -0,0 +1,200 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"sort"
+	"sync"
+	"time"
+
+	transaction "homework/internal/repository/transaction/inmemory"
+)
+
+type NotificationRepository struct {
+	channels       map[int64]*domain.NotificationChannel
+	deliveries     map[int64]domain.Delivery
+	lastChannelID  int64
+	lastDeliveryID int64
+	rwMutex        *sync.RWMutex
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		channels:   make(map[int64]*domain.NotificationChannel),
+		deliveries: make(map[int64]domain.Delivery),
+		rwMutex:    new(sync.RWMutex),
+	}
+}
+
+func (r *NotificationRepository) SaveChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if channel == nil {
+		return errors.New("notification channel is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	r.lastChannelID++
+	channel.ID = r.lastChannelID
+	stored := clone(channel)
+	r.channels[channel.ID] = &stored
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		delete(r.channels, stored.ID)
+	})
+	return nil
+}
+
+func (r *NotificationRepository) GetChannelByID(ctx context.Context, id int64) (*domain.NotificationChannel, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	channel, ok := r.channels[id]
+	if !ok {
+		return nil, usecase.ErrChannelNotFound
+	}
+	result := clone(channel)
+	return &result, nil
+}
+
+func (r *NotificationRepository) GetChannelsByUserID(ctx context.Context, userID int64) ([]domain.NotificationChannel, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	channels := []domain.NotificationChannel{}
+	for _, channel := range r.channels {
+		if channel.UserID == userID {
+			channels = append(channels, clone(channel))
+		}
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ID < channels[j].ID
+	})
+	return channels, nil
+}
+
+func (r *NotificationRepository) DeleteChannel(ctx context.Context, id int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	channel, ok := r.channels[id]
+	if !ok {
+		return usecase.ErrChannelNotFound
+	}
+	delete(r.channels, id)
+	deleted := []domain.Delivery{}
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.ChannelID == id {
+			deleted = append(deleted, delivery)
+			delete(r.deliveries, deliveryID)
+		}
+	}
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		r.channels[id] = channel
+		for _, delivery := range deleted {
+			r.deliveries[delivery.ID] = delivery
+		}
+	})
+	return nil
+}
+
+func (r *NotificationRepository) SaveDelivery(ctx context.Context, delivery *domain.Delivery) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if delivery == nil {
+		return errors.New("delivery is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	if _, ok := r.channels[delivery.ChannelID]; !ok {
+		return usecase.ErrChannelNotFound
+	}
+	r.lastDeliveryID++
+	delivery.ID = r.lastDeliveryID
+	r.deliveries[delivery.ID] = *delivery
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		delete(r.deliveries, delivery.ID)
+	})
+	return nil
+}
+
+func (r *NotificationRepository) ListDeliveries(ctx context.Context, channelID int64, limit int) ([]domain.Delivery, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	deliveries := []domain.Delivery{}
+	for _, delivery := range r.deliveries {
+		if delivery.ChannelID == channelID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID > deliveries[j].ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *NotificationRepository) CountDeliveries(ctx context.Context, channelID int64, status domain.DeliveryStatus, since time.Time) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	count := 0
+	for _, delivery := range r.deliveries {
+		if delivery.ChannelID == channelID && delivery.Status == status && !delivery.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// clone - копия канала, не разделяющая с оригиналом тихие часы
+func clone(channel *domain.NotificationChannel) domain.NotificationChannel {
+	result := *channel
+	if channel.QuietHours != nil {
+		quiet := *channel.QuietHours
+		result.QuietHours = &quiet
+	}
+	return result
+}
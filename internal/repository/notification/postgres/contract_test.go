@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	alertRepository "homework/internal/repository/alert/postgres"
+	sensorRepository "homework/internal/repository/sensor/postgres"
+	userRepository "homework/internal/repository/user/postgres"
+)
+
+type notificationContractSuite struct {
+	contract.NotificationRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *notificationContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Notifications = NewNotificationRepository(db)
+	suite.Alerts = alertRepository.NewAlertRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+	suite.Users = userRepository.NewUserRepository(db)
+}
+
+func (suite *notificationContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestNotificationRepositoryContract(t *testing.T) {
+	suite.Run(t, new(notificationContractSuite))
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
+)
+
+type NotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationRepository(pool *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{
+		pool: pool,
+	}
+}
+
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *NotificationRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
+
+const channelColumns = `id, user_id, kind, target, min_severity, template, quiet_hours, max_per_hour`
+
+const saveChannelQuery = `INSERT INTO notification_channels (user_id, kind, target, min_severity, template, quiet_hours, max_per_hour) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+const getChannelByIDQuery = `SELECT ` + channelColumns + ` FROM notification_channels WHERE id = $1`
+
+const getChannelsByUserIDQuery = `SELECT ` + channelColumns + ` FROM notification_channels WHERE user_id = $1 ORDER BY id`
+
+const deleteChannelQuery = `DELETE FROM notification_channels WHERE id = $1`
+
+const deliveryColumns = `id, channel_id, alert_id, status, reason, created_at`
+
+const saveDeliveryQuery = `INSERT INTO notification_deliveries (channel_id, alert_id, status, reason, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+const listDeliveriesQuery = `SELECT ` + deliveryColumns + ` FROM notification_deliveries WHERE channel_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2`
+
+const countDeliveriesQuery = `SELECT count(*) FROM notification_deliveries WHERE channel_id = $1 AND status = $2 AND created_at >= $3`
+
+const (
+	notificationChannelsUserIDForeignKey      = "notification_channels_user_id_fkey"
+	notificationDeliveriesChannelIDForeignKey = "notification_deliveries_channel_id_fkey"
+	notificationDeliveriesAlertIDForeignKey   = "notification_deliveries_alert_id_fkey"
+)
+
+func (r *NotificationRepository) SaveChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	row := r.db(ctx).QueryRow(ctx, saveChannelQuery, channel.UserID, channel.Kind, channel.Target, channel.MinSeverity, channel.Template, channel.QuietHours, channel.MaxPerHour)
+	err := row.Scan(&channel.ID)
+	switch {
+	case pgerrors.IsForeignKeyViolation(err, notificationChannelsUserIDForeignKey):
+		return usecase.ErrUserNotFound
+	case err != nil:
+		return fmt.Errorf("can't save notification channel: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) GetChannelByID(ctx context.Context, id int64) (*domain.NotificationChannel, error) {
+	channel, err := scanChannel(r.db(ctx).QueryRow(ctx, getChannelByIDQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get notification channel: %w", err)
+	}
+	return channel, nil
+}
+
+func (r *NotificationRepository) GetChannelsByUserID(ctx context.Context, userID int64) ([]domain.NotificationChannel, error) {
+	rows, err := r.db(ctx).Query(ctx, getChannelsByUserIDQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get notification channels: %w", err)
+	}
+	defer rows.Close()
+	channels := []domain.NotificationChannel{}
+	for rows.Next() {
+		channel, err := scanChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan notification channel: %w", err)
+		}
+		channels = append(channels, *channel)
+	}
+	return channels, rows.Err()
+}
+
+func (r *NotificationRepository) DeleteChannel(ctx context.Context, id int64) error {
+	tag, err := r.db(ctx).Exec(ctx, deleteChannelQuery, id)
+	if err != nil {
+		return fmt.Errorf("can't delete notification channel: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return usecase.ErrChannelNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepository) SaveDelivery(ctx context.Context, delivery *domain.Delivery) error {
+	row := r.db(ctx).QueryRow(ctx, saveDeliveryQuery, delivery.ChannelID, delivery.AlertID, delivery.Status, delivery.Reason, delivery.CreatedAt)
+	err := row.Scan(&delivery.ID)
+	switch {
+	case pgerrors.IsForeignKeyViolation(err, notificationDeliveriesChannelIDForeignKey):
+		return usecase.ErrChannelNotFound
+	case pgerrors.IsForeignKeyViolation(err, notificationDeliveriesAlertIDForeignKey):
+		return usecase.ErrAlertNotFound
+	case err != nil:
+		return fmt.Errorf("can't save delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) ListDeliveries(ctx context.Context, channelID int64, limit int) ([]domain.Delivery, error) {
+	rows, err := r.db(ctx).Query(ctx, listDeliveriesQuery, channelID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't list deliveries: %w", err)
+	}
+	defer rows.Close()
+	deliveries := []domain.Delivery{}
+	for rows.Next() {
+		delivery := domain.Delivery{}
+		err = rows.Scan(&delivery.ID, &delivery.ChannelID, &delivery.AlertID, &delivery.Status, &delivery.Reason, &delivery.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *NotificationRepository) CountDeliveries(ctx context.Context, channelID int64, status domain.DeliveryStatus, since time.Time) (int, error) {
+	var count int
+	if err := r.db(ctx).QueryRow(ctx, countDeliveriesQuery, channelID, status, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("can't count deliveries: %w", err)
+	}
+	return count, nil
+}
+
+func scanChannel(row pgx.Row) (*domain.NotificationChannel, error) {
+	channel := &domain.NotificationChannel{}
+	err := row.Scan(&channel.ID, &channel.UserID, &channel.Kind, &channel.Target, &channel.MinSeverity, &channel.Template, &channel.QuietHours, &channel.MaxPerHour)
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	alertRepository "homework/internal/repository/alert/sqlite"
+	sensorRepository "homework/internal/repository/sensor/sqlite"
+	userRepository "homework/internal/repository/user/sqlite"
+)
+
+type notificationContractSuite struct {
+	contract.NotificationRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *notificationContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Notifications = NewNotificationRepository(db)
+	suite.Alerts = alertRepository.NewAlertRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+	suite.Users = userRepository.NewUserRepository(db)
+}
+
+func (suite *notificationContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestNotificationRepositoryContract(t *testing.T) {
+	suite.Run(t, new(notificationContractSuite))
+}
@@ -0,0 +1,170 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/usecase"
+	"time"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *NotificationRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const channelColumns = `id, user_id, kind, target, min_severity, template, quiet_hours, max_per_hour`
+
+const saveChannelQuery = `INSERT INTO notification_channels (user_id, kind, target, min_severity, template, quiet_hours, max_per_hour) VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id`
+
+const getChannelByIDQuery = `SELECT ` + channelColumns + ` FROM notification_channels WHERE id = ?`
+
+const getChannelsByUserIDQuery = `SELECT ` + channelColumns + ` FROM notification_channels WHERE user_id = ? ORDER BY id`
+
+const deleteChannelQuery = `DELETE FROM notification_channels WHERE id = ?`
+
+const deliveryColumns = `id, channel_id, alert_id, status, reason, created_at`
+
+const saveDeliveryQuery = `INSERT INTO notification_deliveries (channel_id, alert_id, status, reason, created_at) VALUES (?, ?, ?, ?, ?) RETURNING id`
+
+const listDeliveriesQuery = `SELECT ` + deliveryColumns + ` FROM notification_deliveries WHERE channel_id = ? ORDER BY created_at DESC, id DESC LIMIT ?`
+
+const countDeliveriesQuery = `SELECT count(*) FROM notification_deliveries WHERE channel_id = ? AND status = ? AND created_at >= ?`
+
+// sqlite не сообщает, какой внешний ключ нарушен, поэтому при ошибке проверяем наличие канала
+const channelExistsQuery = `SELECT EXISTS (SELECT 1 FROM notification_channels WHERE id = ?)`
+
+func (r *NotificationRepository) SaveChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	quietHours, err := sqlitedb.QuietHours(channel.QuietHours)
+	if err != nil {
+		return fmt.Errorf("can't save notification channel: %w", err)
+	}
+	row := r.conn(ctx).QueryRowContext(ctx, saveChannelQuery, channel.UserID, channel.Kind, channel.Target, channel.MinSeverity, channel.Template, quietHours, channel.MaxPerHour)
+	err = row.Scan(&channel.ID)
+	switch {
+	case sqlitedb.IsForeignKeyViolation(err):
+		return usecase.ErrUserNotFound
+	case err != nil:
+		return fmt.Errorf("can't save notification channel: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) GetChannelByID(ctx context.Context, id int64) (*domain.NotificationChannel, error) {
+	channel, err := scanChannel(r.conn(ctx).QueryRowContext(ctx, getChannelByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrChannelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get notification channel: %w", err)
+	}
+	return channel, nil
+}
+
+func (r *NotificationRepository) GetChannelsByUserID(ctx context.Context, userID int64) ([]domain.NotificationChannel, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, getChannelsByUserIDQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get notification channels: %w", err)
+	}
+	defer rows.Close()
+	channels := []domain.NotificationChannel{}
+	for rows.Next() {
+		channel, err := scanChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan notification channel: %w", err)
+		}
+		channels = append(channels, *channel)
+	}
+	return channels, rows.Err()
+}
+
+func (r *NotificationRepository) DeleteChannel(ctx context.Context, id int64) error {
+	res, err := r.conn(ctx).ExecContext(ctx, deleteChannelQuery, id)
+	if err != nil {
+		return fmt.Errorf("can't delete notification channel: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't delete notification channel: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrChannelNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepository) SaveDelivery(ctx context.Context, delivery *domain.Delivery) error {
+	row := r.conn(ctx).QueryRowContext(ctx, saveDeliveryQuery, delivery.ChannelID, delivery.AlertID, delivery.Status, delivery.Reason, sqlitedb.Timestamp(delivery.CreatedAt))
+	err := row.Scan(&delivery.ID)
+	switch {
+	case sqlitedb.IsForeignKeyViolation(err):
+		var exists bool
+		if err := r.conn(ctx).QueryRowContext(ctx, channelExistsQuery, delivery.ChannelID).Scan(&exists); err != nil {
+			return fmt.Errorf("can't save delivery: %w", err)
+		}
+		if !exists {
+			return usecase.ErrChannelNotFound
+		}
+		return usecase.ErrAlertNotFound
+	case err != nil:
+		return fmt.Errorf("can't save delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) ListDeliveries(ctx context.Context, channelID int64, limit int) ([]domain.Delivery, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, listDeliveriesQuery, channelID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't list deliveries: %w", err)
+	}
+	defer rows.Close()
+	deliveries := []domain.Delivery{}
+	for rows.Next() {
+		var createdAt int64
+		delivery := domain.Delivery{}
+		err = rows.Scan(&delivery.ID, &delivery.ChannelID, &delivery.AlertID, &delivery.Status, &delivery.Reason, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan delivery: %w", err)
+		}
+		delivery.CreatedAt = sqlitedb.Time(createdAt)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *NotificationRepository) CountDeliveries(ctx context.Context, channelID int64, status domain.DeliveryStatus, since time.Time) (int, error) {
+	var count int
+	err := r.conn(ctx).QueryRowContext(ctx, countDeliveriesQuery, channelID, status, sqlitedb.Timestamp(since)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("can't count deliveries: %w", err)
+	}
+	return count, nil
+}
+
+func scanChannel(row interface{ Scan(dest ...any) error }) (*domain.NotificationChannel, error) {
+	var quietHours sql.NullString
+	channel := &domain.NotificationChannel{}
+	err := row.Scan(&channel.ID, &channel.UserID, &channel.Kind, &channel.Target, &channel.MinSeverity, &channel.Template, &quietHours, &channel.MaxPerHour)
+	if err != nil {
+		return nil, err
+	}
+	if channel.QuietHours, err = sqlitedb.ParseQuietHours(quietHours); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
@@ -0,0 +1,14 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOutboxRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.OutboxRepositorySuite{
+		Outbox: NewOutboxRepository(),
+	})
+}
@@ -0,0 +1,136 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"sort"
+	"sync"
+
+	transaction "homework/internal/repository/transaction/inmemory"
+)
+
+type OutboxRepository struct {
+	// changes - журнал по возрастанию Seq
+	changes   []domain.Change
+	positions map[string]int64
+	// relays - приёмники, доставку в которые сейчас захватил relay
+	relays  map[string]struct{}
+	lastSeq int64
+	rwMutex *sync.RWMutex
+}
+
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		positions: make(map[string]int64),
+		relays:    make(map[string]struct{}),
+		rwMutex:   new(sync.RWMutex),
+	}
+}
+
+func (r *OutboxRepository) SaveChange(ctx context.Context, change *domain.Change) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if change == nil {
+		return errors.New("change is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	r.lastSeq++
+	change.Seq = r.lastSeq
+	r.changes = append(r.changes, clone(change))
+	seq := change.Seq
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		if idx := r.search(seq - 1); idx < len(r.changes) && r.changes[idx].Seq == seq {
+			r.changes = append(r.changes[:idx], r.changes[idx+1:]...)
+		}
+	})
+	return nil
+}
+
+func (r *OutboxRepository) ListChanges(ctx context.Context, since int64, limit int) ([]domain.Change, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+
+	changes := []domain.Change{}
+	for idx := r.search(since); idx < len(r.changes) && len(changes) < limit; idx++ {
+		changes = append(changes, clone(&r.changes[idx]))
+	}
+	return changes, nil
+}
+
+func (r *OutboxRepository) GetRelayPosition(ctx context.Context, sink string) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	return r.positions[sink], nil
+}
+
+func (r *OutboxRepository) SaveRelayPosition(ctx context.Context, sink string, seq int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	r.positions[sink] = seq
+	return nil
+}
+
+// LockRelay - журнал в памяти доступен одному процессу, захват исключает только параллельные relay в нём
+func (r *OutboxRepository) LockRelay(ctx context.Context, sink string) (func(), bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	if _, ok := r.relays[sink]; ok {
+		return nil, false, nil
+	}
+	r.relays[sink] = struct{}{}
+	return func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		delete(r.relays, sink)
+	}, true, nil
+}
+
+// search - индекс первого изменения с Seq больше since
+func (r *OutboxRepository) search(since int64) int {
+	return sort.Search(len(r.changes), func(i int) bool { return r.changes[i].Seq > since })
+}
+
+func clone(change *domain.Change) domain.Change {
+	result := *change
+	if change.Event != nil {
+		event := *change.Event
+		event.Readings = change.Event.Readings.Clone()
+		result.Event = &event
+	}
+	if change.Sensor != nil {
+		sensor := *change.Sensor
+		sensor.CurrentReadings = change.Sensor.CurrentReadings.Clone()
+		sensor.Calibration = change.Sensor.Calibration.Clone()
+		sensor.AlertRule = change.Sensor.AlertRule.Clone()
+		result.Sensor = &sensor
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type outboxContractSuite struct {
+	contract.OutboxRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *outboxContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	suite.Outbox = NewOutboxRepository(suite.testDB.DbInstance)
+}
+
+func (suite *outboxContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestOutboxRepositoryContract(t *testing.T) {
+	suite.Run(t, new(outboxContractSuite))
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
+)
+
+// outboxLockKey - ключ advisory блокировки записи в журнал. seq выдаётся при вставке, а виден после фиксации,
+// поэтому без блокировки транзакция с меньшим seq могла бы зафиксироваться позже и читатель, уже прошедший
+// больший seq, пропустил бы её. Блокировка держится до конца транзакции, и seq видны в порядке фиксации
+const outboxLockKey = 7_110_045
+
+// relayLockKey - пространство advisory блокировок доставки, второй ключ - hashtext имени приёмника
+const relayLockKey = 7_110_046
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{
+		pool: pool,
+	}
+}
+
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *OutboxRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
+
+const lockOutboxQuery = `SELECT pg_advisory_xact_lock($1)`
+
+const lockRelayQuery = `SELECT pg_try_advisory_lock($1, hashtext($2))`
+
+const unlockRelayQuery = `SELECT pg_advisory_unlock($1, hashtext($2))`
+
+const saveChangeQuery = `INSERT INTO outbox (kind, payload, created_at) VALUES ($1, $2, $3) RETURNING seq`
+
+const listChangesQuery = `SELECT seq, kind, payload, created_at FROM outbox WHERE seq > $1 ORDER BY seq LIMIT $2`
+
+const getRelayPositionQuery = `SELECT seq FROM outbox_relay_positions WHERE sink = $1`
+
+const saveRelayPositionQuery = `INSERT INTO outbox_relay_positions (sink, seq) VALUES ($1, $2)
+ON CONFLICT (sink) DO UPDATE SET seq = excluded.seq`
+
+func (r *OutboxRepository) SaveChange(ctx context.Context, change *domain.Change) error {
+	payload, err := encodePayload(change)
+	if err != nil {
+		return fmt.Errorf("can't save change: %w", err)
+	}
+	if _, err = r.db(ctx).Exec(ctx, lockOutboxQuery, outboxLockKey); err != nil {
+		return fmt.Errorf("can't lock outbox: %w", err)
+	}
+	err = r.db(ctx).QueryRow(ctx, saveChangeQuery, change.Kind, payload, change.CreatedAt).Scan(&change.Seq)
+	if err != nil {
+		return fmt.Errorf("can't save change: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) ListChanges(ctx context.Context, since int64, limit int) ([]domain.Change, error) {
+	rows, err := r.db(ctx).Query(ctx, listChangesQuery, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't list changes: %w", err)
+	}
+	defer rows.Close()
+	changes := []domain.Change{}
+	for rows.Next() {
+		var change domain.Change
+		var data []byte
+		if err = rows.Scan(&change.Seq, &change.Kind, &data, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("can't scan change: %w", err)
+		}
+		if err = decodePayload(&change, data); err != nil {
+			return nil, fmt.Errorf("can't scan change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (r *OutboxRepository) GetRelayPosition(ctx context.Context, sink string) (int64, error) {
+	var seq int64
+	err := r.db(ctx).QueryRow(ctx, getRelayPositionQuery, sink).Scan(&seq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't get relay position: %w", err)
+	}
+	return seq, nil
+}
+
+func (r *OutboxRepository) SaveRelayPosition(ctx context.Context, sink string, seq int64) error {
+	if _, err := r.db(ctx).Exec(ctx, saveRelayPositionQuery, sink, seq); err != nil {
+		return fmt.Errorf("can't save relay position: %w", err)
+	}
+	return nil
+}
+
+// LockRelay - захватывает сессионную advisory блокировку приёмника на отдельном соединении, которое держится
+// до release. Если экземпляр сервиса упадёт, postgres снимет блокировку вместе с его соединением
+func (r *OutboxRepository) LockRelay(ctx context.Context, sink string) (func(), bool, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("can't lock relay: %w", err)
+	}
+	var locked bool
+	if err = conn.QueryRow(ctx, lockRelayQuery, relayLockKey, sink).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, false, fmt.Errorf("can't lock relay: %w", err)
+	}
+	if !locked {
+		conn.Release()
+		return nil, false, nil
+	}
+	return func() {
+		ctx := context.WithoutCancel(ctx)
+		if _, err := conn.Exec(ctx, unlockRelayQuery, relayLockKey, sink); err != nil {
+			// соединение с неснятой блокировкой не возвращается в пул: её снимет закрытие соединения
+			_ = conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}, true, nil
+}
+
+// encodePayload - событие или датчик изменения в json
+func encodePayload(change *domain.Change) ([]byte, error) {
+	switch change.Kind {
+	case domain.ChangeKindEvent:
+		return json.Marshal(change.Event)
+	case domain.ChangeKindSensor:
+		return json.Marshal(change.Sensor)
+	}
+	return nil, fmt.Errorf("unknown change kind %q", change.Kind)
+}
+
+func decodePayload(change *domain.Change, data []byte) error {
+	switch change.Kind {
+	case domain.ChangeKindEvent:
+		return json.Unmarshal(data, &change.Event)
+	case domain.ChangeKindSensor:
+		return json.Unmarshal(data, &change.Sensor)
+	}
+	return fmt.Errorf("unknown change kind %q", change.Kind)
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type outboxContractSuite struct {
+	contract.OutboxRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *outboxContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Outbox = NewOutboxRepository(db)
+}
+
+func (suite *outboxContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestOutboxRepositoryContract(t *testing.T) {
+	suite.Run(t, new(outboxContractSuite))
+}
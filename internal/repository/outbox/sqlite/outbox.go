@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"sync"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type OutboxRepository struct {
+	db *sql.DB
+
+	// relays - приёмники, доставку в которые сейчас захватил relay этого процесса
+	mutex  sync.Mutex
+	relays map[string]struct{}
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db:     db,
+		relays: make(map[string]struct{}),
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *OutboxRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const saveChangeQuery = `INSERT INTO outbox (kind, payload, created_at) VALUES (?, ?, ?) RETURNING seq`
+
+const listChangesQuery = `SELECT seq, kind, payload, created_at FROM outbox WHERE seq > ? ORDER BY seq LIMIT ?`
+
+const getRelayPositionQuery = `SELECT seq FROM outbox_relay_positions WHERE sink = ?`
+
+const saveRelayPositionQuery = `INSERT INTO outbox_relay_positions (sink, seq) VALUES (?, ?)
+ON CONFLICT (sink) DO UPDATE SET seq = excluded.seq`
+
+func (r *OutboxRepository) SaveChange(ctx context.Context, change *domain.Change) error {
+	payload, err := encodePayload(change)
+	if err != nil {
+		return fmt.Errorf("can't save change: %w", err)
+	}
+	row := r.conn(ctx).QueryRowContext(ctx, saveChangeQuery, change.Kind, payload, sqlitedb.Timestamp(change.CreatedAt))
+	if err = row.Scan(&change.Seq); err != nil {
+		return fmt.Errorf("can't save change: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) ListChanges(ctx context.Context, since int64, limit int) ([]domain.Change, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, listChangesQuery, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't list changes: %w", err)
+	}
+	defer rows.Close()
+	changes := []domain.Change{}
+	for rows.Next() {
+		var change domain.Change
+		var payload string
+		var createdAt int64
+		if err = rows.Scan(&change.Seq, &change.Kind, &payload, &createdAt); err != nil {
+			return nil, fmt.Errorf("can't scan change: %w", err)
+		}
+		change.CreatedAt = sqlitedb.Time(createdAt)
+		if err = decodePayload(&change, payload); err != nil {
+			return nil, fmt.Errorf("can't scan change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (r *OutboxRepository) GetRelayPosition(ctx context.Context, sink string) (int64, error) {
+	var seq int64
+	err := r.conn(ctx).QueryRowContext(ctx, getRelayPositionQuery, sink).Scan(&seq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't get relay position: %w", err)
+	}
+	return seq, nil
+}
+
+func (r *OutboxRepository) SaveRelayPosition(ctx context.Context, sink string, seq int64) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, saveRelayPositionQuery, sink, seq); err != nil {
+		return fmt.Errorf("can't save relay position: %w", err)
+	}
+	return nil
+}
+
+// LockRelay - базу sqlite обслуживает один экземпляр сервиса, поэтому захват исключает только параллельные
+// relay в этом процессе: транзакция на время доставки задержала бы все записи в базу
+func (r *OutboxRepository) LockRelay(_ context.Context, sink string) (func(), bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.relays[sink]; ok {
+		return nil, false, nil
+	}
+	r.relays[sink] = struct{}{}
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		delete(r.relays, sink)
+	}, true, nil
+}
+
+// encodePayload - событие или датчик изменения в json
+func encodePayload(change *domain.Change) (string, error) {
+	var data []byte
+	var err error
+	switch change.Kind {
+	case domain.ChangeKindEvent:
+		data, err = json.Marshal(change.Event)
+	case domain.ChangeKindSensor:
+		data, err = json.Marshal(change.Sensor)
+	default:
+		err = fmt.Errorf("unknown change kind %q", change.Kind)
+	}
+	return string(data), err
+}
+
+func decodePayload(change *domain.Change, payload string) error {
+	switch change.Kind {
+	case domain.ChangeKindEvent:
+		return json.Unmarshal([]byte(payload), &change.Event)
+	case domain.ChangeKindSensor:
+		return json.Unmarshal([]byte(payload), &change.Sensor)
+	}
+	return fmt.Errorf("unknown change kind %q", change.Kind)
+}
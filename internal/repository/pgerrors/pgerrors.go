@@ -0,0 +1,61 @@
+package pgerrors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// UniqueViolation - код ошибки нарушения уникальности
+	UniqueViolation = "23505"
+	// ForeignKeyViolation - код ошибки нарушения внешнего ключа
+	ForeignKeyViolation = "23503"
+)
+
+// Constraint - возвращает код ошибки postgres и имя нарушенного ограничения
+func Constraint(err error) (code, constraint string, ok bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return "", "", false
+	}
+	return pgErr.Code, pgErr.ConstraintName, true
+}
+
+// IsUniqueViolation - проверяет, что ошибка вызвана нарушением уникальности ограничения constraint
+func IsUniqueViolation(err error, constraint string) bool {
+	code, name, ok := Constraint(err)
+	return ok && code == UniqueViolation && name == constraint
+}
+
+// IsForeignKeyViolation - проверяет, что ошибка вызвана нарушением внешнего ключа constraint
+func IsForeignKeyViolation(err error, constraint string) bool {
+	code, name, ok := Constraint(err)
+	return ok && code == ForeignKeyViolation && name == constraint
+}
+
+// IsUnavailable - проверяет, что ошибка вызвана недоступностью postgres: соединение не установлено или разорвано,
+// сервер останавливается или перезапускается, а не отверг запрос
+func IsUnavailable(err error) bool {
+	// отмена и истечение ctx - решение вызывающего, а не отказ postgres
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// класс 08 - ошибки соединения, 57P01-57P03 - остановка и запуск сервера, 53300 - слишком много соединений
+		switch pgErr.Code {
+		case "57P01", "57P02", "57P03", "53300":
+			return true
+		}
+		return strings.HasPrefix(pgErr.Code, "08")
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || pgconn.SafeToRetry(err)
+}
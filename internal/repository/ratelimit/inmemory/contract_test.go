@@ -0,0 +1,14 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRateLimitRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.RateLimitRepositorySuite{
+		RateLimits: NewRateLimitRepository(),
+	})
+}
@@ -0,0 +1,75 @@
+package inmemory
+
+import (
+	"context"
+	"homework/internal/domain"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval - как часто удаляются наполнившиеся корзины: они не отличаются от отсутствующих
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+type RateLimitRepository struct {
+	buckets map[string]*bucket
+	mutex   *sync.Mutex
+	now     func() time.Time
+	sweptAt time.Time
+}
+
+func NewRateLimitRepository() *RateLimitRepository {
+	return &RateLimitRepository{
+		buckets: make(map[string]*bucket),
+		mutex:   new(sync.Mutex),
+		now:     time.Now,
+	}
+}
+
+func (r *RateLimitRepository) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (time.Duration, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		now := r.now()
+		r.sweep(now)
+		burst := float64(limit.Burst)
+		tokens := burst
+		if b, ok := r.buckets[key]; ok {
+			tokens = math.Min(burst, b.tokens+now.Sub(b.updatedAt).Seconds()*limit.Rate)
+		}
+		if tokens < 1 {
+			return seconds((1 - tokens) / limit.Rate), nil
+		}
+		tokens--
+		r.buckets[key] = &bucket{tokens: tokens, updatedAt: now, fullAt: now.Add(seconds((burst - tokens) / limit.Rate))}
+		return 0, nil
+	}
+}
+
+// sweep - удаляет наполнившиеся корзины не чаще раза в sweepInterval
+func (r *RateLimitRepository) sweep(now time.Time) {
+	if now.Sub(r.sweptAt) < sweepInterval {
+		return
+	}
+	r.sweptAt = now
+	for key, b := range r.buckets {
+		if !b.fullAt.After(now) {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+// seconds - длительность в секундах, округлённая вверх, чтобы токен к её концу точно появился
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type rateLimitContractSuite struct {
+	contract.RateLimitRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *rateLimitContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	suite.RateLimits = NewRateLimitRepository(suite.testDB.DbInstance)
+}
+
+func (suite *rateLimitContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestRateLimitRepositoryContract(t *testing.T) {
+	suite.Run(t, new(rateLimitContractSuite))
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sweepInterval - как часто реплика удаляет наполнившиеся корзины: они не отличаются от отсутствующих
+const sweepInterval = time.Minute
+
+// RateLimitRepository - корзины токенов в postgres, общие для всех реплик сервиса.
+// Запросы идут мимо транзакции из ctx, чтобы блокировка корзины не держалась до её конца
+type RateLimitRepository struct {
+	pool *pgxpool.Pool
+
+	mutex   sync.Mutex
+	sweptAt time.Time
+}
+
+func NewRateLimitRepository(pool *pgxpool.Pool) *RateLimitRepository {
+	return &RateLimitRepository{
+		pool: pool,
+	}
+}
+
+// refilledTokens - токены в корзине b к текущему моменту, $2 - скорость пополнения, $3 - вместимость
+const refilledTokens = `least($3::float8, b.tokens + extract(epoch FROM now() - b.updated_at)::float8 * $2::float8)`
+
+// takeTokenQuery - забирает токен, если он есть. Пустая корзина не меняется, и строка не возвращается
+const takeTokenQuery = `INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at, full_at)
+VALUES ($1, $3::float8 - 1, now(), now() + make_interval(secs => 1 / $2::float8))
+ON CONFLICT (key) DO UPDATE SET tokens = ` + refilledTokens + ` - 1, updated_at = now(),
+full_at = now() + make_interval(secs => ($3::float8 - ` + refilledTokens + ` + 1) / $2::float8)
+WHERE ` + refilledTokens + ` >= 1`
+
+const getTokensQuery = `SELECT ` + refilledTokens + ` FROM rate_limit_buckets AS b WHERE key = $1`
+
+const sweepQuery = `DELETE FROM rate_limit_buckets WHERE full_at < now()`
+
+func (r *RateLimitRepository) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (time.Duration, error) {
+	if err := r.sweep(ctx); err != nil {
+		return 0, err
+	}
+	tag, err := r.pool.Exec(ctx, takeTokenQuery, key, limit.Rate, float64(limit.Burst))
+	if err != nil {
+		return 0, err
+	}
+	if tag.RowsAffected() > 0 {
+		return 0, nil
+	}
+
+	var tokens float64
+	err = r.pool.QueryRow(ctx, getTokensQuery, key, limit.Rate, float64(limit.Burst)).Scan(&tokens)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// корзину удалили после попытки: значит, она наполнилась, и токен появится при следующей
+		return seconds(1 / limit.Rate), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	// токен мог появиться между запросами, тогда клиент может повторить запрос сразу
+	return max(seconds((1-tokens)/limit.Rate), time.Nanosecond), nil
+}
+
+// sweep - удаляет наполнившиеся корзины не чаще раза в sweepInterval
+func (r *RateLimitRepository) sweep(ctx context.Context) error {
+	r.mutex.Lock()
+	if time.Since(r.sweptAt) < sweepInterval {
+		r.mutex.Unlock()
+		return nil
+	}
+	r.sweptAt = time.Now()
+	r.mutex.Unlock()
+
+	_, err := r.pool.Exec(ctx, sweepQuery)
+	return err
+}
+
+// seconds - длительность в секундах, округлённая вверх, чтобы токен к её концу точно появился
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,17 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	userRepository "homework/internal/repository/user/inmemory"
+)
+
+func TestScheduleRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.ScheduleRepositorySuite{
+		Schedules: NewScheduleRepository(),
+		Users:     userRepository.NewUserRepository(),
+	})
+}
@@ -0,0 +1,261 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"sort"
+	"sync"
+	"time"
+
+	transaction "homework/internal/repository/transaction/inmemory"
+)
+
+type ScheduleRepository struct {
+	schedules map[int64]*domain.Schedule
+	runs      map[int64]domain.ScheduleRun
+	lastID    int64
+	lastRunID int64
+	rwMutex   *sync.RWMutex
+}
+
+func NewScheduleRepository() *ScheduleRepository {
+	return &ScheduleRepository{
+		schedules: make(map[int64]*domain.Schedule),
+		runs:      make(map[int64]domain.ScheduleRun),
+		rwMutex:   new(sync.RWMutex),
+	}
+}
+
+func (r *ScheduleRepository) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if schedule == nil {
+		return errors.New("schedule is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+
+	if schedule.ID == 0 {
+		r.lastID++
+		schedule.ID = r.lastID
+		stored := clone(schedule)
+		r.schedules[schedule.ID] = &stored
+		transaction.OnRollback(ctx, func() {
+			r.rwMutex.Lock()
+			defer r.rwMutex.Unlock()
+			delete(r.schedules, stored.ID)
+		})
+		return nil
+	}
+
+	prev, ok := r.schedules[schedule.ID]
+	if !ok {
+		return usecase.ErrScheduleNotFound
+	}
+	// пользователь и время создания не меняются, как в UPDATE хранилищ на SQL
+	stored := clone(schedule)
+	stored.UserID, stored.CreatedAt = prev.UserID, prev.CreatedAt
+	r.schedules[schedule.ID] = &stored
+	r.rollback(ctx, prev)
+	return nil
+}
+
+func (r *ScheduleRepository) GetScheduleByID(ctx context.Context, id int64) (*domain.Schedule, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	schedule, ok := r.schedules[id]
+	if !ok {
+		return nil, usecase.ErrScheduleNotFound
+	}
+	result := clone(schedule)
+	return &result, nil
+}
+
+func (r *ScheduleRepository) GetSchedulesByUserID(ctx context.Context, userID int64) ([]domain.Schedule, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	schedules := []domain.Schedule{}
+	for _, schedule := range r.schedules {
+		if schedule.UserID == userID {
+			schedules = append(schedules, clone(schedule))
+		}
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules, nil
+}
+
+func (r *ScheduleRepository) DeleteSchedule(ctx context.Context, id int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	schedule, ok := r.schedules[id]
+	if !ok {
+		return usecase.ErrScheduleNotFound
+	}
+	delete(r.schedules, id)
+	deleted := []domain.ScheduleRun{}
+	for runID, run := range r.runs {
+		if run.ScheduleID == id {
+			deleted = append(deleted, run)
+			delete(r.runs, runID)
+		}
+	}
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		r.schedules[id] = schedule
+		for _, run := range deleted {
+			r.runs[run.ID] = run
+		}
+	})
+	return nil
+}
+
+func (r *ScheduleRepository) DueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.Schedule, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	schedules := []domain.Schedule{}
+	for _, schedule := range r.schedules {
+		if schedule.Enabled && schedule.NextRunAt != nil && !schedule.NextRunAt.After(now) {
+			schedules = append(schedules, clone(schedule))
+		}
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].NextRunAt.Equal(*schedules[j].NextRunAt) {
+			return schedules[i].NextRunAt.Before(*schedules[j].NextRunAt)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+	if len(schedules) > limit {
+		schedules = schedules[:limit]
+	}
+	return schedules, nil
+}
+
+func (r *ScheduleRepository) AdvanceSchedule(ctx context.Context, id int64, from time.Time, next *time.Time) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	schedule, ok := r.schedules[id]
+	if !ok || schedule.NextRunAt == nil || !schedule.NextRunAt.Equal(from) {
+		return false, nil
+	}
+	stored := clone(schedule)
+	stored.NextRunAt = clonePtr(next)
+	r.schedules[id] = &stored
+	r.rollback(ctx, schedule)
+	return true, nil
+}
+
+func (r *ScheduleRepository) SaveRun(ctx context.Context, run *domain.ScheduleRun) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if run == nil {
+		return errors.New("schedule run is nil")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	if _, ok := r.schedules[run.ScheduleID]; !ok {
+		return usecase.ErrScheduleNotFound
+	}
+	r.lastRunID++
+	run.ID = r.lastRunID
+	r.runs[run.ID] = *run
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		delete(r.runs, run.ID)
+	})
+	return nil
+}
+
+func (r *ScheduleRepository) ListRuns(ctx context.Context, scheduleID int64, limit int) ([]domain.ScheduleRun, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	r.rwMutex.RLock()
+	runs := []domain.ScheduleRun{}
+	for _, run := range r.runs {
+		if run.ScheduleID == scheduleID {
+			runs = append(runs, run)
+		}
+	}
+	r.rwMutex.RUnlock()
+
+	sort.Slice(runs, func(i, j int) bool {
+		if !runs[i].StartedAt.Equal(runs[j].StartedAt) {
+			return runs[i].StartedAt.After(runs[j].StartedAt)
+		}
+		return runs[i].ID > runs[j].ID
+	})
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// rollback - при откате транзакции возвращает расписанию прежнее значение prev. Вызывается под блокировкой
+func (r *ScheduleRepository) rollback(ctx context.Context, prev *domain.Schedule) {
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		r.schedules[prev.ID] = prev
+	})
+}
+
+// clone - копия расписания, не разделяющая с оригиналом указатели времён и действия
+func clone(schedule *domain.Schedule) domain.Schedule {
+	result := *schedule
+	result.At = clonePtr(schedule.At)
+	result.NextRunAt = clonePtr(schedule.NextRunAt)
+	result.Action.Payload = clonePtr(schedule.Action.Payload)
+	if rule := schedule.Action.AlertRule; rule != nil {
+		result.Action.AlertRule = &domain.AlertRule{Severity: rule.Severity, Min: clonePtr(rule.Min), Max: clonePtr(rule.Max)}
+	}
+	return result
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	result := *v
+	return &result
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	userRepository "homework/internal/repository/user/postgres"
+)
+
+type scheduleContractSuite struct {
+	contract.ScheduleRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *scheduleContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Schedules = NewScheduleRepository(db)
+	suite.Users = userRepository.NewUserRepository(db)
+}
+
+func (suite *scheduleContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestScheduleRepositoryContract(t *testing.T) {
+	suite.Run(t, new(scheduleContractSuite))
+}
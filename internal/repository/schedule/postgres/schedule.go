@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/usecase"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
+)
+
+type ScheduleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewScheduleRepository(pool *pgxpool.Pool) *ScheduleRepository {
+	return &ScheduleRepository{
+		pool: pool,
+	}
+}
+
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *ScheduleRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
+
+const scheduleColumns = `id, user_id, name, cron, once_at, location, enabled, action, next_run_at, created_at`
+
+const saveScheduleQuery = `INSERT INTO schedules (user_id, name, cron, once_at, location, enabled, action, next_run_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+
+const updateScheduleQuery = `UPDATE schedules SET name = $1, cron = $2, once_at = $3, location = $4, enabled = $5, action = $6, next_run_at = $7 WHERE id = $8`
+
+const getScheduleByIDQuery = `SELECT ` + scheduleColumns + ` FROM schedules WHERE id = $1`
+
+const getSchedulesByUserIDQuery = `SELECT ` + scheduleColumns + ` FROM schedules WHERE user_id = $1 ORDER BY id`
+
+const deleteScheduleQuery = `DELETE FROM schedules WHERE id = $1`
+
+const dueSchedulesQuery = `SELECT ` + scheduleColumns + ` FROM schedules WHERE enabled AND next_run_at <= $1 ORDER BY next_run_at, id LIMIT $2`
+
+// advanceScheduleQuery - сравнение и перенос в одном запросе, поэтому срабатывание забирает только один экземпляр сервиса
+const advanceScheduleQuery = `UPDATE schedules SET next_run_at = $1 WHERE id = $2 AND next_run_at = $3`
+
+const runColumns = `id, schedule_id, scheduled_at, started_at, status, error`
+
+const saveRunQuery = `INSERT INTO schedule_runs (schedule_id, scheduled_at, started_at, status, error) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+const listRunsQuery = `SELECT ` + runColumns + ` FROM schedule_runs WHERE schedule_id = $1 ORDER BY started_at DESC, id DESC LIMIT $2`
+
+const (
+	schedulesUserIDForeignKey        = "schedules_user_id_fkey"
+	scheduleRunsScheduleIDForeignKey = "schedule_runs_schedule_id_fkey"
+)
+
+func (r *ScheduleRepository) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	if schedule.ID != 0 {
+		tag, err := r.db(ctx).Exec(ctx, updateScheduleQuery, schedule.Name, schedule.Cron, schedule.At, schedule.Location, schedule.Enabled, schedule.Action, schedule.NextRunAt, schedule.ID)
+		if err != nil {
+			return fmt.Errorf("can't update schedule: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return usecase.ErrScheduleNotFound
+		}
+		return nil
+	}
+
+	row := r.db(ctx).QueryRow(ctx, saveScheduleQuery, schedule.UserID, schedule.Name, schedule.Cron, schedule.At, schedule.Location, schedule.Enabled, schedule.Action, schedule.NextRunAt, schedule.CreatedAt)
+	err := row.Scan(&schedule.ID)
+	switch {
+	case pgerrors.IsForeignKeyViolation(err, schedulesUserIDForeignKey):
+		return usecase.ErrUserNotFound
+	case err != nil:
+		return fmt.Errorf("can't save schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) GetScheduleByID(ctx context.Context, id int64) (*domain.Schedule, error) {
+	schedule, err := scanSchedule(r.db(ctx).QueryRow(ctx, getScheduleByIDQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+func (r *ScheduleRepository) GetSchedulesByUserID(ctx context.Context, userID int64) ([]domain.Schedule, error) {
+	return r.querySchedules(ctx, getSchedulesByUserIDQuery, userID)
+}
+
+func (r *ScheduleRepository) DeleteSchedule(ctx context.Context, id int64) error {
+	tag, err := r.db(ctx).Exec(ctx, deleteScheduleQuery, id)
+	if err != nil {
+		return fmt.Errorf("can't delete schedule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return usecase.ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) DueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.Schedule, error) {
+	return r.querySchedules(ctx, dueSchedulesQuery, now, limit)
+}
+
+func (r *ScheduleRepository) AdvanceSchedule(ctx context.Context, id int64, from time.Time, next *time.Time) (bool, error) {
+	tag, err := r.db(ctx).Exec(ctx, advanceScheduleQuery, next, id, from)
+	if err != nil {
+		return false, fmt.Errorf("can't advance schedule: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *ScheduleRepository) SaveRun(ctx context.Context, run *domain.ScheduleRun) error {
+	row := r.db(ctx).QueryRow(ctx, saveRunQuery, run.ScheduleID, run.ScheduledAt, run.StartedAt, run.Status, run.Error)
+	err := row.Scan(&run.ID)
+	switch {
+	case pgerrors.IsForeignKeyViolation(err, scheduleRunsScheduleIDForeignKey):
+		return usecase.ErrScheduleNotFound
+	case err != nil:
+		return fmt.Errorf("can't save schedule run: %w", err)
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) ListRuns(ctx context.Context, scheduleID int64, limit int) ([]domain.ScheduleRun, error) {
+	rows, err := r.db(ctx).Query(ctx, listRunsQuery, scheduleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't list schedule runs: %w", err)
+	}
+	defer rows.Close()
+	runs := []domain.ScheduleRun{}
+	for rows.Next() {
+		run := domain.ScheduleRun{}
+		if err = rows.Scan(&run.ID, &run.ScheduleID, &run.ScheduledAt, &run.StartedAt, &run.Status, &run.Error); err != nil {
+			return nil, fmt.Errorf("can't scan schedule run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *ScheduleRepository) querySchedules(ctx context.Context, query string, args ...any) ([]domain.Schedule, error) {
+	rows, err := r.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get schedules: %w", err)
+	}
+	defer rows.Close()
+	schedules := []domain.Schedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan schedule: %w", err)
+		}
+		schedules = append(schedules, *schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func scanSchedule(row pgx.Row) (*domain.Schedule, error) {
+	schedule := &domain.Schedule{}
+	err := row.Scan(&schedule.ID, &schedule.UserID, &schedule.Name, &schedule.Cron, &schedule.At, &schedule.Location, &schedule.Enabled, &schedule.Action, &schedule.NextRunAt, &schedule.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
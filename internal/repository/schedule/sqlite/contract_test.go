@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	userRepository "homework/internal/repository/user/sqlite"
+)
+
+type scheduleContractSuite struct {
+	contract.ScheduleRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *scheduleContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Schedules = NewScheduleRepository(db)
+	suite.Users = userRepository.NewUserRepository(db)
+}
+
+func (suite *scheduleContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestScheduleRepositoryContract(t *testing.T) {
+	suite.Run(t, new(scheduleContractSuite))
+}
@@ -0,0 +1,198 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/usecase"
+	"time"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type ScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) *ScheduleRepository {
+	return &ScheduleRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *ScheduleRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const scheduleColumns = `id, user_id, name, cron, once_at, location, enabled, action, next_run_at, created_at`
+
+const saveScheduleQuery = `INSERT INTO schedules (user_id, name, cron, once_at, location, enabled, action, next_run_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
+
+const updateScheduleQuery = `UPDATE schedules SET name = ?, cron = ?, once_at = ?, location = ?, enabled = ?, action = ?, next_run_at = ? WHERE id = ?`
+
+const getScheduleByIDQuery = `SELECT ` + scheduleColumns + ` FROM schedules WHERE id = ?`
+
+const getSchedulesByUserIDQuery = `SELECT ` + scheduleColumns + ` FROM schedules WHERE user_id = ? ORDER BY id`
+
+const deleteScheduleQuery = `DELETE FROM schedules WHERE id = ?`
+
+const dueSchedulesQuery = `SELECT ` + scheduleColumns + ` FROM schedules WHERE enabled AND next_run_at <= ? ORDER BY next_run_at, id LIMIT ?`
+
+// advanceScheduleQuery - сравнение и перенос в одном запросе, поэтому срабатывание забирает только один экземпляр сервиса
+const advanceScheduleQuery = `UPDATE schedules SET next_run_at = ? WHERE id = ? AND next_run_at = ?`
+
+const runColumns = `id, schedule_id, scheduled_at, started_at, status, error`
+
+const saveRunQuery = `INSERT INTO schedule_runs (schedule_id, scheduled_at, started_at, status, error) VALUES (?, ?, ?, ?, ?) RETURNING id`
+
+const listRunsQuery = `SELECT ` + runColumns + ` FROM schedule_runs WHERE schedule_id = ? ORDER BY started_at DESC, id DESC LIMIT ?`
+
+func (r *ScheduleRepository) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	action, err := sqlitedb.ScheduleAction(schedule.Action)
+	if err != nil {
+		return err
+	}
+
+	if schedule.ID != 0 {
+		res, err := r.conn(ctx).ExecContext(ctx, updateScheduleQuery, schedule.Name, schedule.Cron, sqlitedb.NullTimestamp(schedule.At), schedule.Location,
+			schedule.Enabled, action, sqlitedb.NullTimestamp(schedule.NextRunAt), schedule.ID)
+		if err != nil {
+			return fmt.Errorf("can't update schedule: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't update schedule: %w", err)
+		}
+		if affected == 0 {
+			return usecase.ErrScheduleNotFound
+		}
+		return nil
+	}
+
+	row := r.conn(ctx).QueryRowContext(ctx, saveScheduleQuery, schedule.UserID, schedule.Name, schedule.Cron, sqlitedb.NullTimestamp(schedule.At), schedule.Location,
+		schedule.Enabled, action, sqlitedb.NullTimestamp(schedule.NextRunAt), sqlitedb.Timestamp(schedule.CreatedAt))
+	err = row.Scan(&schedule.ID)
+	switch {
+	case sqlitedb.IsForeignKeyViolation(err):
+		return usecase.ErrUserNotFound
+	case err != nil:
+		return fmt.Errorf("can't save schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) GetScheduleByID(ctx context.Context, id int64) (*domain.Schedule, error) {
+	schedule, err := scanSchedule(r.conn(ctx).QueryRowContext(ctx, getScheduleByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+func (r *ScheduleRepository) GetSchedulesByUserID(ctx context.Context, userID int64) ([]domain.Schedule, error) {
+	return r.querySchedules(ctx, getSchedulesByUserIDQuery, userID)
+}
+
+func (r *ScheduleRepository) DeleteSchedule(ctx context.Context, id int64) error {
+	res, err := r.conn(ctx).ExecContext(ctx, deleteScheduleQuery, id)
+	if err != nil {
+		return fmt.Errorf("can't delete schedule: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't delete schedule: %w", err)
+	}
+	if affected == 0 {
+		return usecase.ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) DueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.Schedule, error) {
+	return r.querySchedules(ctx, dueSchedulesQuery, sqlitedb.Timestamp(now), limit)
+}
+
+func (r *ScheduleRepository) AdvanceSchedule(ctx context.Context, id int64, from time.Time, next *time.Time) (bool, error) {
+	res, err := r.conn(ctx).ExecContext(ctx, advanceScheduleQuery, sqlitedb.NullTimestamp(next), id, sqlitedb.Timestamp(from))
+	if err != nil {
+		return false, fmt.Errorf("can't advance schedule: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("can't advance schedule: %w", err)
+	}
+	return affected == 1, nil
+}
+
+func (r *ScheduleRepository) SaveRun(ctx context.Context, run *domain.ScheduleRun) error {
+	row := r.conn(ctx).QueryRowContext(ctx, saveRunQuery, run.ScheduleID, sqlitedb.Timestamp(run.ScheduledAt), sqlitedb.Timestamp(run.StartedAt), run.Status, run.Error)
+	err := row.Scan(&run.ID)
+	switch {
+	case sqlitedb.IsForeignKeyViolation(err):
+		return usecase.ErrScheduleNotFound
+	case err != nil:
+		return fmt.Errorf("can't save schedule run: %w", err)
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) ListRuns(ctx context.Context, scheduleID int64, limit int) ([]domain.ScheduleRun, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, listRunsQuery, scheduleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't list schedule runs: %w", err)
+	}
+	defer rows.Close()
+	runs := []domain.ScheduleRun{}
+	for rows.Next() {
+		var scheduledAt, startedAt int64
+		run := domain.ScheduleRun{}
+		if err = rows.Scan(&run.ID, &run.ScheduleID, &scheduledAt, &startedAt, &run.Status, &run.Error); err != nil {
+			return nil, fmt.Errorf("can't scan schedule run: %w", err)
+		}
+		run.ScheduledAt, run.StartedAt = sqlitedb.Time(scheduledAt), sqlitedb.Time(startedAt)
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *ScheduleRepository) querySchedules(ctx context.Context, query string, args ...any) ([]domain.Schedule, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't get schedules: %w", err)
+	}
+	defer rows.Close()
+	schedules := []domain.Schedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan schedule: %w", err)
+		}
+		schedules = append(schedules, *schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func scanSchedule(row interface{ Scan(dest ...any) error }) (*domain.Schedule, error) {
+	var at, nextRunAt sql.NullInt64
+	var createdAt int64
+	var action string
+	schedule := &domain.Schedule{}
+	err := row.Scan(&schedule.ID, &schedule.UserID, &schedule.Name, &schedule.Cron, &at, &schedule.Location, &schedule.Enabled, &action, &nextRunAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Action, err = sqlitedb.ParseScheduleAction(action); err != nil {
+		return nil, err
+	}
+	schedule.At = sqlitedb.NullTime(at)
+	schedule.NextRunAt = sqlitedb.NullTime(nextRunAt)
+	schedule.CreatedAt = sqlitedb.Time(createdAt)
+	return schedule, nil
+}
@@ -0,0 +1,16 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	transaction "homework/internal/repository/transaction/inmemory"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSensorRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.SensorRepositorySuite{
+		Sensors:    NewSensorRepository(),
+		Transactor: transaction.NewTransactor(),
+	})
+}
@@ -5,8 +5,12 @@ import (
 	"errors"
 	"homework/internal/domain"
 	"homework/internal/usecase"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	transaction "homework/internal/repository/transaction/inmemory"
 )
 
 type SensorRepository struct {
@@ -34,31 +38,92 @@ func (r *SensorRepository) SaveSensor(ctx context.Context, sensor *domain.Sensor
 			return errors.New("nil sensor")
 		}
 		r.rwMutex.Lock()
-		sensor.RegisteredAt = time.Now()
+		defer r.rwMutex.Unlock()
 		if sensor.ID == 0 {
+			if _, ok := r.sensorsBySerialNumber[sensor.SerialNumber]; ok {
+				return usecase.ErrSensorAlreadyExists
+			}
 			sensor.ID = updateID
+			sensor.RegisteredAt = time.Now()
 			updateID++
+		} else {
+			prev, ok := r.sensorsByID[sensor.ID]
+			if !ok {
+				return usecase.ErrSensorNotFound
+			}
+			sensor.SerialNumber = prev.SerialNumber
+			sensor.Type = prev.Type
+			sensor.RegisteredAt = prev.RegisteredAt
 		}
-		r.sensorsByID[sensor.ID] = sensor
-		r.sensorsBySerialNumber[sensor.SerialNumber] = sensor
-		r.rwMutex.Unlock()
+		sensor.UpdatedAt = time.Now()
+		prevByID, okByID := r.sensorsByID[sensor.ID]
+		prevBySerialNumber, okBySerialNumber := r.sensorsBySerialNumber[sensor.SerialNumber]
+
+		stored := clone(sensor)
+		r.sensorsByID[sensor.ID] = &stored
+		r.sensorsBySerialNumber[sensor.SerialNumber] = &stored
+
+		transaction.OnRollback(ctx, func() {
+			r.rwMutex.Lock()
+			defer r.rwMutex.Unlock()
+			if okByID {
+				r.sensorsByID[stored.ID] = prevByID
+			} else {
+				delete(r.sensorsByID, stored.ID)
+			}
+			if okBySerialNumber {
+				r.sensorsBySerialNumber[stored.SerialNumber] = prevBySerialNumber
+			} else {
+				delete(r.sensorsBySerialNumber, stored.SerialNumber)
+			}
+		})
 		return nil
 	}
 }
 
+func (r *SensorRepository) SaveSensorState(ctx context.Context, sensor *domain.Sensor) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if sensor == nil {
+		return errors.New("nil sensor")
+	}
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	prev, ok := r.sensorsByID[sensor.ID]
+	if !ok || !sensor.LastActivity.After(prev.LastActivity) {
+		return nil
+	}
+	stored := clone(prev)
+	stored.CurrentState = sensor.CurrentState
+	stored.CurrentReadings = sensor.CurrentReadings.Clone()
+	stored.LastActivity = sensor.LastActivity
+	stored.UpdatedAt = time.Now()
+	sensor.UpdatedAt = stored.UpdatedAt
+	r.sensorsByID[stored.ID] = &stored
+	r.sensorsBySerialNumber[stored.SerialNumber] = &stored
+
+	transaction.OnRollback(ctx, func() {
+		r.rwMutex.Lock()
+		defer r.rwMutex.Unlock()
+		r.sensorsByID[prev.ID] = prev
+		r.sensorsBySerialNumber[prev.SerialNumber] = prev
+	})
+	return nil
+}
+
 func (r *SensorRepository) GetSensors(ctx context.Context) ([]domain.Sensor, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 		r.rwMutex.RLock()
+		defer r.rwMutex.RUnlock()
 		result := make([]domain.Sensor, 0, len(r.sensorsBySerialNumber))
-		sensors := r.sensorsBySerialNumber
-		r.rwMutex.RUnlock()
-		for _, sensor := range sensors {
-			r.rwMutex.Lock()
-			result = append(result, *sensor)
-			r.rwMutex.Unlock()
+		for _, sensor := range r.sensorsBySerialNumber {
+			result = append(result, clone(sensor))
 		}
 		return result, nil
 	}
@@ -75,10 +140,16 @@ func (r *SensorRepository) GetSensorByID(ctx context.Context, id int64) (*domain
 		if !ok {
 			return nil, usecase.ErrSensorNotFound
 		}
-		return sensor, nil
+		result := clone(sensor)
+		return &result, nil
 	}
 }
 
+// LockSensorByID - транзакции inmemory выполняются последовательно, поэтому датчик не изменится до конца транзакции
+func (r *SensorRepository) LockSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	return r.GetSensorByID(ctx, id)
+}
+
 func (r *SensorRepository) GetSensorBySerialNumber(ctx context.Context, sn string) (*domain.Sensor, error) {
 	select {
 	case <-ctx.Done():
@@ -90,6 +161,80 @@ func (r *SensorRepository) GetSensorBySerialNumber(ctx context.Context, sn strin
 		if !ok {
 			return nil, usecase.ErrSensorNotFound
 		}
-		return sensor, nil
+		result := clone(sensor)
+		return &result, nil
 	}
 }
+
+func (r *SensorRepository) ListSensors(ctx context.Context, query usecase.SensorQuery) ([]domain.Sensor, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		r.rwMutex.RLock()
+		result := make([]domain.Sensor, 0, len(r.sensorsByID))
+		for _, sensor := range r.sensorsByID {
+			if matchSensor(query.Filter, sensor) {
+				result = append(result, clone(sensor))
+			}
+		}
+		r.rwMutex.RUnlock()
+
+		less := sensorLess(query.SortBy)
+		if query.Desc {
+			asc := less
+			less = func(a, b *domain.Sensor) bool { return asc(b, a) }
+		}
+		sort.Slice(result, func(i, j int) bool { return less(&result[i], &result[j]) })
+		if query.After != nil {
+			i := sort.Search(len(result), func(i int) bool { return less(query.After, &result[i]) })
+			result = result[i:]
+		}
+		if len(result) > query.Limit {
+			result = result[:query.Limit]
+		}
+		return result, nil
+	}
+}
+
+func matchSensor(filter usecase.SensorFilter, sensor *domain.Sensor) bool {
+	switch {
+	case filter.Type != "" && sensor.Type != filter.Type:
+		return false
+	case filter.IsActive != nil && sensor.IsActive != *filter.IsActive:
+		return false
+	case !strings.HasPrefix(sensor.SerialNumber, filter.SerialNumberPrefix):
+		return false
+	case !filter.LastActivityFrom.IsZero() && sensor.LastActivity.Before(filter.LastActivityFrom):
+		return false
+	case !filter.LastActivityTo.IsZero() && sensor.LastActivity.After(filter.LastActivityTo):
+		return false
+	}
+	return true
+}
+
+// sensorLess - порядок датчиков по возрастанию поля сортировки
+func sensorLess(sortBy usecase.SensorSortField) func(a, b *domain.Sensor) bool {
+	switch sortBy {
+	case usecase.SensorSortBySerialNumber:
+		return func(a, b *domain.Sensor) bool { return a.SerialNumber < b.SerialNumber }
+	case usecase.SensorSortByLastActivity:
+		return func(a, b *domain.Sensor) bool {
+			if !a.LastActivity.Equal(b.LastActivity) {
+				return a.LastActivity.Before(b.LastActivity)
+			}
+			return a.ID < b.ID
+		}
+	default:
+		return func(a, b *domain.Sensor) bool { return a.ID < b.ID }
+	}
+}
+
+// clone - копия датчика, не разделяющая с оригиналом показания, калибровку и правило тревоги
+func clone(sensor *domain.Sensor) domain.Sensor {
+	result := *sensor
+	result.CurrentReadings = sensor.CurrentReadings.Clone()
+	result.Calibration = sensor.Calibration.Clone()
+	result.AlertRule = sensor.AlertRule.Clone()
+	return result
+}
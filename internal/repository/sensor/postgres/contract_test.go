@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	transaction "homework/internal/repository/transaction/postgres"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type sensorContractSuite struct {
+	contract.SensorRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *sensorContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Sensors = NewSensorRepository(db)
+	suite.Transactor = transaction.NewTransactor(db)
+}
+
+func (suite *sensorContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestSensorRepositoryContract(t *testing.T) {
+	suite.Run(t, new(sensorContractSuite))
+}
@@ -2,12 +2,18 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/repository/sqlquery"
 	"homework/internal/usecase"
-	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
 )
 
 type SensorRepository struct {
@@ -20,75 +26,171 @@ func NewSensorRepository(pool *pgxpool.Pool) *SensorRepository {
 	}
 }
 
-var updateSensorID int64 = 1
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *SensorRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
 
-const saveSensorQuery = `INSERT INTO sensors (id, serial_number, type, current_state, description, is_active, registered_at, last_activity) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+const sensorColumns = `id, serial_number, type, current_state, current_readings, description, is_active, registered_at, last_activity, calibration, alert_rule, updated_at`
 
-const updateSensorQuery = `UPDATE sensors SET current_state = $2, description = $3, is_active = $4, last_activity = $5 WHERE id = $1`
+const saveSensorQuery = `INSERT INTO sensors (serial_number, type, current_state, current_readings, description, is_active, registered_at, last_activity, calibration, alert_rule, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $7) RETURNING id, registered_at, updated_at`
 
-const getSensorsQuery = `SELECT * FROM sensors`
+const updateSensorQuery = `UPDATE sensors SET current_state = $2, current_readings = $3, description = $4, is_active = $5, last_activity = $6, calibration = $7, alert_rule = $8, updated_at = $9 WHERE id = $1 RETURNING updated_at`
 
-const getSensorByID = `SELECT * FROM sensors WHERE id = $1`
+const updateSensorStateQuery = `UPDATE sensors SET current_state = $2, current_readings = $3, last_activity = $4, updated_at = $5 WHERE id = $1 AND (last_activity IS NULL OR last_activity < $4) RETURNING updated_at`
 
-const getSensorBySerialNumber = `SELECT * FROM sensors WHERE serial_number = $1`
+const getSensorsQuery = `SELECT ` + sensorColumns + ` FROM sensors`
 
-func (r *SensorRepository) SaveSensor(ctx context.Context, sensor *domain.Sensor) error {
-	if sensor.ID == 0 {
-		sensor.ID = updateSensorID
-	}
+const getSensorByID = `SELECT ` + sensorColumns + ` FROM sensors WHERE id = $1`
 
-	if _, err := r.GetSensorByID(ctx, sensor.ID); err == nil {
-		_, err = r.pool.Exec(ctx, updateSensorQuery, sensor.ID, sensor.CurrentState, sensor.Description, sensor.IsActive, sensor.LastActivity)
-		if err != nil {
-			return err
+const lockSensorByID = getSensorByID + ` FOR UPDATE`
+
+const getSensorBySerialNumber = `SELECT ` + sensorColumns + ` FROM sensors WHERE serial_number = $1`
+
+const sensorsSerialNumberKey = "sensors_serial_number_key"
+
+func (r *SensorRepository) SaveSensor(ctx context.Context, sensor *domain.Sensor) error {
+	if sensor.ID != 0 {
+		row := r.db(ctx).QueryRow(ctx, updateSensorQuery, sensor.ID, sensor.CurrentState, sensor.CurrentReadings.Clone(), sensor.Description, sensor.IsActive, sensor.LastActivity, sensor.Calibration, sensor.AlertRule, time.Now().Truncate(time.Microsecond))
+		err := row.Scan(&sensor.UpdatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrSensorNotFound
 		}
-		return nil
+		return err
 	}
+
 	sensor.IsActive = false
-	atomic.AddInt64(&updateSensorID, 1)
-	_, err := r.pool.Exec(ctx, saveSensorQuery, sensor.ID, sensor.SerialNumber, sensor.Type, sensor.CurrentState, sensor.Description, sensor.IsActive, time.Now().Truncate(time.Microsecond), sensor.LastActivity)
+	row := r.db(ctx).QueryRow(ctx, saveSensorQuery, sensor.SerialNumber, sensor.Type, sensor.CurrentState, sensor.CurrentReadings.Clone(), sensor.Description, sensor.IsActive, time.Now().Truncate(time.Microsecond), sensor.LastActivity, sensor.Calibration, sensor.AlertRule)
+	err := row.Scan(&sensor.ID, &sensor.RegisteredAt, &sensor.UpdatedAt)
+	if pgerrors.IsUniqueViolation(err, sensorsSerialNumberKey) {
+		return usecase.ErrSensorAlreadyExists
+	}
 	if err != nil {
-		atomic.AddInt64(&updateSensorID, -1)
 		return err
 	}
 	return nil
 }
 
+func (r *SensorRepository) SaveSensorState(ctx context.Context, sensor *domain.Sensor) error {
+	row := r.db(ctx).QueryRow(ctx, updateSensorStateQuery, sensor.ID, sensor.CurrentState, sensor.CurrentReadings.Clone(), sensor.LastActivity, time.Now().Truncate(time.Microsecond))
+	err := row.Scan(&sensor.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// сохранено более позднее состояние
+		return nil
+	}
+	return err
+}
+
 func (r *SensorRepository) GetSensors(ctx context.Context) ([]domain.Sensor, error) {
-	row, err := r.pool.Query(ctx, getSensorsQuery)
+	row, err := r.db(ctx).Query(ctx, getSensorsQuery)
 	if err != nil {
 		return nil, err
 	}
 	defer row.Close()
-	var sensors []domain.Sensor
-	for row.Next() {
-		var sensor domain.Sensor
-		err = row.Scan(&sensor.ID, &sensor.SerialNumber, &sensor.Type, &sensor.CurrentState, &sensor.Description, &sensor.IsActive, &sensor.RegisteredAt, &sensor.LastActivity)
-		if err != nil {
-			return nil, err
-		}
+	return scanSensors(row)
+}
 
-		sensors = append(sensors, sensor)
+func (r *SensorRepository) GetSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	row := r.db(ctx).QueryRow(ctx, getSensorByID, id)
+	sensor, err := scanSensor(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrSensorNotFound
 	}
-	return sensors, nil
+	if err != nil {
+		return nil, err
+	}
+	return sensor, nil
 }
 
-func (r *SensorRepository) GetSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
-	row := r.pool.QueryRow(ctx, getSensorByID, id)
-	var sensor domain.Sensor
-	err := row.Scan(&sensor.ID, &sensor.SerialNumber, &sensor.Type, &sensor.CurrentState, &sensor.Description, &sensor.IsActive, &sensor.RegisteredAt, &sensor.LastActivity)
+func (r *SensorRepository) LockSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	row := r.db(ctx).QueryRow(ctx, lockSensorByID, id)
+	sensor, err := scanSensor(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrSensorNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &sensor, nil
+	return sensor, nil
 }
 
 func (r *SensorRepository) GetSensorBySerialNumber(ctx context.Context, sn string) (*domain.Sensor, error) {
-	row := r.pool.QueryRow(ctx, getSensorBySerialNumber, sn)
+	row := r.db(ctx).QueryRow(ctx, getSensorBySerialNumber, sn)
+	sensor, err := scanSensor(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrSensorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sensor, nil
+}
+
+func (r *SensorRepository) ListSensors(ctx context.Context, query usecase.SensorQuery) ([]domain.Sensor, error) {
+	b := sqlquery.New(sqlquery.Dollar)
+	filter := query.Filter
+	if filter.Type != "" {
+		b.Where("type = ?", filter.Type)
+	}
+	if filter.IsActive != nil {
+		b.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.SerialNumberPrefix != "" {
+		b.Where(`serial_number LIKE ? ESCAPE '\'`, sqlquery.LikePrefix(filter.SerialNumberPrefix))
+	}
+	if !filter.LastActivityFrom.IsZero() {
+		b.Where("last_activity >= ?", filter.LastActivityFrom)
+	}
+	if !filter.LastActivityTo.IsZero() {
+		b.Where("last_activity <= ?", filter.LastActivityTo)
+	}
+
+	direction, after := sqlquery.Order(query.Desc)
+	orderBy := "id " + direction
+	switch query.SortBy {
+	case usecase.SensorSortBySerialNumber:
+		orderBy = "serial_number " + direction
+		if query.After != nil {
+			b.Where("serial_number "+after+" ?", query.After.SerialNumber)
+		}
+	case usecase.SensorSortByLastActivity:
+		orderBy = "last_activity " + direction + ", id " + direction
+		if query.After != nil {
+			b.Where("(last_activity, id) "+after+" (?, ?)", query.After.LastActivity, query.After.ID)
+		}
+	default:
+		if query.After != nil {
+			b.Where("id "+after+" ?", query.After.ID)
+		}
+	}
+
+	sql := `SELECT ` + sensorColumns + ` FROM sensors` + b.WhereClause() + ` ORDER BY ` + orderBy + ` LIMIT ` + b.Arg(query.Limit)
+	rows, err := r.db(ctx).Query(ctx, sql, b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list sensors: %w", err)
+	}
+	defer rows.Close()
+	return scanSensors(rows)
+}
+
+func scanSensors(rows pgx.Rows) ([]domain.Sensor, error) {
+	var sensors []domain.Sensor
+	for rows.Next() {
+		sensor, err := scanSensor(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sensors = append(sensors, *sensor)
+	}
+	return sensors, rows.Err()
+}
+
+func scanSensor(row pgx.Row) (*domain.Sensor, error) {
 	var sensor domain.Sensor
-	err := row.Scan(&sensor.ID, &sensor.SerialNumber, &sensor.Type, &sensor.CurrentState, &sensor.Description, &sensor.IsActive, &sensor.RegisteredAt, &sensor.LastActivity)
+	err := row.Scan(&sensor.ID, &sensor.SerialNumber, &sensor.Type, &sensor.CurrentState, &sensor.CurrentReadings, &sensor.Description, &sensor.IsActive, &sensor.RegisteredAt, &sensor.LastActivity, &sensor.Calibration, &sensor.AlertRule, &sensor.UpdatedAt)
 	if err != nil {
-		return nil, usecase.ErrSensorNotFound
+		return nil, err
 	}
 	return &sensor, nil
 }
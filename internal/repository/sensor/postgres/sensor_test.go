@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"homework/pkg/pg_test"
 	"testing"
 	"time"
@@ -32,7 +33,8 @@ func (suite *SensorTestSuite) TearDownSuite() {
 }
 
 func (suite *SensorTestSuite) TestSensorRepository_SaveSensor() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	sn := "1234567890"
 
@@ -81,7 +83,8 @@ func (suite *SensorTestSuite) TestSensorRepository_SaveSensor() {
 }
 
 func (suite *SensorTestSuite) TestSensorRepository_GetSensors() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	sn := "0987654321"
 
@@ -112,7 +115,8 @@ func (suite *SensorTestSuite) TestSensorRepository_GetSensors() {
 }
 
 func (suite *SensorTestSuite) TestSensorRepository_GetSensorByID() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	sn := "1987654321"
 
@@ -143,7 +147,8 @@ func (suite *SensorTestSuite) TestSensorRepository_GetSensorByID() {
 }
 
 func (suite *SensorTestSuite) TestSensorRepository_GetSensorBySerialNumber() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	sn := "2987654321"
 
@@ -169,6 +174,35 @@ func (suite *SensorTestSuite) TestSensorRepository_GetSensorBySerialNumber() {
 	assert.Equal(suite.T(), newSensor, *sensor)
 }
 
+func (suite *SensorTestSuite) TestSensorRepository_SaveSensorErrors() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	newSensor := domain.Sensor{
+		SerialNumber: "3987654321",
+		Type:         domain.SensorTypeContactClosure,
+		Description:  "test_desc_6",
+	}
+	err := suite.repo.SaveSensor(ctx, &newSensor)
+	assert.Nil(suite.T(), err)
+	assert.NotZero(suite.T(), newSensor.ID)
+
+	duplicate := domain.Sensor{
+		SerialNumber: "3987654321",
+		Type:         domain.SensorTypeContactClosure,
+	}
+	err = suite.repo.SaveSensor(ctx, &duplicate)
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorAlreadyExists)
+
+	unknown := domain.Sensor{
+		ID:           newSensor.ID + 1000,
+		SerialNumber: "4987654321",
+		Type:         domain.SensorTypeContactClosure,
+	}
+	err = suite.repo.SaveSensor(ctx, &unknown)
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+}
+
 func TestSensorTestSuite(t *testing.T) {
 	suite.Run(t, new(SensorTestSuite))
 }
@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	transaction "homework/internal/repository/transaction/sqlite"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type sensorContractSuite struct {
+	contract.SensorRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *sensorContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Sensors = NewSensorRepository(db)
+	suite.Transactor = transaction.NewTransactor(db)
+}
+
+func (suite *sensorContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestSensorRepositoryContract(t *testing.T) {
+	suite.Run(t, new(sensorContractSuite))
+}
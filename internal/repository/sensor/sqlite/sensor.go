@@ -0,0 +1,231 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/repository/sqlquery"
+	"homework/internal/usecase"
+	"time"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type SensorRepository struct {
+	db *sql.DB
+}
+
+func NewSensorRepository(db *sql.DB) *SensorRepository {
+	return &SensorRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *SensorRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const sensorColumns = `id, serial_number, type, current_state, current_readings, description, is_active, registered_at, last_activity, calibration, alert_rule, updated_at`
+
+const saveSensorQuery = `INSERT INTO sensors (serial_number, type, current_state, current_readings, description, is_active, registered_at, last_activity, calibration, alert_rule, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
+
+const updateSensorQuery = `UPDATE sensors SET current_state = ?, current_readings = ?, description = ?, is_active = ?, last_activity = ?, calibration = ?, alert_rule = ?, updated_at = ? WHERE id = ?`
+
+const updateSensorStateQuery = `UPDATE sensors SET current_state = ?, current_readings = ?, last_activity = ?, updated_at = ? WHERE id = ? AND last_activity < ?`
+
+const getSensorsQuery = `SELECT ` + sensorColumns + ` FROM sensors`
+
+const getSensorByID = `SELECT ` + sensorColumns + ` FROM sensors WHERE id = ?`
+
+const getSensorBySerialNumber = `SELECT ` + sensorColumns + ` FROM sensors WHERE serial_number = ?`
+
+func (r *SensorRepository) SaveSensor(ctx context.Context, sensor *domain.Sensor) error {
+	readings, err := sqlitedb.Readings(sensor.CurrentReadings)
+	if err != nil {
+		return fmt.Errorf("can't save sensor: %w", err)
+	}
+	calibration, err := sqlitedb.Calibration(sensor.Calibration)
+	if err != nil {
+		return fmt.Errorf("can't save sensor: %w", err)
+	}
+	alertRule, err := sqlitedb.AlertRule(sensor.AlertRule)
+	if err != nil {
+		return fmt.Errorf("can't save sensor: %w", err)
+	}
+	updatedAt := time.Now().Truncate(time.Microsecond).UTC()
+	if sensor.ID != 0 {
+		res, err := r.conn(ctx).ExecContext(ctx, updateSensorQuery, sensor.CurrentState, readings, sensor.Description, sensor.IsActive, sqlitedb.Timestamp(sensor.LastActivity), calibration, alertRule, sqlitedb.Timestamp(updatedAt), sensor.ID)
+		if err != nil {
+			return fmt.Errorf("can't update sensor: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't update sensor: %w", err)
+		}
+		if affected == 0 {
+			return usecase.ErrSensorNotFound
+		}
+		sensor.UpdatedAt = updatedAt
+		return nil
+	}
+
+	sensor.IsActive = false
+	row := r.conn(ctx).QueryRowContext(ctx, saveSensorQuery, sensor.SerialNumber, sensor.Type, sensor.CurrentState, readings, sensor.Description, sensor.IsActive, sqlitedb.Timestamp(updatedAt), sqlitedb.Timestamp(sensor.LastActivity), calibration, alertRule, sqlitedb.Timestamp(updatedAt))
+	err = row.Scan(&sensor.ID)
+	if sqlitedb.IsUniqueViolation(err, "sensors", "serial_number") {
+		return usecase.ErrSensorAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("can't save sensor: %w", err)
+	}
+	sensor.RegisteredAt = updatedAt
+	sensor.UpdatedAt = updatedAt
+	return nil
+}
+
+func (r *SensorRepository) SaveSensorState(ctx context.Context, sensor *domain.Sensor) error {
+	readings, err := sqlitedb.Readings(sensor.CurrentReadings)
+	if err != nil {
+		return fmt.Errorf("can't save sensor state: %w", err)
+	}
+	updatedAt := time.Now().Truncate(time.Microsecond).UTC()
+	lastActivity := sqlitedb.Timestamp(sensor.LastActivity)
+	res, err := r.conn(ctx).ExecContext(ctx, updateSensorStateQuery, sensor.CurrentState, readings, lastActivity, sqlitedb.Timestamp(updatedAt), sensor.ID, lastActivity)
+	if err != nil {
+		return fmt.Errorf("can't save sensor state: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("can't save sensor state: %w", err)
+	}
+	if affected > 0 {
+		sensor.UpdatedAt = updatedAt
+	}
+	return nil
+}
+
+func (r *SensorRepository) GetSensors(ctx context.Context) ([]domain.Sensor, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, getSensorsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("can't get sensors: %w", err)
+	}
+	defer rows.Close()
+	return scanSensors(rows)
+}
+
+func (r *SensorRepository) GetSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	sensor, err := scanSensor(r.conn(ctx).QueryRowContext(ctx, getSensorByID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrSensorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get sensor by id: %w", err)
+	}
+	return sensor, nil
+}
+
+// LockSensorByID - транзакции sqlite берут блокировку записи при начале (BEGIN IMMEDIATE),
+// поэтому датчик не изменится до конца транзакции и без отдельной блокировки строки
+func (r *SensorRepository) LockSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	return r.GetSensorByID(ctx, id)
+}
+
+func (r *SensorRepository) GetSensorBySerialNumber(ctx context.Context, sn string) (*domain.Sensor, error) {
+	sensor, err := scanSensor(r.conn(ctx).QueryRowContext(ctx, getSensorBySerialNumber, sn))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrSensorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get sensor by serial number: %w", err)
+	}
+	return sensor, nil
+}
+
+func (r *SensorRepository) ListSensors(ctx context.Context, query usecase.SensorQuery) ([]domain.Sensor, error) {
+	b := sqlquery.New(sqlquery.Question)
+	filter := query.Filter
+	if filter.Type != "" {
+		b.Where("type = ?", string(filter.Type))
+	}
+	if filter.IsActive != nil {
+		b.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.SerialNumberPrefix != "" {
+		b.Where(`serial_number LIKE ? ESCAPE '\'`, sqlquery.LikePrefix(filter.SerialNumberPrefix))
+	}
+	if !filter.LastActivityFrom.IsZero() {
+		b.Where("last_activity >= ?", sqlitedb.Timestamp(filter.LastActivityFrom))
+	}
+	if !filter.LastActivityTo.IsZero() {
+		b.Where("last_activity <= ?", sqlitedb.Timestamp(filter.LastActivityTo))
+	}
+
+	direction, after := sqlquery.Order(query.Desc)
+	orderBy := "id " + direction
+	switch query.SortBy {
+	case usecase.SensorSortBySerialNumber:
+		orderBy = "serial_number " + direction
+		if query.After != nil {
+			b.Where("serial_number "+after+" ?", query.After.SerialNumber)
+		}
+	case usecase.SensorSortByLastActivity:
+		orderBy = "last_activity " + direction + ", id " + direction
+		if query.After != nil {
+			b.Where("(last_activity, id) "+after+" (?, ?)", sqlitedb.Timestamp(query.After.LastActivity), query.After.ID)
+		}
+	default:
+		if query.After != nil {
+			b.Where("id "+after+" ?", query.After.ID)
+		}
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, `SELECT `+sensorColumns+` FROM sensors`+b.WhereClause()+` ORDER BY `+orderBy+` LIMIT `+b.Arg(query.Limit), b.Args()...)
+	if err != nil {
+		return nil, fmt.Errorf("can't list sensors: %w", err)
+	}
+	defer rows.Close()
+	return scanSensors(rows)
+}
+
+func scanSensors(rows *sql.Rows) ([]domain.Sensor, error) {
+	var sensors []domain.Sensor
+	for rows.Next() {
+		sensor, err := scanSensor(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan sensor: %w", err)
+		}
+
+		sensors = append(sensors, *sensor)
+	}
+	return sensors, rows.Err()
+}
+
+func scanSensor(row interface{ Scan(dest ...any) error }) (*domain.Sensor, error) {
+	var registeredAt, lastActivity, updatedAt int64
+	var readings, calibration, alertRule sql.NullString
+	sensor := &domain.Sensor{}
+	err := row.Scan(&sensor.ID, &sensor.SerialNumber, &sensor.Type, &sensor.CurrentState, &readings, &sensor.Description, &sensor.IsActive, &registeredAt, &lastActivity, &calibration, &alertRule, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	sensor.RegisteredAt = sqlitedb.Time(registeredAt)
+	sensor.LastActivity = sqlitedb.Time(lastActivity)
+	sensor.UpdatedAt = sqlitedb.Time(updatedAt)
+	sensor.CurrentReadings, err = sqlitedb.ParseReadings(readings)
+	if err != nil {
+		return nil, err
+	}
+	sensor.Calibration, err = sqlitedb.ParseCalibration(calibration)
+	if err != nil {
+		return nil, err
+	}
+	sensor.AlertRule, err = sqlitedb.ParseAlertRule(alertRule)
+	if err != nil {
+		return nil, err
+	}
+	return sensor, nil
+}
@@ -0,0 +1,7 @@
+drop table events;
+
+drop table sensors_users;
+
+drop table sensors;
+
+drop table users;
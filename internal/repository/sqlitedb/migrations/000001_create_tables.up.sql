@@ -0,0 +1,38 @@
+-- Время хранится в микросекундах unix time (UTC)
+
+create table users
+(
+    id   integer primary key autoincrement,
+    name text    not null
+);
+
+create table sensors
+(
+    id            integer primary key autoincrement,
+    serial_number text    not null unique,
+    type          text    not null,
+    current_state integer not null default 0,
+    description   text    not null default '',
+    is_active     boolean not null default false,
+    registered_at integer not null default 0,
+    last_activity integer not null default 0
+);
+
+create table sensors_users
+(
+    id        integer primary key autoincrement,
+    sensor_id integer not null references sensors (id) on delete cascade,
+    user_id   integer not null references users (id) on delete cascade,
+    unique (sensor_id, user_id)
+);
+
+create table events
+(
+    id                   integer primary key autoincrement,
+    timestamp            integer not null,
+    sensor_serial_number text    not null,
+    sensor_id            integer not null references sensors (id) on delete cascade,
+    payload              integer not null
+);
+
+create index events_sensor_id_timestamp_idx on events (sensor_id, timestamp);
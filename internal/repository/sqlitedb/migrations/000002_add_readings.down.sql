@@ -0,0 +1,5 @@
+alter table sensors
+    drop column current_readings;
+
+alter table events
+    drop column readings;
@@ -0,0 +1,8 @@
+-- payload и current_state остаются integer: дробные значения сохраняются в них как real без потери точности
+-- Показания каналов хранятся в json
+
+alter table events
+    add column readings text;
+
+alter table sensors
+    add column current_readings text;
@@ -0,0 +1,4 @@
+-- Калибровка хранится в json
+
+alter table sensors
+    add column calibration text;
@@ -0,0 +1,4 @@
+drop index events_sensor_id_timestamp_id_idx;
+create index events_sensor_id_timestamp_idx on events (sensor_id, timestamp);
+
+drop index sensors_last_activity_id_idx;
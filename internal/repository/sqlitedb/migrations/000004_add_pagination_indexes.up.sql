@@ -0,0 +1,4 @@
+create index sensors_last_activity_id_idx on sensors (last_activity, id);
+
+drop index events_sensor_id_timestamp_idx;
+create index events_sensor_id_timestamp_id_idx on events (sensor_id, timestamp, id);
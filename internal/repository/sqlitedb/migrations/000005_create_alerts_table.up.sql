@@ -0,0 +1,21 @@
+-- Правило тревоги хранится в json
+
+alter table sensors
+    add column alert_rule text;
+
+create table alerts
+(
+    id              integer primary key autoincrement,
+    sensor_id       integer not null references sensors (id) on delete cascade,
+    severity        text    not null,
+    payload         real    not null,
+    triggered_at    integer not null,
+    acknowledged_by integer references users (id) on delete set null,
+    acknowledged_at integer,
+    resolved_by     integer references users (id) on delete set null,
+    resolved_at     integer
+);
+
+-- у датчика может быть только одна неустранённая тревога
+create unique index alerts_sensor_id_unresolved_idx on alerts (sensor_id) where resolved_at is null;
+create index alerts_sensor_id_triggered_at_id_idx on alerts (sensor_id, triggered_at, id);
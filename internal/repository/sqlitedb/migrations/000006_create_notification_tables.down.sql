@@ -0,0 +1,2 @@
+drop table notification_deliveries;
+drop table notification_channels;
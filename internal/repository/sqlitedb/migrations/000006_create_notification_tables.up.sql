@@ -0,0 +1,28 @@
+-- Тихие часы канала хранятся в json
+
+create table notification_channels
+(
+    id           integer primary key autoincrement,
+    user_id      integer not null references users (id) on delete cascade,
+    kind         text    not null,
+    target       text    not null,
+    min_severity text    not null,
+    template     text    not null default '',
+    quiet_hours  text,
+    max_per_hour integer not null default 0
+);
+
+create index notification_channels_user_id_idx on notification_channels (user_id);
+
+create table notification_deliveries
+(
+    id         integer primary key autoincrement,
+    channel_id integer not null references notification_channels (id) on delete cascade,
+    alert_id   integer not null references alerts (id) on delete cascade,
+    status     text    not null,
+    reason     text    not null default '',
+    created_at integer not null
+);
+
+-- журнал канала читается от новых записей к старым, ограничение частоты считает записи за последний час
+create index notification_deliveries_channel_id_created_at_id_idx on notification_deliveries (channel_id, created_at, id);
@@ -0,0 +1,16 @@
+create table commands
+(
+    id           integer primary key autoincrement,
+    sensor_id    integer not null references sensors (id) on delete cascade,
+    payload      real    not null,
+    state        text    not null,
+    reason       text    not null default '',
+    created_at   integer not null,
+    delivered_at integer,
+    completed_at integer
+);
+
+create index commands_sensor_id_created_at_id_idx on commands (sensor_id, created_at, id);
+-- очередь устройства и поиск просроченных команд выбирают только незавершённые команды
+create index commands_sensor_id_pending_idx on commands (sensor_id, id) where state = 'pending';
+create index commands_delivered_at_idx on commands (delivered_at) where state = 'delivered';
@@ -0,0 +1,31 @@
+-- Действие расписания хранится в json
+
+create table schedules
+(
+    id          integer primary key autoincrement,
+    user_id     integer not null references users (id) on delete cascade,
+    name        text    not null,
+    cron        text    not null default '',
+    once_at     integer,
+    location    text    not null default '',
+    enabled     integer not null,
+    action      text    not null,
+    next_run_at integer,
+    created_at  integer not null
+);
+
+create index schedules_user_id_idx on schedules (user_id);
+-- планировщик выбирает только включённые расписания, время которых наступило
+create index schedules_next_run_at_idx on schedules (next_run_at, id) where enabled;
+
+create table schedule_runs
+(
+    id           integer primary key autoincrement,
+    schedule_id  integer not null references schedules (id) on delete cascade,
+    scheduled_at integer not null,
+    started_at   integer not null,
+    status       text    not null,
+    error        text    not null default ''
+);
+
+create index schedule_runs_schedule_id_started_at_id_idx on schedule_runs (schedule_id, started_at, id);
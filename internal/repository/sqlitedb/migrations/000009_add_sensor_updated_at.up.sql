@@ -0,0 +1,7 @@
+-- Время последнего сохранения датчика, по нему HTTP API отдаёт Last-Modified
+
+alter table sensors
+    add column updated_at integer not null default 0;
+
+update sensors
+set updated_at = max(registered_at, last_activity);
@@ -0,0 +1,15 @@
+-- Журнал изменений (transactional outbox), событие или датчик хранятся в json
+
+create table outbox
+(
+    seq        integer primary key autoincrement,
+    kind       text    not null,
+    payload    text    not null,
+    created_at integer not null
+);
+
+create table outbox_relay_positions
+(
+    sink text    not null primary key,
+    seq  integer not null
+);
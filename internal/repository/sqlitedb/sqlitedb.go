@@ -0,0 +1,233 @@
+package sqlitedb
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	driver "modernc.org/sqlite"
+)
+
+// Scheme - схема DSN, по которой выбирается хранилище sqlite
+const Scheme = "sqlite://"
+
+// pragmas - в режиме WAL чтение не блокирует запись, а транзакции сразу берут блокировку записи (BEGIN IMMEDIATE):
+// писатели по очереди ждут её до busy_timeout, а не получают SQLITE_BUSY при повышении блокировки чтения посреди транзакции
+const pragmas = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+
+// maxOpenConns - соединений с файлом базы: долгое чтение, например потоковая выгрузка, занимает одно из них
+// и не останавливает остальные запросы
+const maxOpenConns = 8
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Open - открывает базу sqlite и применяет к ней миграции.
+// dsn - путь к файлу базы, опционально с префиксом sqlite://
+func Open(dsn string) (*sql.DB, error) {
+	path := strings.TrimPrefix(dsn, Scheme)
+	if strings.Contains(path, "?") {
+		path += "&" + pragmas
+	} else {
+		path += "?" + pragmas
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("can't open sqlite database: %w", err)
+	}
+	// у каждого соединения с базой в памяти своя база, поэтому она доступна только через одно соединение
+	if isMemory(path) {
+		db.SetMaxOpenConns(1)
+	} else {
+		db.SetMaxOpenConns(maxOpenConns)
+	}
+
+	if err = migrateDB(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("can't migrate sqlite database: %w", err)
+	}
+	return db, nil
+}
+
+// isMemory - база в памяти, а не в файле
+func isMemory(path string) bool {
+	return strings.HasPrefix(path, ":memory:") || strings.HasPrefix(path, "file::memory:") || strings.Contains(path, "mode=memory")
+}
+
+func migrateDB(db *sql.DB) error {
+	src, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return err
+	}
+	dbDriver, err := sqlite.WithInstance(db, &sqlite.Config{})
+	if err != nil {
+		return err
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", dbDriver)
+	if err != nil {
+		return err
+	}
+	// m.Close не вызывается: он закрыл бы db, которая используется дальше
+	err = m.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Timestamp - переводит время в формат хранения sqlite
+func Timestamp(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// Time - переводит время из формата хранения sqlite
+func Time(v int64) time.Time {
+	return time.UnixMicro(v).UTC()
+}
+
+// Readings - переводит показания каналов в формат хранения sqlite (json), nil для пустых показаний
+func Readings(r domain.Readings) (any, error) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	return marshalJSON(r)
+}
+
+// ParseReadings - переводит показания каналов из формата хранения sqlite
+func ParseReadings(v sql.NullString) (domain.Readings, error) {
+	var r domain.Readings
+	if err := unmarshalJSON(v, &r); err != nil {
+		return nil, err
+	}
+	return r.Clone(), nil
+}
+
+// Calibration - переводит калибровку датчика в формат хранения sqlite (json), nil если калибровки нет
+func Calibration(c *domain.Calibration) (any, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return marshalJSON(c)
+}
+
+// ParseCalibration - переводит калибровку датчика из формата хранения sqlite
+func ParseCalibration(v sql.NullString) (*domain.Calibration, error) {
+	var c *domain.Calibration
+	if err := unmarshalJSON(v, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AlertRule - переводит правило тревоги датчика в формат хранения sqlite (json), nil если правила нет
+func AlertRule(r *domain.AlertRule) (any, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return marshalJSON(r)
+}
+
+// ParseAlertRule - переводит правило тревоги датчика из формата хранения sqlite
+func ParseAlertRule(v sql.NullString) (*domain.AlertRule, error) {
+	var r *domain.AlertRule
+	if err := unmarshalJSON(v, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// QuietHours - переводит тихие часы канала уведомлений в формат хранения sqlite, nil для nil
+func QuietHours(q *domain.QuietHours) (any, error) {
+	if q == nil {
+		return nil, nil
+	}
+	return marshalJSON(q)
+}
+
+// ParseQuietHours - переводит тихие часы канала уведомлений из формата хранения sqlite
+func ParseQuietHours(v sql.NullString) (*domain.QuietHours, error) {
+	var q *domain.QuietHours
+	if err := unmarshalJSON(v, &q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// ScheduleAction - переводит действие расписания в формат хранения sqlite (json)
+func ScheduleAction(a domain.ScheduleAction) (string, error) {
+	return marshalJSON(a)
+}
+
+// ParseScheduleAction - переводит действие расписания из формата хранения sqlite
+func ParseScheduleAction(v string) (domain.ScheduleAction, error) {
+	var a domain.ScheduleAction
+	if err := unmarshalJSON(sql.NullString{String: v, Valid: true}, &a); err != nil {
+		return domain.ScheduleAction{}, err
+	}
+	return a, nil
+}
+
+// NullTimestamp - переводит необязательное время в формат хранения sqlite, nil для nil
+func NullTimestamp(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return Timestamp(*t)
+}
+
+// NullTime - переводит необязательное время из формата хранения sqlite
+func NullTime(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := Time(v.Int64)
+	return &t
+}
+
+func marshalJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("can't marshal %T: %w", v, err)
+	}
+	return string(data), nil
+}
+
+func unmarshalJSON(v sql.NullString, dst any) error {
+	if !v.Valid {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(v.String), dst); err != nil {
+		return fmt.Errorf("can't unmarshal %T: %w", dst, err)
+	}
+	return nil
+}
+
+// IsUniqueViolation - проверяет, что ошибка вызвана нарушением уникальности столбцов columns таблицы table
+func IsUniqueViolation(err error, table string, columns ...string) bool {
+	var sqliteErr *driver.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code() != sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return false
+	}
+	qualified := make([]string, 0, len(columns))
+	for _, column := range columns {
+		qualified = append(qualified, table+"."+column)
+	}
+	return strings.Contains(sqliteErr.Error(), "UNIQUE constraint failed: "+strings.Join(qualified, ", "))
+}
+
+// IsForeignKeyViolation - проверяет, что ошибка вызвана нарушением внешнего ключа
+func IsForeignKeyViolation(err error) bool {
+	var sqliteErr *driver.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
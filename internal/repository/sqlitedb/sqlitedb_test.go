@@ -0,0 +1,74 @@
+package sqlitedb
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen_ReadDoesNotBlockWrites(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, "INSERT INTO users (name) VALUES ('a'), ('b')")
+	require.NoError(t, err)
+
+	// незакрытый результат держит соединение, как потоковая выгрузка
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = db.ExecContext(ctx, "INSERT INTO users (name) VALUES ('c')")
+	assert.NoError(t, err)
+}
+
+func TestOpen_ConcurrentWriteTransactions(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			// чтение перед записью: без BEGIN IMMEDIATE повышение блокировки завершилось бы SQLITE_BUSY
+			var n int
+			if err = tx.QueryRowContext(ctx, "SELECT count(*) FROM users").Scan(&n); err == nil {
+				_, err = tx.ExecContext(ctx, "INSERT INTO users (name) VALUES ('user')")
+			}
+			if err != nil {
+				_ = tx.Rollback()
+				errs <- err
+				return
+			}
+			errs <- tx.Commit()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var n int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT count(*) FROM users").Scan(&n))
+	assert.Equal(t, 20, n)
+}
@@ -0,0 +1,88 @@
+package sqlquery
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Builder - построитель условия WHERE и списка параметров запроса с нумерацией параметров под диалект БД
+type Builder struct {
+	placeholder func(n int) string
+	conditions  []string
+	args        []any
+}
+
+func New(placeholder func(n int) string) *Builder {
+	return &Builder{placeholder: placeholder}
+}
+
+// Dollar - обозначение параметров postgres ($1, $2, ...)
+func Dollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// Question - обозначение параметров sqlite (?)
+func Question(int) string {
+	return "?"
+}
+
+// Where - добавляет условие, каждый символ ? в condition заменяется обозначением очередного параметра из args
+func (b *Builder) Where(condition string, args ...any) {
+	var sb strings.Builder
+	i := 0
+	for _, r := range condition {
+		if r == '?' && i < len(args) {
+			sb.WriteString(b.Arg(args[i]))
+			i++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	b.conditions = append(b.conditions, sb.String())
+}
+
+// In - добавляет условие column IN (...) по списку значений, пустой список условие не добавляет
+func In[T any](b *Builder, column string, values []T) {
+	if len(values) == 0 {
+		return
+	}
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		placeholders[i] = b.Arg(v)
+	}
+	b.conditions = append(b.conditions, column+" IN ("+strings.Join(placeholders, ", ")+")")
+}
+
+// Arg - добавляет параметр и возвращает его обозначение
+func (b *Builder) Arg(v any) string {
+	b.args = append(b.args, v)
+	return b.placeholder(len(b.args))
+}
+
+// WhereClause - условие WHERE из всех добавленных условий, пустая строка если условий нет
+func (b *Builder) WhereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// Args - параметры запроса в порядке добавления
+func (b *Builder) Args() []any {
+	return b.args
+}
+
+// LikePrefix - шаблон LIKE ... ESCAPE '\' для поиска строк, начинающихся с prefix
+func LikePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Order - направление сортировки и оператор сравнения для перехода к следующей странице
+func Order(desc bool) (direction, after string) {
+	if desc {
+		return "DESC", "<"
+	}
+	return "ASC", ">"
+}
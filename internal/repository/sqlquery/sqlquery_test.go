@@ -0,0 +1,48 @@
+package sqlquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("postgres placeholders", func(t *testing.T) {
+		b := New(Dollar)
+		b.Where("type = ?", "cc")
+		b.Where("(last_activity, id) > (?, ?)", 10, 2)
+		limit := b.Arg(5)
+
+		assert.Equal(t, " WHERE type = $1 AND (last_activity, id) > ($2, $3)", b.WhereClause())
+		assert.Equal(t, "$4", limit)
+		assert.Equal(t, []any{"cc", 10, 2, 5}, b.Args())
+	})
+
+	t.Run("sqlite placeholders", func(t *testing.T) {
+		b := New(Question)
+		b.Where("type = ?", "cc")
+		b.Where("is_active = ?", true)
+
+		assert.Equal(t, " WHERE type = ? AND is_active = ?", b.WhereClause())
+		assert.Equal(t, []any{"cc", true}, b.Args())
+	})
+
+	t.Run("in list", func(t *testing.T) {
+		b := New(Dollar)
+		b.Where("timestamp >= ?", 1)
+		In(b, "sensor_id", []int64{7, 8})
+		In(b, "type", []string{})
+
+		assert.Equal(t, " WHERE timestamp >= $1 AND sensor_id IN ($2, $3)", b.WhereClause())
+		assert.Equal(t, []any{1, int64(7), int64(8)}, b.Args())
+	})
+
+	t.Run("no conditions", func(t *testing.T) {
+		assert.Empty(t, New(Dollar).WhereClause())
+	})
+}
+
+func TestLikePrefix(t *testing.T) {
+	assert.Equal(t, `12%`, LikePrefix("12"))
+	assert.Equal(t, `1\%2\_3\\%`, LikePrefix(`1%2_3\`))
+}
@@ -0,0 +1,52 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+)
+
+type txKey struct{}
+
+type transaction struct {
+	rollback []func()
+}
+
+// Transactor - транзакции для inmemory репозиториев.
+// Транзакции выполняются последовательно под общей блокировкой, а при ошибке
+// изменения откатываются функциями, зарегистрированными через OnRollback.
+type Transactor struct {
+	mutex *sync.Mutex
+}
+
+func NewTransactor() *Transactor {
+	return &Transactor{
+		mutex: new(sync.Mutex),
+	}
+}
+
+// WithinTransaction - выполняет fn в транзакции.
+// Если ctx уже принадлежит транзакции, fn выполняется в ней же.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*transaction); ok {
+		return fn(ctx)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tx := &transaction{}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		for i := len(tx.rollback) - 1; i >= 0; i-- {
+			tx.rollback[i]()
+		}
+		return err
+	}
+	return nil
+}
+
+// OnRollback - регистрирует функцию отката изменения, если ctx принадлежит транзакции
+func OnRollback(ctx context.Context, fn func()) {
+	if tx, ok := ctx.Value(txKey{}).(*transaction); ok {
+		tx.rollback = append(tx.rollback, fn)
+	}
+}
@@ -0,0 +1,101 @@
+package inmemory_test
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventRepository "homework/internal/repository/event/inmemory"
+	sensorRepository "homework/internal/repository/sensor/inmemory"
+	transaction "homework/internal/repository/transaction/inmemory"
+)
+
+func TestTransactor_WithinTransaction(t *testing.T) {
+	t.Run("ok, changes are kept on commit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tr := transaction.NewTransactor()
+		er := eventRepository.NewEventRepository()
+
+		err := tr.WithinTransaction(ctx, func(ctx context.Context) error {
+			return er.SaveEvent(ctx, &domain.Event{SensorID: 1, Timestamp: time.Now(), Payload: 1})
+		})
+		assert.NoError(t, err)
+
+		_, err = er.GetLastEventBySensorID(ctx, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ok, changes are rolled back on error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tr := transaction.NewTransactor()
+		sr := sensorRepository.NewSensorRepository()
+		er := eventRepository.NewEventRepository()
+
+		sensor := &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC, CurrentState: 1}
+		assert.NoError(t, sr.SaveSensor(ctx, sensor))
+		assert.NoError(t, er.SaveEvent(ctx, &domain.Event{SensorID: sensor.ID, Timestamp: time.Now(), Payload: 1}))
+
+		expectedError := errors.New("some error")
+		err := tr.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := er.SaveEvent(ctx, &domain.Event{SensorID: sensor.ID, Timestamp: time.Now(), Payload: 2}); err != nil {
+				return err
+			}
+			updated := *sensor
+			updated.CurrentState = 2
+			if err := sr.SaveSensor(ctx, &updated); err != nil {
+				return err
+			}
+			if err := sr.SaveSensor(ctx, &domain.Sensor{SerialNumber: "9876543210", Type: domain.SensorTypeContactClosure}); err != nil {
+				return err
+			}
+			return expectedError
+		})
+		assert.ErrorIs(t, err, expectedError)
+
+		actualSensor, err := sr.GetSensorBySerialNumber(ctx, sensor.SerialNumber)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, actualSensor.CurrentState)
+
+		_, err = sr.GetSensorBySerialNumber(ctx, "9876543210")
+		assert.ErrorIs(t, err, usecase.ErrSensorNotFound)
+
+		event, err := er.GetLastEventBySensorID(ctx, sensor.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, event.Payload)
+	})
+
+	t.Run("ok, transactions are serialized", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tr := transaction.NewTransactor()
+		counter := 0
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, tr.WithinTransaction(ctx, func(context.Context) error {
+					value := counter
+					time.Sleep(time.Microsecond)
+					counter = value + 1
+					return nil
+				}))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 100, counter)
+	})
+}
@@ -0,0 +1,99 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/usecase"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	eventRepository "homework/internal/repository/event/sqlite"
+	sensorRepository "homework/internal/repository/sensor/sqlite"
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type TransactionTestSuite struct {
+	suite.Suite
+	testDbInstance *sql.DB
+
+	transactor *transaction.Transactor
+	er         *eventRepository.EventRepository
+	sr         *sensorRepository.SensorRepository
+}
+
+func (suite *TransactionTestSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.transactor = transaction.NewTransactor(suite.testDbInstance)
+	suite.er = eventRepository.NewEventRepository(suite.testDbInstance)
+	suite.sr = sensorRepository.NewSensorRepository(suite.testDbInstance)
+}
+
+func (suite *TransactionTestSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func (suite *TransactionTestSuite) TestTransactor_Commit() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sensor := &domain.Sensor{SerialNumber: "1234567890", Type: domain.SensorTypeADC}
+	err := suite.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := suite.sr.SaveSensor(ctx, sensor); err != nil {
+			return err
+		}
+		return suite.er.SaveEvent(ctx, &domain.Event{
+			Timestamp:          time.Now().Truncate(time.Microsecond).In(time.UTC),
+			SensorSerialNumber: sensor.SerialNumber,
+			SensorID:           sensor.ID,
+			Payload:            1,
+		})
+	})
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.sr.GetSensorByID(ctx, sensor.ID)
+	assert.Nil(suite.T(), err)
+	_, err = suite.er.GetLastEventBySensorID(ctx, sensor.ID)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *TransactionTestSuite) TestTransactor_Rollback() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	expectedError := errors.New("some error")
+	sensor := &domain.Sensor{SerialNumber: "0987654321", Type: domain.SensorTypeADC}
+	err := suite.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := suite.sr.SaveSensor(ctx, sensor); err != nil {
+			return err
+		}
+		if err := suite.er.SaveEvent(ctx, &domain.Event{
+			Timestamp:          time.Now().Truncate(time.Microsecond).In(time.UTC),
+			SensorSerialNumber: sensor.SerialNumber,
+			SensorID:           sensor.ID,
+			Payload:            1,
+		}); err != nil {
+			return err
+		}
+		return expectedError
+	})
+	assert.ErrorIs(suite.T(), err, expectedError)
+
+	_, err = suite.sr.GetSensorBySerialNumber(ctx, sensor.SerialNumber)
+	assert.Error(suite.T(), err)
+	_, err = suite.er.GetLastEventBySensorID(ctx, sensor.ID)
+	assert.ErrorIs(suite.T(), err, usecase.ErrEventNotFound)
+}
+
+func TestTransactionTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionTestSuite))
+}
@@ -0,0 +1,24 @@
+package inmemory
+
+import (
+	"homework/internal/repository/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/inmemory"
+)
+
+func TestUserRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.UserRepositorySuite{
+		Users: NewUserRepository(),
+	})
+}
+
+func TestSensorOwnerRepositoryContract(t *testing.T) {
+	suite.Run(t, &contract.SensorOwnerRepositorySuite{
+		SensorOwners: NewSensorOwnerRepository(),
+		Users:        NewUserRepository(),
+		Sensors:      sensorRepository.NewSensorRepository(),
+	})
+}
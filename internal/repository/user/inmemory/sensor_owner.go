@@ -3,7 +3,10 @@ package inmemory
 import (
 	"context"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"sync"
+
+	transaction "homework/internal/repository/transaction/inmemory"
 )
 
 type SensorOwnerRepository struct {
@@ -25,8 +28,24 @@ func (r *SensorOwnerRepository) SaveSensorOwner(ctx context.Context, sensorOwner
 		return ctx.Err()
 	default:
 		r.rw.Lock()
+		defer r.rw.Unlock()
+		for _, owner := range r.sensorsOwners[sensorOwner.UserID] {
+			if owner.SensorID == sensorOwner.SensorID {
+				return usecase.ErrSensorOwnerExists
+			}
+		}
 		r.sensorsOwners[sensorOwner.UserID] = append(r.sensorsOwners[sensorOwner.UserID], sensorOwner)
-		r.rw.Unlock()
+		transaction.OnRollback(ctx, func() {
+			r.rw.Lock()
+			defer r.rw.Unlock()
+			owners := r.sensorsOwners[sensorOwner.UserID]
+			for i, owner := range owners {
+				if owner == sensorOwner {
+					r.sensorsOwners[sensorOwner.UserID] = append(owners[:i:i], owners[i+1:]...)
+					break
+				}
+			}
+		})
 		return nil
 	}
 }
@@ -45,3 +64,22 @@ func (r *SensorOwnerRepository) GetSensorsByUserID(ctx context.Context, userID i
 		}
 	}
 }
+
+func (r *SensorOwnerRepository) GetUsersBySensorID(ctx context.Context, sensorID int64) ([]domain.SensorOwner, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		r.rw.RLock()
+		defer r.rw.RUnlock()
+		owners := []domain.SensorOwner{}
+		for _, bindings := range r.sensorsOwners {
+			for _, owner := range bindings {
+				if owner.SensorID == sensorID {
+					owners = append(owners, owner)
+				}
+			}
+		}
+		return owners, nil
+	}
+}
@@ -3,6 +3,7 @@ package inmemory
 import (
 	"context"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"sync"
 	"testing"
 	"time"
@@ -49,6 +50,20 @@ func TestSensorOwnerRepository_SaveSensorOwner(t *testing.T) {
 		assert.Equal(t, list[0].SensorID, int64(5678))
 	})
 
+	t.Run("fail, duplicate binding", func(t *testing.T) {
+		sor := NewSensorOwnerRepository()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sensorOwner := domain.SensorOwner{
+			UserID:   1234,
+			SensorID: 5678,
+		}
+
+		assert.NoError(t, sor.SaveSensorOwner(ctx, sensorOwner))
+		assert.ErrorIs(t, sor.SaveSensorOwner(ctx, sensorOwner), usecase.ErrSensorOwnerExists)
+	})
+
 	t.Run("ok, collision test", func(t *testing.T) {
 		sr := NewSensorOwnerRepository()
 		ctx, cancel := context.WithCancel(context.Background())
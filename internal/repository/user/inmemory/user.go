@@ -5,6 +5,8 @@ import (
 	"homework/internal/domain"
 	"homework/internal/usecase"
 	"sync"
+
+	transaction "homework/internal/repository/transaction/inmemory"
 )
 
 type UserRepository struct {
@@ -39,6 +41,12 @@ func (r *UserRepository) SaveUser(ctx context.Context, user *domain.User) error
 			} else {
 				r.users[user.ID] = user
 			}
+			id := user.ID
+			transaction.OnRollback(ctx, func() {
+				r.rw.Lock()
+				delete(r.users, id)
+				r.rw.Unlock()
+			})
 		}
 		return nil
 	}
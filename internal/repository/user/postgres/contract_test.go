@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"homework/internal/repository/contract"
+	"homework/pkg/pg_test"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/postgres"
+)
+
+type userContractSuite struct {
+	contract.UserRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *userContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.Users = NewUserRepository(db)
+}
+
+func (suite *userContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestUserRepositoryContract(t *testing.T) {
+	suite.Run(t, new(userContractSuite))
+}
+
+type sensorOwnerContractSuite struct {
+	contract.SensorOwnerRepositorySuite
+	testDB *pg_test.TestDatabase
+}
+
+func (suite *sensorOwnerContractSuite) SetupSuite() {
+	suite.testDB = pg_test.SetupTestDatabase()
+	db := suite.testDB.DbInstance
+
+	suite.SensorOwners = NewSensorOwnerRepository(db)
+	suite.Users = NewUserRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+}
+
+func (suite *sensorOwnerContractSuite) TearDownSuite() {
+	suite.testDB.TearDown()
+}
+
+func TestSensorOwnerRepositoryContract(t *testing.T) {
+	suite.Run(t, new(sensorOwnerContractSuite))
+}
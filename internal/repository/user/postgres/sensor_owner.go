@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"homework/internal/domain"
+	"homework/internal/repository/pgerrors"
+	"homework/internal/usecase"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
 )
 
 type SensorOwnerRepository struct {
@@ -18,23 +22,41 @@ func NewSensorOwnerRepository(pool *pgxpool.Pool) *SensorOwnerRepository {
 	}
 }
 
-var updateSensorOwnerID int64 = 1
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *SensorOwnerRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
 
-const saveSensorOwnerQuery = `INSERT INTO sensors_users (id, sensor_id, user_id) VALUES ($1, $2, $3)`
+const saveSensorOwnerQuery = `INSERT INTO sensors_users (sensor_id, user_id) VALUES ($1, $2)`
 
 const getSensorsByUserID = `SELECT (sensor_id) FROM sensors_users WHERE user_id = $1`
 
+const getUsersBySensorID = `SELECT user_id FROM sensors_users WHERE sensor_id = $1`
+
+const (
+	sensorsUsersSensorIDForeignKey = "sensors_users_sensor_id_fkey"
+	sensorsUsersUserIDForeignKey   = "sensors_users_user_id_fkey"
+	sensorsUsersBindingKey         = "sensors_users_sensor_id_user_id_key"
+)
+
 func (r *SensorOwnerRepository) SaveSensorOwner(ctx context.Context, sensorOwner domain.SensorOwner) error {
-	_, err := r.pool.Exec(ctx, saveSensorOwnerQuery, updateSensorOwnerID, sensorOwner.SensorID, sensorOwner.UserID)
+	_, err := r.db(ctx).Exec(ctx, saveSensorOwnerQuery, sensorOwner.SensorID, sensorOwner.UserID)
+	switch {
+	case pgerrors.IsUniqueViolation(err, sensorsUsersBindingKey):
+		return usecase.ErrSensorOwnerExists
+	case pgerrors.IsForeignKeyViolation(err, sensorsUsersSensorIDForeignKey):
+		return usecase.ErrSensorNotFound
+	case pgerrors.IsForeignKeyViolation(err, sensorsUsersUserIDForeignKey):
+		return usecase.ErrUserNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("can't save sensor owner: %w", err)
 	}
-	updateSensorOwnerID++
 	return nil
 }
 
 func (r *SensorOwnerRepository) GetSensorsByUserID(ctx context.Context, userID int64) ([]domain.SensorOwner, error) {
-	rows, err := r.pool.Query(ctx, getSensorsByUserID, userID)
+	rows, err := r.db(ctx).Query(ctx, getSensorsByUserID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("can't get sensors by user: %w", err)
 	}
@@ -52,3 +74,20 @@ func (r *SensorOwnerRepository) GetSensorsByUserID(ctx context.Context, userID i
 	}
 	return sensors, nil
 }
+
+func (r *SensorOwnerRepository) GetUsersBySensorID(ctx context.Context, sensorID int64) ([]domain.SensorOwner, error) {
+	rows, err := r.db(ctx).Query(ctx, getUsersBySensorID, sensorID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get users by sensor: %w", err)
+	}
+	defer rows.Close()
+	var owners []domain.SensorOwner
+	for rows.Next() {
+		owner := domain.SensorOwner{SensorID: sensorID}
+		if err = rows.Scan(&owner.UserID); err != nil {
+			return nil, fmt.Errorf("can't scan sensor owner: %w", err)
+		}
+		owners = append(owners, owner)
+	}
+	return owners, rows.Err()
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"homework/pkg/pg_test"
 	"testing"
 	"time"
@@ -25,6 +26,12 @@ func (suite *SensorOwnerTestSuite) SetupSuite() {
 	suite.testDbInstance = suite.testDB.DbInstance
 
 	suite.repo = NewSensorOwnerRepository(suite.testDbInstance)
+
+	ctx := context.Background()
+	_, err := suite.testDbInstance.Exec(ctx, `INSERT INTO users (id, name) VALUES (1, 'first'), (2, 'second')`)
+	suite.Require().NoError(err)
+	_, err = suite.testDbInstance.Exec(ctx, `INSERT INTO sensors (id, serial_number, type) VALUES (1, '0000000001', 'cc'), (2, '0000000002', 'cc'), (3, '0000000003', 'adc')`)
+	suite.Require().NoError(err)
 }
 
 func (suite *SensorOwnerTestSuite) TearDownSuite() {
@@ -32,7 +39,8 @@ func (suite *SensorOwnerTestSuite) TearDownSuite() {
 }
 
 func (suite *SensorOwnerTestSuite) TestSensorOwnerRepository_SaveSensorOwner() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	err := suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{
 		UserID:   1,
@@ -43,7 +51,8 @@ func (suite *SensorOwnerTestSuite) TestSensorOwnerRepository_SaveSensorOwner() {
 }
 
 func (suite *SensorOwnerTestSuite) TestSensorOwnerRepository_GetSensorsByUserID() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	err := suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{
 		UserID:   2,
@@ -69,6 +78,23 @@ func (suite *SensorOwnerTestSuite) TestSensorOwnerRepository_GetSensorsByUserID(
 	}, sensors)
 }
 
+func (suite *SensorOwnerTestSuite) TestSensorOwnerRepository_SaveSensorOwnerConstraints() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{UserID: 1, SensorID: 3})
+	assert.Nil(suite.T(), err)
+
+	err = suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{UserID: 1, SensorID: 3})
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorOwnerExists)
+
+	err = suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{UserID: 1, SensorID: 100})
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+
+	err = suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{UserID: 100, SensorID: 1})
+	assert.ErrorIs(suite.T(), err, usecase.ErrUserNotFound)
+}
+
 func TestSensorOwnerTestSuite(t *testing.T) {
 	suite.Run(t, new(SensorOwnerTestSuite))
 }
@@ -2,10 +2,15 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	transaction "homework/internal/repository/transaction/postgres"
 )
 
 type UserRepository struct {
@@ -18,27 +23,30 @@ func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
 	}
 }
 
-var updateID int64 = 1
+// db - возвращает транзакцию из ctx, если она есть, иначе пул соединений
+func (r *UserRepository) db(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.pool)
+}
 
-const saveUserQuery = `INSERT INTO users (id, name) VALUES ($1, $2)`
+const saveUserQuery = `INSERT INTO users (name) VALUES ($1) RETURNING id`
 
 const getUserQuery = `SELECT * FROM users WHERE id = $1`
 
 func (r *UserRepository) SaveUser(ctx context.Context, user *domain.User) error {
-	user.ID = updateID
-
-	_, err := r.pool.Exec(ctx, saveUserQuery, user.ID, user.Name)
+	err := r.db(ctx).QueryRow(ctx, saveUserQuery, user.Name).Scan(&user.ID)
 	if err != nil {
 		return fmt.Errorf("can't save user: %w", err)
 	}
-	updateID++
 	return nil
 }
 
 func (r *UserRepository) GetUserByID(ctx context.Context, id int64) (*domain.User, error) {
-	row := r.pool.QueryRow(ctx, getUserQuery, id)
+	row := r.db(ctx).QueryRow(ctx, getUserQuery, id)
 	user := &domain.User{}
 	err := row.Scan(&user.ID, &user.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, usecase.ErrUserNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("can't get user by id: %w", err)
 	}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"homework/internal/domain"
+	"homework/internal/usecase"
 	"homework/pkg/pg_test"
 	"testing"
 	"time"
@@ -32,7 +33,8 @@ func (suite *UserTestSuite) TearDownSuite() {
 }
 
 func (suite *UserTestSuite) TestUserRepository_SaveUser() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	name := "vasya pupkin"
 
@@ -44,7 +46,8 @@ func (suite *UserTestSuite) TestUserRepository_SaveUser() {
 }
 
 func (suite *UserTestSuite) TestUserRepository_GetUserByID() {
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second) //nolint: govet // test stub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	name := "vasya pupkin"
 
@@ -61,6 +64,32 @@ func (suite *UserTestSuite) TestUserRepository_GetUserByID() {
 	assert.Equal(suite.T(), name, user.Name)
 }
 
+func (suite *UserTestSuite) TestUserRepository_SaveUserGeneratesID() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := &domain.User{Name: "first"}
+	second := &domain.User{Name: "second"}
+
+	assert.Nil(suite.T(), suite.repo.SaveUser(ctx, first))
+	assert.Nil(suite.T(), suite.repo.SaveUser(ctx, second))
+
+	assert.NotZero(suite.T(), first.ID)
+	assert.NotEqual(suite.T(), first.ID, second.ID)
+
+	user, err := suite.repo.GetUserByID(ctx, second.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), second, user)
+}
+
+func (suite *UserTestSuite) TestUserRepository_GetUnknownUser() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := suite.repo.GetUserByID(ctx, 100500)
+	assert.ErrorIs(suite.T(), err, usecase.ErrUserNotFound)
+}
+
 func TestUserTestSuite(t *testing.T) {
 	suite.Run(t, new(UserTestSuite))
 }
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"database/sql"
+	"homework/internal/repository/contract"
+	"homework/internal/repository/sqlitedb"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sensorRepository "homework/internal/repository/sensor/sqlite"
+)
+
+type userContractSuite struct {
+	contract.UserRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *userContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.Users = NewUserRepository(db)
+}
+
+func (suite *userContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestUserRepositoryContract(t *testing.T) {
+	suite.Run(t, new(userContractSuite))
+}
+
+type sensorOwnerContractSuite struct {
+	contract.SensorOwnerRepositorySuite
+	testDbInstance *sql.DB
+}
+
+func (suite *sensorOwnerContractSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.SensorOwners = NewSensorOwnerRepository(db)
+	suite.Users = NewUserRepository(db)
+	suite.Sensors = sensorRepository.NewSensorRepository(db)
+}
+
+func (suite *sensorOwnerContractSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func TestSensorOwnerRepositoryContract(t *testing.T) {
+	suite.Run(t, new(sensorOwnerContractSuite))
+}
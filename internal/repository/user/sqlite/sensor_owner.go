@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/usecase"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type SensorOwnerRepository struct {
+	db *sql.DB
+}
+
+func NewSensorOwnerRepository(db *sql.DB) *SensorOwnerRepository {
+	return &SensorOwnerRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *SensorOwnerRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const saveSensorOwnerQuery = `INSERT INTO sensors_users (sensor_id, user_id) VALUES (?, ?)`
+
+const getSensorsByUserID = `SELECT sensor_id FROM sensors_users WHERE user_id = ?`
+
+const getUsersBySensorID = `SELECT user_id FROM sensors_users WHERE sensor_id = ?`
+
+// sqlite не сообщает, какой внешний ключ нарушен, поэтому при ошибке проверяем наличие датчика
+const sensorExistsQuery = `SELECT EXISTS (SELECT 1 FROM sensors WHERE id = ?)`
+
+func (r *SensorOwnerRepository) SaveSensorOwner(ctx context.Context, sensorOwner domain.SensorOwner) error {
+	_, err := r.conn(ctx).ExecContext(ctx, saveSensorOwnerQuery, sensorOwner.SensorID, sensorOwner.UserID)
+	switch {
+	case sqlitedb.IsUniqueViolation(err, "sensors_users", "sensor_id", "user_id"):
+		return usecase.ErrSensorOwnerExists
+	case sqlitedb.IsForeignKeyViolation(err):
+		var exists bool
+		if err := r.conn(ctx).QueryRowContext(ctx, sensorExistsQuery, sensorOwner.SensorID).Scan(&exists); err != nil {
+			return fmt.Errorf("can't save sensor owner: %w", err)
+		}
+		if !exists {
+			return usecase.ErrSensorNotFound
+		}
+		return usecase.ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("can't save sensor owner: %w", err)
+	}
+	return nil
+}
+
+func (r *SensorOwnerRepository) GetSensorsByUserID(ctx context.Context, userID int64) ([]domain.SensorOwner, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, getSensorsByUserID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get sensors by user: %w", err)
+	}
+	defer rows.Close()
+	var sensors []domain.SensorOwner
+	for rows.Next() {
+		sensor := domain.SensorOwner{}
+		sensor.UserID = userID
+		err = rows.Scan(&sensor.SensorID)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan sensor owner: %w", err)
+		}
+
+		sensors = append(sensors, sensor)
+	}
+	return sensors, rows.Err()
+}
+
+func (r *SensorOwnerRepository) GetUsersBySensorID(ctx context.Context, sensorID int64) ([]domain.SensorOwner, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, getUsersBySensorID, sensorID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get users by sensor: %w", err)
+	}
+	defer rows.Close()
+	var owners []domain.SensorOwner
+	for rows.Next() {
+		owner := domain.SensorOwner{SensorID: sensorID}
+		if err = rows.Scan(&owner.UserID); err != nil {
+			return nil, fmt.Errorf("can't scan sensor owner: %w", err)
+		}
+		owners = append(owners, owner)
+	}
+	return owners, rows.Err()
+}
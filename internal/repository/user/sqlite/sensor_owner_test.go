@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"homework/internal/domain"
+	"homework/internal/repository/sqlitedb"
+	"homework/internal/usecase"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// SensorOwnerTestSuite - поведение, которое проверяется только для sqlite: общий контракт привязок проверяется
+// в contract_test.go. sqlite не называет нарушенный внешний ключ, поэтому отсутствующие пользователь и датчик
+// различаются отдельно
+type SensorOwnerTestSuite struct {
+	suite.Suite
+	testDbInstance *sql.DB
+
+	repo *SensorOwnerRepository
+}
+
+func (suite *SensorOwnerTestSuite) SetupSuite() {
+	db, err := sqlitedb.Open(filepath.Join(suite.T().TempDir(), "test.db"))
+	suite.Require().NoError(err)
+	suite.testDbInstance = db
+
+	suite.repo = NewSensorOwnerRepository(suite.testDbInstance)
+
+	ctx := context.Background()
+	_, err = suite.testDbInstance.ExecContext(ctx, `INSERT INTO users (id, name) VALUES (1, 'first')`)
+	suite.Require().NoError(err)
+	_, err = suite.testDbInstance.ExecContext(ctx, `INSERT INTO sensors (id, serial_number, type) VALUES (1, '0000000001', 'cc')`)
+	suite.Require().NoError(err)
+}
+
+func (suite *SensorOwnerTestSuite) TearDownSuite() {
+	_ = suite.testDbInstance.Close()
+}
+
+func (suite *SensorOwnerTestSuite) TestSensorOwnerRepository_SaveSensorOwnerUnknown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{UserID: 1, SensorID: 100})
+	assert.ErrorIs(suite.T(), err, usecase.ErrSensorNotFound)
+
+	err = suite.repo.SaveSensorOwner(ctx, domain.SensorOwner{UserID: 100, SensorID: 1})
+	assert.ErrorIs(suite.T(), err, usecase.ErrUserNotFound)
+}
+
+func TestSensorOwnerTestSuite(t *testing.T) {
+	suite.Run(t, new(SensorOwnerTestSuite))
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"homework/internal/usecase"
+
+	transaction "homework/internal/repository/transaction/sqlite"
+)
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+// conn - возвращает транзакцию из ctx, если она есть, иначе соединение с базой
+func (r *UserRepository) conn(ctx context.Context) transaction.Querier {
+	return transaction.QuerierFromContext(ctx, r.db)
+}
+
+const saveUserQuery = `INSERT INTO users (name) VALUES (?) RETURNING id`
+
+const getUserQuery = `SELECT id, name FROM users WHERE id = ?`
+
+func (r *UserRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	err := r.conn(ctx).QueryRowContext(ctx, saveUserQuery, user.Name).Scan(&user.ID)
+	if err != nil {
+		return fmt.Errorf("can't save user: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id int64) (*domain.User, error) {
+	row := r.conn(ctx).QueryRowContext(ctx, getUserQuery, id)
+	user := &domain.User{}
+	err := row.Scan(&user.ID, &user.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get user by id: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"slices"
+	"sync"
+	"time"
+)
+
+// AlertQuery - параметры выборки тревог
+type AlertQuery struct {
+	// SensorIDs - датчики, тревоги которых выбираются
+	SensorIDs []int64
+	// States - этапы обработки выбираемых тревог, пустой список - все
+	States []domain.AlertState
+	// Limit - максимальное число тревог, 0 - DefaultPageLimit
+	Limit int
+}
+
+// alertSubscriberBuffer - число тревог, которые подписчик может не успеть принять, прежде чем они начнут теряться
+const alertSubscriberBuffer = 16
+
+type Alert struct {
+	alertRepository       AlertRepository
+	userRepository        UserRepository
+	sensorOwnerRepository SensorOwnerRepository
+	transactor            Transactor
+	notifications         *Notification
+
+	mutex       sync.Mutex
+	subscribers map[chan domain.Alert]struct{}
+}
+
+func NewAlert(ar AlertRepository, ur UserRepository, sor SensorOwnerRepository, options ...func(*Alert)) *Alert {
+	a := &Alert{
+		alertRepository:       ar,
+		userRepository:        ur,
+		sensorOwnerRepository: sor,
+		transactor:            noTransactor{},
+		subscribers:           map[chan domain.Alert]struct{}{},
+	}
+	for _, o := range options {
+		o(a)
+	}
+	return a
+}
+
+// WithAlertTransactor - проверка доступа к тревоге и её изменение выполняются в одной транзакции
+func WithAlertTransactor(t Transactor) func(*Alert) {
+	return func(a *Alert) {
+		a.transactor = t
+	}
+}
+
+// WithEventAlerts - события датчиков с правилом тревоги поднимают тревоги alerts
+func WithEventAlerts(alerts *Alert) func(*Event) {
+	return func(e *Event) {
+		e.alerts = alerts
+	}
+}
+
+// raise - поднимает тревогу, если событие нарушает правило датчика и неустранённой тревоги у датчика ещё нет.
+// Вызывается в транзакции сохранения события, возвращает nil, если новая тревога не поднята
+func (a *Alert) raise(ctx context.Context, sensor *domain.Sensor, event *domain.Event) (*domain.Alert, error) {
+	if !sensor.AlertRule.Triggered(event.Payload) {
+		return nil, nil
+	}
+	_, err := a.alertRepository.GetUnresolvedAlertBySensorID(ctx, sensor.ID)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, ErrAlertNotFound) {
+		return nil, err
+	}
+	alert := &domain.Alert{
+		SensorID:    sensor.ID,
+		Severity:    sensor.AlertRule.Severity,
+		Payload:     event.Payload,
+		TriggeredAt: event.Timestamp,
+	}
+	err = a.alertRepository.SaveAlert(ctx, alert)
+	// тревогу успел поднять параллельно сохранённое событие
+	if errors.Is(err, ErrAlertExists) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// GetUserAlerts - функция получения тревог датчиков, привязанных к пользователю, от новых к старым
+func (a *Alert) GetUserAlerts(ctx context.Context, userID int64, query AlertQuery) ([]domain.Alert, error) {
+	for _, state := range query.States {
+		if !validAlertState(state) {
+			return nil, ErrInvalidAlertState
+		}
+	}
+	limit, err := pageLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = limit
+	if query.SensorIDs, err = a.userSensorIDs(ctx, userID); err != nil {
+		return nil, err
+	}
+	if len(query.SensorIDs) == 0 {
+		return []domain.Alert{}, nil
+	}
+	return a.alertRepository.ListAlerts(ctx, query)
+}
+
+// AcknowledgeAlert - функция подтверждения тревоги пользователем, у которого есть доступ к её датчику.
+// Повторное подтверждение не меняет тревогу, устранённую тревогу подтвердить нельзя (ErrAlertResolved)
+func (a *Alert) AcknowledgeAlert(ctx context.Context, userID, alertID int64) (*domain.Alert, error) {
+	return a.update(ctx, userID, alertID, func(alert *domain.Alert, now time.Time) (bool, error) {
+		if alert.ResolvedAt != nil {
+			return false, ErrAlertResolved
+		}
+		if alert.AcknowledgedAt != nil {
+			return false, nil
+		}
+		alert.AcknowledgedBy, alert.AcknowledgedAt = &userID, &now
+		return true, nil
+	})
+}
+
+// ResolveAlert - функция устранения тревоги пользователем, у которого есть доступ к её датчику.
+// Повторное устранение не меняет тревогу, после устранения датчик может поднять новую тревогу
+func (a *Alert) ResolveAlert(ctx context.Context, userID, alertID int64) (*domain.Alert, error) {
+	return a.update(ctx, userID, alertID, func(alert *domain.Alert, now time.Time) (bool, error) {
+		if alert.ResolvedAt != nil {
+			return false, nil
+		}
+		alert.ResolvedBy, alert.ResolvedAt = &userID, &now
+		return true, nil
+	})
+}
+
+// update - применяет change к тревоге, доступной пользователю, и рассылает её подписчикам, если change её изменил.
+// Тревога датчика, к которому у пользователя нет доступа, считается ненайденной
+func (a *Alert) update(ctx context.Context, userID, alertID int64, change func(alert *domain.Alert, now time.Time) (bool, error)) (*domain.Alert, error) {
+	var alert *domain.Alert
+	var changed bool
+	err := a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		sensorIDs, err := a.userSensorIDs(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if alert, err = a.alertRepository.GetAlertByID(ctx, alertID); err != nil {
+			return err
+		}
+		if !slices.Contains(sensorIDs, alert.SensorID) {
+			return ErrAlertNotFound
+		}
+		if changed, err = change(alert, time.Now().Truncate(time.Microsecond).UTC()); err != nil || !changed {
+			return err
+		}
+		return a.alertRepository.SaveAlert(ctx, alert)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		a.notify(*alert)
+	}
+	return alert, nil
+}
+
+// userSensorIDs - ID датчиков, привязанных к пользователю, ErrUserNotFound если пользователя нет
+func (a *Alert) userSensorIDs(ctx context.Context, userID int64) ([]int64, error) {
+	if _, err := a.userRepository.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	owners, err := a.sensorOwnerRepository.GetSensorsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(owners))
+	for _, owner := range owners {
+		ids = append(ids, owner.SensorID)
+	}
+	return ids, nil
+}
+
+// SubscribeAlerts - функция подписки на поднятые и изменённые тревоги датчиков, привязанных к пользователю.
+// Канал закрывается после отмены ctx. Тревоги, которые подписчик не успевает принять, теряются,
+// чтобы медленный подписчик не задерживал сохранение событий
+func (a *Alert) SubscribeAlerts(ctx context.Context, userID int64) (<-chan domain.Alert, error) {
+	if _, err := a.userRepository.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	in := make(chan domain.Alert, alertSubscriberBuffer)
+	a.mutex.Lock()
+	a.subscribers[in] = struct{}{}
+	a.mutex.Unlock()
+
+	out := make(chan domain.Alert)
+	go func() {
+		defer close(out)
+		defer func() {
+			a.mutex.Lock()
+			delete(a.subscribers, in)
+			a.mutex.Unlock()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case alert := <-in:
+				// привязки проверяются при доставке, потому что могут измениться за время подписки
+				sensorIDs, err := a.userSensorIDs(ctx, userID)
+				if err != nil || !slices.Contains(sensorIDs, alert.SensorID) {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- alert:
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// raised - рассылает подписчикам новую тревогу и ставит её в очередь уведомлений
+func (a *Alert) raised(alert domain.Alert) {
+	a.notify(alert)
+	if a.notifications != nil {
+		a.notifications.enqueue(alert)
+	}
+}
+
+// notify - рассылает тревогу подписчикам
+func (a *Alert) notify(alert domain.Alert) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for subscriber := range a.subscribers {
+		select {
+		case subscriber <- alert:
+		default:
+		}
+	}
+}
+
+func validAlertState(state domain.AlertState) bool {
+	switch state {
+	case domain.AlertStateOpen, domain.AlertStateAcknowledged, domain.AlertStateResolved:
+		return true
+	}
+	return false
+}
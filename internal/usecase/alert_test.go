@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"homework/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// alertOwner - пользователь 1 с доступом к датчику 1
+func alertOwner(ctrl *gomock.Controller) (*MockUserRepository, *MockSensorOwnerRepository) {
+	ur := NewMockUserRepository(ctrl)
+	ur.EXPECT().GetUserByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.User{ID: 1}, nil)
+	ur.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, ErrUserNotFound)
+	sor := NewMockSensorOwnerRepository(ctrl)
+	sor.EXPECT().GetSensorsByUserID(gomock.Any(), int64(1)).AnyTimes().Return([]domain.SensorOwner{{UserID: 1, SensorID: 1}}, nil)
+	return ur, sor
+}
+
+func Test_event_ReceiveEventRaisesAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := 0.0
+	sensor := func() *domain.Sensor {
+		return &domain.Sensor{
+			ID:           1,
+			SerialNumber: "0123456789",
+			Type:         domain.SensorTypeContactClosure,
+			AlertRule:    &domain.AlertRule{Severity: domain.AlertSeverityCritical, Max: &limit},
+		}
+	}
+
+	t.Run("ok, alert raised and delivered", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(sensor(), nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).Return(nil)
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetUnresolvedAlertBySensorID(ctx, int64(1)).Times(1).Return(nil, ErrAlertNotFound)
+		ar.EXPECT().SaveAlert(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, alert *domain.Alert) error {
+			alert.ID = 7
+			return nil
+		})
+		ur, sor := alertOwner(ctrl)
+
+		a := NewAlert(ar, ur, sor)
+		alerts, err := a.SubscribeAlerts(ctx, 1)
+		require.NoError(t, err)
+		e := NewEvent(er, sr, WithEventAlerts(a))
+
+		event := &domain.Event{SensorSerialNumber: "0123456789", Payload: 1}
+		require.NoError(t, e.ReceiveEvent(ctx, event))
+
+		select {
+		case alert := <-alerts:
+			assert.Equal(t, int64(7), alert.ID)
+			assert.Equal(t, int64(1), alert.SensorID)
+			assert.Equal(t, domain.AlertSeverityCritical, alert.Severity)
+			assert.Equal(t, 1.0, alert.Payload)
+			assert.Equal(t, event.Timestamp, alert.TriggeredAt)
+			assert.Equal(t, domain.AlertStateOpen, alert.State())
+		case <-time.After(time.Second):
+			t.Fatal("alert not delivered")
+		}
+	})
+
+	t.Run("ok, no new alert while one is unresolved", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(sensor(), nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).Return(nil)
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetUnresolvedAlertBySensorID(ctx, int64(1)).Times(1).Return(&domain.Alert{ID: 7, SensorID: 1}, nil)
+		ar.EXPECT().SaveAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		e := NewEvent(er, sr, WithEventAlerts(NewAlert(ar, nil, nil)))
+		assert.NoError(t, e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789", Payload: 1}))
+	})
+
+	t.Run("ok, payload within rule", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(sensor(), nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).Return(nil)
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetUnresolvedAlertBySensorID(gomock.Any(), gomock.Any()).Times(0)
+
+		e := NewEvent(er, sr, WithEventAlerts(NewAlert(ar, nil, nil)))
+		assert.NoError(t, e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789", Payload: 0}))
+	})
+}
+
+func Test_alert_GetUserAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ur, sor := alertOwner(ctrl)
+
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expected := []domain.Alert{{ID: 1, SensorID: 1}}
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().ListAlerts(ctx, AlertQuery{
+			SensorIDs: []int64{1},
+			States:    []domain.AlertState{domain.AlertStateOpen},
+			Limit:     DefaultPageLimit,
+		}).Times(1).Return(expected, nil)
+
+		a := NewAlert(ar, ur, sor)
+		alerts, err := a.GetUserAlerts(ctx, 1, AlertQuery{States: []domain.AlertState{domain.AlertStateOpen}})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, alerts)
+	})
+
+	t.Run("ok, user without sensors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ur := NewMockUserRepository(ctrl)
+		ur.EXPECT().GetUserByID(ctx, int64(2)).Times(1).Return(&domain.User{ID: 2}, nil)
+		sor := NewMockSensorOwnerRepository(ctrl)
+		sor.EXPECT().GetSensorsByUserID(ctx, int64(2)).Times(1).Return(nil, nil)
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().ListAlerts(gomock.Any(), gomock.Any()).Times(0)
+
+		a := NewAlert(ar, ur, sor)
+		alerts, err := a.GetUserAlerts(ctx, 2, AlertQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Alert{}, alerts)
+	})
+
+	t.Run("fail, invalid query", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		a := NewAlert(NewMockAlertRepository(ctrl), ur, sor)
+		_, err := a.GetUserAlerts(ctx, 1, AlertQuery{States: []domain.AlertState{"closed"}})
+		assert.ErrorIs(t, err, ErrInvalidAlertState)
+		_, err = a.GetUserAlerts(ctx, 1, AlertQuery{Limit: MaxPageLimit + 1})
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		_, err = a.GetUserAlerts(ctx, 2, AlertQuery{})
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func Test_alert_AcknowledgeAndResolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ur, sor := alertOwner(ctrl)
+	now := time.Now().UTC()
+	userID := int64(1)
+
+	t.Run("ok, acknowledge then resolve", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stored := &domain.Alert{ID: 7, SensorID: 1, TriggeredAt: now}
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetAlertByID(ctx, int64(7)).Times(2).DoAndReturn(func(context.Context, int64) (*domain.Alert, error) {
+			alert := *stored
+			return &alert, nil
+		})
+		ar.EXPECT().SaveAlert(ctx, gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, alert *domain.Alert) error {
+			*stored = *alert
+			return nil
+		})
+
+		a := NewAlert(ar, ur, sor)
+		alerts, err := a.SubscribeAlerts(ctx, userID)
+		require.NoError(t, err)
+
+		alert, err := a.AcknowledgeAlert(ctx, userID, 7)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AlertStateAcknowledged, alert.State())
+		assert.Equal(t, &userID, alert.AcknowledgedBy)
+		assert.Equal(t, domain.AlertStateAcknowledged, (<-alerts).State())
+
+		alert, err = a.ResolveAlert(ctx, userID, 7)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AlertStateResolved, alert.State())
+		assert.Equal(t, &userID, alert.ResolvedBy)
+		assert.Equal(t, domain.AlertStateResolved, (<-alerts).State())
+	})
+
+	t.Run("ok, repeated resolve does not change alert", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		resolvedAt := now
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetAlertByID(ctx, int64(7)).Times(1).Return(&domain.Alert{ID: 7, SensorID: 1, ResolvedAt: &resolvedAt}, nil)
+		ar.EXPECT().SaveAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		alert, err := NewAlert(ar, ur, sor).ResolveAlert(ctx, userID, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, &resolvedAt, alert.ResolvedAt)
+	})
+
+	t.Run("fail, resolved alert can't be acknowledged", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		resolvedAt := now
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetAlertByID(ctx, int64(7)).Times(1).Return(&domain.Alert{ID: 7, SensorID: 1, ResolvedAt: &resolvedAt}, nil)
+		ar.EXPECT().SaveAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := NewAlert(ar, ur, sor).AcknowledgeAlert(ctx, userID, 7)
+		assert.ErrorIs(t, err, ErrAlertResolved)
+	})
+
+	t.Run("fail, alert of other user's sensor", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ar := NewMockAlertRepository(ctrl)
+		ar.EXPECT().GetAlertByID(ctx, int64(8)).Times(1).Return(&domain.Alert{ID: 8, SensorID: 2}, nil)
+		ar.EXPECT().SaveAlert(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := NewAlert(ar, ur, sor).AcknowledgeAlert(ctx, userID, 8)
+		assert.ErrorIs(t, err, ErrAlertNotFound)
+	})
+}
+
+func Test_alert_SubscribeAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ur, sor := alertOwner(ctrl)
+	a := NewAlert(NewMockAlertRepository(ctrl), ur, sor)
+
+	t.Run("ok, only alerts of user's sensors delivered", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		alerts, err := a.SubscribeAlerts(ctx, 1)
+		require.NoError(t, err)
+
+		a.notify(domain.Alert{ID: 1, SensorID: 2})
+		a.notify(domain.Alert{ID: 2, SensorID: 1})
+		assert.Equal(t, int64(2), (<-alerts).ID)
+
+		cancel()
+		_, ok := <-alerts
+		assert.False(t, ok)
+	})
+
+	t.Run("fail, user not found", func(t *testing.T) {
+		_, err := a.SubscribeAlerts(context.Background(), 2)
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"sync"
+	"time"
+)
+
+// CommandQuery - параметры выборки команд
+type CommandQuery struct {
+	// SensorID - устройство, команды которого выбираются
+	SensorID int64
+	// States - этапы доставки выбираемых команд, пустой список - все
+	States []domain.CommandState
+	// Limit - максимальное число команд, 0 - DefaultPageLimit
+	Limit int
+}
+
+const (
+	// DefaultAckTimeout - время, за которое устройство должно подтвердить доставленную команду
+	DefaultAckTimeout = time.Minute
+	// ackTimeoutReason - причина неудачи команды, которую устройство не подтвердило вовремя
+	ackTimeoutReason = "acknowledgement timeout"
+)
+
+type Command struct {
+	commandRepository CommandRepository
+	sensorRepository  SensorRepository
+	sensorTypes       *SensorTypeRegistry
+	ackTimeout        time.Duration
+	now               func() time.Time
+
+	mutex sync.Mutex
+	// pending - по устройству канал, который закрывается при появлении у него новой команды
+	pending map[int64]chan struct{}
+}
+
+func NewCommand(cr CommandRepository, sr SensorRepository, options ...func(*Command)) *Command {
+	c := &Command{
+		commandRepository: cr,
+		sensorRepository:  sr,
+		sensorTypes:       DefaultSensorTypeRegistry(),
+		ackTimeout:        DefaultAckTimeout,
+		now:               time.Now,
+		pending:           map[int64]chan struct{}{},
+	}
+	for _, o := range options {
+		o(c)
+	}
+	return c
+}
+
+// WithCommandSensorTypes - реестр типов датчиков, по которому определяются исполнительные устройства и проверяется payload команды
+func WithCommandSensorTypes(registry *SensorTypeRegistry) func(*Command) {
+	return func(c *Command) {
+		c.sensorTypes = registry
+	}
+}
+
+// WithAckTimeout - время, за которое устройство должно подтвердить доставленную команду
+func WithAckTimeout(timeout time.Duration) func(*Command) {
+	return func(c *Command) {
+		c.ackTimeout = timeout
+	}
+}
+
+// WithCommandClock - источник текущего времени для доставки и завершения команд
+func WithCommandClock(now func() time.Time) func(*Command) {
+	return func(c *Command) {
+		c.now = now
+	}
+}
+
+// WithEventCommands - события с command_id подтверждают команды commands
+func WithEventCommands(commands *Command) func(*Event) {
+	return func(e *Event) {
+		e.commands = commands
+	}
+}
+
+// SendCommand - функция постановки команды в очередь исполнительного устройства.
+// ErrNotActuator, если тип устройства не принимает команды, ErrPayloadOutOfRange, если состояние недопустимо для типа
+func (c *Command) SendCommand(ctx context.Context, sensorID int64, payload float64) (*domain.Command, error) {
+	if err := c.checkPayload(ctx, sensorID, payload); err != nil {
+		return nil, err
+	}
+
+	command := &domain.Command{
+		SensorID:  sensorID,
+		Payload:   payload,
+		State:     domain.CommandStatePending,
+		CreatedAt: c.timestamp(),
+	}
+	if err := c.commandRepository.SaveCommand(ctx, command); err != nil {
+		return nil, err
+	}
+	c.wake(sensorID)
+	return command, nil
+}
+
+// checkPayload - проверяет, что устройство принимает команды и payload допустим для его типа
+func (c *Command) checkPayload(ctx context.Context, sensorID int64, payload float64) error {
+	sensor, err := c.sensorRepository.GetSensorByID(ctx, sensorID)
+	if err != nil {
+		return err
+	}
+	info, ok := c.sensorTypes.Lookup(sensor.Type)
+	if !ok || !info.Actuator {
+		return ErrNotActuator
+	}
+	if !info.Accepts(payload) {
+		return ErrPayloadOutOfRange
+	}
+	return nil
+}
+
+// GetCommands - функция получения команд устройства от новых к старым
+func (c *Command) GetCommands(ctx context.Context, query CommandQuery) ([]domain.Command, error) {
+	for _, state := range query.States {
+		if !state.Valid() {
+			return nil, ErrInvalidCommandState
+		}
+	}
+	limit, err := pageLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = limit
+	if _, err = c.sensorRepository.GetSensorByID(ctx, query.SensorID); err != nil {
+		return nil, err
+	}
+	return c.commandRepository.ListCommands(ctx, query)
+}
+
+// GetCommand - функция получения команды устройства, команда другого устройства считается ненайденной
+func (c *Command) GetCommand(ctx context.Context, sensorID, commandID int64) (*domain.Command, error) {
+	if _, err := c.sensorRepository.GetSensorByID(ctx, sensorID); err != nil {
+		return nil, err
+	}
+	command, err := c.commandRepository.GetCommandByID(ctx, commandID)
+	if err != nil {
+		return nil, err
+	}
+	if command.SensorID != sensorID {
+		return nil, ErrCommandNotFound
+	}
+	return command, nil
+}
+
+// NextCommand - функция доставки устройству самой старой ожидающей команды. Если команд нет, ждёт новую не дольше wait
+// и возвращает ErrCommandNotFound, если она так и не появилась. Доставленная команда должна быть подтверждена за время
+// WithAckTimeout, в том числе если устройство её так и не получило из-за обрыва соединения
+func (c *Command) NextCommand(ctx context.Context, sensorID int64, wait time.Duration) (*domain.Command, error) {
+	if _, err := c.sensorRepository.GetSensorByID(ctx, sensorID); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		// канал берётся до проверки очереди, чтобы не пропустить команду, созданную между проверкой и ожиданием
+		ready := c.waitCommand(sensorID)
+		command, err := c.commandRepository.DeliverNextCommand(ctx, sensorID, c.timestamp())
+		if !errors.Is(err, ErrCommandNotFound) {
+			return command, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, ErrCommandNotFound
+		case <-ready:
+		}
+	}
+}
+
+// acknowledge - подтверждает команду событием устройства, вызывается в транзакции сохранения события.
+// Команда выполнена, если устройство перешло в её состояние, иначе она завершается неудачей.
+// Подтверждение, пришедшее после истечения времени ожидания, всё равно засчитывается, повторное - ничего не меняет
+func (c *Command) acknowledge(ctx context.Context, sensor *domain.Sensor, event *domain.Event) error {
+	command, err := c.commandRepository.GetCommandByID(ctx, event.CommandID)
+	if err != nil {
+		return err
+	}
+	if command.SensorID != sensor.ID {
+		return ErrCommandNotFound
+	}
+	if command.State == domain.CommandStateAcknowledged {
+		return nil
+	}
+	completedAt := c.timestamp()
+	command.CompletedAt = &completedAt
+	if event.Payload == command.Payload {
+		command.State, command.Reason = domain.CommandStateAcknowledged, ""
+	} else {
+		command.State, command.Reason = domain.CommandStateFailed, fmt.Sprintf("device reported state %v", event.Payload)
+	}
+	return c.commandRepository.SaveCommand(ctx, command)
+}
+
+// Run - завершает неудачей команды, не подтверждённые вовремя, пока не отменён ctx
+func (c *Command) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.ackTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// ошибка хранилища повторится на следующем тике, а просроченные команды никуда не денутся
+			_, _ = c.ExpireCommands(ctx)
+		}
+	}
+}
+
+// ExpireCommands - функция завершения неудачей команд, доставленных раньше, чем WithAckTimeout назад, и не подтверждённых
+func (c *Command) ExpireCommands(ctx context.Context) (int, error) {
+	now := c.timestamp()
+	return c.commandRepository.FailExpiredCommands(ctx, now.Add(-c.ackTimeout), ackTimeoutReason, now)
+}
+
+// waitCommand - канал, который закроется при появлении у устройства новой команды
+func (c *Command) waitCommand(sensorID int64) <-chan struct{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ready, ok := c.pending[sensorID]
+	if !ok {
+		ready = make(chan struct{})
+		c.pending[sensorID] = ready
+	}
+	return ready
+}
+
+// wake - будит всех, кто ждёт команду устройства
+func (c *Command) wake(sensorID int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if ready, ok := c.pending[sensorID]; ok {
+		close(ready)
+		delete(c.pending, sensorID)
+	}
+}
+
+// timestamp - текущее время с точностью, которую сохраняют все хранилища
+func (c *Command) timestamp() time.Time {
+	return c.now().Truncate(time.Microsecond).UTC()
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"homework/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// actuator - реле 1 с серийным номером 0123456789 и обычный датчик 2
+func actuator(ctrl *gomock.Controller) *MockSensorRepository {
+	sr := NewMockSensorRepository(ctrl)
+	sr.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.Sensor{ID: 1, SerialNumber: "0123456789", Type: domain.SensorTypeRelay}, nil)
+	sr.EXPECT().GetSensorByID(gomock.Any(), int64(2)).AnyTimes().Return(&domain.Sensor{ID: 2, Type: domain.SensorTypeContactClosure}, nil)
+	sr.EXPECT().GetSensorByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, ErrSensorNotFound)
+	return sr
+}
+
+func Test_command_SendCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ok, command queued", func(t *testing.T) {
+		cr := NewMockCommandRepository(ctrl)
+		cr.EXPECT().SaveCommand(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, command *domain.Command) error {
+			command.ID = 3
+			return nil
+		})
+		c := NewCommand(cr, actuator(ctrl), WithCommandClock(func() time.Time { return createdAt }))
+
+		command, err := c.SendCommand(ctx, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Command{
+			ID:        3,
+			SensorID:  1,
+			Payload:   1,
+			State:     domain.CommandStatePending,
+			CreatedAt: createdAt,
+		}, *command)
+	})
+
+	tests := []struct {
+		name     string
+		sensorID int64
+		payload  float64
+		wantErr  error
+	}{
+		{"fail, sensor not found", 5, 1, ErrSensorNotFound},
+		{"fail, not actuator", 2, 1, ErrNotActuator},
+		{"fail, payload out of range", 1, 2, ErrPayloadOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCommand(NewMockCommandRepository(ctrl), actuator(ctrl))
+			_, err := c.SendCommand(ctx, tt.sensorID, tt.payload)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_command_GetCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	cr := NewMockCommandRepository(ctrl)
+	cr.EXPECT().GetCommandByID(ctx, int64(3)).AnyTimes().Return(&domain.Command{ID: 3, SensorID: 1}, nil)
+	c := NewCommand(cr, actuator(ctrl))
+
+	command, err := c.GetCommand(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), command.ID)
+
+	_, err = c.GetCommand(ctx, 2, 3)
+	assert.ErrorIs(t, err, ErrCommandNotFound)
+
+	_, err = c.GetCommands(ctx, CommandQuery{SensorID: 1, States: []domain.CommandState{"lost"}})
+	assert.ErrorIs(t, err, ErrInvalidCommandState)
+}
+
+func Test_command_NextCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("ok, pending command delivered at once", func(t *testing.T) {
+		cr := NewMockCommandRepository(ctrl)
+		cr.EXPECT().DeliverNextCommand(ctx, int64(1), gomock.Any()).Times(1).Return(&domain.Command{ID: 3, SensorID: 1, State: domain.CommandStateDelivered}, nil)
+		c := NewCommand(cr, actuator(ctrl))
+
+		command, err := c.NextCommand(ctx, 1, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), command.ID)
+	})
+
+	t.Run("ok, waiting for new command", func(t *testing.T) {
+		cr := NewMockCommandRepository(ctrl)
+		gomock.InOrder(
+			cr.EXPECT().DeliverNextCommand(ctx, int64(1), gomock.Any()).Times(1).Return(nil, ErrCommandNotFound),
+			cr.EXPECT().DeliverNextCommand(ctx, int64(1), gomock.Any()).Times(1).Return(&domain.Command{ID: 3, SensorID: 1}, nil),
+		)
+		cr.EXPECT().SaveCommand(ctx, gomock.Any()).Times(1).Return(nil)
+		c := NewCommand(cr, actuator(ctrl))
+
+		done := make(chan *domain.Command)
+		go func() {
+			command, err := c.NextCommand(ctx, 1, time.Minute)
+			assert.NoError(t, err)
+			done <- command
+		}()
+		// команда создаётся, когда NextCommand уже ждёт или ещё не проверил очередь, - в обоих случаях она доставляется
+		time.Sleep(50 * time.Millisecond)
+		_, err := c.SendCommand(ctx, 1, 1)
+		require.NoError(t, err)
+
+		select {
+		case command := <-done:
+			assert.Equal(t, int64(3), command.ID)
+		case <-time.After(time.Second):
+			t.Fatal("command not delivered")
+		}
+	})
+
+	t.Run("fail, no commands until timeout", func(t *testing.T) {
+		cr := NewMockCommandRepository(ctrl)
+		cr.EXPECT().DeliverNextCommand(ctx, int64(1), gomock.Any()).Times(1).Return(nil, ErrCommandNotFound)
+		c := NewCommand(cr, actuator(ctrl))
+
+		_, err := c.NextCommand(ctx, 1, 10*time.Millisecond)
+		assert.ErrorIs(t, err, ErrCommandNotFound)
+	})
+
+	t.Run("fail, sensor not found", func(t *testing.T) {
+		c := NewCommand(NewMockCommandRepository(ctrl), actuator(ctrl))
+		_, err := c.NextCommand(ctx, 5, time.Minute)
+		assert.ErrorIs(t, err, ErrSensorNotFound)
+	})
+}
+
+func Test_command_ExpireCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cr := NewMockCommandRepository(ctrl)
+	cr.EXPECT().FailExpiredCommands(ctx, now.Add(-30*time.Second), ackTimeoutReason, now).Times(1).Return(2, nil)
+	c := NewCommand(cr, actuator(ctrl), WithAckTimeout(30*time.Second), WithCommandClock(func() time.Time { return now }))
+
+	failed, err := c.ExpireCommands(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, failed)
+}
+
+func Test_event_ReceiveEventAcknowledgesCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	completedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		command    domain.Command
+		payload    float64
+		wantSave   bool
+		wantState  domain.CommandState
+		wantReason string
+		wantErr    error
+	}{
+		{
+			name:      "ok, acknowledged",
+			command:   domain.Command{ID: 3, SensorID: 1, Payload: 1, State: domain.CommandStateDelivered},
+			payload:   1,
+			wantSave:  true,
+			wantState: domain.CommandStateAcknowledged,
+		},
+		{
+			name:      "ok, late acknowledgement after timeout",
+			command:   domain.Command{ID: 3, SensorID: 1, Payload: 1, State: domain.CommandStateFailed, Reason: ackTimeoutReason},
+			payload:   1,
+			wantSave:  true,
+			wantState: domain.CommandStateAcknowledged,
+		},
+		{
+			name:       "ok, device reported other state",
+			command:    domain.Command{ID: 3, SensorID: 1, Payload: 1, State: domain.CommandStateDelivered},
+			payload:    0,
+			wantSave:   true,
+			wantState:  domain.CommandStateFailed,
+			wantReason: "device reported state 0",
+		},
+		{
+			name:    "ok, repeated acknowledgement changes nothing",
+			command: domain.Command{ID: 3, SensorID: 1, Payload: 1, State: domain.CommandStateAcknowledged},
+			payload: 0,
+		},
+		{
+			name:    "fail, command of other device",
+			command: domain.Command{ID: 3, SensorID: 2, Payload: 1, State: domain.CommandStateDelivered},
+			payload: 1,
+			wantErr: ErrCommandNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := NewMockSensorRepository(ctrl)
+			sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(&domain.Sensor{ID: 1, SerialNumber: "0123456789", Type: domain.SensorTypeRelay}, nil)
+			sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Return(nil)
+			er := NewMockEventRepository(ctrl)
+			er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).Return(nil)
+			cr := NewMockCommandRepository(ctrl)
+			command := tt.command
+			cr.EXPECT().GetCommandByID(ctx, int64(3)).Times(1).Return(&command, nil)
+			if tt.wantSave {
+				cr.EXPECT().SaveCommand(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, command *domain.Command) error {
+					assert.Equal(t, tt.wantState, command.State)
+					assert.Equal(t, tt.wantReason, command.Reason)
+					assert.Equal(t, &completedAt, command.CompletedAt)
+					return nil
+				})
+			}
+
+			e := NewEvent(er, sr, WithEventCommands(NewCommand(cr, sr, WithCommandClock(func() time.Time { return completedAt }))))
+			err := e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789", Payload: tt.payload, CommandID: 3})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -3,18 +3,55 @@ package usecase
 import (
 	"context"
 	"homework/internal/domain"
+	"sync"
 	"time"
 )
 
+// eventSubscriberBuffer - число событий, которые подписчик может не успеть принять, прежде чем они начнут теряться
+const eventSubscriberBuffer = 64
+
 type Event struct {
 	eventRepository  EventRepository
 	sensorRepository SensorRepository
+	transactor       Transactor
+	sensorTypes      *SensorTypeRegistry
+	importBatchSize  int
+	alerts           *Alert
+	commands         *Command
+	rateLimiter      *RateLimiter
+	buffer           *EventBuffer
+	outbox           *Outbox
+
+	mutex       sync.Mutex
+	subscribers map[chan domain.Event]struct{}
 }
 
-func NewEvent(er EventRepository, sr SensorRepository) *Event {
-	return &Event{
+func NewEvent(er EventRepository, sr SensorRepository, options ...func(*Event)) *Event {
+	e := &Event{
 		eventRepository:  er,
 		sensorRepository: sr,
+		transactor:       noTransactor{},
+		sensorTypes:      DefaultSensorTypeRegistry(),
+		importBatchSize:  DefaultImportBatchSize,
+		subscribers:      map[chan domain.Event]struct{}{},
+	}
+	for _, o := range options {
+		o(e)
+	}
+	return e
+}
+
+// WithEventTransactor - сохранение события и обновление состояния датчика выполняются в одной транзакции
+func WithEventTransactor(t Transactor) func(*Event) {
+	return func(e *Event) {
+		e.transactor = t
+	}
+}
+
+// WithEventSensorTypes - реестр типов датчиков, по которому проверяется payload события
+func WithEventSensorTypes(registry *SensorTypeRegistry) func(*Event) {
+	return func(e *Event) {
+		e.sensorTypes = registry
 	}
 }
 
@@ -22,31 +59,105 @@ func (e *Event) ReceiveEvent(ctx context.Context, event *domain.Event) error {
 	if event == nil {
 		return ErrInvalidEventTimestamp
 	}
+	if err := e.allow(ctx, event); err != nil {
+		return err
+	}
 	if e.sensorRepository != nil {
-		sensor, err := e.sensorRepository.GetSensorBySerialNumber(ctx, event.SensorSerialNumber)
+		event.Timestamp = time.Now()
+		if e.buffer != nil {
+			return e.buffer.write(ctx, []domain.Event{*event}, func(ctx context.Context) error {
+				err := e.store(ctx, event)
+				if err != nil {
+					// транзакция откатилась, отложенное событие ещё не сохранено
+					event.ID = 0
+				}
+				return err
+			})
+		}
+		if err := e.store(ctx, event); err != nil {
+			return err
+		}
+	}
+	if e.eventRepository == nil {
+		return ErrInvalidEventTimestamp
+	}
+	return nil
+}
+
+// store - сохраняет событие с уже заданным временем и обновляет состояние датчика в одной транзакции,
+// после чего рассылает событие подписчикам
+func (e *Event) store(ctx context.Context, event *domain.Event) error {
+	var alert *domain.Alert
+	err := e.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		sensor, err := e.acceptingSensor(ctx, event)
 		if err != nil {
-			return ErrSensorNotFound
+			return err
 		}
-		event.Timestamp = time.Now()
 		event.SensorID = sensor.ID
 		err = e.eventRepository.SaveEvent(ctx, event)
 		if err != nil {
 			return err
 		}
 
-		sensor.LastActivity = time.Now()
+		sensor.LastActivity = event.Timestamp
 		sensor.CurrentState = event.Payload
-		err = e.sensorRepository.SaveSensor(ctx, sensor)
-		if err != nil {
+		sensor.CurrentReadings = event.Readings.Clone()
+		if err = e.sensorRepository.SaveSensorState(ctx, sensor); err != nil {
 			return err
 		}
+
+		if event.CommandID != 0 {
+			if e.commands == nil {
+				return ErrCommandNotFound
+			}
+			if err = e.commands.acknowledge(ctx, sensor, event); err != nil {
+				return err
+			}
+		}
+
+		if e.alerts != nil {
+			if alert, err = e.alerts.raise(ctx, sensor, event); err != nil {
+				return err
+			}
+		}
+		return e.recordEvents(ctx, *event)
+	})
+	if err != nil {
+		return err
 	}
-	if e.eventRepository == nil {
-		return ErrInvalidEventTimestamp
+	if alert != nil {
+		e.alerts.raised(*alert)
 	}
+	e.notify(*event)
 	return nil
 }
 
+// allow - событие не превышает ограничение частоты событий датчика или хранилище корзин недоступно при подключённом буфере
+func (e *Event) allow(ctx context.Context, event *domain.Event) error {
+	if e.rateLimiter == nil {
+		return nil
+	}
+	err := e.rateLimiter.Allow(ctx, RateLimitScopeSensor, event.SensorSerialNumber)
+	// пока хранилище корзин недоступно, событие принимается, чтобы его отложил буфер
+	if err != nil && e.buffer != nil && e.buffer.unavailable(err) {
+		return nil
+	}
+	return err
+}
+
+// acceptingSensor - датчик события, если он принимает payload события
+func (e *Event) acceptingSensor(ctx context.Context, event *domain.Event) (*domain.Sensor, error) {
+	sensor, err := e.sensorRepository.GetSensorBySerialNumber(ctx, event.SensorSerialNumber)
+	if err != nil {
+		return nil, err
+	}
+	// датчики неизвестного реестру типа принимают любой payload
+	if info, ok := e.sensorTypes.Lookup(sensor.Type); ok && !info.Accepts(event.Payload) {
+		return nil, ErrPayloadOutOfRange
+	}
+	return sensor, nil
+}
+
 func (e *Event) GetLastEventBySensorID(ctx context.Context, id int64) (*domain.Event, error) {
 	sensorID, err := e.eventRepository.GetLastEventBySensorID(ctx, id)
 	if err != nil {
@@ -65,3 +176,94 @@ func (e *Event) GetEventsBySensorIDWithDate(ctx context.Context, id int64, start
 	}
 	return events, nil
 }
+
+// ExportEvents - функция выгрузки событий датчиков в диапазоне времени, события передаются в fn по одному
+func (e *Event) ExportEvents(ctx context.Context, query EventExportQuery, fn func(domain.Event) error) error {
+	if query.Start.After(query.End) {
+		return ErrInputDate
+	}
+	return e.eventRepository.ExportEvents(ctx, query, fn)
+}
+
+// ListEvents - функция получения страницы событий датчика в диапазоне времени.
+// cursor - курсор из предыдущего вызова (пустой для первой страницы), возвращается курсор следующей страницы или пустая строка
+func (e *Event) ListEvents(ctx context.Context, query EventQuery, cursor string) ([]domain.Event, string, error) {
+	if query.Start.After(query.End) {
+		return nil, "", ErrInputDate
+	}
+	limit, err := pageLimit(query.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if cursor != "" {
+		if query.After, err = parseEventCursor(query, cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// запрашивается на одно событие больше, чтобы узнать, есть ли следующая страница
+	query.Limit = limit + 1
+	events, err := e.eventRepository.ListEvents(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(events) <= limit {
+		return events, "", nil
+	}
+	events = events[:limit]
+	return events, newEventCursor(query, events[limit-1]), nil
+}
+
+// SubscribeEvents - функция подписки на новые события датчика sensorID, 0 - всех датчиков.
+// Канал закрывается после отмены ctx. Импортированные исторические события не рассылаются, а события,
+// которые подписчик не успевает принять, теряются, чтобы медленный подписчик не задерживал приём событий
+func (e *Event) SubscribeEvents(ctx context.Context, sensorID int64) (<-chan domain.Event, error) {
+	if sensorID != 0 {
+		if _, err := e.sensorRepository.GetSensorByID(ctx, sensorID); err != nil {
+			return nil, err
+		}
+	}
+	in := make(chan domain.Event, eventSubscriberBuffer)
+	e.mutex.Lock()
+	e.subscribers[in] = struct{}{}
+	e.mutex.Unlock()
+
+	out := make(chan domain.Event)
+	go func() {
+		defer close(out)
+		defer func() {
+			e.mutex.Lock()
+			delete(e.subscribers, in)
+			e.mutex.Unlock()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-in:
+				if sensorID != 0 && event.SensorID != sensorID {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- event:
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// notify - рассылает сохранённое событие подписчикам
+func (e *Event) notify(event domain.Event) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for subscriber := range e.subscribers {
+		event.Readings = event.Readings.Clone()
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_event_ReceiveEvent(t *testing.T) {
@@ -74,7 +75,7 @@ func Test_event_ReceiveEvent(t *testing.T) {
 			ID: 1,
 		}, nil)
 		expectedError := errors.New("some error")
-		sr.EXPECT().SaveSensor(ctx, gomock.Any()).Times(1).Times(1).Return(expectedError)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Times(1).Return(expectedError)
 
 		er := NewMockEventRepository(ctrl)
 		er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).Return(nil)
@@ -97,8 +98,8 @@ func Test_event_ReceiveEvent(t *testing.T) {
 		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(&domain.Sensor{
 			ID: 1,
 		}, nil)
-		sr.EXPECT().SaveSensor(ctx, gomock.Any()).Times(1).Do(func(_ context.Context, s *domain.Sensor) {
-			assert.Equal(t, int64(8), s.CurrentState)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Do(func(_ context.Context, s *domain.Sensor) {
+			assert.Equal(t, 8.0, s.CurrentState)
 			assert.NotEmpty(t, s.LastActivity)
 		})
 
@@ -118,6 +119,154 @@ func Test_event_ReceiveEvent(t *testing.T) {
 		})
 		assert.NoError(t, err)
 	})
+
+	t.Run("err, payload out of range", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(&domain.Sensor{
+			ID:   1,
+			Type: domain.SensorTypeHumidity,
+		}, nil)
+		sr.EXPECT().SaveSensorState(gomock.Any(), gomock.Any()).Times(0)
+
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(0)
+
+		e := NewEvent(er, sr)
+		err := e.ReceiveEvent(ctx, &domain.Event{
+			SensorSerialNumber: "0123456789",
+			Payload:            101,
+		})
+		assert.ErrorIs(t, err, ErrPayloadOutOfRange)
+	})
+
+	t.Run("ok, fractional payload and readings", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		readings := domain.Readings{"temperature": 21.5, "humidity": 40}
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(&domain.Sensor{
+			ID:   1,
+			Type: domain.SensorTypeADC,
+		}, nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Do(func(_ context.Context, s *domain.Sensor) {
+			assert.Equal(t, 21.5, s.CurrentState)
+			assert.Equal(t, readings, s.CurrentReadings)
+		})
+
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, event *domain.Event) error {
+			assert.Equal(t, readings, event.Readings)
+			return nil
+		})
+
+		e := NewEvent(er, sr)
+		err := e.ReceiveEvent(ctx, &domain.Event{
+			SensorSerialNumber: "0123456789",
+			Payload:            21.5,
+			Readings:           readings,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("err, fractional state", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(&domain.Sensor{
+			ID:   1,
+			Type: domain.SensorTypeContactClosure,
+		}, nil)
+
+		e := NewEvent(NewMockEventRepository(ctrl), sr)
+		err := e.ReceiveEvent(ctx, &domain.Event{
+			SensorSerialNumber: "0123456789",
+			Payload:            0.5,
+		})
+		assert.ErrorIs(t, err, ErrPayloadOutOfRange)
+	})
+
+	t.Run("ok, custom sensor type", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Times(1).Return(&domain.Sensor{
+			ID:   1,
+			Type: "thermometer",
+		}, nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).Return(nil)
+
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Times(1).Return(nil)
+
+		min, max := -40.0, 125.0
+		registry := NewSensorTypeRegistry(domain.SensorTypeInfo{
+			Type:       "thermometer",
+			Unit:       "°C",
+			Semantics:  domain.PayloadSemanticsMeasurement,
+			MinPayload: &min,
+			MaxPayload: &max,
+		})
+		e := NewEvent(er, sr, WithEventSensorTypes(registry))
+		err := e.ReceiveEvent(ctx, &domain.Event{
+			SensorSerialNumber: "0123456789",
+			Payload:            -10,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func Test_event_ReceiveEventWithTransactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type txKey struct{}
+
+	t.Run("ok, repositories use transaction context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		txCtx := context.WithValue(ctx, txKey{}, "tx")
+
+		tr := NewMockTransactor(ctrl)
+		tr.EXPECT().WithinTransaction(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, fn func(context.Context) error) error {
+			return fn(txCtx)
+		})
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(txCtx, "0123456789").Times(1).Return(&domain.Sensor{ID: 1}, nil)
+		sr.EXPECT().SaveSensorState(txCtx, gomock.Any()).Times(1).Return(nil)
+
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(txCtx, gomock.Any()).Times(1).Return(nil)
+
+		e := NewEvent(er, sr, WithEventTransactor(tr))
+		err := e.ReceiveEvent(ctx, &domain.Event{
+			SensorSerialNumber: "0123456789",
+			Payload:            8,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("err, transaction error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expectedError := errors.New("can't commit")
+		tr := NewMockTransactor(ctrl)
+		tr.EXPECT().WithinTransaction(ctx, gomock.Any()).Times(1).Return(expectedError)
+
+		e := NewEvent(NewMockEventRepository(ctrl), NewMockSensorRepository(ctrl), WithEventTransactor(tr))
+		err := e.ReceiveEvent(ctx, &domain.Event{
+			SensorSerialNumber: "0123456789",
+		})
+		assert.ErrorIs(t, err, expectedError)
+	})
 }
 
 func Test_event_GetEventsBySensorIDWithDate(t *testing.T) {
@@ -133,7 +282,7 @@ func Test_event_GetEventsBySensorIDWithDate(t *testing.T) {
 			{
 				Timestamp:          start.Add(time.Minute),
 				SensorSerialNumber: "0123456789",
-				Payload:            int64(8),
+				Payload:            8.0,
 			},
 		}, nil)
 		sr := NewMockSensorRepository(ctrl)
@@ -160,3 +309,134 @@ func Test_event_GetEventsBySensorIDWithDate(t *testing.T) {
 		assert.Nil(t, events)
 	})
 }
+
+func Test_event_ListEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("fail, invalid query", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		e := NewEvent(NewMockEventRepository(ctrl), NewMockSensorRepository(ctrl))
+		now := time.Now()
+
+		_, _, err := e.ListEvents(ctx, EventQuery{SensorID: 1, Start: now, End: now.Add(-time.Second)}, "")
+		assert.ErrorIs(t, err, ErrInputDate)
+		_, _, err = e.ListEvents(ctx, EventQuery{SensorID: 1, Start: now, End: now, Limit: MaxPageLimit + 1}, "")
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		_, _, err = e.ListEvents(ctx, EventQuery{SensorID: 1, Start: now, End: now}, "%%%")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("ok, pages", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		start := time.Now().UTC()
+		end := start.Add(time.Hour)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().ListEvents(ctx, EventQuery{SensorID: 1, Start: start, End: end, Desc: true, Limit: 2}).Times(1).Return([]domain.Event{
+			{ID: 2, SensorID: 1, Timestamp: start},
+			{ID: 1, SensorID: 1, Timestamp: start},
+		}, nil)
+		er.EXPECT().ListEvents(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, query EventQuery) ([]domain.Event, error) {
+			assert.Equal(t, int64(2), query.After.ID)
+			assert.True(t, start.Equal(query.After.Timestamp))
+			return []domain.Event{{ID: 1, SensorID: 1, Timestamp: start}}, nil
+		})
+
+		e := NewEvent(er, NewMockSensorRepository(ctrl))
+		query := EventQuery{SensorID: 1, Start: start, End: end, Desc: true, Limit: 1}
+
+		page, cursor, err := e.ListEvents(ctx, query, "")
+		assert.NoError(t, err)
+		assert.Len(t, page, 1)
+		assert.NotEmpty(t, cursor)
+
+		_, _, err = e.ListEvents(ctx, EventQuery{SensorID: 2, Start: start, End: end, Desc: true, Limit: 1}, cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		page, cursor, err = e.ListEvents(ctx, query, cursor)
+		assert.NoError(t, err)
+		assert.Len(t, page, 1)
+		assert.Empty(t, cursor)
+	})
+}
+
+func Test_event_ExportEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("fail, invalid dates", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		e := NewEvent(NewMockEventRepository(ctrl), NewMockSensorRepository(ctrl))
+		now := time.Now()
+
+		err := e.ExportEvents(ctx, EventExportQuery{Start: now, End: now.Add(-time.Second)}, func(domain.Event) error { return nil })
+		assert.ErrorIs(t, err, ErrInputDate)
+	})
+
+	t.Run("ok, events passed to fn", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+		query := EventExportQuery{SensorIDs: []int64{1, 2}, Start: now, End: now}
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().ExportEvents(ctx, query, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, _ EventExportQuery, fn func(domain.Event) error) error {
+			for _, id := range []int64{1, 2} {
+				if err := fn(domain.Event{ID: id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		e := NewEvent(er, NewMockSensorRepository(ctrl))
+
+		var ids []int64
+		err := e.ExportEvents(ctx, query, func(event domain.Event) error {
+			ids = append(ids, event.ID)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, ids)
+	})
+}
+
+func Test_event_SubscribeEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sr := NewMockSensorRepository(ctrl)
+	sr.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.Sensor{ID: 1}, nil)
+	sr.EXPECT().GetSensorByID(gomock.Any(), int64(2)).AnyTimes().Return(nil, ErrSensorNotFound)
+	e := NewEvent(NewMockEventRepository(ctrl), sr)
+
+	t.Run("ok, only events of the sensor delivered", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events, err := e.SubscribeEvents(ctx, 1)
+		require.NoError(t, err)
+		all, err := e.SubscribeEvents(ctx, 0)
+		require.NoError(t, err)
+
+		e.notify(domain.Event{ID: 1, SensorID: 3})
+		e.notify(domain.Event{ID: 2, SensorID: 1})
+		assert.Equal(t, int64(2), (<-events).ID)
+		assert.Equal(t, int64(1), (<-all).ID)
+		assert.Equal(t, int64(2), (<-all).ID)
+
+		cancel()
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("fail, sensor not found", func(t *testing.T) {
+		_, err := e.SubscribeEvents(context.Background(), 2)
+		assert.ErrorIs(t, err, ErrSensorNotFound)
+	})
+}
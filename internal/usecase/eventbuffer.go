@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"homework/internal/domain"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultEventBufferRetryInterval - как часто буфер пробует вернуть отложенные события в хранилище
+	DefaultEventBufferRetryInterval = 5 * time.Second
+	// DefaultEventBufferReplayBatch - сколько отложенных событий читается из буфера за раз
+	DefaultEventBufferReplayBatch = 100
+)
+
+// EventBufferStats - состояние буфера событий
+type EventBufferStats struct {
+	// Events - отложенные события, ещё не сохранённые в хранилище
+	Events int
+	// Bytes - размер отложенных событий в буфере
+	Bytes int64
+	// Corrupted - повреждённые записи буфера, пропущенные при чтении
+	Corrupted uint64
+	// Replayed - отложенные события, сохранённые в хранилище
+	Replayed uint64
+	// Discarded - отложенные события, которые хранилище отвергло, например событие неизвестного датчика
+	Discarded uint64
+}
+
+// EventBuffer - буфер событий на время недоступности хранилища. Пока хранилище недоступно, события
+// дописываются в EventBufferRepository без проверки, а после его восстановления сохраняются в порядке приёма
+// так же, как в Event.ReceiveEvent; события, не прошедшие проверку, отбрасываются. Пока в буфере есть события,
+// новые события тоже попадают в буфер, чтобы не обогнать отложенные
+type EventBuffer struct {
+	repository    EventBufferRepository
+	events        *Event
+	unavailable   func(error) bool
+	retryInterval time.Duration
+	replayBatch   int
+
+	// mutex - запись в хранилище идёт под RLock, возврат событий из буфера - под Lock
+	mutex     sync.RWMutex
+	replayed  atomic.Uint64
+	discarded atomic.Uint64
+}
+
+// NewEventBuffer - буфер событий в br, unavailable определяет ошибки хранилища, при которых событие откладывается
+func NewEventBuffer(br EventBufferRepository, unavailable func(error) bool, options ...func(*EventBuffer)) *EventBuffer {
+	b := &EventBuffer{
+		repository:    br,
+		unavailable:   unavailable,
+		retryInterval: DefaultEventBufferRetryInterval,
+		replayBatch:   DefaultEventBufferReplayBatch,
+	}
+	for _, o := range options {
+		o(b)
+	}
+	return b
+}
+
+// WithEventBufferRetryInterval - как часто проверять, не восстановилось ли хранилище
+func WithEventBufferRetryInterval(interval time.Duration) func(*EventBuffer) {
+	return func(b *EventBuffer) {
+		b.retryInterval = interval
+	}
+}
+
+// WithEventBuffer - события, которые не удалось сохранить из-за недоступности хранилища, откладываются в b,
+// ReceiveEvent при этом не возвращает ошибку и не задаёт ID события. b сохраняет отложенные события через e
+func WithEventBuffer(b *EventBuffer) func(*Event) {
+	return func(e *Event) {
+		e.buffer = b
+		b.events = e
+	}
+}
+
+// write - сохраняет события через save, а если хранилище недоступно или в буфере уже есть события, дописывает их в буфер
+func (b *EventBuffer) write(ctx context.Context, events []domain.Event, save func(ctx context.Context) error) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	if b.repository.Stats().Events == 0 {
+		err := save(ctx)
+		if err == nil || !b.unavailable(err) {
+			return err
+		}
+	}
+	return b.repository.AppendEvents(ctx, events)
+}
+
+// Run - возвращает отложенные события в хранилище сразу, в том числе оставшиеся с прошлого запуска,
+// а затем раз в retryInterval, пока не отменён ctx
+func (b *EventBuffer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.retryInterval)
+	defer ticker.Stop()
+	for {
+		// хранилище всё ещё недоступно - события подождут следующего раза
+		_ = b.Replay(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Replay - сохраняет отложенные события в порядке приёма, пока буфер не опустеет.
+// Если хранилище снова недоступно, возвращает его ошибку, оставшиеся события остаются в буфере
+func (b *EventBuffer) Replay(ctx context.Context) error {
+	for {
+		replayed, err := b.replay(ctx)
+		if err != nil || replayed == 0 {
+			return err
+		}
+	}
+}
+
+// replay - сохраняет одну пачку отложенных событий и удаляет из буфера обработанные
+func (b *EventBuffer) replay(ctx context.Context) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	events, err := b.repository.PeekEvents(ctx, b.replayBatch)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	done := 0
+	var storeErr error
+	for idx := range events {
+		err = b.events.store(ctx, &events[idx])
+		// после отмены ctx событие не отвергнуто хранилищем, а просто не сохранено
+		if err != nil && (ctx.Err() != nil || b.unavailable(err)) {
+			storeErr = err
+			break
+		}
+		if err != nil {
+			b.discarded.Add(1)
+		} else {
+			b.replayed.Add(1)
+		}
+		done++
+	}
+	if done > 0 {
+		if err = b.repository.DropEvents(ctx, done); err != nil {
+			return 0, err
+		}
+	}
+	return done, storeErr
+}
+
+// Stats - состояние буфера и число возвращённых в хранилище событий с момента запуска
+func (b *EventBuffer) Stats() EventBufferStats {
+	stats := b.repository.Stats()
+	stats.Replayed = b.replayed.Load()
+	stats.Discarded = b.discarded.Load()
+	return stats
+}
+
+// writeBatch - сохраняет пачку принятых событий, при недоступном хранилище откладывая её в буфер
+func (e *Event) writeBatch(ctx context.Context, batch []domain.Event) error {
+	if e.buffer == nil {
+		return e.saveBatch(ctx, batch)
+	}
+	return e.buffer.write(ctx, batch, func(ctx context.Context) error {
+		return e.saveBatch(ctx, batch)
+	})
+}
+
+// deferUnavailable - откладывает событие со временем приёма в буфер, если err - недоступность хранилища
+// и буфер подключён, иначе возвращает err. Датчик и команда события проверяются, когда буфер вернёт его в хранилище
+func (e *Event) deferUnavailable(ctx context.Context, event *domain.Event, err error) error {
+	if e.buffer == nil || !e.buffer.unavailable(err) {
+		return err
+	}
+	event.Timestamp = time.Now()
+	e.buffer.mutex.RLock()
+	defer e.buffer.mutex.RUnlock()
+	return e.buffer.repository.AppendEvents(ctx, []domain.Event{*event})
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStorageDown = errors.New("storage is down")
+
+func isStorageDown(err error) bool {
+	return errors.Is(err, errStorageDown)
+}
+
+func Test_eventBuffer_ReceiveEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sensor := &domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}
+
+	t.Run("ok, event buffered while storage is down", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(nil, errStorageDown)
+		br := NewMockEventBufferRepository(ctrl)
+		br.EXPECT().Stats().Return(EventBufferStats{})
+		br.EXPECT().AppendEvents(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, events []domain.Event) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, 1.5, events[0].Payload)
+			assert.False(t, events[0].Timestamp.IsZero(), "событие откладывается со временем приёма")
+			return nil
+		})
+
+		e := NewEvent(NewMockEventRepository(ctrl), sr, WithEventBuffer(NewEventBuffer(br, isStorageDown)))
+		event := &domain.Event{SensorSerialNumber: "0123456789", Payload: 1.5}
+		require.NoError(t, e.ReceiveEvent(ctx, event))
+		assert.Zero(t, event.ID)
+	})
+
+	t.Run("ok, event buffered behind backlog", func(t *testing.T) {
+		ctx := context.Background()
+
+		br := NewMockEventBufferRepository(ctrl)
+		br.EXPECT().Stats().Return(EventBufferStats{Events: 3})
+		br.EXPECT().AppendEvents(ctx, gomock.Len(1)).Return(nil)
+
+		e := NewEvent(NewMockEventRepository(ctrl), NewMockSensorRepository(ctrl), WithEventBuffer(NewEventBuffer(br, isStorageDown)))
+		require.NoError(t, e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789"}))
+	})
+
+	t.Run("err, rejected event is not buffered", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(nil, ErrSensorNotFound)
+		br := NewMockEventBufferRepository(ctrl)
+		br.EXPECT().Stats().Return(EventBufferStats{})
+
+		e := NewEvent(NewMockEventRepository(ctrl), sr, WithEventBuffer(NewEventBuffer(br, isStorageDown)))
+		err := e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789"})
+		assert.ErrorIs(t, err, ErrSensorNotFound)
+	})
+
+	t.Run("err, buffer is full", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(sensor, nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Return(errStorageDown)
+		br := NewMockEventBufferRepository(ctrl)
+		br.EXPECT().Stats().Return(EventBufferStats{})
+		br.EXPECT().AppendEvents(ctx, gomock.Any()).Return(ErrEventBufferFull)
+
+		e := NewEvent(er, sr, WithEventBuffer(NewEventBuffer(br, isStorageDown)))
+		err := e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789"})
+		assert.ErrorIs(t, err, ErrEventBufferFull)
+	})
+}
+
+func Test_eventBuffer_storageDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// общие корзины ограничителя и датчики в том же недоступном хранилище
+	newEvent := func(t *testing.T) *Event {
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(gomock.Any(), "sensor:0123456789", gomock.Any()).Return(time.Duration(0), errStorageDown)
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(gomock.Any(), "0123456789").Return(nil, errStorageDown)
+		br := NewMockEventBufferRepository(ctrl)
+		br.EXPECT().Stats().Return(EventBufferStats{}).AnyTimes()
+		br.EXPECT().AppendEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []domain.Event) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, 1.5, events[0].Payload)
+			assert.False(t, events[0].Timestamp.IsZero(), "событие откладывается со временем приёма")
+			return nil
+		})
+		limiter := NewRateLimiter(rr, WithRateLimit(RateLimitScopeSensor, domain.RateLimit{Rate: 1, Burst: 1}))
+		return NewEvent(NewMockEventRepository(ctrl), sr, WithEventRateLimiter(limiter), WithEventBuffer(NewEventBuffer(br, isStorageDown)))
+	}
+
+	t.Run("ok, received event buffered", func(t *testing.T) {
+		e := newEvent(t)
+		require.NoError(t, e.ReceiveEvent(context.Background(), &domain.Event{SensorSerialNumber: "0123456789", Payload: 1.5}))
+	})
+
+	t.Run("ok, enqueued event buffered", func(t *testing.T) {
+		i := NewIngestion(newEvent(t))
+		require.NoError(t, i.EnqueueEvent(context.Background(), &domain.Event{SensorSerialNumber: "0123456789", Payload: 1.5}))
+		assert.Zero(t, i.Stats().Queued)
+	})
+}
+
+func Test_eventBuffer_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newSensor := func() *domain.Sensor {
+		return &domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}
+	}
+	buffered := []domain.Event{
+		{SensorSerialNumber: "0123456789", Payload: 1},
+		{SensorSerialNumber: "9876543210", Payload: 2},
+		{SensorSerialNumber: "0123456789", Payload: 3},
+	}
+
+	t.Run("ok, events saved in order, rejected event discarded", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(newSensor(), nil).Times(2)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "9876543210").Return(nil, ErrSensorNotFound)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Return(nil).Times(2)
+		var saved []float64
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.Event) error {
+			saved = append(saved, event.Payload)
+			return nil
+		}).Times(2)
+		br := NewMockEventBufferRepository(ctrl)
+		gomock.InOrder(
+			br.EXPECT().PeekEvents(ctx, 2).Return(buffered[:2], nil),
+			br.EXPECT().DropEvents(ctx, 2).Return(nil),
+			br.EXPECT().PeekEvents(ctx, 2).Return(buffered[2:], nil),
+			br.EXPECT().DropEvents(ctx, 1).Return(nil),
+			br.EXPECT().PeekEvents(ctx, 2).Return(nil, nil),
+		)
+		br.EXPECT().Stats().Return(EventBufferStats{})
+
+		b := NewEventBuffer(br, isStorageDown)
+		b.replayBatch = 2
+		NewEvent(er, sr, WithEventBuffer(b))
+		require.NoError(t, b.Replay(ctx))
+		assert.Equal(t, []float64{1, 3}, saved)
+		assert.Equal(t, EventBufferStats{Replayed: 2, Discarded: 1}, b.Stats())
+	})
+
+	t.Run("err, storage is down again", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		gomock.InOrder(
+			sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(newSensor(), nil),
+			sr.EXPECT().GetSensorBySerialNumber(ctx, "9876543210").Return(nil, errStorageDown),
+		)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Return(nil)
+		br := NewMockEventBufferRepository(ctrl)
+		gomock.InOrder(
+			br.EXPECT().PeekEvents(ctx, DefaultEventBufferReplayBatch).Return(buffered, nil),
+			br.EXPECT().DropEvents(ctx, 1).Return(nil),
+		)
+
+		b := NewEventBuffer(br, isStorageDown)
+		NewEvent(er, sr, WithEventBuffer(b))
+		assert.ErrorIs(t, b.Replay(ctx), errStorageDown)
+	})
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// DefaultImportBatchSize - число событий, сохраняемых при импорте одним вызовом EventRepository.SaveEvents
+const DefaultImportBatchSize = 1000
+
+// ImportLine - запись импортируемого файла
+type ImportLine[T any] struct {
+	// Line - номер строки файла, с которой начинается запись
+	Line int
+	// Value - разобранная запись
+	Value T
+	// Err - ошибка разбора записи, Value при этом не используется
+	Err error
+}
+
+// WithEventImportBatchSize - число событий, сохраняемых при импорте за раз
+func WithEventImportBatchSize(size int) func(*Event) {
+	return func(e *Event) {
+		e.importBatchSize = size
+	}
+}
+
+// ImportEvents - функция импорта исторических событий с исходным временем.
+// События проверяются по существующим датчикам и сохраняются пачками через EventRepository.SaveEvents,
+// ошибки отдельных записей попадают в отчёт и не прерывают импорт. Состояние датчика обновляется,
+// если импортировано событие новее его последней активности
+func (e *Event) ImportEvents(ctx context.Context, lines iter.Seq[ImportLine[domain.Event]]) (domain.ImportReport, error) {
+	report := domain.ImportReport{Errors: []domain.ImportError{}}
+	sensors := newSensorCache(e.sensorRepository)
+	batch := make([]domain.Event, 0, e.importBatchSize)
+	batchLines := make([]int, 0, e.importBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.saveImportBatch(ctx, batch); err != nil {
+			for _, line := range batchLines {
+				report.Fail(line, err)
+			}
+		} else {
+			report.Imported += len(batch)
+		}
+		batch = batch[:0]
+		batchLines = batchLines[:0]
+	}
+
+	for line := range lines {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Total++
+		if line.Err != nil {
+			report.Fail(line.Line, line.Err)
+			continue
+		}
+		event := line.Value
+		if err := e.validateImportEvent(ctx, sensors, &event); err != nil {
+			report.Fail(line.Line, err)
+			continue
+		}
+		batch = append(batch, event)
+		batchLines = append(batchLines, line.Line)
+		if len(batch) >= e.importBatchSize {
+			flush()
+		}
+	}
+	flush()
+	return report, ctx.Err()
+}
+
+func (e *Event) validateImportEvent(ctx context.Context, sensors *sensorCache, event *domain.Event) error {
+	if event.Timestamp.IsZero() {
+		return ErrInvalidEventTimestamp
+	}
+	var sensor *domain.Sensor
+	var err error
+	if event.SensorSerialNumber != "" {
+		sensor, err = sensors.bySerialNumber(ctx, event.SensorSerialNumber)
+		if err == nil && event.SensorID != 0 && event.SensorID != sensor.ID {
+			return ErrSensorMismatch
+		}
+	} else {
+		sensor, err = sensors.byID(ctx, event.SensorID)
+	}
+	if err != nil {
+		return err
+	}
+	if info, ok := e.sensorTypes.Lookup(sensor.Type); ok && !info.Accepts(event.Payload) {
+		return ErrPayloadOutOfRange
+	}
+	event.ID = 0
+	event.SensorID = sensor.ID
+	event.SensorSerialNumber = sensor.SerialNumber
+	event.Unit = ""
+	return nil
+}
+
+// saveImportBatch - сохраняет пачку событий и обновляет состояние датчиков в одной транзакции
+func (e *Event) saveImportBatch(ctx context.Context, batch []domain.Event) error {
+	latest := map[int64]domain.Event{}
+	for _, event := range batch {
+		if last, ok := latest[event.SensorID]; !ok || event.Timestamp.After(last.Timestamp) {
+			latest[event.SensorID] = event
+		}
+	}
+	return e.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := e.eventRepository.SaveEvents(ctx, batch); err != nil {
+			return err
+		}
+		// состояние сравнивается с сохранённым, а не с датчиком из кэша: за время импорта могли прийти новые события
+		for _, id := range slices.Sorted(maps.Keys(latest)) {
+			event := latest[id]
+			sensor := &domain.Sensor{
+				ID:              id,
+				LastActivity:    event.Timestamp,
+				CurrentState:    event.Payload,
+				CurrentReadings: event.Readings.Clone(),
+			}
+			if err := e.sensorRepository.SaveSensorState(ctx, sensor); err != nil {
+				return err
+			}
+		}
+		return e.recordEvents(ctx, batch...)
+	})
+}
+
+// sensorCache - датчики, уже найденные при импорте, чтобы не запрашивать хранилище на каждую запись
+type sensorCache struct {
+	repository SensorRepository
+	ids        map[int64]*domain.Sensor
+	serials    map[string]*domain.Sensor
+	missing    map[string]bool
+}
+
+func newSensorCache(repository SensorRepository) *sensorCache {
+	return &sensorCache{
+		repository: repository,
+		ids:        map[int64]*domain.Sensor{},
+		serials:    map[string]*domain.Sensor{},
+		missing:    map[string]bool{},
+	}
+}
+
+func (c *sensorCache) put(sensor *domain.Sensor) {
+	c.ids[sensor.ID] = sensor
+	c.serials[sensor.SerialNumber] = sensor
+}
+
+func (c *sensorCache) bySerialNumber(ctx context.Context, sn string) (*domain.Sensor, error) {
+	if sensor, ok := c.serials[sn]; ok {
+		return sensor, nil
+	}
+	if c.missing[sn] {
+		return nil, ErrSensorNotFound
+	}
+	sensor, err := c.repository.GetSensorBySerialNumber(ctx, sn)
+	if errors.Is(err, ErrSensorNotFound) {
+		c.missing[sn] = true
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.put(sensor)
+	return sensor, nil
+}
+
+func (c *sensorCache) byID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	if sensor, ok := c.ids[id]; ok {
+		return sensor, nil
+	}
+	sensor, err := c.repository.GetSensorByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.put(sensor)
+	return sensor, nil
+}
+
+// ImportSensors - функция импорта датчиков по правилам RegisterSensor.
+// Датчики с уже зарегистрированным серийным номером пропускаются, ошибки отдельных записей попадают в отчёт
+func (s *Sensor) ImportSensors(ctx context.Context, lines iter.Seq[ImportLine[domain.Sensor]]) (domain.ImportReport, error) {
+	report := domain.ImportReport{Errors: []domain.ImportError{}}
+	for line := range lines {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Total++
+		if line.Err != nil {
+			report.Fail(line.Line, line.Err)
+			continue
+		}
+		sensor := line.Value
+		sensor.ID = 0
+		if err := validateCalibration(sensor.Calibration); err != nil {
+			report.Fail(line.Line, err)
+			continue
+		}
+		if err := validateAlertRule(sensor.AlertRule); err != nil {
+			report.Fail(line.Line, err)
+			continue
+		}
+		registered, err := s.RegisterSensor(ctx, &sensor)
+		switch {
+		case err != nil:
+			report.Fail(line.Line, err)
+		// RegisterSensor возвращает переданный датчик, только если создал его
+		case registered != &sensor:
+			report.Skipped++
+		default:
+			report.Imported++
+		}
+	}
+	return report, ctx.Err()
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_event_ImportEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lines := slices.Values([]ImportLine[domain.Event]{
+		{Line: 2, Value: domain.Event{SensorSerialNumber: "0000000001", Timestamp: start, Payload: 1}},
+		{Line: 3, Err: errors.New("broken line")},
+		{Line: 4, Value: domain.Event{SensorID: 1, Timestamp: start.Add(time.Hour), Payload: 0}},
+		{Line: 5, Value: domain.Event{SensorSerialNumber: "0000000002", Timestamp: start, Payload: 1}},
+		{Line: 6, Value: domain.Event{SensorSerialNumber: "0000000001", Timestamp: start, Payload: 5}},
+		{Line: 7, Value: domain.Event{SensorSerialNumber: "0000000001", Payload: 1}},
+		{Line: 8, Value: domain.Event{SensorSerialNumber: "0000000001", SensorID: 2, Timestamp: start, Payload: 1}},
+		{Line: 9, Value: domain.Event{SensorSerialNumber: "0000000001", Timestamp: start.Add(-time.Hour), Payload: 1, Unit: "W"}},
+	})
+
+	t.Run("ok, valid lines imported in batches", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sensor := &domain.Sensor{ID: 1, SerialNumber: "0000000001", Type: domain.SensorTypeContactClosure, LastActivity: start.Add(time.Minute)}
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0000000001").Times(1).Return(sensor, nil)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0000000002").Times(1).Return(nil, ErrSensorNotFound)
+		// более раннее событие второй пачки тоже передаётся хранилищу: его сравнивает с сохранённым состоянием само хранилище
+		gomock.InOrder(
+			sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, sensor *domain.Sensor) error {
+				assert.Equal(t, int64(1), sensor.ID)
+				assert.Equal(t, start.Add(time.Hour), sensor.LastActivity)
+				assert.Equal(t, 0.0, sensor.CurrentState)
+				return nil
+			}),
+			sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, sensor *domain.Sensor) error {
+				assert.Equal(t, start.Add(-time.Hour), sensor.LastActivity)
+				return nil
+			}),
+		)
+
+		er := NewMockEventRepository(ctrl)
+		gomock.InOrder(
+			er.EXPECT().SaveEvents(ctx, []domain.Event{
+				{SensorID: 1, SensorSerialNumber: "0000000001", Timestamp: start, Payload: 1},
+				{SensorID: 1, SensorSerialNumber: "0000000001", Timestamp: start.Add(time.Hour), Payload: 0},
+			}).Times(1).Return(nil),
+			er.EXPECT().SaveEvents(ctx, []domain.Event{
+				{SensorID: 1, SensorSerialNumber: "0000000001", Timestamp: start.Add(-time.Hour), Payload: 1},
+			}).Times(1).Return(nil),
+		)
+
+		e := NewEvent(er, sr, WithEventImportBatchSize(2))
+
+		report, err := e.ImportEvents(ctx, lines)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, report.Total)
+		assert.Equal(t, 3, report.Imported)
+		assert.Equal(t, 5, report.Failed)
+		assert.Equal(t, []domain.ImportError{
+			{Line: 3, Message: "broken line"},
+			{Line: 5, Message: ErrSensorNotFound.Error()},
+			{Line: 6, Message: ErrPayloadOutOfRange.Error()},
+			{Line: 7, Message: ErrInvalidEventTimestamp.Error()},
+			{Line: 8, Message: ErrSensorMismatch.Error()},
+		}, report.Errors)
+	})
+
+	t.Run("fail, batch error reported for each line", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0000000001").Times(1).Return(&domain.Sensor{ID: 1, SerialNumber: "0000000001"}, nil)
+		expectedError := errors.New("some error")
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvents(ctx, gomock.Any()).Times(1).Return(expectedError)
+
+		e := NewEvent(er, sr)
+
+		report, err := e.ImportEvents(ctx, slices.Values([]ImportLine[domain.Event]{
+			{Line: 2, Value: domain.Event{SensorSerialNumber: "0000000001", Timestamp: start}},
+			{Line: 3, Value: domain.Event{SensorSerialNumber: "0000000001", Timestamp: start}},
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Imported)
+		assert.Equal(t, []domain.ImportError{{Line: 2, Message: "some error"}, {Line: 3, Message: "some error"}}, report.Errors)
+	})
+}
+
+func Test_sensor_ImportSensors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sr := NewMockSensorRepository(ctrl)
+	sr.EXPECT().GetSensorBySerialNumber(ctx, "0000000001").Times(1).Return(nil, ErrSensorNotFound)
+	sr.EXPECT().SaveSensor(ctx, gomock.Any()).Times(1).Return(nil)
+	sr.EXPECT().GetSensorBySerialNumber(ctx, "0000000002").Times(1).Return(&domain.Sensor{ID: 2}, nil)
+
+	s := NewSensor(sr)
+
+	report, err := s.ImportSensors(ctx, slices.Values([]ImportLine[domain.Sensor]{
+		{Line: 2, Value: domain.Sensor{SerialNumber: "0000000001", Type: domain.SensorTypeADC}},
+		{Line: 3, Value: domain.Sensor{SerialNumber: "0000000002", Type: domain.SensorTypeADC}},
+		{Line: 4, Value: domain.Sensor{SerialNumber: "1", Type: domain.SensorTypeADC}},
+		{Line: 5, Value: domain.Sensor{SerialNumber: "0000000003", Type: domain.SensorTypeADC, Calibration: &domain.Calibration{}}},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportReport{
+		Total:    4,
+		Imported: 1,
+		Skipped:  1,
+		Failed:   2,
+		Errors: []domain.ImportError{
+			{Line: 4, Message: ErrWrongSensorSerialNumber.Error()},
+			{Line: 5, Message: ErrInvalidCalibration.Error()},
+		},
+	}, report)
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"homework/internal/domain"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultIngestionWorkers - число обработчиков очереди событий
+	DefaultIngestionWorkers = 4
+	// DefaultIngestionQueueSize - сколько принятых событий может ждать сохранения, прежде чем приём начнёт отказывать
+	DefaultIngestionQueueSize = 4096
+	// DefaultIngestionBatchSize - наибольшее число событий, сохраняемых одной транзакцией
+	DefaultIngestionBatchSize = 100
+	// DefaultIngestionFlushInterval - сколько обработчик ждёт, пока наберётся пачка, после первого события в ней
+	DefaultIngestionFlushInterval = 50 * time.Millisecond
+	// ingestionFlushTimeout - время на сохранение оставшихся в очереди событий при остановке
+	ingestionFlushTimeout = 10 * time.Second
+)
+
+// IngestionStats - состояние асинхронного приёма событий
+type IngestionStats struct {
+	// Queued - события в очереди, ещё не сохранённые
+	Queued int
+	// Accepted - события, принятые в очередь с момента запуска
+	Accepted uint64
+	// Rejected - события, не принятые из-за переполненной или остановленной очереди
+	Rejected uint64
+	// Saved - сохранённые события, в том числе отложенные в буфер EventBuffer
+	Saved uint64
+	// Failed - принятые события, которые не удалось сохранить
+	Failed uint64
+}
+
+// Ingestion - асинхронный приём событий: событие проверяется и ставится в очередь, а сохраняют его обработчики
+// пачками через EventRepository.SaveEvents. События одного датчика попадают к одному обработчику и сохраняются
+// в порядке приёма, состояние датчика обновляется один раз за пачку
+type Ingestion struct {
+	events        *Event
+	queueSize     int
+	batchSize     int
+	flushInterval time.Duration
+	queues        []chan domain.Event
+
+	// mutex защищает stopped: после остановки события в очереди больше не ставятся
+	mutex    sync.RWMutex
+	stopped  bool
+	accepted atomic.Uint64
+	rejected atomic.Uint64
+	saved    atomic.Uint64
+	failed   atomic.Uint64
+}
+
+func NewIngestion(events *Event, options ...func(*Ingestion)) *Ingestion {
+	i := &Ingestion{
+		events:        events,
+		queues:        make([]chan domain.Event, DefaultIngestionWorkers),
+		queueSize:     DefaultIngestionQueueSize,
+		batchSize:     DefaultIngestionBatchSize,
+		flushInterval: DefaultIngestionFlushInterval,
+	}
+	for _, o := range options {
+		o(i)
+	}
+	// очередь делится между обработчиками поровну
+	for w := range i.queues {
+		i.queues[w] = make(chan domain.Event, max(1, (i.queueSize+len(i.queues)-1)/len(i.queues)))
+	}
+	return i
+}
+
+// WithIngestionWorkers - число обработчиков очереди, каждый сохраняет события своей части датчиков
+func WithIngestionWorkers(workers int) func(*Ingestion) {
+	return func(i *Ingestion) {
+		if workers > 0 {
+			i.queues = make([]chan domain.Event, workers)
+		}
+	}
+}
+
+// WithIngestionQueueSize - сколько событий может ждать сохранения на всех обработчиках вместе
+func WithIngestionQueueSize(size int) func(*Ingestion) {
+	return func(i *Ingestion) {
+		if size > 0 {
+			i.queueSize = size
+		}
+	}
+}
+
+// WithIngestionBatch - пачка сохраняется, когда в ней size событий или через interval после первого события
+func WithIngestionBatch(size int, interval time.Duration) func(*Ingestion) {
+	return func(i *Ingestion) {
+		if size > 0 {
+			i.batchSize = size
+		}
+		if interval > 0 {
+			i.flushInterval = interval
+		}
+	}
+}
+
+// EnqueueEvent - функция приёма события без ожидания сохранения. Событие проверяется так же, как в
+// Event.ReceiveEvent, получает время приёма и ID датчика; ID события не задаётся. Если хранилище недоступно,
+// событие откладывается в EventBuffer, подключённый к Event. Если очередь переполнена
+// или приём остановлен, возвращается ErrIngestionUnavailable
+func (i *Ingestion) EnqueueEvent(ctx context.Context, event *domain.Event) error {
+	if event == nil {
+		return ErrInvalidEventTimestamp
+	}
+	if err := i.events.allow(ctx, event); err != nil {
+		return err
+	}
+	sensor, err := i.events.acceptingSensor(ctx, event)
+	if err != nil {
+		return i.events.deferUnavailable(ctx, event, err)
+	}
+	if event.CommandID != 0 && i.events.commands == nil {
+		return ErrCommandNotFound
+	}
+	event.Timestamp = time.Now()
+	event.SensorID = sensor.ID
+
+	queued := *event
+	queued.Readings = event.Readings.Clone()
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	if i.stopped {
+		i.rejected.Add(1)
+		return fmt.Errorf("%w: ingestion is stopped", ErrIngestionUnavailable)
+	}
+	select {
+	case i.queues[i.worker(event.SensorSerialNumber)] <- queued:
+		i.accepted.Add(1)
+		return nil
+	default:
+		i.rejected.Add(1)
+		return fmt.Errorf("%w: queue is full", ErrIngestionUnavailable)
+	}
+}
+
+// worker - обработчик, к которому попадают события датчика
+func (i *Ingestion) worker(serialNumber string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(serialNumber))
+	return int(hash.Sum32() % uint32(len(i.queues)))
+}
+
+// Run - сохраняет события из очереди, пока не отменён ctx. После отмены новые события не принимаются,
+// а оставшиеся в очереди сохраняются, прежде чем Run вернёт управление
+func (i *Ingestion) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, queue := range i.queues {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			i.work(ctx, queue)
+		}()
+	}
+	wg.Wait()
+
+	i.mutex.Lock()
+	i.stopped = true
+	i.mutex.Unlock()
+
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ingestionFlushTimeout)
+	defer cancel()
+	for _, queue := range i.queues {
+		batch := make([]domain.Event, 0, i.batchSize)
+		for len(queue) > 0 {
+			batch = append(batch, <-queue)
+			if len(batch) == i.batchSize {
+				i.save(flushCtx, batch)
+				batch = batch[:0]
+			}
+		}
+		if len(batch) > 0 {
+			i.save(flushCtx, batch)
+		}
+	}
+}
+
+// work - собирает события из очереди обработчика в пачки и сохраняет их
+func (i *Ingestion) work(ctx context.Context, queue chan domain.Event) {
+	batch := make([]domain.Event, 0, i.batchSize)
+	// пачка, начатая до отмены ctx, сохраняется полностью
+	saveCtx := context.WithoutCancel(ctx)
+	for {
+		// после отмены очередь дочитывает Run, собирая полные пачки
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-queue:
+			batch = append(batch[:0], event)
+		}
+
+		deadline := time.NewTimer(i.flushInterval)
+	collect:
+		for len(batch) < i.batchSize {
+			select {
+			case event := <-queue:
+				batch = append(batch, event)
+			case <-deadline.C:
+				break collect
+			case <-ctx.Done():
+				break collect
+			}
+		}
+		deadline.Stop()
+		i.save(saveCtx, batch)
+	}
+}
+
+// save - сохраняет пачку, а если она не сохранилась целиком, то по одному событию,
+// чтобы одно неверное событие, например с неизвестной командой, не потеряло остальные.
+// Пока хранилище недоступно, события откладываются в EventBuffer, если он подключён к Event.
+// Событие, которое не удалось ни сохранить, ни отложить, теряется и попадает в журнал
+func (i *Ingestion) save(ctx context.Context, batch []domain.Event) {
+	err := i.events.writeBatch(ctx, batch)
+	if err == nil {
+		i.saved.Add(uint64(len(batch)))
+		return
+	}
+	if len(batch) == 1 {
+		i.drop(batch[0], err)
+		return
+	}
+	for _, event := range batch {
+		if err := i.events.writeBatch(ctx, []domain.Event{event}); err != nil {
+			i.drop(event, err)
+			continue
+		}
+		i.saved.Add(1)
+	}
+}
+
+// drop - учитывает потерянное событие
+func (i *Ingestion) drop(event domain.Event, err error) {
+	i.failed.Add(1)
+	log.Printf("ingestion: event of sensor %s dropped: %v", event.SensorSerialNumber, err)
+}
+
+// Stats - состояние очереди и число событий по результатам с момента запуска
+func (i *Ingestion) Stats() IngestionStats {
+	stats := IngestionStats{
+		Accepted: i.accepted.Load(),
+		Rejected: i.rejected.Load(),
+		Saved:    i.saved.Load(),
+		Failed:   i.failed.Load(),
+	}
+	for _, queue := range i.queues {
+		stats.Queued += len(queue)
+	}
+	return stats
+}
+
+// saveBatch - сохраняет принятые события в одной транзакции. Подтверждения команд и тревоги обрабатываются
+// по каждому событию в порядке приёма, а состояние датчика обновляется один раз - по последнему событию
+func (e *Event) saveBatch(ctx context.Context, batch []domain.Event) error {
+	var alerts []domain.Alert
+	err := e.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		alerts = alerts[:0]
+		if err := e.eventRepository.SaveEvents(ctx, batch); err != nil {
+			return err
+		}
+		sensors := map[int64]*domain.Sensor{}
+		var order []int64
+		for idx := range batch {
+			event := &batch[idx]
+			sensor, ok := sensors[event.SensorID]
+			if !ok {
+				var err error
+				if sensor, err = e.sensorRepository.GetSensorByID(ctx, event.SensorID); err != nil {
+					return err
+				}
+				sensors[sensor.ID] = sensor
+				order = append(order, sensor.ID)
+			}
+			if event.CommandID != 0 {
+				if e.commands == nil {
+					return ErrCommandNotFound
+				}
+				if err := e.commands.acknowledge(ctx, sensor, event); err != nil {
+					return err
+				}
+			}
+			sensor.LastActivity = event.Timestamp
+			sensor.CurrentState = event.Payload
+			sensor.CurrentReadings = event.Readings.Clone()
+			if e.alerts == nil {
+				continue
+			}
+			alert, err := e.alerts.raise(ctx, sensor, event)
+			if err != nil {
+				return err
+			}
+			if alert != nil {
+				alerts = append(alerts, *alert)
+			}
+		}
+		for _, id := range order {
+			if err := e.sensorRepository.SaveSensorState(ctx, sensors[id]); err != nil {
+				return err
+			}
+		}
+		return e.recordEvents(ctx, batch...)
+	})
+	if err != nil {
+		return err
+	}
+	for _, alert := range alerts {
+		e.alerts.raised(alert)
+	}
+	for _, event := range batch {
+		e.notify(event)
+	}
+	return nil
+}
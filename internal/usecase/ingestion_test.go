@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ingestion_EnqueueEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sensor := &domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}
+
+	t.Run("err, sensor not found", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(nil, ErrSensorNotFound)
+
+		i := NewIngestion(NewEvent(NewMockEventRepository(ctrl), sr))
+		err := i.EnqueueEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789"})
+		assert.ErrorIs(t, err, ErrSensorNotFound)
+		assert.Zero(t, i.Stats().Queued)
+	})
+
+	t.Run("err, queue is full", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(sensor, nil).Times(3)
+
+		i := NewIngestion(NewEvent(NewMockEventRepository(ctrl), sr), WithIngestionWorkers(1), WithIngestionQueueSize(2))
+		event := &domain.Event{SensorSerialNumber: "0123456789", Payload: 1}
+		require.NoError(t, i.EnqueueEvent(ctx, event))
+		assert.Equal(t, sensor.ID, event.SensorID)
+		assert.False(t, event.Timestamp.IsZero())
+		require.NoError(t, i.EnqueueEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789", Payload: 2}))
+
+		err := i.EnqueueEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789", Payload: 3})
+		assert.ErrorIs(t, err, ErrIngestionUnavailable)
+		assert.Equal(t, IngestionStats{Queued: 2, Accepted: 2, Rejected: 1}, i.Stats())
+	})
+}
+
+func Test_ingestion_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newSensor := func() *domain.Sensor {
+		return &domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}
+	}
+	enqueue := func(t *testing.T, i *Ingestion, payloads ...float64) {
+		for _, payload := range payloads {
+			require.NoError(t, i.EnqueueEvent(context.Background(), &domain.Event{SensorSerialNumber: "0123456789", Payload: payload}))
+		}
+	}
+	payloads := func(events []domain.Event) []float64 {
+		var result []float64
+		for _, event := range events {
+			result = append(result, event.Payload)
+		}
+		return result
+	}
+
+	t.Run("ok, batch with coalesced sensor state", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		saved := make(chan domain.Sensor, 1)
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(gomock.Any(), "0123456789").Return(newSensor(), nil).Times(3)
+		sr.EXPECT().GetSensorByID(gomock.Any(), int64(7)).Return(newSensor(), nil)
+		sr.EXPECT().SaveSensorState(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, sensor *domain.Sensor) error {
+			saved <- *sensor
+			return nil
+		})
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []domain.Event) error {
+			assert.Equal(t, []float64{1, 2, 3}, payloads(events), "события датчика в порядке приёма")
+			return nil
+		})
+
+		i := NewIngestion(NewEvent(er, sr), WithIngestionBatch(3, time.Minute))
+		go i.Run(ctx)
+		enqueue(t, i, 1, 2, 3)
+
+		select {
+		case sensor := <-saved:
+			assert.Equal(t, 3.0, sensor.CurrentState)
+		case <-time.After(5 * time.Second):
+			t.Fatal("batch was not saved")
+		}
+		assert.Eventually(t, func() bool { return i.Stats().Saved == 3 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("ok, queue flushed on shutdown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		sr := NewMockSensorRepository(ctrl)
+		// третье событие приходит после остановки
+		sr.EXPECT().GetSensorBySerialNumber(gomock.Any(), "0123456789").Return(newSensor(), nil).Times(3)
+		sr.EXPECT().GetSensorByID(gomock.Any(), int64(7)).Return(newSensor(), nil)
+		sr.EXPECT().SaveSensorState(gomock.Any(), gomock.Any()).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, events []domain.Event) error {
+			assert.NoError(t, ctx.Err(), "сохранение при остановке не отменено")
+			assert.Equal(t, []float64{1, 2}, payloads(events))
+			return nil
+		})
+
+		i := NewIngestion(NewEvent(er, sr), WithIngestionWorkers(1))
+		enqueue(t, i, 1, 2)
+		cancel()
+		i.Run(ctx)
+
+		assert.Equal(t, IngestionStats{Accepted: 2, Saved: 2}, i.Stats())
+		err := i.EnqueueEvent(context.Background(), &domain.Event{SensorSerialNumber: "0123456789"})
+		assert.ErrorIs(t, err, ErrIngestionUnavailable)
+		assert.Equal(t, uint64(1), i.Stats().Rejected)
+	})
+
+	t.Run("ok, failed batch saved by one event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(gomock.Any(), "0123456789").Return(newSensor(), nil).Times(2)
+		sr.EXPECT().GetSensorByID(gomock.Any(), int64(7)).Return(newSensor(), nil)
+		sr.EXPECT().SaveSensorState(gomock.Any(), gomock.Any()).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		gomock.InOrder(
+			er.EXPECT().SaveEvents(gomock.Any(), gomock.Len(2)).Return(errors.New("some error")),
+			er.EXPECT().SaveEvents(gomock.Any(), gomock.Len(1)).Return(errors.New("some error")),
+			er.EXPECT().SaveEvents(gomock.Any(), gomock.Len(1)).Return(nil),
+		)
+
+		i := NewIngestion(NewEvent(er, sr), WithIngestionWorkers(1))
+		enqueue(t, i, 1, 2)
+		cancel()
+		i.Run(ctx)
+
+		assert.Equal(t, IngestionStats{Accepted: 2, Saved: 1, Failed: 1}, i.Stats())
+	})
+
+	t.Run("ok, batch buffered while storage is down", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(gomock.Any(), "0123456789").Return(newSensor(), nil).Times(2)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvents(gomock.Any(), gomock.Len(2)).Return(errStorageDown)
+		br := NewMockEventBufferRepository(ctrl)
+		br.EXPECT().Stats().Return(EventBufferStats{})
+		br.EXPECT().AppendEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []domain.Event) error {
+			assert.Equal(t, []float64{1, 2}, payloads(events))
+			return nil
+		})
+
+		i := NewIngestion(NewEvent(er, sr, WithEventBuffer(NewEventBuffer(br, isStorageDown))), WithIngestionWorkers(1))
+		enqueue(t, i, 1, 2)
+		cancel()
+		i.Run(ctx)
+
+		assert.Equal(t, IngestionStats{Accepted: 2, Saved: 2}, i.Stats())
+	})
+}
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+const (
+	// notificationQueueSize - число поднятых тревог, ожидающих рассылки, после которого новые тревоги не рассылаются,
+	// чтобы недоступный почтовый сервер не задерживал сохранение событий
+	notificationQueueSize = 256
+	// DefaultSendTimeout - время на отправку одного уведомления
+	DefaultSendTimeout = 10 * time.Second
+	// rateLimitPeriod - период, за который считается MaxPerHour канала
+	rateLimitPeriod = time.Hour
+)
+
+// defaultNotificationTemplate - шаблон текста уведомления для каналов без своего шаблона
+const defaultNotificationTemplate = `Датчик {{.Sensor.SerialNumber}}{{with .Sensor.Description}} ({{.}}){{end}}: ` +
+	`значение {{.Value}}{{with .Unit}} {{.}}{{end}}, важность {{.Alert.Severity}}, ` +
+	`время {{.Alert.TriggeredAt.Format "2006-01-02 15:04:05 MST"}}`
+
+// NotificationData - данные, доступные в шаблоне уведомления
+type NotificationData struct {
+	Alert  domain.Alert
+	Sensor domain.Sensor
+	// Value - откалиброванное значение события, поднявшего тревогу
+	Value float64
+	// Unit - единица измерения Value, пустая у неоткалиброванных датчиков
+	Unit string
+}
+
+type Notification struct {
+	notificationRepository NotificationRepository
+	userRepository         UserRepository
+	sensorOwnerRepository  SensorOwnerRepository
+	sensorRepository       SensorRepository
+	senders                map[domain.NotificationChannelKind]NotificationSender
+	outbound               domain.OutboundNetworks
+	sendTimeout            time.Duration
+	now                    func() time.Time
+	queue                  chan domain.Alert
+	// dropped - тревоги, не поставленные в переполненную очередь рассылки
+	dropped atomic.Uint64
+}
+
+func NewNotification(nr NotificationRepository, ur UserRepository, sor SensorOwnerRepository, sr SensorRepository, options ...func(*Notification)) *Notification {
+	n := &Notification{
+		notificationRepository: nr,
+		userRepository:         ur,
+		sensorOwnerRepository:  sor,
+		sensorRepository:       sr,
+		senders:                map[domain.NotificationChannelKind]NotificationSender{},
+		sendTimeout:            DefaultSendTimeout,
+		now:                    time.Now,
+		queue:                  make(chan domain.Alert, notificationQueueSize),
+	}
+	for _, o := range options {
+		o(n)
+	}
+	return n
+}
+
+// WithNotificationSender - уведомления каналов kind отправляются через sender.
+// Каналы, для которых отправителя нет, создать нельзя
+func WithNotificationSender(kind domain.NotificationChannelKind, sender NotificationSender) func(*Notification) {
+	return func(n *Notification) {
+		n.senders[kind] = sender
+	}
+}
+
+// WithNotificationOutboundNetworks - внутренние сети, адреса которых можно указывать в URL push каналов
+func WithNotificationOutboundNetworks(networks domain.OutboundNetworks) func(*Notification) {
+	return func(n *Notification) {
+		n.outbound = networks
+	}
+}
+
+// WithSendTimeout - время на отправку одного уведомления
+func WithSendTimeout(timeout time.Duration) func(*Notification) {
+	return func(n *Notification) {
+		n.sendTimeout = timeout
+	}
+}
+
+// WithNotificationClock - источник текущего времени для тихих часов и ограничения частоты
+func WithNotificationClock(now func() time.Time) func(*Notification) {
+	return func(n *Notification) {
+		n.now = now
+	}
+}
+
+// WithAlertNotifications - о поднятых тревогах рассылаются уведомления notifications
+func WithAlertNotifications(notifications *Notification) func(*Alert) {
+	return func(a *Alert) {
+		a.notifications = notifications
+	}
+}
+
+// CreateChannel - функция создания канала уведомлений пользователя channel.UserID
+func (n *Notification) CreateChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	if channel.MinSeverity == "" {
+		channel.MinSeverity = domain.AlertSeverityInfo
+	}
+	if err := n.validateChannel(channel); err != nil {
+		return err
+	}
+	return n.notificationRepository.SaveChannel(ctx, channel)
+}
+
+// GetUserChannels - функция получения каналов уведомлений пользователя
+func (n *Notification) GetUserChannels(ctx context.Context, userID int64) ([]domain.NotificationChannel, error) {
+	if _, err := n.userRepository.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	channels, err := n.notificationRepository.GetChannelsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if channels == nil {
+		channels = []domain.NotificationChannel{}
+	}
+	return channels, nil
+}
+
+// DeleteChannel - функция удаления канала уведомлений пользователя вместе с журналом доставки
+func (n *Notification) DeleteChannel(ctx context.Context, userID, channelID int64) error {
+	if _, err := n.userChannel(ctx, userID, channelID); err != nil {
+		return err
+	}
+	return n.notificationRepository.DeleteChannel(ctx, channelID)
+}
+
+// GetChannelDeliveries - функция получения журнала доставки канала пользователя от новых записей к старым
+func (n *Notification) GetChannelDeliveries(ctx context.Context, userID, channelID int64, limit int) ([]domain.Delivery, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = n.userChannel(ctx, userID, channelID); err != nil {
+		return nil, err
+	}
+	return n.notificationRepository.ListDeliveries(ctx, channelID, limit)
+}
+
+// userChannel - канал пользователя, канал другого пользователя считается ненайденным
+func (n *Notification) userChannel(ctx context.Context, userID, channelID int64) (*domain.NotificationChannel, error) {
+	if _, err := n.userRepository.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	channel, err := n.notificationRepository.GetChannelByID(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.UserID != userID {
+		return nil, ErrChannelNotFound
+	}
+	return channel, nil
+}
+
+// enqueue - ставит поднятую тревогу в очередь рассылки, не дожидаясь отправки.
+// Если очередь переполнена, тревога не рассылается: это попадает в журнал и счётчик Dropped
+func (n *Notification) enqueue(alert domain.Alert) {
+	select {
+	case n.queue <- alert:
+	default:
+		n.dropped.Add(1)
+		log.Printf("notification queue is full, alert %d of sensor %d is not dispatched", alert.ID, alert.SensorID)
+	}
+}
+
+// Dropped - число тревог, уведомления о которых не рассылались из-за переполненной очереди, с момента запуска
+func (n *Notification) Dropped() uint64 {
+	return n.dropped.Load()
+}
+
+// Run - рассылает уведомления о тревогах из очереди, пока не отменён ctx
+func (n *Notification) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case alert := <-n.queue:
+			// ошибки отправки уже записаны в журнал доставки, а ошибки хранилища повторить некому
+			_ = n.Dispatch(ctx, alert)
+		}
+	}
+}
+
+// Dispatch - функция рассылки уведомлений о тревоге во все подходящие по важности каналы пользователей её датчика.
+// Каждая попытка, в том числе подавленная тихими часами или ограничением частоты, записывается в журнал доставки
+func (n *Notification) Dispatch(ctx context.Context, alert domain.Alert) error {
+	sensor, err := n.sensorRepository.GetSensorByID(ctx, alert.SensorID)
+	if err != nil {
+		return err
+	}
+	owners, err := n.sensorOwnerRepository.GetUsersBySensorID(ctx, alert.SensorID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, owner := range owners {
+		channels, err := n.notificationRepository.GetChannelsByUserID(ctx, owner.UserID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, channel := range channels {
+			if !alert.Severity.AtLeast(channel.MinSeverity) {
+				continue
+			}
+			if err = n.deliver(ctx, channel, alert, *sensor); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver - отправляет уведомление в канал, если это не запрещено его настройками, и записывает итог в журнал
+func (n *Notification) deliver(ctx context.Context, channel domain.NotificationChannel, alert domain.Alert, sensor domain.Sensor) error {
+	now := n.now()
+	delivery := &domain.Delivery{
+		ChannelID: channel.ID,
+		AlertID:   alert.ID,
+		CreatedAt: now.Truncate(time.Microsecond).UTC(),
+	}
+
+	limited, err := n.rateLimited(ctx, channel, now)
+	if err != nil {
+		return err
+	}
+	switch {
+	case channel.QuietHours.Contains(now):
+		delivery.Status, delivery.Reason = domain.DeliveryStatusSuppressed, "quiet hours"
+	case limited:
+		delivery.Status, delivery.Reason = domain.DeliveryStatusSuppressed, fmt.Sprintf("rate limit of %d per hour exceeded", channel.MaxPerHour)
+	default:
+		if err = n.send(ctx, channel, alert, sensor); err != nil {
+			delivery.Status, delivery.Reason = domain.DeliveryStatusFailed, err.Error()
+		} else {
+			delivery.Status = domain.DeliveryStatusSent
+		}
+	}
+	return n.notificationRepository.SaveDelivery(ctx, delivery)
+}
+
+// rateLimited - проверяет, исчерпан ли лимит отправленных за последний час уведомлений канала
+func (n *Notification) rateLimited(ctx context.Context, channel domain.NotificationChannel, now time.Time) (bool, error) {
+	if channel.MaxPerHour <= 0 {
+		return false, nil
+	}
+	sent, err := n.notificationRepository.CountDeliveries(ctx, channel.ID, domain.DeliveryStatusSent, now.Add(-rateLimitPeriod))
+	if err != nil {
+		return false, err
+	}
+	return sent >= channel.MaxPerHour, nil
+}
+
+func (n *Notification) send(ctx context.Context, channel domain.NotificationChannel, alert domain.Alert, sensor domain.Sensor) error {
+	sender, ok := n.senders[channel.Kind]
+	if !ok {
+		return fmt.Errorf("no sender for %s channels", channel.Kind)
+	}
+	message, err := RenderNotification(channel, alert, sensor)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, n.sendTimeout)
+	defer cancel()
+	return sender.Send(ctx, channel, message)
+}
+
+// RenderNotification - функция подготовки уведомления о тревоге по шаблону канала
+func RenderNotification(channel domain.NotificationChannel, alert domain.Alert, sensor domain.Sensor) (domain.NotificationMessage, error) {
+	text := channel.Template
+	if text == "" {
+		text = defaultNotificationTemplate
+	}
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return domain.NotificationMessage{}, fmt.Errorf("can't parse template: %w", err)
+	}
+	data := NotificationData{
+		Alert:  alert,
+		Sensor: sensor,
+		Value:  sensor.Calibration.Apply(alert.Payload),
+	}
+	if sensor.Calibration != nil {
+		data.Unit = sensor.Calibration.Unit
+	}
+	var b strings.Builder
+	if err = tmpl.Execute(&b, data); err != nil {
+		return domain.NotificationMessage{}, fmt.Errorf("can't execute template: %w", err)
+	}
+
+	name := sensor.Description
+	if name == "" {
+		name = sensor.SerialNumber
+	}
+	return domain.NotificationMessage{
+		Subject: fmt.Sprintf("[%s] %s", alert.Severity, name),
+		Text:    b.String(),
+		Alert:   alert,
+		Sensor:  sensor,
+	}, nil
+}
+
+func (n *Notification) validateChannel(channel *domain.NotificationChannel) error {
+	if _, ok := n.senders[channel.Kind]; !ok {
+		return ErrInvalidChannel
+	}
+	switch channel.Kind {
+	case domain.NotificationChannelEmail:
+		if _, err := mail.ParseAddress(channel.Target); err != nil {
+			return ErrInvalidChannel
+		}
+	case domain.NotificationChannelPush:
+		u, err := url.Parse(channel.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidChannel
+		}
+		// адрес проверяется еще раз при отправке, здесь отсекаются явно внутренние URL
+		if !n.outbound.AllowsHost(u.Hostname()) {
+			return ErrInvalidChannel
+		}
+	}
+	if !channel.MinSeverity.Valid() || channel.MaxPerHour < 0 {
+		return ErrInvalidChannel
+	}
+	if q := channel.QuietHours; q != nil {
+		if q.Start < 0 || q.Start >= 24*60 || q.End < 0 || q.End >= 24*60 {
+			return ErrInvalidChannel
+		}
+		if _, err := time.LoadLocation(q.Location); err != nil {
+			return ErrInvalidChannel
+		}
+	}
+	// шаблон с ошибкой в имени поля разбирается, поэтому проверяется отрисовкой пустой тревоги
+	if _, err := RenderNotification(*channel, domain.Alert{}, domain.Sensor{}); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidChannel, err)
+	}
+	return nil
+}
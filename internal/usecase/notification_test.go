@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notification_CreateChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sender := NewMockNotificationSender(ctrl)
+
+	t.Run("ok, min severity defaults to info", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().SaveChannel(ctx, gomock.Any()).Times(1).Return(nil)
+
+		n := NewNotification(nr, nil, nil, nil, WithNotificationSender(domain.NotificationChannelEmail, sender))
+		channel := &domain.NotificationChannel{UserID: 1, Kind: domain.NotificationChannelEmail, Target: "owner@example.com"}
+		assert.NoError(t, n.CreateChannel(ctx, channel))
+		assert.Equal(t, domain.AlertSeverityInfo, channel.MinSeverity)
+	})
+
+	t.Run("fail, invalid channel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().SaveChannel(gomock.Any(), gomock.Any()).Times(0)
+		n := NewNotification(nr, nil, nil, nil,
+			WithNotificationSender(domain.NotificationChannelEmail, sender),
+			WithNotificationSender(domain.NotificationChannelPush, sender),
+		)
+
+		for name, channel := range map[string]domain.NotificationChannel{
+			"unknown kind":        {Kind: "sms", Target: "+70000000000"},
+			"invalid email":       {Kind: domain.NotificationChannelEmail, Target: "owner"},
+			"invalid url":         {Kind: domain.NotificationChannelPush, Target: "ftp://example.com"},
+			"invalid severity":    {Kind: domain.NotificationChannelPush, Target: "https://example.com", MinSeverity: "fatal"},
+			"negative rate limit": {Kind: domain.NotificationChannelPush, Target: "https://example.com", MaxPerHour: -1},
+			"invalid location": {Kind: domain.NotificationChannelPush, Target: "https://example.com",
+				QuietHours: &domain.QuietHours{Start: 0, End: 60, Location: "Mars/Olympus"}},
+			"invalid template":       {Kind: domain.NotificationChannelPush, Target: "https://example.com", Template: "{{.Sensor"},
+			"unknown template field": {Kind: domain.NotificationChannelPush, Target: "https://example.com", Template: "{{.Humidity}}"},
+			"quiet hours out of day": {Kind: domain.NotificationChannelPush, Target: "https://example.com", QuietHours: &domain.QuietHours{Start: 0, End: 24 * 60}},
+			"loopback url":           {Kind: domain.NotificationChannelPush, Target: "http://127.0.0.1:8080/hook"},
+			"localhost url":          {Kind: domain.NotificationChannelPush, Target: "http://localhost:8080/hook"},
+			"ipv6 loopback url":      {Kind: domain.NotificationChannelPush, Target: "http://[::1]/hook"},
+			"link-local url":         {Kind: domain.NotificationChannelPush, Target: "http://169.254.169.254/latest/meta-data"},
+			"private url":            {Kind: domain.NotificationChannelPush, Target: "https://10.0.0.1/hook"},
+			"unspecified url":        {Kind: domain.NotificationChannelPush, Target: "http://0.0.0.0/hook"},
+		} {
+			assert.ErrorIs(t, n.CreateChannel(ctx, &channel), ErrInvalidChannel, name)
+		}
+
+		// без отправителя канал создать нельзя
+		channel := &domain.NotificationChannel{Kind: domain.NotificationChannelEmail, Target: "owner@example.com"}
+		assert.ErrorIs(t, NewNotification(nr, nil, nil, nil).CreateChannel(ctx, channel), ErrInvalidChannel)
+	})
+
+	t.Run("ok, allowed internal network", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		channel := &domain.NotificationChannel{Kind: domain.NotificationChannelPush, Target: "http://10.1.2.3:8080/hook",
+			UserID: 1, MinSeverity: domain.AlertSeverityInfo}
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().SaveChannel(ctx, channel).Return(nil)
+
+		n := NewNotification(nr, nil, nil, nil,
+			WithNotificationSender(domain.NotificationChannelPush, sender),
+			WithNotificationOutboundNetworks(domain.OutboundNetworks{netip.MustParsePrefix("10.1.0.0/16")}),
+		)
+		require.NoError(t, n.CreateChannel(ctx, channel))
+
+		channel.Target = "http://10.2.0.1/hook"
+		assert.ErrorIs(t, n.CreateChannel(ctx, channel), ErrInvalidChannel)
+	})
+}
+
+func Test_notification_DeleteChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ur, _ := alertOwner(ctrl)
+
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().GetChannelByID(ctx, int64(3)).Times(1).Return(&domain.NotificationChannel{ID: 3, UserID: 1}, nil)
+		nr.EXPECT().DeleteChannel(ctx, int64(3)).Times(1).Return(nil)
+
+		assert.NoError(t, NewNotification(nr, ur, nil, nil).DeleteChannel(ctx, 1, 3))
+	})
+
+	t.Run("fail, channel of other user", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().GetChannelByID(ctx, int64(4)).Times(1).Return(&domain.NotificationChannel{ID: 4, UserID: 2}, nil)
+		nr.EXPECT().DeleteChannel(gomock.Any(), gomock.Any()).Times(0)
+
+		assert.ErrorIs(t, NewNotification(nr, ur, nil, nil).DeleteChannel(ctx, 1, 4), ErrChannelNotFound)
+	})
+}
+
+func Test_notification_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 2024-06-01 03:00 UTC
+	now := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	alert := domain.Alert{ID: 7, SensorID: 1, Severity: domain.AlertSeverityWarning, Payload: 100, TriggeredAt: now}
+	sensor := &domain.Sensor{
+		ID:           1,
+		SerialNumber: "0000000001",
+		Description:  "Котельная",
+		Calibration:  &domain.Calibration{Offset: -40, Scale: 0.5, Unit: "°C"},
+	}
+	email := domain.NotificationChannel{
+		ID:          1,
+		UserID:      1,
+		Kind:        domain.NotificationChannelEmail,
+		Target:      "owner@example.com",
+		MinSeverity: domain.AlertSeverityInfo,
+		Template:    "{{.Sensor.Description}}: {{.Value}} {{.Unit}}",
+	}
+
+	newNotification := func(nr NotificationRepository, sender NotificationSender, channels ...domain.NotificationChannel) *Notification {
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorByID(gomock.Any(), int64(1)).AnyTimes().Return(sensor, nil)
+		sor := NewMockSensorOwnerRepository(ctrl)
+		sor.EXPECT().GetUsersBySensorID(gomock.Any(), int64(1)).AnyTimes().Return([]domain.SensorOwner{{UserID: 1, SensorID: 1}}, nil)
+		nr.(*MockNotificationRepository).EXPECT().GetChannelsByUserID(gomock.Any(), int64(1)).AnyTimes().Return(channels, nil)
+		return NewNotification(nr, nil, sor, sr,
+			WithNotificationSender(domain.NotificationChannelEmail, sender),
+			WithNotificationClock(func() time.Time { return now }),
+		)
+	}
+	expectDelivery := func(nr *MockNotificationRepository, status domain.DeliveryStatus, reason string) {
+		nr.EXPECT().SaveDelivery(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, delivery *domain.Delivery) error {
+			assert.Equal(t, email.ID, delivery.ChannelID)
+			assert.Equal(t, alert.ID, delivery.AlertID)
+			assert.Equal(t, status, delivery.Status)
+			assert.Contains(t, delivery.Reason, reason)
+			assert.Equal(t, now, delivery.CreatedAt)
+			return nil
+		})
+	}
+
+	t.Run("ok, sent by template", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		nr := NewMockNotificationRepository(ctrl)
+		expectDelivery(nr, domain.DeliveryStatusSent, "")
+		sender := NewMockNotificationSender(ctrl)
+		sender.EXPECT().Send(gomock.Any(), email, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, _ domain.NotificationChannel, message domain.NotificationMessage) error {
+			assert.Equal(t, "[warning] Котельная", message.Subject)
+			assert.Equal(t, "Котельная: 10 °C", message.Text)
+			assert.Equal(t, alert, message.Alert)
+			return nil
+		})
+
+		assert.NoError(t, newNotification(nr, sender, email).Dispatch(ctx, alert))
+	})
+
+	t.Run("ok, channel with higher min severity skipped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		critical := email
+		critical.MinSeverity = domain.AlertSeverityCritical
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().SaveDelivery(gomock.Any(), gomock.Any()).Times(0)
+		sender := NewMockNotificationSender(ctrl)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, newNotification(nr, sender, critical).Dispatch(ctx, alert))
+	})
+
+	t.Run("ok, suppressed in quiet hours", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		quiet := email
+		quiet.QuietHours = &domain.QuietHours{Start: 22 * 60, End: 7 * 60}
+		nr := NewMockNotificationRepository(ctrl)
+		expectDelivery(nr, domain.DeliveryStatusSuppressed, "quiet hours")
+		sender := NewMockNotificationSender(ctrl)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, newNotification(nr, sender, quiet).Dispatch(ctx, alert))
+	})
+
+	t.Run("ok, suppressed by rate limit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		limited := email
+		limited.MaxPerHour = 2
+		nr := NewMockNotificationRepository(ctrl)
+		nr.EXPECT().CountDeliveries(gomock.Any(), email.ID, domain.DeliveryStatusSent, now.Add(-time.Hour)).Times(1).Return(2, nil)
+		expectDelivery(nr, domain.DeliveryStatusSuppressed, "rate limit")
+		sender := NewMockNotificationSender(ctrl)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, newNotification(nr, sender, limited).Dispatch(ctx, alert))
+	})
+
+	t.Run("ok, send error recorded", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		nr := NewMockNotificationRepository(ctrl)
+		expectDelivery(nr, domain.DeliveryStatusFailed, "connection refused")
+		sender := NewMockNotificationSender(ctrl)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.New("connection refused"))
+
+		assert.NoError(t, newNotification(nr, sender, email).Dispatch(ctx, alert))
+	})
+}
+
+func Test_notification_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sr := NewMockSensorRepository(ctrl)
+	sr.EXPECT().GetSensorByID(gomock.Any(), int64(1)).Times(1).Return(&domain.Sensor{ID: 1, SerialNumber: "0000000001"}, nil)
+	sor := NewMockSensorOwnerRepository(ctrl)
+	sor.EXPECT().GetUsersBySensorID(gomock.Any(), int64(1)).Times(1).Return([]domain.SensorOwner{{UserID: 1, SensorID: 1}}, nil)
+	nr := NewMockNotificationRepository(ctrl)
+	nr.EXPECT().GetChannelsByUserID(gomock.Any(), int64(1)).Times(1).Return([]domain.NotificationChannel{
+		{ID: 1, UserID: 1, Kind: domain.NotificationChannelPush, Target: "https://example.com", MinSeverity: domain.AlertSeverityInfo},
+	}, nil)
+	delivered := make(chan domain.Delivery, 1)
+	nr.EXPECT().SaveDelivery(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, delivery *domain.Delivery) error {
+		delivered <- *delivery
+		return nil
+	})
+	sender := NewMockNotificationSender(ctrl)
+	sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	n := NewNotification(nr, nil, sor, sr, WithNotificationSender(domain.NotificationChannelPush, sender))
+	a := NewAlert(nil, nil, nil, WithAlertNotifications(n))
+	go n.Run(ctx)
+
+	a.raised(domain.Alert{ID: 7, SensorID: 1, Severity: domain.AlertSeverityCritical})
+	select {
+	case delivery := <-delivered:
+		assert.Equal(t, int64(7), delivery.AlertID)
+		assert.Equal(t, domain.DeliveryStatusSent, delivery.Status)
+	case <-time.After(time.Second):
+		require.Fail(t, "alert not dispatched")
+	}
+}
+
+func Test_notification_enqueue(t *testing.T) {
+	n := NewNotification(nil, nil, nil, nil)
+	for i := range notificationQueueSize {
+		n.enqueue(domain.Alert{ID: int64(i + 1), SensorID: 1})
+	}
+	assert.Zero(t, n.Dropped())
+
+	// очередь переполнена, пока рассылка не запущена: тревога не теряется молча
+	n.enqueue(domain.Alert{ID: notificationQueueSize + 1, SensorID: 1})
+	assert.Equal(t, uint64(1), n.Dropped())
+	assert.Len(t, n.queue, notificationQueueSize)
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultOutboxPollInterval - как часто relay проверяет журнал изменений на новые записи
+	DefaultOutboxPollInterval = time.Second
+	// DefaultOutboxBatchSize - наибольшее число изменений, доставляемых в приёмник за раз
+	DefaultOutboxBatchSize = 100
+)
+
+// OutboxSinkStats - доставка изменений в приёмник
+type OutboxSinkStats struct {
+	// Name - имя приёмника
+	Name string
+	// Published - изменения, доставленные с момента запуска
+	Published uint64
+	// Failed - неудачные попытки доставки с момента запуска
+	Failed uint64
+}
+
+// outboxSink - приёмник изменений и счётчики доставки в него
+type outboxSink struct {
+	name      string
+	sink      ChangeSink
+	published atomic.Uint64
+	failed    atomic.Uint64
+}
+
+// Outbox - поток изменений: принятые события и изменения датчиков записываются в журнал в одной транзакции
+// с их сохранением, а relay доставляет записи журнала в приёмники по порядку Seq. Позиция приёмника сохраняется
+// после доставки, поэтому после сбоя изменения могут быть доставлены повторно, но не теряются
+type Outbox struct {
+	repository   OutboxRepository
+	sinks        []*outboxSink
+	pollInterval time.Duration
+	batchSize    int
+}
+
+func NewOutbox(or OutboxRepository, options ...func(*Outbox)) *Outbox {
+	o := &Outbox{
+		repository:   or,
+		pollInterval: DefaultOutboxPollInterval,
+		batchSize:    DefaultOutboxBatchSize,
+	}
+	for _, opt := range options {
+		opt(o)
+	}
+	return o
+}
+
+// WithOutboxSink - relay доставляет изменения в sink; name отличает позицию приёмника в журнале и не должно меняться
+func WithOutboxSink(name string, sink ChangeSink) func(*Outbox) {
+	return func(o *Outbox) {
+		o.sinks = append(o.sinks, &outboxSink{name: name, sink: sink})
+	}
+}
+
+// WithOutboxRelay - relay проверяет журнал раз в pollInterval и доставляет не более batchSize изменений за раз
+func WithOutboxRelay(pollInterval time.Duration, batchSize int) func(*Outbox) {
+	return func(o *Outbox) {
+		if pollInterval > 0 {
+			o.pollInterval = pollInterval
+		}
+		if batchSize > 0 {
+			o.batchSize = batchSize
+		}
+	}
+}
+
+// WithEventOutbox - принятые события записываются в журнал изменений o
+func WithEventOutbox(o *Outbox) func(*Event) {
+	return func(e *Event) {
+		e.outbox = o
+	}
+}
+
+// WithSensorOutbox - регистрация и изменения датчиков записываются в журнал изменений o
+func WithSensorOutbox(o *Outbox) func(*Sensor) {
+	return func(s *Sensor) {
+		s.outbox = o
+	}
+}
+
+// ListChanges - функция получения не более limit изменений, следующих за изменением since, по возрастанию Seq.
+// Чтобы получить следующие изменения, since задаётся равным Seq последнего полученного изменения
+func (o *Outbox) ListChanges(ctx context.Context, since int64, limit int) ([]domain.Change, error) {
+	if since < 0 {
+		return nil, ErrInvalidCursor
+	}
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	return o.repository.ListChanges(ctx, since, limit)
+}
+
+// Run - доставляет новые изменения в приёмники раз в pollInterval, пока не отменён ctx
+func (o *Outbox) Run(ctx context.Context) {
+	if len(o.sinks) == 0 {
+		return
+	}
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// неудачная доставка учтена в Stats и повторится в следующий раз
+			_ = o.Relay(ctx)
+		}
+	}
+}
+
+// Relay - доставляет в каждый приёмник все изменения после его позиции. Ошибка одного приёмника
+// не задерживает доставку в остальные
+func (o *Outbox) Relay(ctx context.Context) error {
+	var errs []error
+	for _, sink := range o.sinks {
+		if err := o.relay(ctx, sink); err != nil {
+			sink.failed.Add(1)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// relay - доставляет изменения в приёмник пачками, пока не дойдёт до конца журнала. Если доставку в приёмник
+// уже захватил другой экземпляр сервиса, ничего не делает: иначе экземпляры доставляли бы одни изменения повторно
+func (o *Outbox) relay(ctx context.Context, sink *outboxSink) error {
+	release, ok, err := o.repository.LockRelay(ctx, sink.name)
+	if err != nil || !ok {
+		return err
+	}
+	defer release()
+	position, err := o.repository.GetRelayPosition(ctx, sink.name)
+	if err != nil {
+		return err
+	}
+	for {
+		changes, err := o.repository.ListChanges(ctx, position, o.batchSize)
+		if err != nil || len(changes) == 0 {
+			return err
+		}
+		if err = sink.sink.Publish(ctx, changes); err != nil {
+			return err
+		}
+		position = changes[len(changes)-1].Seq
+		if err = o.repository.SaveRelayPosition(ctx, sink.name, position); err != nil {
+			return err
+		}
+		sink.published.Add(uint64(len(changes)))
+		if len(changes) < o.batchSize {
+			return nil
+		}
+	}
+}
+
+// Stats - доставка изменений в приёмники с момента запуска в порядке их подключения
+func (o *Outbox) Stats() []OutboxSinkStats {
+	stats := make([]OutboxSinkStats, 0, len(o.sinks))
+	for _, sink := range o.sinks {
+		stats = append(stats, OutboxSinkStats{
+			Name:      sink.name,
+			Published: sink.published.Load(),
+			Failed:    sink.failed.Load(),
+		})
+	}
+	return stats
+}
+
+// record - записывает изменение в журнал, вызывается в транзакции изменения
+func (o *Outbox) record(ctx context.Context, change domain.Change) error {
+	change.CreatedAt = time.Now()
+	return o.repository.SaveChange(ctx, &change)
+}
+
+// recordEvents - записывает принятые события в журнал изменений, если он подключён
+func (e *Event) recordEvents(ctx context.Context, events ...domain.Event) error {
+	if e.outbox == nil {
+		return nil
+	}
+	for idx := range events {
+		event := events[idx]
+		event.Readings = event.Readings.Clone()
+		if err := e.outbox.record(ctx, domain.Change{Kind: domain.ChangeKindEvent, Event: &event}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordSensor - записывает датчик после изменения в журнал изменений, если он подключён
+func (s *Sensor) recordSensor(ctx context.Context, sensor domain.Sensor) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.record(ctx, domain.Change{Kind: domain.ChangeKindSensor, Sensor: &sensor})
+}
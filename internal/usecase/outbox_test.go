@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_outbox_record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("ok, accepted event recorded", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(&domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}, nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.Event) error {
+			event.ID = 3
+			return nil
+		})
+		or := NewMockOutboxRepository(ctrl)
+		or.EXPECT().SaveChange(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *domain.Change) error {
+			assert.Equal(t, domain.ChangeKindEvent, change.Kind)
+			require.NotNil(t, change.Event)
+			assert.Equal(t, int64(3), change.Event.ID)
+			assert.Equal(t, int64(7), change.Event.SensorID)
+			assert.False(t, change.CreatedAt.IsZero())
+			return nil
+		})
+
+		e := NewEvent(er, sr, WithEventOutbox(NewOutbox(or)))
+		require.NoError(t, e.ReceiveEvent(ctx, &domain.Event{SensorSerialNumber: "0123456789", Payload: 1}))
+	})
+
+	t.Run("err, event not saved without its change", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(&domain.Sensor{ID: 7, SerialNumber: "0123456789", Type: domain.SensorTypeADC}, nil)
+		sr.EXPECT().SaveSensorState(ctx, gomock.Any()).Return(nil)
+		er := NewMockEventRepository(ctrl)
+		er.EXPECT().SaveEvent(ctx, gomock.Any()).Return(nil)
+		or := NewMockOutboxRepository(ctrl)
+		or.EXPECT().SaveChange(ctx, gomock.Any()).Return(errors.New("some error"))
+
+		e := NewEvent(er, sr, WithEventOutbox(NewOutbox(or)))
+		event := &domain.Event{SensorSerialNumber: "0123456789", Payload: 1}
+		assert.Error(t, e.ReceiveEvent(ctx, event))
+		assert.Zero(t, event.ID)
+	})
+
+	t.Run("ok, registered sensor recorded", func(t *testing.T) {
+		ctx := context.Background()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, "0123456789").Return(nil, ErrSensorNotFound)
+		sr.EXPECT().SaveSensor(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, sensor *domain.Sensor) error {
+			sensor.ID = 7
+			return nil
+		})
+		or := NewMockOutboxRepository(ctrl)
+		or.EXPECT().SaveChange(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *domain.Change) error {
+			assert.Equal(t, domain.ChangeKindSensor, change.Kind)
+			require.NotNil(t, change.Sensor)
+			assert.Equal(t, int64(7), change.Sensor.ID)
+			return nil
+		})
+
+		s := NewSensor(sr, WithSensorOutbox(NewOutbox(or)))
+		_, err := s.RegisterSensor(ctx, &domain.Sensor{SerialNumber: "0123456789", Type: domain.SensorTypeADC})
+		require.NoError(t, err)
+	})
+}
+
+func Test_outbox_ListChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	or := NewMockOutboxRepository(ctrl)
+	or.EXPECT().ListChanges(ctx, int64(5), DefaultPageLimit).Return([]domain.Change{{Seq: 6}}, nil)
+
+	o := NewOutbox(or)
+	changes, err := o.ListChanges(ctx, 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Change{{Seq: 6}}, changes)
+
+	_, err = o.ListChanges(ctx, -1, 0)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = o.ListChanges(ctx, 0, MaxPageLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func Test_outbox_Relay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("ok, changes published in batches", func(t *testing.T) {
+		ctx := context.Background()
+
+		or := NewMockOutboxRepository(ctrl)
+		sink := NewMockChangeSink(ctrl)
+		released := false
+		gomock.InOrder(
+			or.EXPECT().LockRelay(ctx, "webhook").Return(func() { released = true }, true, nil),
+			or.EXPECT().GetRelayPosition(ctx, "webhook").Return(int64(4), nil),
+			or.EXPECT().ListChanges(ctx, int64(4), 2).Return([]domain.Change{{Seq: 5}, {Seq: 7}}, nil),
+			sink.EXPECT().Publish(ctx, []domain.Change{{Seq: 5}, {Seq: 7}}).Return(nil),
+			or.EXPECT().SaveRelayPosition(ctx, "webhook", int64(7)).Return(nil),
+			or.EXPECT().ListChanges(ctx, int64(7), 2).Return([]domain.Change{{Seq: 8}}, nil),
+			sink.EXPECT().Publish(ctx, []domain.Change{{Seq: 8}}).Return(nil),
+			or.EXPECT().SaveRelayPosition(ctx, "webhook", int64(8)).Return(nil),
+		)
+
+		o := NewOutbox(or, WithOutboxSink("webhook", sink), WithOutboxRelay(0, 2))
+		require.NoError(t, o.Relay(ctx))
+		assert.Equal(t, []OutboxSinkStats{{Name: "webhook", Published: 3}}, o.Stats())
+		assert.True(t, released)
+	})
+
+	t.Run("ok, sink relayed by another replica", func(t *testing.T) {
+		ctx := context.Background()
+
+		or := NewMockOutboxRepository(ctrl)
+		or.EXPECT().LockRelay(ctx, "webhook").Return(nil, false, nil)
+		sink := NewMockChangeSink(ctrl)
+		sink.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(0)
+
+		o := NewOutbox(or, WithOutboxSink("webhook", sink))
+		require.NoError(t, o.Relay(ctx))
+		assert.Equal(t, []OutboxSinkStats{{Name: "webhook"}}, o.Stats())
+	})
+
+	t.Run("err, failed sink keeps its position", func(t *testing.T) {
+		ctx := context.Background()
+
+		or := NewMockOutboxRepository(ctrl)
+		failing := NewMockChangeSink(ctrl)
+		sink := NewMockChangeSink(ctrl)
+		or.EXPECT().LockRelay(ctx, gomock.Any()).Return(func() {}, true, nil).Times(2)
+		or.EXPECT().GetRelayPosition(ctx, "webhook").Return(int64(0), nil)
+		or.EXPECT().GetRelayPosition(ctx, "file").Return(int64(0), nil)
+		or.EXPECT().ListChanges(ctx, int64(0), DefaultOutboxBatchSize).Return([]domain.Change{{Seq: 1}}, nil).Times(2)
+		failing.EXPECT().Publish(ctx, gomock.Any()).Return(errors.New("some error"))
+		sink.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+		or.EXPECT().SaveRelayPosition(ctx, "file", int64(1)).Return(nil)
+
+		o := NewOutbox(or, WithOutboxSink("webhook", failing), WithOutboxSink("file", sink))
+		assert.Error(t, o.Relay(ctx))
+		assert.Equal(t, []OutboxSinkStats{{Name: "webhook", Failed: 1}, {Name: "file", Published: 1}}, o.Stats())
+	})
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"homework/internal/domain"
+	"time"
+)
+
+const (
+	// DefaultPageLimit - размер страницы, если limit не задан
+	DefaultPageLimit = 100
+	// MaxPageLimit - максимальный размер страницы
+	MaxPageLimit = 1000
+)
+
+// SensorSortField - поле сортировки списка датчиков
+type SensorSortField string
+
+const (
+	SensorSortByID           SensorSortField = "id"
+	SensorSortBySerialNumber SensorSortField = "serial_number"
+	SensorSortByLastActivity SensorSortField = "last_activity"
+)
+
+// SensorFilter - фильтр списка датчиков, незаданные поля выборку не ограничивают
+type SensorFilter struct {
+	// Type - тип датчика
+	Type domain.SensorType
+	// IsActive - флаг активности датчика
+	IsActive *bool
+	// SerialNumberPrefix - префикс серийного номера
+	SerialNumberPrefix string
+	// LastActivityFrom - начало диапазона [from, to] времени последнего события
+	LastActivityFrom time.Time
+	// LastActivityTo - конец диапазона [from, to] времени последнего события
+	LastActivityTo time.Time
+}
+
+// SensorQuery - запрос страницы списка датчиков
+type SensorQuery struct {
+	Filter SensorFilter
+	// SortBy - поле сортировки, при равенстве значений датчики упорядочиваются по ID
+	SortBy SensorSortField
+	// Desc - сортировка по убыванию
+	Desc bool
+	// After - последний датчик предыдущей страницы (заданы ID и поле сортировки), nil - первая страница
+	After *domain.Sensor
+	// Limit - максимальное число датчиков на странице
+	Limit int
+}
+
+// EventQuery - запрос страницы событий датчика в диапазоне времени [Start, End]
+type EventQuery struct {
+	SensorID int64
+	Start    time.Time
+	End      time.Time
+	// Desc - сортировка по убыванию времени, при равенстве времени события упорядочиваются по ID
+	Desc bool
+	// After - последнее событие предыдущей страницы (заданы ID и Timestamp), nil - первая страница
+	After *domain.Event
+	// Limit - максимальное число событий на странице
+	Limit int
+}
+
+// EventExportQuery - запрос выгрузки событий нескольких датчиков в диапазоне времени [Start, End]
+type EventExportQuery struct {
+	// SensorIDs - датчики, события которых выгружаются, пустой список - все датчики
+	SensorIDs []int64
+	Start     time.Time
+	End       time.Time
+}
+
+// sensorCursor - содержимое курсора страницы датчиков
+type sensorCursor struct {
+	SortBy       SensorSortField `json:"s"`
+	Desc         bool            `json:"d,omitempty"`
+	ID           int64           `json:"id"`
+	SerialNumber string          `json:"sn,omitempty"`
+	LastActivity *time.Time      `json:"la,omitempty"`
+}
+
+// eventCursor - содержимое курсора страницы событий
+type eventCursor struct {
+	SensorID  int64     `json:"sid"`
+	Desc      bool      `json:"d,omitempty"`
+	ID        int64     `json:"id"`
+	Timestamp time.Time `json:"ts"`
+}
+
+func pageLimit(limit int) (int, error) {
+	switch {
+	case limit == 0:
+		return DefaultPageLimit, nil
+	case limit < 0 || limit > MaxPageLimit:
+		return 0, ErrInvalidLimit
+	}
+	return limit, nil
+}
+
+func encodeCursor(v any) string {
+	data, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func newSensorCursor(query SensorQuery, last domain.Sensor) string {
+	c := sensorCursor{SortBy: query.SortBy, Desc: query.Desc, ID: last.ID}
+	switch query.SortBy {
+	case SensorSortBySerialNumber:
+		c.SerialNumber = last.SerialNumber
+	case SensorSortByLastActivity:
+		c.LastActivity = &last.LastActivity
+	}
+	return encodeCursor(c)
+}
+
+// parseSensorCursor - восстанавливает последний датчик предыдущей страницы, курсор должен соответствовать сортировке query
+func parseSensorCursor(query SensorQuery, cursor string) (*domain.Sensor, error) {
+	var c sensorCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	if c.SortBy != query.SortBy || c.Desc != query.Desc {
+		return nil, ErrInvalidCursor
+	}
+	after := &domain.Sensor{ID: c.ID, SerialNumber: c.SerialNumber}
+	if query.SortBy == SensorSortByLastActivity {
+		if c.LastActivity == nil {
+			return nil, ErrInvalidCursor
+		}
+		after.LastActivity = *c.LastActivity
+	}
+	return after, nil
+}
+
+func newEventCursor(query EventQuery, last domain.Event) string {
+	return encodeCursor(eventCursor{SensorID: query.SensorID, Desc: query.Desc, ID: last.ID, Timestamp: last.Timestamp})
+}
+
+// parseEventCursor - восстанавливает последнее событие предыдущей страницы, курсор должен соответствовать датчику и сортировке query
+func parseEventCursor(query EventQuery, cursor string) (*domain.Event, error) {
+	var c eventCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	if c.SensorID != query.SensorID || c.Desc != query.Desc {
+		return nil, ErrInvalidCursor
+	}
+	return &domain.Event{ID: c.ID, SensorID: c.SensorID, Timestamp: c.Timestamp}, nil
+}
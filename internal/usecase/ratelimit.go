@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"homework/internal/domain"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitScope - по какому признаку запросы делятся на корзины ограничителя
+type RateLimitScope string
+
+const (
+	// RateLimitScopeSensor - события одного датчика, ключ - серийный номер
+	RateLimitScopeSensor RateLimitScope = "sensor"
+	// RateLimitScopeToken - запросы с одним токеном API из заголовка Authorization
+	RateLimitScopeToken RateLimitScope = "token"
+	// RateLimitScopeIP - запросы с одного адреса клиента
+	RateLimitScopeIP RateLimitScope = "ip"
+)
+
+// RateLimitScopes - все области ограничения в порядке вывода метрик
+var RateLimitScopes = []RateLimitScope{RateLimitScopeSensor, RateLimitScopeToken, RateLimitScopeIP}
+
+// RateLimitError - запрос отклонён ограничителем, errors.Is(err, ErrRateLimited)
+type RateLimitError struct {
+	Scope RateLimitScope
+	// RetryAfter - через сколько в корзине появится токен
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v: %s limit, retry after %s", ErrRateLimited, e.Scope, e.RetryAfter.Round(time.Millisecond))
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// RateLimiter - ограничение частоты запросов корзинами токенов из RateLimitRepository, своё для каждой области
+type RateLimiter struct {
+	rateLimitRepository RateLimitRepository
+	fallback            RateLimitRepository
+	unavailable         func(error) bool
+	limits              map[RateLimitScope]domain.RateLimit
+	rejected            map[RateLimitScope]*atomic.Uint64
+}
+
+func NewRateLimiter(rr RateLimitRepository, options ...func(*RateLimiter)) *RateLimiter {
+	l := &RateLimiter{
+		rateLimitRepository: rr,
+		limits:              map[RateLimitScope]domain.RateLimit{},
+		rejected:            map[RateLimitScope]*atomic.Uint64{},
+	}
+	for _, scope := range RateLimitScopes {
+		l.rejected[scope] = new(atomic.Uint64)
+	}
+	for _, o := range options {
+		o(l)
+	}
+	return l
+}
+
+// WithRateLimit - ограничение для области scope, без него запросы области не ограничиваются
+func WithRateLimit(scope RateLimitScope, limit domain.RateLimit) func(*RateLimiter) {
+	return func(l *RateLimiter) {
+		if limit.Enabled() {
+			l.limits[scope] = limit
+		}
+	}
+}
+
+// WithRateLimitFallback - корзины в fallback, пока основное хранилище недоступно: unavailable определяет такие ошибки.
+// Без него ошибка хранилища возвращается из Allow и запрос отклоняется
+func WithRateLimitFallback(fallback RateLimitRepository, unavailable func(error) bool) func(*RateLimiter) {
+	return func(l *RateLimiter) {
+		l.fallback = fallback
+		l.unavailable = unavailable
+	}
+}
+
+// WithEventRateLimiter - события датчика отклоняются, если он превысил ограничение RateLimitScopeSensor
+func WithEventRateLimiter(l *RateLimiter) func(*Event) {
+	return func(e *Event) {
+		e.rateLimiter = l
+	}
+}
+
+// Allow - функция получения токена из корзины key области scope.
+// Если токена нет, возвращается *RateLimitError со временем до его появления
+func (l *RateLimiter) Allow(ctx context.Context, scope RateLimitScope, key string) error {
+	limit, ok := l.limits[scope]
+	if !ok {
+		return nil
+	}
+	key = string(scope) + ":" + key
+	retryAfter, err := l.rateLimitRepository.TakeToken(ctx, key, limit)
+	if err != nil && l.fallback != nil && l.unavailable(err) {
+		// общие корзины недоступны: реплика ограничивает запросы сама, не отказывая всем клиентам
+		log.Printf("rate limit storage unavailable, using local buckets: %v", err)
+		retryAfter, err = l.fallback.TakeToken(ctx, key, limit)
+	}
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		l.rejected[scope].Add(1)
+		return &RateLimitError{Scope: scope, RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Rejected - число отклонённых запросов по областям с момента запуска
+func (l *RateLimiter) Rejected() map[RateLimitScope]uint64 {
+	rejected := make(map[RateLimitScope]uint64, len(l.rejected))
+	for scope, counter := range l.rejected {
+		rejected[scope] = counter.Load()
+	}
+	return rejected
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rateLimiter_Allow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := domain.RateLimit{Rate: 1, Burst: 5}
+
+	t.Run("ok, token taken", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(ctx, "ip:10.0.0.1", limit).Return(time.Duration(0), nil)
+
+		l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeIP, limit))
+		assert.NoError(t, l.Allow(ctx, RateLimitScopeIP, "10.0.0.1"))
+		assert.Zero(t, l.Rejected()[RateLimitScopeIP])
+	})
+
+	t.Run("fail, no tokens", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(ctx, "sensor:0123456789", limit).Return(1500*time.Millisecond, nil).Times(2)
+
+		l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeSensor, limit))
+		err := l.Allow(ctx, RateLimitScopeSensor, "0123456789")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		var rateLimitErr *RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.Equal(t, RateLimitScopeSensor, rateLimitErr.Scope)
+		assert.Equal(t, 1500*time.Millisecond, rateLimitErr.RetryAfter)
+
+		assert.Error(t, l.Allow(ctx, RateLimitScopeSensor, "0123456789"))
+		assert.Equal(t, map[RateLimitScope]uint64{RateLimitScopeSensor: 2, RateLimitScopeToken: 0, RateLimitScopeIP: 0}, l.Rejected())
+	})
+
+	t.Run("ok, scope without limit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeIP, limit), WithRateLimit(RateLimitScopeToken, domain.RateLimit{}))
+		assert.NoError(t, l.Allow(ctx, RateLimitScopeToken, "token"))
+		assert.NoError(t, l.Allow(ctx, RateLimitScopeSensor, "0123456789"))
+	})
+
+	t.Run("fail, repository error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expectedError := errors.New("some error")
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(ctx, gomock.Any(), limit).Return(time.Duration(0), expectedError)
+
+		l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeToken, limit))
+		assert.ErrorIs(t, l.Allow(ctx, RateLimitScopeToken, "token"), expectedError)
+		assert.Zero(t, l.Rejected()[RateLimitScopeToken])
+	})
+
+	t.Run("ok, fallback while storage unavailable", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		unavailableError := errors.New("connection refused")
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(ctx, "sensor:0123456789", limit).Return(time.Duration(0), unavailableError).Times(2)
+		fallback := NewMockRateLimitRepository(ctrl)
+		gomock.InOrder(
+			fallback.EXPECT().TakeToken(ctx, "sensor:0123456789", limit).Return(time.Duration(0), nil),
+			fallback.EXPECT().TakeToken(ctx, "sensor:0123456789", limit).Return(time.Second, nil),
+		)
+
+		l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeSensor, limit),
+			WithRateLimitFallback(fallback, func(err error) bool { return errors.Is(err, unavailableError) }))
+		assert.NoError(t, l.Allow(ctx, RateLimitScopeSensor, "0123456789"))
+		assert.ErrorIs(t, l.Allow(ctx, RateLimitScopeSensor, "0123456789"), ErrRateLimited)
+		assert.Equal(t, uint64(1), l.Rejected()[RateLimitScopeSensor])
+	})
+
+	t.Run("fail, fallback skips other errors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expectedError := errors.New("some error")
+		rr := NewMockRateLimitRepository(ctrl)
+		rr.EXPECT().TakeToken(ctx, gomock.Any(), limit).Return(time.Duration(0), expectedError)
+		fallback := NewMockRateLimitRepository(ctrl)
+		fallback.EXPECT().TakeToken(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeToken, limit),
+			WithRateLimitFallback(fallback, func(error) bool { return false }))
+		assert.ErrorIs(t, l.Allow(ctx, RateLimitScopeToken, "token"), expectedError)
+	})
+}
+
+func Test_event_ReceiveEvent_rateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rr := NewMockRateLimitRepository(ctrl)
+	rr.EXPECT().TakeToken(ctx, "sensor:0123456789", gomock.Any()).Return(time.Second, nil)
+	er := NewMockEventRepository(ctrl)
+	er.EXPECT().SaveEvent(gomock.Any(), gomock.Any()).Times(0)
+	sr := NewMockSensorRepository(ctrl)
+	sr.EXPECT().GetSensorBySerialNumber(gomock.Any(), gomock.Any()).Times(0)
+
+	l := NewRateLimiter(rr, WithRateLimit(RateLimitScopeSensor, domain.RateLimit{Rate: 1, Burst: 1}))
+	e := NewEvent(er, sr, WithEventRateLimiter(l))
+	err := e.ReceiveEvent(ctx, &domain.Event{Timestamp: time.Now(), SensorSerialNumber: "0123456789", Payload: 1})
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
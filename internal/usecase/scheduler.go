@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"homework/internal/domain"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSchedulePollInterval - как часто планировщик проверяет, не пора ли выполнить расписания
+	DefaultSchedulePollInterval = 10 * time.Second
+	// DefaultActionTimeout - время на выполнение действия одного срабатывания
+	DefaultActionTimeout = 10 * time.Second
+	// dueBatchSize - сколько подошедших расписаний выполняется за одну проверку, остальные ждут следующей
+	dueBatchSize = 100
+)
+
+// ScheduleWebhook - тело запроса webhook действия расписания
+type ScheduleWebhook struct {
+	ScheduleID  int64     `json:"schedule_id"`
+	Name        string    `json:"name"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+type Scheduler struct {
+	scheduleRepository    ScheduleRepository
+	userRepository        UserRepository
+	sensorOwnerRepository SensorOwnerRepository
+	commands              *Command
+	sensors               *Sensor
+	webhook               WebhookCaller
+	pollInterval          time.Duration
+	actionTimeout         time.Duration
+	now                   func() time.Time
+}
+
+func NewScheduler(sr ScheduleRepository, ur UserRepository, sor SensorOwnerRepository, options ...func(*Scheduler)) *Scheduler {
+	s := &Scheduler{
+		scheduleRepository:    sr,
+		userRepository:        ur,
+		sensorOwnerRepository: sor,
+		pollInterval:          DefaultSchedulePollInterval,
+		actionTimeout:         DefaultActionTimeout,
+		now:                   time.Now,
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+// WithSchedulerCommands - расписания с действием command отправляют команды через commands
+func WithSchedulerCommands(commands *Command) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.commands = commands
+	}
+}
+
+// WithSchedulerSensors - расписания с действием alert_rule меняют правила тревоги через sensors
+func WithSchedulerSensors(sensors *Sensor) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.sensors = sensors
+	}
+}
+
+// WithSchedulerWebhook - расписания с действием webhook отправляют запросы через caller
+func WithSchedulerWebhook(caller WebhookCaller) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.webhook = caller
+	}
+}
+
+// WithSchedulePollInterval - как часто планировщик проверяет, не пора ли выполнить расписания
+func WithSchedulePollInterval(interval time.Duration) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.pollInterval = interval
+	}
+}
+
+// WithActionTimeout - время на выполнение действия одного срабатывания
+func WithActionTimeout(timeout time.Duration) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.actionTimeout = timeout
+	}
+}
+
+// WithSchedulerClock - источник текущего времени для вычисления и выполнения срабатываний
+func WithSchedulerClock(now func() time.Time) func(*Scheduler) {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
+
+// CreateSchedule - функция создания расписания пользователя schedule.UserID.
+// Датчик действия должен быть привязан к пользователю, иначе ErrSensorNotFound
+func (s *Scheduler) CreateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	if err := s.validateSchedule(schedule); err != nil {
+		return err
+	}
+	if _, err := s.userRepository.GetUserByID(ctx, schedule.UserID); err != nil {
+		return err
+	}
+	if err := s.validateAction(ctx, schedule.UserID, schedule.Action); err != nil {
+		return err
+	}
+	now := s.timestamp()
+	schedule.CreatedAt = now
+	if schedule.At != nil {
+		at := schedule.At.Truncate(time.Microsecond).UTC()
+		schedule.At = &at
+	}
+	if err := s.planNextRun(schedule, now); err != nil {
+		return err
+	}
+	return s.scheduleRepository.SaveSchedule(ctx, schedule)
+}
+
+// GetUserSchedules - функция получения расписаний пользователя
+func (s *Scheduler) GetUserSchedules(ctx context.Context, userID int64) ([]domain.Schedule, error) {
+	if _, err := s.userRepository.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	schedules, err := s.scheduleRepository.GetSchedulesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if schedules == nil {
+		schedules = []domain.Schedule{}
+	}
+	return schedules, nil
+}
+
+// GetSchedule - функция получения расписания пользователя
+func (s *Scheduler) GetSchedule(ctx context.Context, userID, scheduleID int64) (*domain.Schedule, error) {
+	return s.userSchedule(ctx, userID, scheduleID)
+}
+
+// SetScheduleEnabled - функция включения и выключения расписания пользователя.
+// Включённое расписание срабатывает в ближайшее подходящее время, пропущенные за время выключения срабатывания не выполняются
+func (s *Scheduler) SetScheduleEnabled(ctx context.Context, userID, scheduleID int64, enabled bool) (*domain.Schedule, error) {
+	schedule, err := s.userSchedule(ctx, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Enabled = enabled
+	if err = s.planNextRun(schedule, s.timestamp()); err != nil {
+		return nil, err
+	}
+	if err = s.scheduleRepository.SaveSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// DeleteSchedule - функция удаления расписания пользователя вместе с историей срабатываний
+func (s *Scheduler) DeleteSchedule(ctx context.Context, userID, scheduleID int64) error {
+	if _, err := s.userSchedule(ctx, userID, scheduleID); err != nil {
+		return err
+	}
+	return s.scheduleRepository.DeleteSchedule(ctx, scheduleID)
+}
+
+// GetScheduleRuns - функция получения истории срабатываний расписания пользователя от новых к старым
+func (s *Scheduler) GetScheduleRuns(ctx context.Context, userID, scheduleID int64, limit int) ([]domain.ScheduleRun, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.userSchedule(ctx, userID, scheduleID); err != nil {
+		return nil, err
+	}
+	return s.scheduleRepository.ListRuns(ctx, scheduleID, limit)
+}
+
+// Run - выполняет подошедшие расписания, пока не отменён ctx
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		// ошибка хранилища повторится на следующей проверке, а подошедшие расписания никуда не денутся
+		_, _ = s.RunDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue - функция выполнения расписаний, время срабатывания которых наступило. Возвращает число выполненных срабатываний.
+// Срабатывание сначала забирается переносом расписания на следующее время, поэтому при нескольких экземплярах сервиса
+// оно выполняется не больше одного раза. Если сервис был остановлен, пропущенные срабатывания выполняются одним
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	now := s.timestamp()
+	due, err := s.scheduleRepository.DueSchedules(ctx, now, dueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	ran := 0
+	var errs []error
+	for _, schedule := range due {
+		scheduledAt := *schedule.NextRunAt
+		next, err := schedule.NextRun(now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+			continue
+		}
+		claimed, err := s.scheduleRepository.AdvanceSchedule(ctx, schedule.ID, scheduledAt, next)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err = s.fire(ctx, schedule, scheduledAt); err != nil {
+			errs = append(errs, err)
+		}
+		ran++
+	}
+	return ran, errors.Join(errs...)
+}
+
+// fire - выполняет действие расписания и записывает итог в историю срабатываний
+func (s *Scheduler) fire(ctx context.Context, schedule domain.Schedule, scheduledAt time.Time) error {
+	run := &domain.ScheduleRun{
+		ScheduleID:  schedule.ID,
+		ScheduledAt: scheduledAt,
+		StartedAt:   s.timestamp(),
+		Status:      domain.ScheduleRunSucceeded,
+	}
+	if err := s.execute(ctx, schedule, scheduledAt); err != nil {
+		run.Status, run.Error = domain.ScheduleRunFailed, err.Error()
+	}
+	return s.scheduleRepository.SaveRun(ctx, run)
+}
+
+func (s *Scheduler) execute(ctx context.Context, schedule domain.Schedule, scheduledAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, s.actionTimeout)
+	defer cancel()
+	action := schedule.Action
+	switch action.Kind {
+	case domain.ScheduleActionCommand:
+		if s.commands == nil {
+			return fmt.Errorf("no executor for %s actions", action.Kind)
+		}
+		_, err := s.commands.SendCommand(ctx, action.SensorID, *action.Payload)
+		return err
+	case domain.ScheduleActionWebhook:
+		if s.webhook == nil {
+			return fmt.Errorf("no executor for %s actions", action.Kind)
+		}
+		return s.webhook.Call(ctx, action.URL, ScheduleWebhook{
+			ScheduleID:  schedule.ID,
+			Name:        schedule.Name,
+			ScheduledAt: scheduledAt,
+		})
+	case domain.ScheduleActionAlertRule:
+		if s.sensors == nil {
+			return fmt.Errorf("no executor for %s actions", action.Kind)
+		}
+		_, err := s.sensors.SetAlertRule(ctx, action.SensorID, action.AlertRule, nil)
+		return err
+	default:
+		return fmt.Errorf("unknown action %q", action.Kind)
+	}
+}
+
+// planNextRun - задаёт время следующего срабатывания после now, у выключенного расписания его нет
+func (s *Scheduler) planNextRun(schedule *domain.Schedule, now time.Time) error {
+	if !schedule.Enabled {
+		schedule.NextRunAt = nil
+		return nil
+	}
+	next, err := schedule.NextRun(now)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	schedule.NextRunAt = next
+	return nil
+}
+
+// userSchedule - расписание пользователя, расписание другого пользователя считается ненайденным
+func (s *Scheduler) userSchedule(ctx context.Context, userID, scheduleID int64) (*domain.Schedule, error) {
+	if _, err := s.userRepository.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	schedule, err := s.scheduleRepository.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.UserID != userID {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+func (s *Scheduler) validateSchedule(schedule *domain.Schedule) error {
+	if strings.TrimSpace(schedule.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSchedule)
+	}
+	if (schedule.Cron == "") == (schedule.At == nil) {
+		return fmt.Errorf("%w: exactly one of cron and at is required", ErrInvalidSchedule)
+	}
+	if schedule.Cron != "" {
+		if _, err := domain.ParseCron(schedule.Cron); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+	}
+	if _, err := time.LoadLocation(schedule.Location); err != nil {
+		return fmt.Errorf("%w: unknown location %q", ErrInvalidSchedule, schedule.Location)
+	}
+
+	action := schedule.Action
+	switch action.Kind {
+	case domain.ScheduleActionCommand:
+		if s.commands == nil || action.Payload == nil {
+			return fmt.Errorf("%w: command action requires payload", ErrInvalidSchedule)
+		}
+	case domain.ScheduleActionWebhook:
+		u, err := url.Parse(action.URL)
+		if s.webhook == nil || err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: webhook action requires http or https url", ErrInvalidSchedule)
+		}
+	case domain.ScheduleActionAlertRule:
+		if s.sensors == nil {
+			return fmt.Errorf("%w: alert rule actions are not supported", ErrInvalidSchedule)
+		}
+		if err := validateAlertRule(action.AlertRule); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidSchedule, action.Kind)
+	}
+	return nil
+}
+
+// validateAction - проверяет, что датчик действия привязан к пользователю и команда допустима для устройства
+func (s *Scheduler) validateAction(ctx context.Context, userID int64, action domain.ScheduleAction) error {
+	if action.Kind == domain.ScheduleActionWebhook {
+		return nil
+	}
+	owners, err := s.sensorOwnerRepository.GetSensorsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(owners, func(o domain.SensorOwner) bool { return o.SensorID == action.SensorID }) {
+		return ErrSensorNotFound
+	}
+	if action.Kind == domain.ScheduleActionCommand {
+		return s.commands.checkPayload(ctx, action.SensorID, *action.Payload)
+	}
+	return nil
+}
+
+// timestamp - текущее время с точностью, которую сохраняют все хранилища
+func (s *Scheduler) timestamp() time.Time {
+	return s.now().Truncate(time.Microsecond).UTC()
+}
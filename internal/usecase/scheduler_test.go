@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"homework/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scheduleOwner - пользователь 1, которому привязаны реле 1 и датчик 2
+func scheduleOwner(ctrl *gomock.Controller) (*MockUserRepository, *MockSensorOwnerRepository) {
+	ur := NewMockUserRepository(ctrl)
+	ur.EXPECT().GetUserByID(gomock.Any(), int64(1)).AnyTimes().Return(&domain.User{ID: 1, Name: "owner"}, nil)
+	ur.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, ErrUserNotFound)
+	sor := NewMockSensorOwnerRepository(ctrl)
+	sor.EXPECT().GetSensorsByUserID(gomock.Any(), int64(1)).AnyTimes().Return([]domain.SensorOwner{{UserID: 1, SensorID: 1}, {UserID: 1, SensorID: 2}}, nil)
+	return ur, sor
+}
+
+func Test_scheduler_CreateSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	// 2024-06-07 - пятница
+	now := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+	clock := WithSchedulerClock(func() time.Time { return now })
+	ur, sor := scheduleOwner(ctrl)
+	sr := actuator(ctrl)
+	commands := NewCommand(NewMockCommandRepository(ctrl), sr)
+	night := 0.0
+
+	t.Run("ok, next run in schedule location", func(t *testing.T) {
+		scheduleRepository := NewMockScheduleRepository(ctrl)
+		scheduleRepository.EXPECT().SaveSchedule(ctx, gomock.Any()).Times(1).Return(nil)
+		s := NewScheduler(scheduleRepository, ur, sor, clock, WithSchedulerCommands(commands))
+
+		schedule := &domain.Schedule{
+			UserID:   1,
+			Name:     "heating night mode",
+			Cron:     "0 23 * * 1-5",
+			Location: "Europe/Moscow",
+			Enabled:  true,
+			Action:   domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night},
+		}
+		require.NoError(t, s.CreateSchedule(ctx, schedule))
+		assert.Equal(t, now, schedule.CreatedAt)
+		require.NotNil(t, schedule.NextRunAt)
+		assert.Equal(t, time.Date(2024, 6, 7, 20, 0, 0, 0, time.UTC), *schedule.NextRunAt)
+	})
+
+	t.Run("ok, disabled schedule has no next run", func(t *testing.T) {
+		scheduleRepository := NewMockScheduleRepository(ctrl)
+		scheduleRepository.EXPECT().SaveSchedule(ctx, gomock.Any()).Times(1).Return(nil)
+		s := NewScheduler(scheduleRepository, ur, sor, clock, WithSchedulerWebhook(NewMockWebhookCaller(ctrl)))
+
+		schedule := &domain.Schedule{
+			UserID: 1,
+			Name:   "report",
+			Cron:   "0 9 * * *",
+			Action: domain.ScheduleAction{Kind: domain.ScheduleActionWebhook, URL: "https://example.com/hook"},
+		}
+		require.NoError(t, s.CreateSchedule(ctx, schedule))
+		assert.Nil(t, schedule.NextRunAt)
+	})
+
+	at := now.Add(time.Hour)
+	tests := []struct {
+		name     string
+		schedule domain.Schedule
+		wantErr  error
+	}{
+		{"fail, no name", domain.Schedule{UserID: 1, Cron: "* * * * *", Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night}}, ErrInvalidSchedule},
+		{"fail, both cron and at", domain.Schedule{UserID: 1, Name: "x", Cron: "* * * * *", At: &at, Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night}}, ErrInvalidSchedule},
+		{"fail, neither cron nor at", domain.Schedule{UserID: 1, Name: "x", Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night}}, ErrInvalidSchedule},
+		{"fail, invalid cron", domain.Schedule{UserID: 1, Name: "x", Cron: "0 25 * * *", Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night}}, ErrInvalidSchedule},
+		{"fail, unknown location", domain.Schedule{UserID: 1, Name: "x", At: &at, Location: "Mars/Olympus", Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night}}, ErrInvalidSchedule},
+		{"fail, command without payload", domain.Schedule{UserID: 1, Name: "x", At: &at, Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1}}, ErrInvalidSchedule},
+		{"fail, webhook without executor", domain.Schedule{UserID: 1, Name: "x", At: &at, Action: domain.ScheduleAction{Kind: domain.ScheduleActionWebhook, URL: "https://example.com"}}, ErrInvalidSchedule},
+		{"fail, unknown action", domain.Schedule{UserID: 1, Name: "x", At: &at, Action: domain.ScheduleAction{Kind: "reboot"}}, ErrInvalidSchedule},
+		{"fail, user not found", domain.Schedule{UserID: 5, Name: "x", At: &at, Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night}}, ErrUserNotFound},
+		{"fail, sensor of other user", domain.Schedule{UserID: 1, Name: "x", At: &at, Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 3, Payload: &night}}, ErrSensorNotFound},
+		{"fail, not actuator", domain.Schedule{UserID: 1, Name: "x", At: &at, Action: domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 2, Payload: &night}}, ErrNotActuator},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(NewMockScheduleRepository(ctrl), ur, sor, clock, WithSchedulerCommands(commands))
+			assert.ErrorIs(t, s.CreateSchedule(ctx, &tt.schedule), tt.wantErr)
+		})
+	}
+}
+
+func Test_scheduler_SetScheduleEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	now := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+	ur, sor := scheduleOwner(ctrl)
+
+	scheduleRepository := NewMockScheduleRepository(ctrl)
+	scheduleRepository.EXPECT().GetScheduleByID(ctx, int64(3)).AnyTimes().DoAndReturn(func(context.Context, int64) (*domain.Schedule, error) {
+		return &domain.Schedule{ID: 3, UserID: 1, Cron: "0 23 * * *"}, nil
+	})
+	scheduleRepository.EXPECT().GetScheduleByID(ctx, int64(4)).AnyTimes().Return(&domain.Schedule{ID: 4, UserID: 2}, nil)
+	scheduleRepository.EXPECT().SaveSchedule(ctx, gomock.Any()).Times(2).Return(nil)
+	s := NewScheduler(scheduleRepository, ur, sor, WithSchedulerClock(func() time.Time { return now }))
+
+	schedule, err := s.SetScheduleEnabled(ctx, 1, 3, true)
+	require.NoError(t, err)
+	require.NotNil(t, schedule.NextRunAt)
+	assert.Equal(t, time.Date(2024, 6, 7, 23, 0, 0, 0, time.UTC), *schedule.NextRunAt)
+
+	schedule, err = s.SetScheduleEnabled(ctx, 1, 3, false)
+	require.NoError(t, err)
+	assert.Nil(t, schedule.NextRunAt)
+
+	_, err = s.SetScheduleEnabled(ctx, 1, 4, true)
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func Test_scheduler_RunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	scheduledAt := time.Date(2024, 6, 7, 20, 0, 0, 0, time.UTC)
+	now := scheduledAt.Add(5 * time.Second)
+	clock := WithSchedulerClock(func() time.Time { return now })
+	ur, sor := scheduleOwner(ctrl)
+	night := 0.0
+	nextNight := time.Date(2024, 6, 10, 20, 0, 0, 0, time.UTC)
+
+	nightMode := domain.Schedule{
+		ID:        3,
+		UserID:    1,
+		Name:      "heating night mode",
+		Cron:      "0 23 * * 1-5",
+		Location:  "Europe/Moscow",
+		Enabled:   true,
+		Action:    domain.ScheduleAction{Kind: domain.ScheduleActionCommand, SensorID: 1, Payload: &night},
+		NextRunAt: &scheduledAt,
+	}
+
+	t.Run("ok, command sent and schedule advanced", func(t *testing.T) {
+		cr := NewMockCommandRepository(ctrl)
+		cr.EXPECT().SaveCommand(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, command *domain.Command) error {
+			assert.Equal(t, int64(1), command.SensorID)
+			assert.Equal(t, night, command.Payload)
+			return nil
+		})
+		scheduleRepository := NewMockScheduleRepository(ctrl)
+		gomock.InOrder(
+			scheduleRepository.EXPECT().DueSchedules(ctx, now, dueBatchSize).Times(1).Return([]domain.Schedule{nightMode}, nil),
+			scheduleRepository.EXPECT().AdvanceSchedule(ctx, int64(3), scheduledAt, &nextNight).Times(1).Return(true, nil),
+			scheduleRepository.EXPECT().SaveRun(ctx, &domain.ScheduleRun{
+				ScheduleID:  3,
+				ScheduledAt: scheduledAt,
+				StartedAt:   now,
+				Status:      domain.ScheduleRunSucceeded,
+			}).Times(1).Return(nil),
+		)
+		s := NewScheduler(scheduleRepository, ur, sor, clock, WithSchedulerCommands(NewCommand(cr, actuator(ctrl))))
+
+		ran, err := s.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ran)
+	})
+
+	t.Run("ok, run claimed by other instance is skipped", func(t *testing.T) {
+		scheduleRepository := NewMockScheduleRepository(ctrl)
+		scheduleRepository.EXPECT().DueSchedules(ctx, now, dueBatchSize).Times(1).Return([]domain.Schedule{nightMode}, nil)
+		scheduleRepository.EXPECT().AdvanceSchedule(ctx, int64(3), scheduledAt, &nextNight).Times(1).Return(false, nil)
+		scheduleRepository.EXPECT().SaveRun(gomock.Any(), gomock.Any()).Times(0)
+		s := NewScheduler(scheduleRepository, ur, sor, clock, WithSchedulerCommands(NewCommand(NewMockCommandRepository(ctrl), actuator(ctrl))))
+
+		ran, err := s.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, ran)
+	})
+
+	t.Run("ok, one-shot webhook failure recorded", func(t *testing.T) {
+		once := domain.Schedule{
+			ID:        4,
+			UserID:    1,
+			Name:      "vacation over",
+			At:        &scheduledAt,
+			Enabled:   true,
+			Action:    domain.ScheduleAction{Kind: domain.ScheduleActionWebhook, URL: "https://example.com/hook"},
+			NextRunAt: &scheduledAt,
+		}
+		webhook := NewMockWebhookCaller(ctrl)
+		webhook.EXPECT().Call(gomock.Any(), "https://example.com/hook", ScheduleWebhook{ScheduleID: 4, Name: "vacation over", ScheduledAt: scheduledAt}).
+			Times(1).Return(errors.New("webhook responded with 503 Service Unavailable"))
+		scheduleRepository := NewMockScheduleRepository(ctrl)
+		scheduleRepository.EXPECT().DueSchedules(ctx, now, dueBatchSize).Times(1).Return([]domain.Schedule{once}, nil)
+		scheduleRepository.EXPECT().AdvanceSchedule(ctx, int64(4), scheduledAt, nil).Times(1).Return(true, nil)
+		scheduleRepository.EXPECT().SaveRun(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, run *domain.ScheduleRun) error {
+			assert.Equal(t, domain.ScheduleRunFailed, run.Status)
+			assert.Equal(t, "webhook responded with 503 Service Unavailable", run.Error)
+			return nil
+		})
+		s := NewScheduler(scheduleRepository, ur, sor, clock, WithSchedulerWebhook(webhook))
+
+		ran, err := s.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ran)
+	})
+
+	t.Run("ok, alert rule armed", func(t *testing.T) {
+		rule := &domain.AlertRule{Max: &night, Severity: domain.AlertSeverityCritical}
+		arm := domain.Schedule{
+			ID:        5,
+			UserID:    1,
+			Name:      "arm door",
+			At:        &scheduledAt,
+			Enabled:   true,
+			Action:    domain.ScheduleAction{Kind: domain.ScheduleActionAlertRule, SensorID: 2, AlertRule: rule},
+			NextRunAt: &scheduledAt,
+		}
+		sr := actuator(ctrl)
+		sr.EXPECT().LockSensorByID(gomock.Any(), int64(2)).Times(1).Return(&domain.Sensor{ID: 2, Type: domain.SensorTypeContactClosure}, nil)
+		sr.EXPECT().SaveSensor(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, sensor *domain.Sensor) error {
+			assert.Equal(t, rule, sensor.AlertRule)
+			return nil
+		})
+		scheduleRepository := NewMockScheduleRepository(ctrl)
+		scheduleRepository.EXPECT().DueSchedules(ctx, now, dueBatchSize).Times(1).Return([]domain.Schedule{arm}, nil)
+		scheduleRepository.EXPECT().AdvanceSchedule(ctx, int64(5), scheduledAt, nil).Times(1).Return(true, nil)
+		scheduleRepository.EXPECT().SaveRun(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, run *domain.ScheduleRun) error {
+			assert.Equal(t, domain.ScheduleRunSucceeded, run.Status)
+			return nil
+		})
+		s := NewScheduler(scheduleRepository, ur, sor, clock, WithSchedulerSensors(NewSensor(sr)))
+
+		ran, err := s.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ran)
+	})
+}
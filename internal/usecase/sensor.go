@@ -4,22 +4,43 @@ import (
 	"context"
 	"errors"
 	"homework/internal/domain"
+	"math"
 )
 
-type void struct{}
-
 type Sensor struct {
 	sensorRepository SensorRepository
-	sensorTypes      map[domain.SensorType]void
+	sensorTypes      *SensorTypeRegistry
+	transactor       Transactor
+	outbox           *Outbox
 }
 
-func NewSensor(sr SensorRepository) *Sensor {
-	return &Sensor{
+// SensorCondition - условие на текущее состояние датчика, при котором его можно изменить.
+// nil - изменение без условия
+type SensorCondition func(sensor domain.Sensor) bool
+
+func NewSensor(sr SensorRepository, options ...func(*Sensor)) *Sensor {
+	s := &Sensor{
 		sensorRepository: sr,
-		sensorTypes: map[domain.SensorType]void{
-			domain.SensorTypeContactClosure: {},
-			domain.SensorTypeADC:            {},
-		},
+		sensorTypes:      DefaultSensorTypeRegistry(),
+		transactor:       noTransactor{},
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
+}
+
+// WithSensorTypes - реестр типов датчиков, допустимых при регистрации
+func WithSensorTypes(registry *SensorTypeRegistry) func(*Sensor) {
+	return func(s *Sensor) {
+		s.sensorTypes = registry
+	}
+}
+
+// WithSensorTransactor - проверка условия на датчик и его изменение выполняются в одной транзакции
+func WithSensorTransactor(t Transactor) func(*Sensor) {
+	return func(s *Sensor) {
+		s.transactor = t
 	}
 }
 
@@ -27,14 +48,19 @@ func (s *Sensor) RegisterSensor(ctx context.Context, sensor *domain.Sensor) (*do
 	if len(sensor.SerialNumber) != 10 {
 		return nil, ErrWrongSensorSerialNumber
 	}
-	if _, ok := s.sensorTypes[sensor.Type]; !ok {
+	if _, ok := s.sensorTypes.Lookup(sensor.Type); !ok {
 		return nil, ErrWrongSensorType
 	}
 
 	sensorBySerialNumber, err := s.sensorRepository.GetSensorBySerialNumber(ctx, sensor.SerialNumber)
 	if err != nil {
 		if errors.Is(err, ErrSensorNotFound) {
-			err := s.sensorRepository.SaveSensor(ctx, sensor)
+			err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := s.sensorRepository.SaveSensor(ctx, sensor); err != nil {
+					return err
+				}
+				return s.recordSensor(ctx, *sensor)
+			})
 			if err != nil {
 				return nil, err
 			}
@@ -49,6 +75,43 @@ func (s *Sensor) GetSensors(ctx context.Context) ([]domain.Sensor, error) {
 	return s.sensorRepository.GetSensors(ctx)
 }
 
+// ListSensors - функция получения страницы списка датчиков.
+// cursor - курсор из предыдущего вызова (пустой для первой страницы), возвращается курсор следующей страницы или пустая строка
+func (s *Sensor) ListSensors(ctx context.Context, query SensorQuery, cursor string) ([]domain.Sensor, string, error) {
+	switch query.SortBy {
+	case "":
+		query.SortBy = SensorSortByID
+	case SensorSortByID, SensorSortBySerialNumber, SensorSortByLastActivity:
+	default:
+		return nil, "", ErrInvalidSort
+	}
+	filter := query.Filter
+	if !filter.LastActivityFrom.IsZero() && !filter.LastActivityTo.IsZero() && filter.LastActivityFrom.After(filter.LastActivityTo) {
+		return nil, "", ErrInputDate
+	}
+	limit, err := pageLimit(query.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if cursor != "" {
+		if query.After, err = parseSensorCursor(query, cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// запрашивается на один датчик больше, чтобы узнать, есть ли следующая страница
+	query.Limit = limit + 1
+	sensors, err := s.sensorRepository.ListSensors(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(sensors) <= limit {
+		return sensors, "", nil
+	}
+	sensors = sensors[:limit]
+	return sensors, newSensorCursor(query, sensors[limit-1]), nil
+}
+
 func (s *Sensor) GetSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
 	sensor, err := s.sensorRepository.GetSensorByID(ctx, id)
 	if err != nil {
@@ -56,3 +119,105 @@ func (s *Sensor) GetSensorByID(ctx context.Context, id int64) (*domain.Sensor, e
 	}
 	return sensor, nil
 }
+
+// GetSensorTypes - функция получения списка поддерживаемых типов датчиков
+func (s *Sensor) GetSensorTypes(ctx context.Context) ([]domain.SensorTypeInfo, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		return s.sensorTypes.List(), nil
+	}
+}
+
+// SetCalibration - функция установки калибровки датчика, nil удаляет калибровку.
+// Сохранённые значения остаются сырыми, калибровка применяется при выдаче.
+// Если датчик не удовлетворяет condition, возвращается ErrSensorModified
+func (s *Sensor) SetCalibration(ctx context.Context, id int64, calibration *domain.Calibration, condition SensorCondition) (*domain.Sensor, error) {
+	if err := validateCalibration(calibration); err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, condition, func(sensor *domain.Sensor) {
+		sensor.Calibration = calibration
+	})
+}
+
+// SetAlertRule - функция установки правила тревоги датчика, nil удаляет правило.
+// Уже поднятые тревоги датчика не меняются.
+// Если датчик не удовлетворяет condition, возвращается ErrSensorModified
+func (s *Sensor) SetAlertRule(ctx context.Context, id int64, rule *domain.AlertRule, condition SensorCondition) (*domain.Sensor, error) {
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, condition, func(sensor *domain.Sensor) {
+		sensor.AlertRule = rule
+	})
+}
+
+// update - проверяет condition на текущем состоянии датчика и сохраняет датчик, изменённый change
+func (s *Sensor) update(ctx context.Context, id int64, condition SensorCondition, change func(sensor *domain.Sensor)) (*domain.Sensor, error) {
+	var sensor *domain.Sensor
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if sensor, err = s.sensorRepository.LockSensorByID(ctx, id); err != nil {
+			return err
+		}
+		if condition != nil && !condition(*sensor) {
+			return ErrSensorModified
+		}
+		change(sensor)
+		if err = s.sensorRepository.SaveSensor(ctx, sensor); err != nil {
+			return err
+		}
+		return s.recordSensor(ctx, *sensor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sensor, nil
+}
+
+func validateAlertRule(r *domain.AlertRule) error {
+	if r == nil {
+		return nil
+	}
+	if !r.Severity.Valid() || (r.Min == nil && r.Max == nil) {
+		return ErrInvalidAlertRule
+	}
+	if (r.Min != nil && !isFinite(*r.Min)) || (r.Max != nil && !isFinite(*r.Max)) {
+		return ErrInvalidAlertRule
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return ErrInvalidAlertRule
+	}
+	return nil
+}
+
+func validateCalibration(c *domain.Calibration) error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Table) == 0 {
+		if c.Scale == 0 || !isFinite(c.Scale) || !isFinite(c.Offset) {
+			return ErrInvalidCalibration
+		}
+		return nil
+	}
+	// таблица из одной точки не задаёт наклон, поэтому точек должно быть хотя бы две
+	if len(c.Table) < 2 {
+		return ErrInvalidCalibration
+	}
+	for i, point := range c.Table {
+		if !isFinite(point.Raw) || !isFinite(point.Value) {
+			return ErrInvalidCalibration
+		}
+		if i > 0 && point.Raw <= c.Table[i-1].Raw {
+			return ErrInvalidCalibration
+		}
+	}
+	return nil
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
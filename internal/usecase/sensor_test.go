@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"homework/internal/domain"
+	"math"
 	"testing"
 	"time"
 
@@ -164,6 +165,77 @@ func Test_sensor_RegisterSensor(t *testing.T) {
 	})
 }
 
+func Test_sensor_RegisterSensorTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("ok, all built-in types", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, gomock.Any()).AnyTimes().Return(nil, ErrSensorNotFound)
+		sr.EXPECT().SaveSensor(ctx, gomock.Any()).AnyTimes().Return(nil)
+
+		s := NewSensor(sr)
+
+		for _, info := range DefaultSensorTypes() {
+			_, err := s.RegisterSensor(ctx, &domain.Sensor{
+				Type:         info.Type,
+				SerialNumber: "1234567890",
+			})
+			assert.NoError(t, err, info.Type)
+		}
+	})
+
+	t.Run("ok, custom registry", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().GetSensorBySerialNumber(ctx, gomock.Any()).Times(1).Return(nil, ErrSensorNotFound)
+		sr.EXPECT().SaveSensor(ctx, gomock.Any()).Times(1).Return(nil)
+
+		s := NewSensor(sr, WithSensorTypes(NewSensorTypeRegistry(domain.SensorTypeInfo{
+			Type:      "thermometer",
+			Semantics: domain.PayloadSemanticsMeasurement,
+		})))
+
+		_, err := s.RegisterSensor(ctx, &domain.Sensor{
+			Type:         "thermometer",
+			SerialNumber: "1234567890",
+		})
+		assert.NoError(t, err)
+
+		_, err = s.RegisterSensor(ctx, &domain.Sensor{
+			Type:         domain.SensorTypeADC,
+			SerialNumber: "1234567890",
+		})
+		assert.ErrorIs(t, err, ErrWrongSensorType)
+	})
+}
+
+func Test_sensor_GetSensorTypes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := NewSensor(nil)
+
+	types, err := s.GetSensorTypes(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, types, len(DefaultSensorTypes()))
+	for i := 1; i < len(types); i++ {
+		assert.Less(t, types[i-1].Type, types[i].Type)
+	}
+
+	humidity, ok := NewSensorTypeRegistry(types...).Lookup(domain.SensorTypeHumidity)
+	assert.True(t, ok)
+	assert.Equal(t, "%", humidity.Unit)
+	assert.True(t, humidity.Accepts(100))
+	assert.False(t, humidity.Accepts(101))
+	assert.False(t, humidity.Accepts(-1))
+}
+
 func Test_sensor_GetSensors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -200,6 +272,75 @@ func Test_sensor_GetSensors(t *testing.T) {
 	})
 }
 
+func Test_sensor_ListSensors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("fail, invalid query", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s := NewSensor(NewMockSensorRepository(ctrl))
+		now := time.Now()
+
+		_, _, err := s.ListSensors(ctx, SensorQuery{SortBy: "type"}, "")
+		assert.ErrorIs(t, err, ErrInvalidSort)
+		_, _, err = s.ListSensors(ctx, SensorQuery{Limit: MaxPageLimit + 1}, "")
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		_, _, err = s.ListSensors(ctx, SensorQuery{Limit: -1}, "")
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		_, _, err = s.ListSensors(ctx, SensorQuery{Filter: SensorFilter{LastActivityFrom: now, LastActivityTo: now.Add(-time.Second)}}, "")
+		assert.ErrorIs(t, err, ErrInputDate)
+		_, _, err = s.ListSensors(ctx, SensorQuery{}, "not a cursor")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("ok, pages", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().ListSensors(ctx, SensorQuery{SortBy: SensorSortBySerialNumber, Limit: 3}).Times(1).Return([]domain.Sensor{
+			{ID: 3, SerialNumber: "a"},
+			{ID: 1, SerialNumber: "b"},
+			{ID: 2, SerialNumber: "c"},
+		}, nil)
+		sr.EXPECT().ListSensors(ctx, SensorQuery{SortBy: SensorSortBySerialNumber, After: &domain.Sensor{ID: 1, SerialNumber: "b"}, Limit: 3}).Times(1).Return([]domain.Sensor{
+			{ID: 2, SerialNumber: "c"},
+		}, nil)
+
+		s := NewSensor(sr)
+
+		page, cursor, err := s.ListSensors(ctx, SensorQuery{SortBy: SensorSortBySerialNumber, Limit: 2}, "")
+		assert.NoError(t, err)
+		assert.Len(t, page, 2)
+		assert.NotEmpty(t, cursor)
+
+		_, _, err = s.ListSensors(ctx, SensorQuery{SortBy: SensorSortByID, Limit: 2}, cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+
+		page, cursor, err = s.ListSensors(ctx, SensorQuery{SortBy: SensorSortBySerialNumber, Limit: 2}, cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Sensor{{ID: 2, SerialNumber: "c"}}, page)
+		assert.Empty(t, cursor)
+	})
+
+	t.Run("ok, default limit and sort", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().ListSensors(ctx, SensorQuery{SortBy: SensorSortByID, Limit: DefaultPageLimit + 1}).Times(1).Return(nil, nil)
+
+		s := NewSensor(sr)
+
+		page, cursor, err := s.ListSensors(ctx, SensorQuery{}, "")
+		assert.NoError(t, err)
+		assert.Empty(t, page)
+		assert.Empty(t, cursor)
+	})
+}
+
 func Test_sensor_GetSensorByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -253,3 +394,140 @@ func Test_sensor_GetSensorByID(t *testing.T) {
 		assert.NotNil(t, sensor)
 	})
 }
+
+func Test_sensor_SetCalibration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calibration := &domain.Calibration{Offset: -40, Scale: 0.5, Unit: "°C"}
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(ctx, int64(1)).Times(1).Return(&domain.Sensor{ID: 1, CurrentState: 100}, nil)
+		sr.EXPECT().SaveSensor(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, s *domain.Sensor) error {
+			assert.Equal(t, calibration, s.Calibration)
+			assert.Equal(t, 100.0, s.CurrentState, "в хранилище остаётся сырое значение")
+			return nil
+		})
+
+		s := NewSensor(sr)
+		sensor, err := s.SetCalibration(ctx, 1, calibration, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, calibration, sensor.Calibration)
+	})
+
+	t.Run("fail, invalid calibration", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(gomock.Any(), gomock.Any()).Times(0)
+		sr.EXPECT().SaveSensor(gomock.Any(), gomock.Any()).Times(0)
+
+		s := NewSensor(sr)
+		for _, calibration := range []*domain.Calibration{
+			{Offset: 1},
+			{Table: []domain.CalibrationPoint{{Raw: 0, Value: 1}}},
+			{Table: []domain.CalibrationPoint{{Raw: 10, Value: 1}, {Raw: 10, Value: 2}}},
+			{Table: []domain.CalibrationPoint{{Raw: 10, Value: 1}, {Raw: 0, Value: 2}}},
+		} {
+			_, err := s.SetCalibration(ctx, 1, calibration, nil)
+			assert.ErrorIs(t, err, ErrInvalidCalibration)
+		}
+	})
+
+	t.Run("fail, sensor not found", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(ctx, int64(1)).Times(1).Return(nil, ErrSensorNotFound)
+
+		s := NewSensor(sr)
+		_, err := s.SetCalibration(ctx, 1, nil, nil)
+		assert.ErrorIs(t, err, ErrSensorNotFound)
+	})
+
+	t.Run("fail, sensor modified", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(ctx, int64(1)).Times(1).Return(&domain.Sensor{ID: 1, CurrentState: 100}, nil)
+		sr.EXPECT().SaveSensor(gomock.Any(), gomock.Any()).Times(0)
+
+		s := NewSensor(sr)
+		_, err := s.SetCalibration(ctx, 1, nil, func(sensor domain.Sensor) bool {
+			return sensor.CurrentState == 50
+		})
+		assert.ErrorIs(t, err, ErrSensorModified)
+	})
+
+	t.Run("ok, condition checked within transaction", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		txCtx := context.WithValue(ctx, struct{}{}, "tx")
+		tr := NewMockTransactor(ctrl)
+		tr.EXPECT().WithinTransaction(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, fn func(context.Context) error) error {
+			return fn(txCtx)
+		})
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(txCtx, int64(1)).Times(1).Return(&domain.Sensor{ID: 1, CurrentState: 100}, nil)
+		sr.EXPECT().SaveSensor(txCtx, gomock.Any()).Times(1).Return(nil)
+
+		s := NewSensor(sr, WithSensorTransactor(tr))
+		_, err := s.SetCalibration(ctx, 1, nil, func(sensor domain.Sensor) bool {
+			return sensor.CurrentState == 100
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func Test_sensor_SetAlertRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bound := func(v float64) *float64 { return &v }
+
+	t.Run("ok", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rule := &domain.AlertRule{Severity: domain.AlertSeverityCritical, Max: bound(0)}
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(ctx, int64(1)).Times(1).Return(&domain.Sensor{ID: 1}, nil)
+		sr.EXPECT().SaveSensor(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, s *domain.Sensor) error {
+			assert.Equal(t, rule, s.AlertRule)
+			return nil
+		})
+
+		s := NewSensor(sr)
+		sensor, err := s.SetAlertRule(ctx, 1, rule, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, rule, sensor.AlertRule)
+	})
+
+	t.Run("fail, invalid rule", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sr := NewMockSensorRepository(ctrl)
+		sr.EXPECT().LockSensorByID(gomock.Any(), gomock.Any()).Times(0)
+
+		s := NewSensor(sr)
+		for _, rule := range []*domain.AlertRule{
+			{Severity: domain.AlertSeverityCritical},
+			{Severity: "fatal", Max: bound(0)},
+			{Severity: domain.AlertSeverityInfo, Min: bound(10), Max: bound(0)},
+			{Severity: domain.AlertSeverityInfo, Min: bound(math.Inf(-1))},
+		} {
+			_, err := s.SetAlertRule(ctx, 1, rule, nil)
+			assert.ErrorIs(t, err, ErrInvalidAlertRule)
+		}
+	})
+}
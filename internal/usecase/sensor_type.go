@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"homework/internal/domain"
+	"sort"
+	"sync"
+)
+
+// SensorTypeRegistry - реестр поддерживаемых типов датчиков.
+// Новый тип датчика добавляется регистрацией описания, без изменения схемы БД
+type SensorTypeRegistry struct {
+	types   map[domain.SensorType]domain.SensorTypeInfo
+	rwMutex *sync.RWMutex
+}
+
+func NewSensorTypeRegistry(types ...domain.SensorTypeInfo) *SensorTypeRegistry {
+	r := &SensorTypeRegistry{
+		types:   make(map[domain.SensorType]domain.SensorTypeInfo, len(types)),
+		rwMutex: new(sync.RWMutex),
+	}
+	for _, t := range types {
+		r.Register(t)
+	}
+	return r
+}
+
+// DefaultSensorTypeRegistry - реестр со всеми встроенными типами датчиков
+func DefaultSensorTypeRegistry() *SensorTypeRegistry {
+	return NewSensorTypeRegistry(DefaultSensorTypes()...)
+}
+
+// DefaultSensorTypes - описания встроенных типов датчиков
+func DefaultSensorTypes() []domain.SensorTypeInfo {
+	return []domain.SensorTypeInfo{
+		{
+			Type:        domain.SensorTypeContactClosure,
+			Description: "Contact closure",
+			Semantics:   domain.PayloadSemanticsState,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(1),
+		},
+		{
+			Type:        domain.SensorTypeADC,
+			Description: "Analog-to-digital converter",
+			Semantics:   domain.PayloadSemanticsMeasurement,
+		},
+		{
+			Type:        domain.SensorTypeMotion,
+			Description: "Motion detector",
+			Semantics:   domain.PayloadSemanticsState,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(1),
+		},
+		{
+			Type:        domain.SensorTypeHumidity,
+			Description: "Relative humidity",
+			Unit:        "%",
+			Semantics:   domain.PayloadSemanticsMeasurement,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(100),
+		},
+		{
+			Type:        domain.SensorTypeCO2,
+			Description: "CO2 concentration",
+			Unit:        "ppm",
+			Semantics:   domain.PayloadSemanticsMeasurement,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(10000),
+		},
+		{
+			Type:        domain.SensorTypePowerMeter,
+			Description: "Power meter",
+			Unit:        "W",
+			Semantics:   domain.PayloadSemanticsMeasurement,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(100000),
+		},
+		{
+			Type:        domain.SensorTypeBattery,
+			Description: "Battery level",
+			Unit:        "%",
+			Semantics:   domain.PayloadSemanticsMeasurement,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(100),
+		},
+		{
+			Type:        domain.SensorTypeDoorLock,
+			Description: "Door lock",
+			Semantics:   domain.PayloadSemanticsState,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(1),
+		},
+		{
+			Type:        domain.SensorTypeRelay,
+			Description: "Relay",
+			Semantics:   domain.PayloadSemanticsState,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(1),
+			Actuator:    true,
+		},
+		{
+			Type:        domain.SensorTypeValve,
+			Description: "Valve",
+			Semantics:   domain.PayloadSemanticsState,
+			MinPayload:  bound(0),
+			MaxPayload:  bound(1),
+			Actuator:    true,
+		},
+	}
+}
+
+// Register - добавляет тип датчика в реестр или заменяет существующее описание
+func (r *SensorTypeRegistry) Register(info domain.SensorTypeInfo) {
+	r.rwMutex.Lock()
+	defer r.rwMutex.Unlock()
+	r.types[info.Type] = info
+}
+
+// Lookup - возвращает описание типа датчика
+func (r *SensorTypeRegistry) Lookup(t domain.SensorType) (domain.SensorTypeInfo, bool) {
+	r.rwMutex.RLock()
+	defer r.rwMutex.RUnlock()
+	info, ok := r.types[t]
+	return info, ok
+}
+
+// List - возвращает описания всех типов датчиков, упорядоченные по типу
+func (r *SensorTypeRegistry) List() []domain.SensorTypeInfo {
+	r.rwMutex.RLock()
+	types := make([]domain.SensorTypeInfo, 0, len(r.types))
+	for _, info := range r.types {
+		types = append(types, info)
+	}
+	r.rwMutex.RUnlock()
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Type < types[j].Type
+	})
+	return types
+}
+
+func bound(v float64) *float64 {
+	return &v
+}
@@ -16,27 +16,104 @@ var (
 	ErrUserNotFound            = errors.New("user not found")
 	ErrEventNotFound           = errors.New("event not found")
 	ErrInputDate               = errors.New("input date is required")
+	ErrSensorAlreadyExists     = errors.New("sensor with this serial number already exists")
+	ErrSensorOwnerExists       = errors.New("sensor is already attached to user")
+	ErrPayloadOutOfRange       = errors.New("event payload is out of range for sensor type")
+	ErrInvalidCalibration      = errors.New("invalid sensor calibration")
+	ErrInvalidLimit            = errors.New("invalid page limit")
+	ErrInvalidCursor           = errors.New("invalid page cursor")
+	ErrInvalidSort             = errors.New("invalid sort field")
+	ErrSensorMismatch          = errors.New("sensor id does not match serial number")
+	ErrAlertNotFound           = errors.New("alert not found")
+	ErrAlertExists             = errors.New("sensor already has unresolved alert")
+	ErrAlertResolved           = errors.New("alert is already resolved")
+	ErrInvalidAlertRule        = errors.New("invalid alert rule")
+	ErrInvalidAlertState       = errors.New("invalid alert state")
+	ErrChannelNotFound         = errors.New("notification channel not found")
+	ErrInvalidChannel          = errors.New("invalid notification channel")
+	ErrCommandNotFound         = errors.New("command not found")
+	ErrNotActuator             = errors.New("sensor type does not accept commands")
+	ErrInvalidCommandState     = errors.New("invalid command state")
+	ErrScheduleNotFound        = errors.New("schedule not found")
+	ErrInvalidSchedule         = errors.New("invalid schedule")
+	ErrSensorModified          = errors.New("sensor was modified since it was read")
+	ErrRateLimited             = errors.New("rate limit exceeded")
+	ErrIngestionUnavailable    = errors.New("event ingestion is unavailable")
+	ErrEventBufferFull         = errors.New("event buffer is full")
 )
 
 //go:generate mockgen -source usecase.go -package usecase -destination usecase_mock.go
 type SensorRepository interface {
-	// SaveSensor - функция сохранения датчика
+	// SaveSensor - функция сохранения датчика.
+	// Датчик без ID создаётся (ErrSensorAlreadyExists при занятом серийном номере), с ID - обновляется (ErrSensorNotFound, если его нет)
 	SaveSensor(ctx context.Context, sensor *domain.Sensor) error
+	// SaveSensorState - функция обновления состояния датчика по событию: меняет только CurrentState, CurrentReadings,
+	// LastActivity и UpdatedAt, чтобы не затереть изменённые тем временем калибровку, описание и правило тревоги.
+	// Состояние обновляется, только если sensor.LastActivity позже сохранённой, иначе вызов ничего не меняет
+	SaveSensorState(ctx context.Context, sensor *domain.Sensor) error
 	// GetSensors - функция получения списка датчиков
 	GetSensors(ctx context.Context) ([]domain.Sensor, error)
 	// GetSensorByID - функция получения датчика по ID
 	GetSensorByID(ctx context.Context, id int64) (*domain.Sensor, error)
+	// LockSensorByID - функция получения датчика по ID для изменения в транзакции ctx: параллельные транзакции,
+	// изменяющие тот же датчик, ждут её завершения, поэтому проверенное по датчику условие остаётся верным до сохранения
+	LockSensorByID(ctx context.Context, id int64) (*domain.Sensor, error)
 	// GetSensorBySerialNumber - функция получения датчика по серийному номеру
 	GetSensorBySerialNumber(ctx context.Context, sn string) (*domain.Sensor, error)
+	// ListSensors - функция получения не более query.Limit датчиков, подходящих под фильтр, следующих за query.After в порядке сортировки
+	ListSensors(ctx context.Context, query SensorQuery) ([]domain.Sensor, error)
 }
 
 type EventRepository interface {
-	// SaveEvent - функция сохранения события по датчику
+	// SaveEvent - функция сохранения события по датчику, задаёт ID события
 	SaveEvent(ctx context.Context, event *domain.Event) error
-	// GetLastEventBySensorID - функция получения последнего события по ID датчика
+	// SaveEvents - функция массового сохранения событий, задаёт ID событий.
+	// События сохраняются все или ни одного, ErrSensorNotFound если датчика какого-то события нет
+	SaveEvents(ctx context.Context, events []domain.Event) error
+	// GetLastEventBySensorID - функция получения последнего по времени события по ID датчика, ErrEventNotFound если событий нет
 	GetLastEventBySensorID(ctx context.Context, id int64) (*domain.Event, error)
-	// GetEventsBySensorIDWithDate - функция получения событий в определенном диапазоне по ID датчика
+	// GetEventsBySensorIDWithDate - функция получения событий в диапазоне [start, end] по ID датчика, упорядоченных по времени.
+	// Если событий нет, возвращается пустой список без ошибки
 	GetEventsBySensorIDWithDate(ctx context.Context, id int64, start, end time.Time) ([]domain.Event, error)
+	// ListEvents - функция получения не более query.Limit событий датчика в диапазоне [start, end], следующих за query.After в порядке сортировки
+	ListEvents(ctx context.Context, query EventQuery) ([]domain.Event, error)
+	// ExportEvents - функция последовательной выдачи в fn событий в диапазоне [start, end], упорядоченных по времени и ID,
+	// без загрузки всей выборки в память. Ошибка fn прерывает выдачу и возвращается как есть
+	ExportEvents(ctx context.Context, query EventExportQuery, fn func(domain.Event) error) error
+}
+
+// EventBufferRepository - журнал событий, отложенных на время недоступности хранилища, в порядке приёма
+type EventBufferRepository interface {
+	// AppendEvents - функция записи событий в конец журнала, все или ни одного.
+	// ErrEventBufferFull, если события не помещаются в журнал
+	AppendEvents(ctx context.Context, events []domain.Event) error
+	// PeekEvents - функция получения не более limit событий из начала журнала без их удаления
+	PeekEvents(ctx context.Context, limit int) ([]domain.Event, error)
+	// DropEvents - функция удаления n событий из начала журнала, полученных PeekEvents
+	DropEvents(ctx context.Context, n int) error
+	// Stats - число и размер событий в журнале и число пропущенных повреждённых записей
+	Stats() EventBufferStats
+}
+
+// OutboxRepository - журнал изменений (transactional outbox) и позиции доставки его записей в приёмники
+type OutboxRepository interface {
+	// SaveChange - функция записи изменения в журнал, задаёт Seq. Вызывается в транзакции самого изменения:
+	// запись видна читателям журнала, только если изменение сохранено
+	SaveChange(ctx context.Context, change *domain.Change) error
+	// ListChanges - функция получения не более limit изменений с Seq больше since по возрастанию Seq
+	ListChanges(ctx context.Context, since int64, limit int) ([]domain.Change, error)
+	// GetRelayPosition - функция получения Seq последнего изменения, доставленного в приёмник sink, 0 если доставок не было
+	GetRelayPosition(ctx context.Context, sink string) (int64, error)
+	// SaveRelayPosition - функция сохранения Seq последнего изменения, доставленного в приёмник sink
+	SaveRelayPosition(ctx context.Context, sink string, seq int64) error
+	// LockRelay - функция захвата доставки в приёмник sink. Пока захват не снят вызовом release, другие
+	// экземпляры сервиса с тем же хранилищем получают ok == false и не доставляют изменения в этот приёмник
+	LockRelay(ctx context.Context, sink string) (release func(), ok bool, err error)
+}
+
+type ChangeSink interface {
+	// Publish - функция доставки изменений в порядке Seq. После ошибки те же изменения будут доставлены повторно
+	Publish(ctx context.Context, changes []domain.Change) error
 }
 
 type UserRepository interface {
@@ -51,4 +128,103 @@ type SensorOwnerRepository interface {
 	SaveSensorOwner(ctx context.Context, sensorOwner domain.SensorOwner) error
 	// GetSensorsByUserID -функция, возвращающая список привязок для пользователя
 	GetSensorsByUserID(ctx context.Context, userID int64) ([]domain.SensorOwner, error)
+	// GetUsersBySensorID - функция, возвращающая список привязок датчика к пользователям
+	GetUsersBySensorID(ctx context.Context, sensorID int64) ([]domain.SensorOwner, error)
+}
+
+type AlertRepository interface {
+	// SaveAlert - функция сохранения тревоги. Тревога без ID создаётся (ErrAlertExists, если у датчика уже есть неустранённая тревога),
+	// у тревоги с ID обновляются подтверждение и устранение (ErrAlertNotFound, если её нет)
+	SaveAlert(ctx context.Context, alert *domain.Alert) error
+	// GetAlertByID - функция получения тревоги по ID, ErrAlertNotFound если её нет
+	GetAlertByID(ctx context.Context, id int64) (*domain.Alert, error)
+	// GetUnresolvedAlertBySensorID - функция получения неустранённой тревоги датчика, ErrAlertNotFound если её нет
+	GetUnresolvedAlertBySensorID(ctx context.Context, sensorID int64) (*domain.Alert, error)
+	// ListAlerts - функция получения не более query.Limit тревог датчиков query.SensorIDs в состояниях query.States,
+	// от новых к старым по времени и ID
+	ListAlerts(ctx context.Context, query AlertQuery) ([]domain.Alert, error)
+}
+
+type NotificationRepository interface {
+	// SaveChannel - функция создания канала уведомлений, задаёт ID канала. ErrUserNotFound, если пользователя нет
+	SaveChannel(ctx context.Context, channel *domain.NotificationChannel) error
+	// GetChannelByID - функция получения канала по ID, ErrChannelNotFound если его нет
+	GetChannelByID(ctx context.Context, id int64) (*domain.NotificationChannel, error)
+	// GetChannelsByUserID - функция получения каналов пользователя в порядке создания
+	GetChannelsByUserID(ctx context.Context, userID int64) ([]domain.NotificationChannel, error)
+	// DeleteChannel - функция удаления канала вместе с его журналом доставки, ErrChannelNotFound если канала нет
+	DeleteChannel(ctx context.Context, id int64) error
+	// SaveDelivery - функция сохранения записи журнала доставки, задаёт ID записи
+	SaveDelivery(ctx context.Context, delivery *domain.Delivery) error
+	// ListDeliveries - функция получения не более limit записей журнала доставки канала от новых к старым
+	ListDeliveries(ctx context.Context, channelID int64, limit int) ([]domain.Delivery, error)
+	// CountDeliveries - функция подсчёта записей журнала канала со статусом status, созданных не раньше since
+	CountDeliveries(ctx context.Context, channelID int64, status domain.DeliveryStatus, since time.Time) (int, error)
+}
+
+type CommandRepository interface {
+	// SaveCommand - функция сохранения команды. Команда без ID создаётся (ErrSensorNotFound, если устройства нет),
+	// у команды с ID обновляются этап, причина неудачи и времена доставки и завершения (ErrCommandNotFound, если её нет)
+	SaveCommand(ctx context.Context, command *domain.Command) error
+	// GetCommandByID - функция получения команды по ID, ErrCommandNotFound если её нет
+	GetCommandByID(ctx context.Context, id int64) (*domain.Command, error)
+	// ListCommands - функция получения не более query.Limit команд устройства query.SensorID в состояниях query.States,
+	// от новых к старым по времени создания и ID
+	ListCommands(ctx context.Context, query CommandQuery) ([]domain.Command, error)
+	// DeliverNextCommand - функция, атомарно переводящая самую старую ожидающую команду устройства в состояние delivered
+	// со временем доставки at. ErrCommandNotFound, если ожидающих команд нет
+	DeliverNextCommand(ctx context.Context, sensorID int64, at time.Time) (*domain.Command, error)
+	// FailExpiredCommands - функция перевода в состояние failed с причиной reason и временем завершения at
+	// всех команд, доставленных раньше deliveredBefore и до сих пор не подтверждённых. Возвращает число таких команд
+	FailExpiredCommands(ctx context.Context, deliveredBefore time.Time, reason string, at time.Time) (int, error)
+}
+
+type ScheduleRepository interface {
+	// SaveSchedule - функция сохранения расписания. Расписание без ID создаётся (ErrUserNotFound, если пользователя нет),
+	// у расписания с ID обновляются все поля, кроме пользователя и времени создания (ErrScheduleNotFound, если его нет)
+	SaveSchedule(ctx context.Context, schedule *domain.Schedule) error
+	// GetScheduleByID - функция получения расписания по ID, ErrScheduleNotFound если его нет
+	GetScheduleByID(ctx context.Context, id int64) (*domain.Schedule, error)
+	// GetSchedulesByUserID - функция получения расписаний пользователя в порядке создания
+	GetSchedulesByUserID(ctx context.Context, userID int64) ([]domain.Schedule, error)
+	// DeleteSchedule - функция удаления расписания вместе с историей срабатываний, ErrScheduleNotFound если его нет
+	DeleteSchedule(ctx context.Context, id int64) error
+	// DueSchedules - функция получения не более limit включённых расписаний, время следующего срабатывания которых не позже now,
+	// по возрастанию этого времени и ID
+	DueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.Schedule, error)
+	// AdvanceSchedule - функция, атомарно переносящая следующее срабатывание расписания с from на next.
+	// false, если время срабатывания уже не равно from, то есть срабатывание забрал другой экземпляр сервиса
+	AdvanceSchedule(ctx context.Context, id int64, from time.Time, next *time.Time) (bool, error)
+	// SaveRun - функция сохранения записи истории срабатываний, задаёт ID записи
+	SaveRun(ctx context.Context, run *domain.ScheduleRun) error
+	// ListRuns - функция получения не более limit записей истории срабатываний расписания от новых к старым
+	ListRuns(ctx context.Context, scheduleID int64, limit int) ([]domain.ScheduleRun, error)
+}
+
+type WebhookCaller interface {
+	// Call - функция отправки body JSON POST запросом на url, ошибка при недоступности адреса и ответе не 2xx
+	Call(ctx context.Context, url string, body any) error
+}
+
+type NotificationSender interface {
+	// Send - функция отправки уведомления в канал. Ошибка записывается в журнал доставки
+	Send(ctx context.Context, channel domain.NotificationChannel, message domain.NotificationMessage) error
+}
+
+type RateLimitRepository interface {
+	// TakeToken - функция получения токена из корзины key с ограничением limit. Если корзина пуста,
+	// токен не забирается и возвращается время, через которое он появится, иначе - 0
+	TakeToken(ctx context.Context, key string, limit domain.RateLimit) (time.Duration, error)
+}
+
+type Transactor interface {
+	// WithinTransaction - функция выполнения fn в одной транзакции для всех репозиториев, получивших её ctx
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// noTransactor - Transactor по умолчанию, выполняющий fn без транзакции
+type noTransactor struct{}
+
+func (noTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
 }
@@ -81,6 +81,36 @@ func (mr *MockSensorRepositoryMockRecorder) GetSensors(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensors", reflect.TypeOf((*MockSensorRepository)(nil).GetSensors), ctx)
 }
 
+// ListSensors mocks base method.
+func (m *MockSensorRepository) ListSensors(ctx context.Context, query SensorQuery) ([]domain.Sensor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSensors", ctx, query)
+	ret0, _ := ret[0].([]domain.Sensor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSensors indicates an expected call of ListSensors.
+func (mr *MockSensorRepositoryMockRecorder) ListSensors(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSensors", reflect.TypeOf((*MockSensorRepository)(nil).ListSensors), ctx, query)
+}
+
+// LockSensorByID mocks base method.
+func (m *MockSensorRepository) LockSensorByID(ctx context.Context, id int64) (*domain.Sensor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSensorByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Sensor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSensorByID indicates an expected call of LockSensorByID.
+func (mr *MockSensorRepositoryMockRecorder) LockSensorByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSensorByID", reflect.TypeOf((*MockSensorRepository)(nil).LockSensorByID), ctx, id)
+}
+
 // SaveSensor mocks base method.
 func (m *MockSensorRepository) SaveSensor(ctx context.Context, sensor *domain.Sensor) error {
 	m.ctrl.T.Helper()
@@ -95,6 +125,20 @@ func (mr *MockSensorRepositoryMockRecorder) SaveSensor(ctx, sensor interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSensor", reflect.TypeOf((*MockSensorRepository)(nil).SaveSensor), ctx, sensor)
 }
 
+// SaveSensorState mocks base method.
+func (m *MockSensorRepository) SaveSensorState(ctx context.Context, sensor *domain.Sensor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSensorState", ctx, sensor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSensorState indicates an expected call of SaveSensorState.
+func (mr *MockSensorRepositoryMockRecorder) SaveSensorState(ctx, sensor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSensorState", reflect.TypeOf((*MockSensorRepository)(nil).SaveSensorState), ctx, sensor)
+}
+
 // MockEventRepository is a mock of EventRepository interface.
 type MockEventRepository struct {
 	ctrl     *gomock.Controller
@@ -118,6 +162,20 @@ func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ExportEvents mocks base method.
+func (m *MockEventRepository) ExportEvents(ctx context.Context, query EventExportQuery, fn func(domain.Event) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEvents", ctx, query, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEvents indicates an expected call of ExportEvents.
+func (mr *MockEventRepositoryMockRecorder) ExportEvents(ctx, query, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEvents", reflect.TypeOf((*MockEventRepository)(nil).ExportEvents), ctx, query, fn)
+}
+
 // GetEventsBySensorIDWithDate mocks base method.
 func (m *MockEventRepository) GetEventsBySensorIDWithDate(ctx context.Context, id int64, start, end time.Time) ([]domain.Event, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +206,21 @@ func (mr *MockEventRepositoryMockRecorder) GetLastEventBySensorID(ctx, id interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastEventBySensorID", reflect.TypeOf((*MockEventRepository)(nil).GetLastEventBySensorID), ctx, id)
 }
 
+// ListEvents mocks base method.
+func (m *MockEventRepository) ListEvents(ctx context.Context, query EventQuery) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, query)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockEventRepositoryMockRecorder) ListEvents(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEventRepository)(nil).ListEvents), ctx, query)
+}
+
 // SaveEvent mocks base method.
 func (m *MockEventRepository) SaveEvent(ctx context.Context, event *domain.Event) error {
 	m.ctrl.T.Helper()
@@ -162,6 +235,234 @@ func (mr *MockEventRepositoryMockRecorder) SaveEvent(ctx, event interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEvent", reflect.TypeOf((*MockEventRepository)(nil).SaveEvent), ctx, event)
 }
 
+// SaveEvents mocks base method.
+func (m *MockEventRepository) SaveEvents(ctx context.Context, events []domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEvents indicates an expected call of SaveEvents.
+func (mr *MockEventRepositoryMockRecorder) SaveEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEvents", reflect.TypeOf((*MockEventRepository)(nil).SaveEvents), ctx, events)
+}
+
+// MockEventBufferRepository is a mock of EventBufferRepository interface.
+type MockEventBufferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventBufferRepositoryMockRecorder
+}
+
+// MockEventBufferRepositoryMockRecorder is the mock recorder for MockEventBufferRepository.
+type MockEventBufferRepositoryMockRecorder struct {
+	mock *MockEventBufferRepository
+}
+
+// NewMockEventBufferRepository creates a new mock instance.
+func NewMockEventBufferRepository(ctrl *gomock.Controller) *MockEventBufferRepository {
+	mock := &MockEventBufferRepository{ctrl: ctrl}
+	mock.recorder = &MockEventBufferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventBufferRepository) EXPECT() *MockEventBufferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendEvents mocks base method.
+func (m *MockEventBufferRepository) AppendEvents(ctx context.Context, events []domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendEvents indicates an expected call of AppendEvents.
+func (mr *MockEventBufferRepositoryMockRecorder) AppendEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvents", reflect.TypeOf((*MockEventBufferRepository)(nil).AppendEvents), ctx, events)
+}
+
+// DropEvents mocks base method.
+func (m *MockEventBufferRepository) DropEvents(ctx context.Context, n int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropEvents", ctx, n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropEvents indicates an expected call of DropEvents.
+func (mr *MockEventBufferRepositoryMockRecorder) DropEvents(ctx, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropEvents", reflect.TypeOf((*MockEventBufferRepository)(nil).DropEvents), ctx, n)
+}
+
+// PeekEvents mocks base method.
+func (m *MockEventBufferRepository) PeekEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeekEvents", ctx, limit)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeekEvents indicates an expected call of PeekEvents.
+func (mr *MockEventBufferRepositoryMockRecorder) PeekEvents(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeekEvents", reflect.TypeOf((*MockEventBufferRepository)(nil).PeekEvents), ctx, limit)
+}
+
+// Stats mocks base method.
+func (m *MockEventBufferRepository) Stats() EventBufferStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(EventBufferStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockEventBufferRepositoryMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockEventBufferRepository)(nil).Stats))
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRelayPosition mocks base method.
+func (m *MockOutboxRepository) GetRelayPosition(ctx context.Context, sink string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelayPosition", ctx, sink)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelayPosition indicates an expected call of GetRelayPosition.
+func (mr *MockOutboxRepositoryMockRecorder) GetRelayPosition(ctx, sink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelayPosition", reflect.TypeOf((*MockOutboxRepository)(nil).GetRelayPosition), ctx, sink)
+}
+
+// ListChanges mocks base method.
+func (m *MockOutboxRepository) ListChanges(ctx context.Context, since int64, limit int) ([]domain.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChanges", ctx, since, limit)
+	ret0, _ := ret[0].([]domain.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChanges indicates an expected call of ListChanges.
+func (mr *MockOutboxRepositoryMockRecorder) ListChanges(ctx, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChanges", reflect.TypeOf((*MockOutboxRepository)(nil).ListChanges), ctx, since, limit)
+}
+
+// LockRelay mocks base method.
+func (m *MockOutboxRepository) LockRelay(ctx context.Context, sink string) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRelay", ctx, sink)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LockRelay indicates an expected call of LockRelay.
+func (mr *MockOutboxRepositoryMockRecorder) LockRelay(ctx, sink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRelay", reflect.TypeOf((*MockOutboxRepository)(nil).LockRelay), ctx, sink)
+}
+
+// SaveChange mocks base method.
+func (m *MockOutboxRepository) SaveChange(ctx context.Context, change *domain.Change) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChange indicates an expected call of SaveChange.
+func (mr *MockOutboxRepositoryMockRecorder) SaveChange(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChange", reflect.TypeOf((*MockOutboxRepository)(nil).SaveChange), ctx, change)
+}
+
+// SaveRelayPosition mocks base method.
+func (m *MockOutboxRepository) SaveRelayPosition(ctx context.Context, sink string, seq int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRelayPosition", ctx, sink, seq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRelayPosition indicates an expected call of SaveRelayPosition.
+func (mr *MockOutboxRepositoryMockRecorder) SaveRelayPosition(ctx, sink, seq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRelayPosition", reflect.TypeOf((*MockOutboxRepository)(nil).SaveRelayPosition), ctx, sink, seq)
+}
+
+// MockChangeSink is a mock of ChangeSink interface.
+type MockChangeSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeSinkMockRecorder
+}
+
+// MockChangeSinkMockRecorder is the mock recorder for MockChangeSink.
+type MockChangeSinkMockRecorder struct {
+	mock *MockChangeSink
+}
+
+// NewMockChangeSink creates a new mock instance.
+func NewMockChangeSink(ctrl *gomock.Controller) *MockChangeSink {
+	mock := &MockChangeSink{ctrl: ctrl}
+	mock.recorder = &MockChangeSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeSink) EXPECT() *MockChangeSinkMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockChangeSink) Publish(ctx context.Context, changes []domain.Change) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockChangeSinkMockRecorder) Publish(ctx, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockChangeSink)(nil).Publish), ctx, changes)
+}
+
 // MockUserRepository is a mock of UserRepository interface.
 type MockUserRepository struct {
 	ctrl     *gomock.Controller
@@ -252,6 +553,21 @@ func (mr *MockSensorOwnerRepositoryMockRecorder) GetSensorsByUserID(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorsByUserID", reflect.TypeOf((*MockSensorOwnerRepository)(nil).GetSensorsByUserID), ctx, userID)
 }
 
+// GetUsersBySensorID mocks base method.
+func (m *MockSensorOwnerRepository) GetUsersBySensorID(ctx context.Context, sensorID int64) ([]domain.SensorOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersBySensorID", ctx, sensorID)
+	ret0, _ := ret[0].([]domain.SensorOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersBySensorID indicates an expected call of GetUsersBySensorID.
+func (mr *MockSensorOwnerRepositoryMockRecorder) GetUsersBySensorID(ctx, sensorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersBySensorID", reflect.TypeOf((*MockSensorOwnerRepository)(nil).GetUsersBySensorID), ctx, sensorID)
+}
+
 // SaveSensorOwner mocks base method.
 func (m *MockSensorOwnerRepository) SaveSensorOwner(ctx context.Context, sensorOwner domain.SensorOwner) error {
 	m.ctrl.T.Helper()
@@ -265,3 +581,596 @@ func (mr *MockSensorOwnerRepositoryMockRecorder) SaveSensorOwner(ctx, sensorOwne
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSensorOwner", reflect.TypeOf((*MockSensorOwnerRepository)(nil).SaveSensorOwner), ctx, sensorOwner)
 }
+
+// MockAlertRepository is a mock of AlertRepository interface.
+type MockAlertRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRepositoryMockRecorder
+}
+
+// MockAlertRepositoryMockRecorder is the mock recorder for MockAlertRepository.
+type MockAlertRepositoryMockRecorder struct {
+	mock *MockAlertRepository
+}
+
+// NewMockAlertRepository creates a new mock instance.
+func NewMockAlertRepository(ctrl *gomock.Controller) *MockAlertRepository {
+	mock := &MockAlertRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRepository) EXPECT() *MockAlertRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAlertByID mocks base method.
+func (m *MockAlertRepository) GetAlertByID(ctx context.Context, id int64) (*domain.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertByID indicates an expected call of GetAlertByID.
+func (mr *MockAlertRepositoryMockRecorder) GetAlertByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertByID", reflect.TypeOf((*MockAlertRepository)(nil).GetAlertByID), ctx, id)
+}
+
+// GetUnresolvedAlertBySensorID mocks base method.
+func (m *MockAlertRepository) GetUnresolvedAlertBySensorID(ctx context.Context, sensorID int64) (*domain.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnresolvedAlertBySensorID", ctx, sensorID)
+	ret0, _ := ret[0].(*domain.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnresolvedAlertBySensorID indicates an expected call of GetUnresolvedAlertBySensorID.
+func (mr *MockAlertRepositoryMockRecorder) GetUnresolvedAlertBySensorID(ctx, sensorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnresolvedAlertBySensorID", reflect.TypeOf((*MockAlertRepository)(nil).GetUnresolvedAlertBySensorID), ctx, sensorID)
+}
+
+// ListAlerts mocks base method.
+func (m *MockAlertRepository) ListAlerts(ctx context.Context, query AlertQuery) ([]domain.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlerts", ctx, query)
+	ret0, _ := ret[0].([]domain.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlerts indicates an expected call of ListAlerts.
+func (mr *MockAlertRepositoryMockRecorder) ListAlerts(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlerts", reflect.TypeOf((*MockAlertRepository)(nil).ListAlerts), ctx, query)
+}
+
+// SaveAlert mocks base method.
+func (m *MockAlertRepository) SaveAlert(ctx context.Context, alert *domain.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAlert", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAlert indicates an expected call of SaveAlert.
+func (mr *MockAlertRepositoryMockRecorder) SaveAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAlert", reflect.TypeOf((*MockAlertRepository)(nil).SaveAlert), ctx, alert)
+}
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountDeliveries mocks base method.
+func (m *MockNotificationRepository) CountDeliveries(ctx context.Context, channelID int64, status domain.DeliveryStatus, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeliveries", ctx, channelID, status, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeliveries indicates an expected call of CountDeliveries.
+func (mr *MockNotificationRepositoryMockRecorder) CountDeliveries(ctx, channelID, status, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeliveries", reflect.TypeOf((*MockNotificationRepository)(nil).CountDeliveries), ctx, channelID, status, since)
+}
+
+// DeleteChannel mocks base method.
+func (m *MockNotificationRepository) DeleteChannel(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannel indicates an expected call of DeleteChannel.
+func (mr *MockNotificationRepositoryMockRecorder) DeleteChannel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockNotificationRepository)(nil).DeleteChannel), ctx, id)
+}
+
+// GetChannelByID mocks base method.
+func (m *MockNotificationRepository) GetChannelByID(ctx context.Context, id int64) (*domain.NotificationChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelByID", ctx, id)
+	ret0, _ := ret[0].(*domain.NotificationChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelByID indicates an expected call of GetChannelByID.
+func (mr *MockNotificationRepositoryMockRecorder) GetChannelByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelByID", reflect.TypeOf((*MockNotificationRepository)(nil).GetChannelByID), ctx, id)
+}
+
+// GetChannelsByUserID mocks base method.
+func (m *MockNotificationRepository) GetChannelsByUserID(ctx context.Context, userID int64) ([]domain.NotificationChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]domain.NotificationChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelsByUserID indicates an expected call of GetChannelsByUserID.
+func (mr *MockNotificationRepositoryMockRecorder) GetChannelsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelsByUserID", reflect.TypeOf((*MockNotificationRepository)(nil).GetChannelsByUserID), ctx, userID)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockNotificationRepository) ListDeliveries(ctx context.Context, channelID int64, limit int) ([]domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, channelID, limit)
+	ret0, _ := ret[0].([]domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockNotificationRepositoryMockRecorder) ListDeliveries(ctx, channelID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockNotificationRepository)(nil).ListDeliveries), ctx, channelID, limit)
+}
+
+// SaveChannel mocks base method.
+func (m *MockNotificationRepository) SaveChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChannel", ctx, channel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChannel indicates an expected call of SaveChannel.
+func (mr *MockNotificationRepositoryMockRecorder) SaveChannel(ctx, channel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChannel", reflect.TypeOf((*MockNotificationRepository)(nil).SaveChannel), ctx, channel)
+}
+
+// SaveDelivery mocks base method.
+func (m *MockNotificationRepository) SaveDelivery(ctx context.Context, delivery *domain.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDelivery indicates an expected call of SaveDelivery.
+func (mr *MockNotificationRepositoryMockRecorder) SaveDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDelivery", reflect.TypeOf((*MockNotificationRepository)(nil).SaveDelivery), ctx, delivery)
+}
+
+// MockCommandRepository is a mock of CommandRepository interface.
+type MockCommandRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandRepositoryMockRecorder
+}
+
+// MockCommandRepositoryMockRecorder is the mock recorder for MockCommandRepository.
+type MockCommandRepositoryMockRecorder struct {
+	mock *MockCommandRepository
+}
+
+// NewMockCommandRepository creates a new mock instance.
+func NewMockCommandRepository(ctrl *gomock.Controller) *MockCommandRepository {
+	mock := &MockCommandRepository{ctrl: ctrl}
+	mock.recorder = &MockCommandRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandRepository) EXPECT() *MockCommandRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeliverNextCommand mocks base method.
+func (m *MockCommandRepository) DeliverNextCommand(ctx context.Context, sensorID int64, at time.Time) (*domain.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverNextCommand", ctx, sensorID, at)
+	ret0, _ := ret[0].(*domain.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverNextCommand indicates an expected call of DeliverNextCommand.
+func (mr *MockCommandRepositoryMockRecorder) DeliverNextCommand(ctx, sensorID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverNextCommand", reflect.TypeOf((*MockCommandRepository)(nil).DeliverNextCommand), ctx, sensorID, at)
+}
+
+// FailExpiredCommands mocks base method.
+func (m *MockCommandRepository) FailExpiredCommands(ctx context.Context, deliveredBefore time.Time, reason string, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExpiredCommands", ctx, deliveredBefore, reason, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExpiredCommands indicates an expected call of FailExpiredCommands.
+func (mr *MockCommandRepositoryMockRecorder) FailExpiredCommands(ctx, deliveredBefore, reason, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExpiredCommands", reflect.TypeOf((*MockCommandRepository)(nil).FailExpiredCommands), ctx, deliveredBefore, reason, at)
+}
+
+// GetCommandByID mocks base method.
+func (m *MockCommandRepository) GetCommandByID(ctx context.Context, id int64) (*domain.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommandByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommandByID indicates an expected call of GetCommandByID.
+func (mr *MockCommandRepositoryMockRecorder) GetCommandByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandByID", reflect.TypeOf((*MockCommandRepository)(nil).GetCommandByID), ctx, id)
+}
+
+// ListCommands mocks base method.
+func (m *MockCommandRepository) ListCommands(ctx context.Context, query CommandQuery) ([]domain.Command, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommands", ctx, query)
+	ret0, _ := ret[0].([]domain.Command)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommands indicates an expected call of ListCommands.
+func (mr *MockCommandRepositoryMockRecorder) ListCommands(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommands", reflect.TypeOf((*MockCommandRepository)(nil).ListCommands), ctx, query)
+}
+
+// SaveCommand mocks base method.
+func (m *MockCommandRepository) SaveCommand(ctx context.Context, command *domain.Command) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCommand", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCommand indicates an expected call of SaveCommand.
+func (mr *MockCommandRepositoryMockRecorder) SaveCommand(ctx, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommand", reflect.TypeOf((*MockCommandRepository)(nil).SaveCommand), ctx, command)
+}
+
+// MockScheduleRepository is a mock of ScheduleRepository interface.
+type MockScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleRepositoryMockRecorder
+}
+
+// MockScheduleRepositoryMockRecorder is the mock recorder for MockScheduleRepository.
+type MockScheduleRepositoryMockRecorder struct {
+	mock *MockScheduleRepository
+}
+
+// NewMockScheduleRepository creates a new mock instance.
+func NewMockScheduleRepository(ctrl *gomock.Controller) *MockScheduleRepository {
+	mock := &MockScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleRepository) EXPECT() *MockScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdvanceSchedule mocks base method.
+func (m *MockScheduleRepository) AdvanceSchedule(ctx context.Context, id int64, from time.Time, next *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceSchedule", ctx, id, from, next)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceSchedule indicates an expected call of AdvanceSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) AdvanceSchedule(ctx, id, from, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).AdvanceSchedule), ctx, id, from, next)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockScheduleRepository) DeleteSchedule(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteSchedule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteSchedule), ctx, id)
+}
+
+// DueSchedules mocks base method.
+func (m *MockScheduleRepository) DueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueSchedules", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueSchedules indicates an expected call of DueSchedules.
+func (mr *MockScheduleRepositoryMockRecorder) DueSchedules(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueSchedules", reflect.TypeOf((*MockScheduleRepository)(nil).DueSchedules), ctx, now, limit)
+}
+
+// GetScheduleByID mocks base method.
+func (m *MockScheduleRepository) GetScheduleByID(ctx context.Context, id int64) (*domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleByID indicates an expected call of GetScheduleByID.
+func (mr *MockScheduleRepositoryMockRecorder) GetScheduleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleByID", reflect.TypeOf((*MockScheduleRepository)(nil).GetScheduleByID), ctx, id)
+}
+
+// GetSchedulesByUserID mocks base method.
+func (m *MockScheduleRepository) GetSchedulesByUserID(ctx context.Context, userID int64) ([]domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulesByUserID", ctx, userID)
+	ret0, _ := ret[0].([]domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulesByUserID indicates an expected call of GetSchedulesByUserID.
+func (mr *MockScheduleRepositoryMockRecorder) GetSchedulesByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulesByUserID", reflect.TypeOf((*MockScheduleRepository)(nil).GetSchedulesByUserID), ctx, userID)
+}
+
+// ListRuns mocks base method.
+func (m *MockScheduleRepository) ListRuns(ctx context.Context, scheduleID int64, limit int) ([]domain.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, scheduleID, limit)
+	ret0, _ := ret[0].([]domain.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockScheduleRepositoryMockRecorder) ListRuns(ctx, scheduleID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockScheduleRepository)(nil).ListRuns), ctx, scheduleID, limit)
+}
+
+// SaveRun mocks base method.
+func (m *MockScheduleRepository) SaveRun(ctx context.Context, run *domain.ScheduleRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRun indicates an expected call of SaveRun.
+func (mr *MockScheduleRepositoryMockRecorder) SaveRun(ctx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRun", reflect.TypeOf((*MockScheduleRepository)(nil).SaveRun), ctx, run)
+}
+
+// SaveSchedule mocks base method.
+func (m *MockScheduleRepository) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSchedule indicates an expected call of SaveSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) SaveSchedule(ctx, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).SaveSchedule), ctx, schedule)
+}
+
+// MockWebhookCaller is a mock of WebhookCaller interface.
+type MockWebhookCaller struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookCallerMockRecorder
+}
+
+// MockWebhookCallerMockRecorder is the mock recorder for MockWebhookCaller.
+type MockWebhookCallerMockRecorder struct {
+	mock *MockWebhookCaller
+}
+
+// NewMockWebhookCaller creates a new mock instance.
+func NewMockWebhookCaller(ctrl *gomock.Controller) *MockWebhookCaller {
+	mock := &MockWebhookCaller{ctrl: ctrl}
+	mock.recorder = &MockWebhookCallerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookCaller) EXPECT() *MockWebhookCallerMockRecorder {
+	return m.recorder
+}
+
+// Call mocks base method.
+func (m *MockWebhookCaller) Call(ctx context.Context, url string, body any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", ctx, url, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockWebhookCallerMockRecorder) Call(ctx, url, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockWebhookCaller)(nil).Call), ctx, url, body)
+}
+
+// MockNotificationSender is a mock of NotificationSender interface.
+type MockNotificationSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSenderMockRecorder
+}
+
+// MockNotificationSenderMockRecorder is the mock recorder for MockNotificationSender.
+type MockNotificationSenderMockRecorder struct {
+	mock *MockNotificationSender
+}
+
+// NewMockNotificationSender creates a new mock instance.
+func NewMockNotificationSender(ctrl *gomock.Controller) *MockNotificationSender {
+	mock := &MockNotificationSender{ctrl: ctrl}
+	mock.recorder = &MockNotificationSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSender) EXPECT() *MockNotificationSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockNotificationSender) Send(ctx context.Context, channel domain.NotificationChannel, message domain.NotificationMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, channel, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockNotificationSenderMockRecorder) Send(ctx, channel, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationSender)(nil).Send), ctx, channel, message)
+}
+
+// MockRateLimitRepository is a mock of RateLimitRepository interface.
+type MockRateLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitRepositoryMockRecorder
+}
+
+// MockRateLimitRepositoryMockRecorder is the mock recorder for MockRateLimitRepository.
+type MockRateLimitRepositoryMockRecorder struct {
+	mock *MockRateLimitRepository
+}
+
+// NewMockRateLimitRepository creates a new mock instance.
+func NewMockRateLimitRepository(ctrl *gomock.Controller) *MockRateLimitRepository {
+	mock := &MockRateLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockRateLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitRepository) EXPECT() *MockRateLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// TakeToken mocks base method.
+func (m *MockRateLimitRepository) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeToken", ctx, key, limit)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeToken indicates an expected call of TakeToken.
+func (mr *MockRateLimitRepositoryMockRecorder) TakeToken(ctx, key, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeToken", reflect.TypeOf((*MockRateLimitRepository)(nil).TakeToken), ctx, key, limit)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
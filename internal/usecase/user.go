@@ -9,13 +9,26 @@ type User struct {
 	userRepository        UserRepository
 	sensorOwnerRepository SensorOwnerRepository
 	sensorRepository      SensorRepository
+	transactor            Transactor
 }
 
-func NewUser(ur UserRepository, sor SensorOwnerRepository, sr SensorRepository) *User {
-	return &User{
+func NewUser(ur UserRepository, sor SensorOwnerRepository, sr SensorRepository, options ...func(*User)) *User {
+	u := &User{
 		userRepository:        ur,
 		sensorOwnerRepository: sor,
 		sensorRepository:      sr,
+		transactor:            noTransactor{},
+	}
+	for _, o := range options {
+		o(u)
+	}
+	return u
+}
+
+// WithUserTransactor - проверка пользователя и датчика и их привязка выполняются в одной транзакции
+func WithUserTransactor(t Transactor) func(*User) {
+	return func(u *User) {
+		u.transactor = t
 	}
 }
 
@@ -31,21 +44,19 @@ func (u *User) RegisterUser(ctx context.Context, user *domain.User) (*domain.Use
 }
 
 func (u *User) AttachSensorToUser(ctx context.Context, userID, sensorID int64) error {
-	if _, err := u.userRepository.GetUserByID(ctx, userID); err != nil {
-		return err
-	}
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := u.userRepository.GetUserByID(ctx, userID); err != nil {
+			return err
+		}
 
-	if u.sensorRepository == nil {
-		return ErrUserNotFound
-	}
-	if _, err := u.sensorRepository.GetSensorByID(ctx, sensorID); err != nil {
-		return err
-	}
-	err := u.sensorOwnerRepository.SaveSensorOwner(ctx, domain.SensorOwner{UserID: userID, SensorID: sensorID})
-	if err != nil {
-		return err
-	}
-	return nil
+		if u.sensorRepository == nil {
+			return ErrUserNotFound
+		}
+		if _, err := u.sensorRepository.GetSensorByID(ctx, sensorID); err != nil {
+			return err
+		}
+		return u.sensorOwnerRepository.SaveSensorOwner(ctx, domain.SensorOwner{UserID: userID, SensorID: sensorID})
+	})
 }
 
 func (u *User) GetUserSensors(ctx context.Context, userID int64) ([]domain.Sensor, error) {
@@ -0,0 +1,19 @@
+drop index events_sensor_id_timestamp_idx;
+
+alter table events
+    drop constraint events_sensor_id_fkey,
+    drop constraint events_pkey,
+    drop column id;
+
+alter table sensors_users
+    drop constraint sensors_users_sensor_id_user_id_key,
+    drop constraint sensors_users_user_id_fkey,
+    drop constraint sensors_users_sensor_id_fkey,
+    drop constraint sensors_users_pkey;
+
+alter table sensors
+    drop constraint sensors_serial_number_key,
+    drop constraint sensors_pkey;
+
+alter table users
+    drop constraint users_pkey;
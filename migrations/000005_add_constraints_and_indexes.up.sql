@@ -0,0 +1,19 @@
+alter table users
+    add constraint users_pkey primary key (id);
+
+alter table sensors
+    add constraint sensors_pkey primary key (id),
+    add constraint sensors_serial_number_key unique (serial_number);
+
+alter table sensors_users
+    add constraint sensors_users_pkey primary key (id),
+    add constraint sensors_users_sensor_id_fkey foreign key (sensor_id) references sensors (id) on delete cascade,
+    add constraint sensors_users_user_id_fkey foreign key (user_id) references users (id) on delete cascade,
+    add constraint sensors_users_sensor_id_user_id_key unique (sensor_id, user_id);
+
+alter table events
+    add column id bigserial not null,
+    add constraint events_pkey primary key (id),
+    add constraint events_sensor_id_fkey foreign key (sensor_id) references sensors (id) on delete cascade;
+
+create index events_sensor_id_timestamp_idx on events (sensor_id, timestamp);
@@ -0,0 +1,15 @@
+-- датчики типов из реестра, которых нет в перечислении, откатить нельзя: их нужно удалить заранее,
+-- иначе откат остановится с понятной ошибкой, не изменив схему
+do
+$$
+    begin
+        if exists (select 1 from sensors where type not in ('cc', 'adc')) then
+            raise exception 'can''t roll back sensor types: delete sensors with types other than cc and adc first';
+        end if;
+    end
+$$;
+
+create type sensor_type as enum ('cc', 'adc');
+
+alter table sensors
+    alter column type type sensor_type using type::sensor_type;
@@ -0,0 +1,4 @@
+alter table sensors
+    alter column type type text using type::text;
+
+drop type sensor_type;
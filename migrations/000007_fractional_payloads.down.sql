@@ -0,0 +1,7 @@
+alter table sensors
+    drop column current_readings,
+    alter column current_state type bigint using round(current_state);
+
+alter table events
+    drop column readings,
+    alter column payload type bigint using round(payload);
@@ -0,0 +1,7 @@
+alter table events
+    alter column payload type double precision,
+    add column readings jsonb;
+
+alter table sensors
+    alter column current_state type double precision,
+    add column current_readings jsonb;
@@ -0,0 +1,2 @@
+alter table sensors
+    add column calibration jsonb;
@@ -0,0 +1,5 @@
+drop index events_sensor_id_timestamp_id_idx;
+create index events_sensor_id_timestamp_idx on events (sensor_id, timestamp);
+
+drop index sensors_serial_number_pattern_idx;
+drop index sensors_last_activity_id_idx;
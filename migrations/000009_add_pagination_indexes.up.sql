@@ -0,0 +1,5 @@
+create index sensors_last_activity_id_idx on sensors (last_activity, id);
+create index sensors_serial_number_pattern_idx on sensors (serial_number text_pattern_ops);
+
+drop index events_sensor_id_timestamp_idx;
+create index events_sensor_id_timestamp_id_idx on events (sensor_id, timestamp, id);
@@ -0,0 +1,4 @@
+drop table alerts;
+
+alter table sensors
+    drop column alert_rule;
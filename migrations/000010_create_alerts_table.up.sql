@@ -0,0 +1,23 @@
+alter table sensors
+    add column alert_rule jsonb;
+
+create table alerts
+(
+    id              bigserial        not null,
+    sensor_id       bigint           not null,
+    severity        text             not null,
+    payload         double precision not null,
+    triggered_at    timestamp        not null,
+    acknowledged_by bigint,
+    acknowledged_at timestamp,
+    resolved_by     bigint,
+    resolved_at     timestamp,
+    constraint alerts_pkey primary key (id),
+    constraint alerts_sensor_id_fkey foreign key (sensor_id) references sensors (id) on delete cascade,
+    constraint alerts_acknowledged_by_fkey foreign key (acknowledged_by) references users (id) on delete set null,
+    constraint alerts_resolved_by_fkey foreign key (resolved_by) references users (id) on delete set null
+);
+
+-- у датчика может быть только одна неустранённая тревога
+create unique index alerts_sensor_id_unresolved_idx on alerts (sensor_id) where resolved_at is null;
+create index alerts_sensor_id_triggered_at_id_idx on alerts (sensor_id, triggered_at, id);
@@ -0,0 +1,31 @@
+create table notification_channels
+(
+    id           bigserial not null,
+    user_id      bigint    not null,
+    kind         text      not null,
+    target       text      not null,
+    min_severity text      not null,
+    template     text      not null default '',
+    quiet_hours  jsonb,
+    max_per_hour integer   not null default 0,
+    constraint notification_channels_pkey primary key (id),
+    constraint notification_channels_user_id_fkey foreign key (user_id) references users (id) on delete cascade
+);
+
+create index notification_channels_user_id_idx on notification_channels (user_id);
+
+create table notification_deliveries
+(
+    id         bigserial not null,
+    channel_id bigint    not null,
+    alert_id   bigint    not null,
+    status     text      not null,
+    reason     text      not null default '',
+    created_at timestamp not null,
+    constraint notification_deliveries_pkey primary key (id),
+    constraint notification_deliveries_channel_id_fkey foreign key (channel_id) references notification_channels (id) on delete cascade,
+    constraint notification_deliveries_alert_id_fkey foreign key (alert_id) references alerts (id) on delete cascade
+);
+
+-- журнал канала читается от новых записей к старым, ограничение частоты считает записи за последний час
+create index notification_deliveries_channel_id_created_at_id_idx on notification_deliveries (channel_id, created_at, id);
@@ -0,0 +1,18 @@
+create table commands
+(
+    id           bigserial        not null,
+    sensor_id    bigint           not null,
+    payload      double precision not null,
+    state        text             not null,
+    reason       text             not null default '',
+    created_at   timestamp        not null,
+    delivered_at timestamp,
+    completed_at timestamp,
+    constraint commands_pkey primary key (id),
+    constraint commands_sensor_id_fkey foreign key (sensor_id) references sensors (id) on delete cascade
+);
+
+create index commands_sensor_id_created_at_id_idx on commands (sensor_id, created_at, id);
+-- очередь устройства и поиск просроченных команд выбирают только незавершённые команды
+create index commands_sensor_id_pending_idx on commands (sensor_id, id) where state = 'pending';
+create index commands_delivered_at_idx on commands (delivered_at) where state = 'delivered';
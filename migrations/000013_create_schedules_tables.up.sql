@@ -0,0 +1,33 @@
+create table schedules
+(
+    id          bigserial not null,
+    user_id     bigint    not null,
+    name        text      not null,
+    cron        text      not null default '',
+    once_at     timestamp,
+    location    text      not null default '',
+    enabled     boolean   not null,
+    action      jsonb     not null,
+    next_run_at timestamp,
+    created_at  timestamp not null,
+    constraint schedules_pkey primary key (id),
+    constraint schedules_user_id_fkey foreign key (user_id) references users (id) on delete cascade
+);
+
+create index schedules_user_id_idx on schedules (user_id);
+-- планировщик выбирает только включённые расписания, время которых наступило
+create index schedules_next_run_at_idx on schedules (next_run_at, id) where enabled;
+
+create table schedule_runs
+(
+    id           bigserial not null,
+    schedule_id  bigint    not null,
+    scheduled_at timestamp not null,
+    started_at   timestamp not null,
+    status       text      not null,
+    error        text      not null default '',
+    constraint schedule_runs_pkey primary key (id),
+    constraint schedule_runs_schedule_id_fkey foreign key (schedule_id) references schedules (id) on delete cascade
+);
+
+create index schedule_runs_schedule_id_started_at_id_idx on schedule_runs (schedule_id, started_at, id);
@@ -0,0 +1,10 @@
+-- Время последнего сохранения датчика, по нему HTTP API отдаёт Last-Modified
+
+alter table sensors
+    add column updated_at timestamp;
+
+update sensors
+set updated_at = coalesce(greatest(registered_at, last_activity), now());
+
+alter table sensors
+    alter column updated_at set not null;
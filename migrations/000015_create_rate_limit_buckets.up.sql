@@ -0,0 +1,14 @@
+-- Корзины токенов ограничителя частоты запросов, общие для всех реплик сервиса.
+-- Время берётся из часов базы, поэтому расхождение часов реплик не влияет на пополнение корзин
+
+create table rate_limit_buckets
+(
+    key        text             not null,
+    tokens     double precision not null,
+    updated_at timestamptz      not null,
+    -- full_at - когда корзина наполнится: после этого её можно удалить, она не отличается от новой
+    full_at    timestamptz      not null,
+    constraint rate_limit_buckets_pkey primary key (key)
+);
+
+create index rate_limit_buckets_full_at_idx on rate_limit_buckets (full_at);
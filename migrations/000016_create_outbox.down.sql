@@ -0,0 +1,2 @@
+drop table outbox_relay_positions;
+drop table outbox;
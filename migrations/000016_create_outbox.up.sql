@@ -0,0 +1,21 @@
+-- Журнал изменений (transactional outbox): принятые события и изменения датчиков записываются
+-- в одной транзакции с их сохранением, читатели потока изменений продвигаются по seq
+
+create table outbox
+(
+    seq        bigint generated always as identity,
+    kind       text        not null,
+    -- payload - событие или датчик в json
+    payload    jsonb       not null,
+    created_at timestamptz not null,
+    constraint outbox_pkey primary key (seq)
+);
+
+-- Позиции relay: seq последнего изменения, доставленного в приёмник
+
+create table outbox_relay_positions
+(
+    sink text   not null,
+    seq  bigint not null,
+    constraint outbox_relay_positions_pkey primary key (sink)
+);
@@ -0,0 +1 @@
+-- Последовательности не возвращаются назад: иначе они снова выдавали бы занятые id
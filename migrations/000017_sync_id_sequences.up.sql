@@ -0,0 +1,11 @@
+-- До перехода на значения id по умолчанию пользователи, датчики и привязки сохранялись с явными id,
+-- поэтому последовательности этих таблиц могли остаться в начале и выдавать уже занятые id
+
+select setval(pg_get_serial_sequence('users', 'id'), coalesce(max(id), 0) + 1, false)
+from users;
+
+select setval(pg_get_serial_sequence('sensors', 'id'), coalesce(max(id), 0) + 1, false)
+from sensors;
+
+select setval(pg_get_serial_sequence('sensors_users', 'id'), coalesce(max(id), 0) + 1, false)
+from sensors_users;
@@ -0,0 +1,196 @@
+package smtp_test
+
+//nolint: revive // test stub
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Message - письмо, принятое сервером
+type Message struct {
+	From string
+	To   []string
+	// Data - письмо с заголовками, как его передал клиент
+	Data string
+}
+
+// Server - SMTP сервер, который принимает письма без проверки и хранит их в памяти.
+// Поддерживает команды, нужные net/smtp без STARTTLS и AUTH
+type Server struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mutex    sync.Mutex
+	messages []Message
+	reject   map[string]bool
+	received chan Message
+}
+
+// NewServer - запускает сервер на addr, например "127.0.0.1:0"
+func NewServer(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("can't listen: %w", err)
+	}
+	s := &Server{
+		listener: listener,
+		reject:   map[string]bool{},
+		received: make(chan Message, 100),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr - адрес, на котором сервер принимает соединения
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Reject - письма на адрес rcpt отклоняются с кодом 550
+func (s *Server) Reject(rcpt string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reject[rcpt] = true
+}
+
+// Messages - принятые письма в порядке получения
+func (s *Server) Messages() []Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Received - канал, в который попадает каждое принятое письмо. Письма, которые никто не читает, после первых 100 не попадают в канал
+func (s *Server) Received() <-chan Message {
+	return s.received
+}
+
+// Close - останавливает сервер и дожидается завершения открытых сессий
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(conn)
+		}()
+	}
+}
+
+// session - диалог с одним клиентом по RFC 5321
+func (s *Server) session(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := fmt.Fprintf(conn, "%s\r\n", line)
+		return err == nil
+	}
+	if !reply("220 localhost fake SMTP") {
+		return
+	}
+	var message Message
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		var ok bool
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			ok = reply("250-localhost\r\n250 8BITMIME")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message = Message{From: address(line[len("MAIL FROM:"):])}
+			ok = reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			rcpt := address(line[len("RCPT TO:"):])
+			s.mutex.Lock()
+			rejected := s.reject[rcpt]
+			s.mutex.Unlock()
+			if rejected {
+				ok = reply("550 mailbox unavailable")
+				break
+			}
+			message.To = append(message.To, rcpt)
+			ok = reply("250 OK")
+		case command == "DATA":
+			if !reply("354 end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			if message.Data, err = readData(r); err != nil {
+				return
+			}
+			s.store(message)
+			ok = reply("250 OK")
+		case command == "RSET":
+			message = Message{}
+			ok = reply("250 OK")
+		case command == "NOOP":
+			ok = reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			ok = reply("502 command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+func (s *Server) store(message Message) {
+	s.mutex.Lock()
+	s.messages = append(s.messages, message)
+	s.mutex.Unlock()
+	select {
+	case s.received <- message:
+	default:
+	}
+}
+
+// readData - читает тело письма до строки из одной точки, снимая экранирование точек в начале строк
+func readData(r *bufio.Reader) (string, error) {
+	var b strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" || line == ".\n" {
+			return b.String(), nil
+		}
+		b.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+// address - адрес из аргумента MAIL FROM или RCPT TO без угловых скобок и параметров
+func address(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if end := strings.IndexByte(arg, '>'); strings.HasPrefix(arg, "<") && end > 0 {
+		return arg[1:end]
+	}
+	if fields := strings.Fields(arg); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}